@@ -59,6 +59,9 @@ func main() {
 		commands.OptimizeCommand,
 		commands.PurgeCommand,
 		commands.CleanUpCommand,
+		commands.JobsCommand,
+		commands.ReportsCommand,
+		commands.LabelsCommand,
 		commands.CopyCommand,
 		commands.ConvertCommand,
 		commands.ResampleCommand,
@@ -8,6 +8,9 @@ const (
 	ResourceConfigOptions Resource = "config_options"
 	ResourceSettings      Resource = "settings"
 	ResourceLogs          Resource = "logs"
+	ResourceAudit         Resource = "audit"
+	ResourceJobs          Resource = "jobs"
+	ResourceReports       Resource = "reports"
 	ResourceAccounts      Resource = "accounts"
 	ResourceAlbums        Resource = "albums"
 	ResourceCameras       Resource = "cameras"
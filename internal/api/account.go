@@ -248,6 +248,8 @@ func CreateAccount(router *gin.RouterGroup) {
 			return
 		}
 
+		Audit(c, s.User, "accounts.create", fmt.Sprintf("%d", m.ID), nil, m)
+
 		event.SuccessMsg(i18n.MsgAccountCreated)
 
 		c.JSON(http.StatusOK, m)
@@ -292,6 +294,8 @@ func UpdateAccount(router *gin.RouterGroup) {
 			return
 		}
 
+		before := f
+
 		// 2) Update form with values from request
 		if err := c.BindJSON(&f); err != nil {
 			log.Error(err)
@@ -306,6 +310,8 @@ func UpdateAccount(router *gin.RouterGroup) {
 			return
 		}
 
+		Audit(c, s.User, "accounts.update", c.Param("id"), before, f)
+
 		event.SuccessMsg(i18n.MsgAccountSaved)
 
 		m, err = query.AccountByID(id)
@@ -357,6 +363,8 @@ func DeleteAccount(router *gin.RouterGroup) {
 			return
 		}
 
+		Audit(c, s.User, "accounts.delete", c.Param("id"), m, nil)
+
 		event.SuccessMsg(i18n.MsgAccountDeleted)
 
 		c.JSON(http.StatusOK, m)
@@ -215,6 +215,8 @@ func DeleteAlbum(router *gin.RouterGroup) {
 
 		conf.Db().Delete(&a)
 
+		Audit(c, s.User, "albums.delete", a.AlbumUID, a, nil)
+
 		UpdateClientConfig()
 
 		SaveAlbumAsYaml(a)
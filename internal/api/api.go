@@ -33,6 +33,8 @@ package api
 
 import (
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/photoprism/photoprism/internal/event"
@@ -52,6 +54,9 @@ func logError(prefix string, err error) {
 func UpdateClientConfig() {
 	conf := service.Config()
 
+	// Map tiles may have changed as well.
+	FlushTileCache()
+
 	event.Publish("config.updated", event.Data{"config": conf.UserConfig()})
 }
 
@@ -102,6 +107,11 @@ func AbortAlreadyExists(c *gin.Context, s string) {
 	Abort(c, http.StatusConflict, i18n.ErrAlreadyExists, s)
 }
 
+func AbortTooManyRequests(c *gin.Context, wait time.Duration) {
+	c.Header("Retry-After", strconv.Itoa(int(wait.Seconds()+0.5)))
+	Abort(c, http.StatusTooManyRequests, i18n.ErrTooManyRequests)
+}
+
 func AbortFeatureDisabled(c *gin.Context) {
 	Abort(c, http.StatusForbidden, i18n.ErrFeatureDisabled)
 }
@@ -0,0 +1,144 @@
+package api
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/query"
+)
+
+// AuditSession returns a short hash that identifies the session without revealing its id.
+func AuditSession(id string) string {
+	if id == "" {
+		return ""
+	}
+
+	h := sha256.Sum256([]byte(id))
+
+	return hex.EncodeToString(h[:])[:16]
+}
+
+// Audit appends an entry for the current user action to the audit log,
+// before and after are compared to store the changed fields only.
+func Audit(c *gin.Context, actor entity.User, action, uid string, before, after interface{}) {
+	m := entity.NewAuditLog(actor, AuditSession(SessionID(c)), c.ClientIP(), action, uid, before, after)
+
+	if err := m.Create(); err != nil {
+		log.Errorf("audit: %s (%s)", err, action)
+	}
+}
+
+// AuditUIDs appends one audit log entry per affected resource UID,
+// e.g. for batch actions that change the same fields of each resource.
+func AuditUIDs(c *gin.Context, actor entity.User, action string, uids []string, before, after interface{}) {
+	for _, uid := range uids {
+		Audit(c, actor, action, uid, before, after)
+	}
+}
+
+// GET /api/v1/audit
+//
+// Query:
+//   q: string Action prefix or actor name
+//   actor: string Actor name or UID
+//   action: string Action prefix, e.g. "photos."
+//   resource: string Resource UID
+//   client: string Client IP address
+//   session: string Session hash
+//   before, after: string Date as YYYY-MM-DD
+//   count: int Max result count
+//   offset: int Result offset
+func GetAuditLog(router *gin.RouterGroup) {
+	router.GET("/audit", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourceAudit, acl.ActionSearch)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		var f form.AuditSearch
+
+		if err := c.MustBindWith(&f, binding.Form); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		result, err := query.AuditLogs(f)
+
+		if err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		AddCountHeader(c, len(result))
+		AddLimitHeader(c, f.Count)
+		AddOffsetHeader(c, f.Offset)
+
+		c.JSON(http.StatusOK, result)
+	})
+}
+
+// GET /api/v1/audit/export
+//
+// Returns matching audit log entries as JSON lines, accepts the same query parameters as GET /api/v1/audit.
+func ExportAuditLog(router *gin.RouterGroup) {
+	router.GET("/audit/export", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourceAudit, acl.ActionExport)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		var f form.AuditSearch
+
+		if err := c.MustBindWith(&f, binding.Form); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		f.Count = query.MaxResults
+
+		fileName := fmt.Sprintf("audit-%s.jsonl", time.Now().UTC().Format("20060102-150405"))
+
+		c.Header("Content-Type", "application/x-ndjson")
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s", fileName))
+		c.Status(http.StatusOK)
+
+		enc := json.NewEncoder(c.Writer)
+
+		for {
+			result, err := query.AuditLogs(f)
+
+			if err != nil {
+				log.Errorf("audit: %s", err)
+				return
+			}
+
+			for _, m := range result {
+				if err := enc.Encode(m); err != nil {
+					log.Errorf("audit: %s", err)
+					return
+				}
+			}
+
+			if len(result) < f.Count {
+				return
+			}
+
+			c.Writer.Flush()
+
+			f.Offset += len(result)
+		}
+	})
+}
@@ -0,0 +1,100 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestAuditSession(t *testing.T) {
+	assert.Equal(t, "", AuditSession(""))
+	assert.Len(t, AuditSession("69be27ac5ca305b394046a83f6fda18167ca3d3f2dbe7ac0"), 16)
+	assert.NotContains(t, AuditSession("69be27ac5ca305b394046a83f6fda18167ca3d3f2dbe7ac0"), "69be27ac")
+}
+
+func TestGetAuditLog(t *testing.T) {
+	t.Run("photo update", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		UpdatePhoto(router)
+		GetAuditLog(router)
+
+		title := fmt.Sprintf("Audited %d", time.Now().UnixNano())
+
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/photos/pt9jtdre2lvl0y13", fmt.Sprintf(`{"Title": "%s"}`, title))
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		r = PerformRequest(app, "GET", "/api/v1/audit?action=photos.update&resource=pt9jtdre2lvl0y13&count=1")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "photos.update", gjson.Get(r.Body.String(), "0.Action").String())
+		assert.Equal(t, "admin", gjson.Get(r.Body.String(), "0.ActorName").String())
+		assert.Equal(t, title, gjson.Get(r.Body.String(), "0.Diff.Title.new").String())
+	})
+	t.Run("batch edit", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		BatchPhotosEdit(router)
+		GetAuditLog(router)
+
+		title := fmt.Sprintf("Batch Audited %d", time.Now().UnixNano())
+
+		r := PerformRequestWithBody(app, "POST", "/api/v1/batch/photos/edit", fmt.Sprintf(`{"photos": ["pt9jtdre2lvl0y13"], "Title": "%s"}`, title))
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		r = PerformRequest(app, "GET", "/api/v1/audit?action=photos.edit&resource=pt9jtdre2lvl0y13&count=1")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, title, gjson.Get(r.Body.String(), "0.Diff.Title.new").String())
+		assert.Equal(t, "manual", gjson.Get(r.Body.String(), "0.Diff.TitleSrc.new").String())
+	})
+	t.Run("batch archive", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		BatchPhotosArchive(router)
+		BatchPhotosRestore(router)
+		GetAuditLog(router)
+
+		r := PerformRequestWithBody(app, "POST", "/api/v1/batch/photos/archive", `{"photos": ["pt9jtdre2lvl0y13"]}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		r = PerformRequestWithBody(app, "POST", "/api/v1/batch/photos/restore", `{"photos": ["pt9jtdre2lvl0y13"]}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		r = PerformRequest(app, "GET", "/api/v1/audit?action=photos.archive&resource=pt9jtdre2lvl0y13&count=1")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "true", gjson.Get(r.Body.String(), "0.Diff.Archived.new").String())
+
+		r = PerformRequest(app, "GET", "/api/v1/audit?action=photos.restore&resource=pt9jtdre2lvl0y13&count=1")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "false", gjson.Get(r.Body.String(), "0.Diff.Archived.new").String())
+	})
+	t.Run("invalid query", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetAuditLog(router)
+		r := PerformRequest(app, "GET", "/api/v1/audit?q=xxx:yyy")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
+
+func TestExportAuditLog(t *testing.T) {
+	app, router, _ := NewApiTest()
+	BatchAlbumsDelete(router)
+	ExportAuditLog(router)
+
+	r := PerformRequestWithBody(app, "POST", "/api/v1/batch/albums/delete", `{"albums": ["at9lxuqxpogaudit"]}`)
+	assert.Equal(t, http.StatusOK, r.Code)
+
+	r = PerformRequest(app, "GET", "/api/v1/audit/export?action=albums.delete")
+	assert.Equal(t, http.StatusOK, r.Code)
+	assert.Equal(t, "application/x-ndjson", r.Header().Get("Content-Type"))
+	assert.Contains(t, r.Header().Get("Content-Disposition"), "attachment")
+
+	lines := strings.Split(strings.TrimSpace(r.Body.String()), "\n")
+
+	assert.LessOrEqual(t, 1, len(lines))
+
+	for _, l := range lines {
+		assert.Equal(t, "albums.delete", gjson.Get(l, "Action").String())
+	}
+}
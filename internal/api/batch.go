@@ -14,6 +14,7 @@ import (
 	"github.com/photoprism/photoprism/internal/form"
 	"github.com/photoprism/photoprism/internal/i18n"
 	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/txt"
 )
 
 // POST /api/v1/batch/photos/archive
@@ -69,6 +70,8 @@ func BatchPhotosArchive(router *gin.RouterGroup) {
 
 		event.EntitiesArchived("photos", f.Photos)
 
+		AuditUIDs(c, s.User, "photos.archive", f.Photos, event.Data{"Archived": false}, event.Data{"Archived": true})
+
 		c.JSON(http.StatusOK, i18n.NewResponse(http.StatusOK, i18n.MsgSelectionArchived))
 	})
 }
@@ -125,6 +128,8 @@ func BatchPhotosRestore(router *gin.RouterGroup) {
 
 		event.EntitiesRestored("photos", f.Photos)
 
+		AuditUIDs(c, s.User, "photos.restore", f.Photos, event.Data{"Archived": true}, event.Data{"Archived": false})
+
 		c.JSON(http.StatusOK, i18n.NewResponse(http.StatusOK, i18n.MsgSelectionRestored))
 	})
 }
@@ -163,11 +168,14 @@ func BatchPhotosApprove(router *gin.RouterGroup) {
 		var approved entity.Photos
 
 		for _, p := range photos {
+			before := p
+
 			if err := p.Approve(); err != nil {
 				log.Errorf("approve: %s", err)
 			} else {
 				approved = append(approved, p)
 				SavePhotoAsYaml(p)
+				Audit(c, s.User, "photos.approve", p.PhotoUID, before, p)
 			}
 		}
 
@@ -203,6 +211,10 @@ func BatchAlbumsDelete(router *gin.RouterGroup) {
 
 		log.Infof("albums: deleting %s", f.String())
 
+		var albums entity.Albums
+
+		logError("albums", entity.Db().Where("album_uid IN (?)", f.Albums).Find(&albums).Error)
+
 		entity.Db().Where("album_uid IN (?)", f.Albums).Delete(&entity.Album{})
 		entity.Db().Where("album_uid IN (?)", f.Albums).Delete(&entity.PhotoAlbum{})
 
@@ -210,6 +222,10 @@ func BatchAlbumsDelete(router *gin.RouterGroup) {
 
 		event.EntitiesDeleted("albums", f.Albums)
 
+		for _, a := range albums {
+			Audit(c, s.User, "albums.delete", a.AlbumUID, a, nil)
+		}
+
 		c.JSON(http.StatusOK, i18n.NewResponse(http.StatusOK, i18n.MsgAlbumsDeleted))
 	})
 }
@@ -248,8 +264,24 @@ func BatchPhotosPrivate(router *gin.RouterGroup) {
 		logError("photos", entity.UpdatePhotoCounts())
 
 		if photos, err := query.PhotoSelection(f); err == nil {
+			batch := entity.NewBatchUID()
+
 			for _, p := range photos {
 				SavePhotoAsYaml(p)
+
+				// Add flag to edit history so that it can be undone.
+				if after, err := form.NewPhoto(p); err != nil {
+					log.Errorf("private: %s", err)
+				} else {
+					before := after
+					before.PhotoPrivate = !after.PhotoPrivate
+
+					if _, err := entity.SavePhotoChanges(p.PhotoUID, batch, s.User, before, after); err != nil {
+						log.Errorf("private: %s", err)
+					}
+
+					Audit(c, s.User, "photos.private", p.PhotoUID, before, after)
+				}
 			}
 
 			event.EntitiesUpdated("photos", photos)
@@ -296,7 +328,11 @@ func BatchLabelsDelete(router *gin.RouterGroup) {
 		}
 
 		for _, label := range labels {
-			logError("labels", label.Delete())
+			if err := label.Delete(); err != nil {
+				log.Errorf("labels: %s", err)
+			} else {
+				Audit(c, s.User, "labels.delete", label.LabelUID, label, nil)
+			}
 		}
 
 		UpdateClientConfig()
@@ -363,8 +399,227 @@ func BatchPhotosDelete(router *gin.RouterGroup) {
 			UpdateClientConfig()
 
 			event.EntitiesDeleted("photos", deleted.UIDs())
+
+			for _, p := range deleted {
+				Audit(c, s.User, "photos.delete", p.PhotoUID, p, nil)
+			}
 		}
 
 		c.JSON(http.StatusOK, i18n.NewResponse(http.StatusOK, i18n.MsgPermanentlyDeleted))
 	})
 }
+
+// POST /api/v1/batch/photos/edit
+//
+// Applies the form values to all selected photos, changes can be undone with POST /api/v1/changes/:batch/undo.
+func BatchPhotosEdit(router *gin.RouterGroup) {
+	router.POST("/batch/photos/edit", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourcePhotos, acl.ActionUpdate)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		var f form.BatchEdit
+
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		if f.Selection.Empty() {
+			Abort(c, http.StatusBadRequest, i18n.ErrNoItemsSelected)
+			return
+		}
+
+		if _, err := f.Offset(); err != nil || f.NoChanges() {
+			AbortBadRequest(c)
+			return
+		} else if f.PlaceID != "" && entity.FindPlace(f.PlaceID, "") == nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		log.Infof("photos: editing %s", f.Selection.String())
+
+		photos, err := query.PhotoSelection(f.Selection)
+
+		if err != nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		batch := entity.NewBatchUID()
+
+		var edited entity.Photos
+
+		for i, sel := range photos {
+			m, err := query.PhotoByUID(sel.PhotoUID)
+
+			if err != nil {
+				log.Errorf("edit: %s (find %s)", err, sel.String())
+				continue
+			}
+
+			before, err := batchEditForm(m)
+
+			if err != nil {
+				log.Errorf("edit: %s (%s)", err, m.String())
+				continue
+			}
+
+			after, err := f.Apply(before, i+1)
+
+			if err != nil {
+				log.Errorf("edit: %s (%s)", err, m.String())
+				continue
+			}
+
+			// Labels based on keywords are removed when saving if their keyword was removed.
+			keywordLabels, err := query.KeywordPhotoLabels(m.ID)
+
+			if err != nil {
+				log.Errorf("edit: %s (%s)", err, m.String())
+			}
+
+			if err := entity.SavePhotoForm(m, after); err != nil {
+				log.Errorf("edit: %s (save %s)", err, m.String())
+				continue
+			}
+
+			batchEditKeywordLabels(m, keywordLabels, batch, s.User)
+
+			if f.HasLabels() {
+				batchEditLabels(m, f, batch, s.User)
+			}
+
+			p, err := query.PhotoPreloadByUID(m.PhotoUID)
+
+			if err != nil {
+				log.Errorf("edit: %s (%s)", err, m.String())
+				continue
+			}
+
+			// Changes are recorded as saved, including the place and keywords found for a new location.
+			if saved, err := batchEditForm(p); err != nil {
+				log.Errorf("edit: %s (%s)", err, m.String())
+			} else {
+				if _, err := entity.SavePhotoChanges(m.PhotoUID, batch, s.User, before, saved); err != nil {
+					log.Errorf("edit: %s (save changes)", err)
+				}
+
+				Audit(c, s.User, "photos.edit", m.PhotoUID, before, saved)
+			}
+
+			if f.HasLabels() {
+				logError("edit", p.SaveLabels())
+			}
+
+			SavePhotoAsYaml(p)
+
+			edited = append(edited, p)
+		}
+
+		UpdateClientConfig()
+
+		event.EntitiesUpdated("photos", edited)
+
+		c.JSON(http.StatusOK, gin.H{"code": http.StatusOK, "message": i18n.Msg(i18n.MsgChangesSaved), "batch": batch, "count": len(edited)})
+	})
+}
+
+// batchEditLabels adds and removes the labels of a batch edit,
+// the changes are recorded in the edit history so that they can be undone.
+func batchEditLabels(m entity.Photo, f form.BatchEdit, batch string, user entity.User) {
+	for _, name := range f.AddLabels {
+		label := entity.FirstOrCreateLabel(entity.NewLabel(name, 0))
+
+		if label == nil {
+			log.Errorf("edit: failed creating label %s", txt.Quote(name))
+			continue
+		}
+
+		logError("edit", label.Restore())
+
+		var before *entity.PhotoLabel
+
+		if photoLabel, err := query.PhotoLabel(m.ID, label.ID); err == nil {
+			before = &photoLabel
+		}
+
+		photoLabel := entity.FirstOrCreatePhotoLabel(entity.NewPhotoLabel(m.ID, label.ID, 0, entity.SrcManual))
+
+		if photoLabel == nil {
+			log.Errorf("edit: failed adding label %s to %s", txt.Quote(name), m.String())
+			continue
+		} else if photoLabel.Uncertainty > 0 {
+			logError("edit", photoLabel.Updates(map[string]interface{}{
+				"Uncertainty": 0,
+				"LabelSrc":    entity.SrcManual,
+			}))
+
+			photoLabel.Uncertainty = 0
+			photoLabel.LabelSrc = entity.SrcManual
+		}
+
+		logError("edit", entity.SavePhotoLabelChange(m.PhotoUID, batch, user, *label, before, photoLabel))
+	}
+
+	for _, name := range f.RemoveLabels {
+		label := entity.FindLabel(name)
+
+		if label == nil {
+			continue
+		}
+
+		photoLabel, err := query.PhotoLabel(m.ID, label.ID)
+
+		if err != nil {
+			continue
+		}
+
+		before := photoLabel
+
+		if photoLabel.LabelSrc == entity.SrcManual || photoLabel.LabelSrc == entity.SrcKeyword {
+			logError("edit", entity.Db().Delete(&photoLabel).Error)
+			logError("edit", entity.SavePhotoLabelChange(m.PhotoUID, batch, user, *label, &before, nil))
+		} else {
+			photoLabel.Uncertainty = 100
+			logError("edit", entity.Db().Save(&photoLabel).Error)
+			logError("edit", entity.SavePhotoLabelChange(m.PhotoUID, batch, user, *label, &before, &photoLabel))
+		}
+	}
+}
+
+// batchEditForm returns the form values of a photo including its details, which are not copied by form.NewPhoto.
+func batchEditForm(m entity.Photo) (f form.Photo, err error) {
+	if f, err = form.NewPhoto(m); err != nil {
+		return f, err
+	}
+
+	if m.Details != nil {
+		f.Details, err = form.NewDetails(m.Details)
+	}
+
+	// Details may not exist yet.
+	f.Details.PhotoID = m.ID
+
+	return f, err
+}
+
+// batchEditKeywordLabels records the removal of labels that were based on keywords removed by a batch edit,
+// so that they can be undone together with the keywords.
+func batchEditKeywordLabels(m entity.Photo, keywordLabels entity.PhotoLabels, batch string, user entity.User) {
+	for _, before := range keywordLabels {
+		if before.Label == nil {
+			continue
+		} else if _, err := query.PhotoLabel(m.ID, before.LabelID); err == nil {
+			continue
+		}
+
+		before := before
+
+		logError("edit", entity.SavePhotoLabelChange(m.PhotoUID, batch, user, *before.Label, &before, nil))
+	}
+}
@@ -274,3 +274,113 @@ func TestBatchPhotosDelete(t *testing.T) {
 		assert.Equal(t, http.StatusForbidden, r.Code)
 	})
 }
+
+func TestBatchPhotosEdit(t *testing.T) {
+	t.Run("successful request", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetPhoto(router)
+		BatchPhotosEdit(router)
+		UndoPhotoChanges(router)
+
+		r := PerformRequest(app, "GET", "/api/v1/photos/pt9jtxrexxvl0y22")
+		oldTitleSrc := gjson.Get(r.Body.String(), "TitleSrc").String()
+
+		r = PerformRequestWithBody(app, "POST", "/api/v1/batch/photos/edit",
+			`{"photos": ["pt9jtxrexxvl0y22"], "Title": "Batch {n}", "TakenAt": "2019-05-04T12:00:00Z", "TimeZone": "UTC", "AddKeywords": ["batchedit"], "AddLabels": ["Batch Label"]}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, int64(1), gjson.Get(r.Body.String(), "count").Int())
+		batch := gjson.Get(r.Body.String(), "batch").String()
+
+		r = PerformRequest(app, "GET", "/api/v1/photos/pt9jtxrexxvl0y22")
+		assert.Equal(t, "Batch 1", gjson.Get(r.Body.String(), "Title").String())
+		assert.Equal(t, "manual", gjson.Get(r.Body.String(), "TitleSrc").String())
+		assert.Equal(t, int64(2019), gjson.Get(r.Body.String(), "Year").Int())
+		assert.Contains(t, gjson.Get(r.Body.String(), "Details.Keywords").String(), "batchedit")
+		assert.Contains(t, r.Body.String(), "Batch Label")
+
+		r = PerformRequest(app, "POST", "/api/v1/changes/"+batch+"/undo")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.LessOrEqual(t, int64(1), gjson.Get(r.Body.String(), "reverted").Int())
+
+		r = PerformRequest(app, "GET", "/api/v1/photos/pt9jtxrexxvl0y22")
+		assert.NotEqual(t, "Batch 1", gjson.Get(r.Body.String(), "Title").String())
+		assert.Equal(t, oldTitleSrc, gjson.Get(r.Body.String(), "TitleSrc").String())
+	})
+	t.Run("no changes", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		BatchPhotosEdit(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/batch/photos/edit", `{"photos": ["pt9jtxrexxvl0y22"]}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("no items selected", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		BatchPhotosEdit(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/batch/photos/edit", `{"photos": [], "Title": "Foo"}`)
+		assert.Equal(t, i18n.Msg(i18n.ErrNoItemsSelected), gjson.Get(r.Body.String(), "error").String())
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("invalid offset", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		BatchPhotosEdit(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/batch/photos/edit", `{"photos": ["pt9jtxrexxvl0y22"], "TakenOffset": "one day"}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("place", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetPhoto(router)
+		BatchPhotosEdit(router)
+		UndoPhotoChanges(router)
+
+		r := PerformRequest(app, "GET", "/api/v1/photos/pt9jtxrexxvl0y22")
+		oldPlaceID := gjson.Get(r.Body.String(), "PlaceID").String()
+
+		r = PerformRequestWithBody(app, "POST", "/api/v1/batch/photos/edit", `{"photos": ["pt9jtxrexxvl0y22"], "PlaceID": "s2:1ef744d1e279"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		batch := gjson.Get(r.Body.String(), "batch").String()
+
+		r = PerformRequest(app, "GET", "/api/v1/photos/pt9jtxrexxvl0y22")
+		assert.Equal(t, "s2:1ef744d1e279", gjson.Get(r.Body.String(), "PlaceID").String())
+		assert.Equal(t, "manual", gjson.Get(r.Body.String(), "PlaceSrc").String())
+
+		r = PerformRequest(app, "POST", "/api/v1/changes/"+batch+"/undo")
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		r = PerformRequest(app, "GET", "/api/v1/photos/pt9jtxrexxvl0y22")
+		assert.Equal(t, oldPlaceID, gjson.Get(r.Body.String(), "PlaceID").String())
+	})
+	t.Run("invalid place", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		BatchPhotosEdit(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/batch/photos/edit", `{"photos": ["pt9jtxrexxvl0y22"], "PlaceID": "s2:xxxxxxxxxxxx"}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("keyword labels", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetPhoto(router)
+		BatchPhotosEdit(router)
+		UndoPhotoChanges(router)
+
+		r := PerformRequestWithBody(app, "POST", "/api/v1/batch/photos/edit", `{"photos": ["pt9jtdre2lvl0yh7"], "AddLabels": ["Batchword"]}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		r = PerformRequestWithBody(app, "POST", "/api/v1/batch/photos/edit", `{"photos": ["pt9jtxrexxvl0y22"], "AddKeywords": ["batchword"]}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		r = PerformRequest(app, "GET", "/api/v1/photos/pt9jtxrexxvl0y22")
+		assert.Contains(t, r.Body.String(), "Batchword")
+
+		r = PerformRequestWithBody(app, "POST", "/api/v1/batch/photos/edit", `{"photos": ["pt9jtxrexxvl0y22"], "RemoveKeywords": ["batchword"]}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		batch := gjson.Get(r.Body.String(), "batch").String()
+
+		r = PerformRequest(app, "GET", "/api/v1/photos/pt9jtxrexxvl0y22")
+assert.NotContains(t, r.Body.String(), "Batchword")
+
+		r = PerformRequest(app, "POST", "/api/v1/changes/"+batch+"/undo")
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		r = PerformRequest(app, "GET", "/api/v1/photos/pt9jtxrexxvl0y22")
+		assert.Contains(t, gjson.Get(r.Body.String(), "Details.Keywords").String(), "batchword")
+		assert.Contains(t, r.Body.String(), "Batchword")
+	})
+}
@@ -20,6 +20,7 @@ func (a MaxAge) String() string {
 var (
 	CoverCacheTTL MaxAge = 3600           // 1 hour
 	ThumbCacheTTL MaxAge = 3600 * 24 * 90 // ~ 3 months
+	MapTileTTL    MaxAge = 3600 * 24 * 7  // 1 week
 )
 
 type ThumbCache struct {
@@ -60,6 +61,13 @@ func RemoveFromAlbumCoverCache(uid string) {
 	}
 }
 
+// FlushTileCache clears the map tile cache, e.g. after photos have been changed.
+func FlushTileCache() {
+	service.TileCache().Flush()
+
+	log.Debugf("geo: flushed tile cache")
+}
+
 // FlushCoverCache clears the complete cover cache.
 func FlushCoverCache() {
 	service.CoverCache().Flush()
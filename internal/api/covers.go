@@ -81,7 +81,7 @@ func AlbumCover(router *gin.RouterGroup) {
 
 		fileName := photoprism.FileName(f.FileRoot, f.FileName)
 
-		if !fs.FileExists(fileName) {
+		if !fs.FileExists(fileName) && !f.Remote() {
 			log.Errorf("%s: could not find original for %s", albumCover, fileName)
 			c.Data(http.StatusOK, "image/svg+xml", albumIconSvg)
 
@@ -92,7 +92,7 @@ func AlbumCover(router *gin.RouterGroup) {
 		}
 
 		// Use original file if thumb size exceeds limit, see https://github.com/photoprism/photoprism/issues/157
-		if thumbType.ExceedsSizeUncached() && c.Query("download") == "" {
+		if thumbType.ExceedsSizeUncached() && c.Query("download") == "" && !f.Remote() {
 			log.Debugf("%s: using original, size exceeds limit (width %d, height %d)", albumCover, thumbType.Width, thumbType.Height)
 			AddCoverCacheHeader(c)
 			c.File(fileName)
@@ -101,13 +101,13 @@ func AlbumCover(router *gin.RouterGroup) {
 
 		var thumbnail string
 
-		if conf.ThumbUncached() || thumbType.OnDemand() {
-			thumbnail, err = thumb.FromFile(fileName, f.FileHash, conf.ThumbPath(), thumbType.Width, thumbType.Height, f.FileOrientation, thumbType.Options...)
-		} else {
-			thumbnail, err = thumb.FromCache(fileName, f.FileHash, conf.ThumbPath(), thumbType.Width, thumbType.Height, thumbType.Options...)
-		}
+		thumbnail, err = ThumbFromFile(conf, fileName, f, thumbType)
 
-		if err != nil {
+		if err == thumb.ErrThumbNotCached && f.Remote() {
+			log.Debugf("%s: %s not available for remote file %s", albumCover, typeName, txt.Quote(f.FileName))
+			c.Data(http.StatusNotFound, "image/svg+xml", albumIconSvg)
+			return
+		} else if err != nil {
 			log.Errorf("%s: %s", albumCover, err)
 			c.Data(http.StatusOK, "image/svg+xml", albumIconSvg)
 			return
@@ -191,7 +191,7 @@ func LabelCover(router *gin.RouterGroup) {
 
 		fileName := photoprism.FileName(f.FileRoot, f.FileName)
 
-		if !fs.FileExists(fileName) {
+		if !fs.FileExists(fileName) && !f.Remote() {
 			log.Errorf("%s: file %s is missing", labelCover, txt.Quote(f.FileName))
 			c.Data(http.StatusOK, "image/svg+xml", labelIconSvg)
 
@@ -202,7 +202,7 @@ func LabelCover(router *gin.RouterGroup) {
 		}
 
 		// Use original file if thumb size exceeds limit, see https://github.com/photoprism/photoprism/issues/157
-		if thumbType.ExceedsSizeUncached() {
+		if thumbType.ExceedsSizeUncached() && !f.Remote() {
 			log.Debugf("%s: using original, size exceeds limit (width %d, height %d)", labelCover, thumbType.Width, thumbType.Height)
 
 			AddCoverCacheHeader(c)
@@ -213,13 +213,13 @@ func LabelCover(router *gin.RouterGroup) {
 
 		var thumbnail string
 
-		if conf.ThumbUncached() || thumbType.OnDemand() {
-			thumbnail, err = thumb.FromFile(fileName, f.FileHash, conf.ThumbPath(), thumbType.Width, thumbType.Height, f.FileOrientation, thumbType.Options...)
-		} else {
-			thumbnail, err = thumb.FromCache(fileName, f.FileHash, conf.ThumbPath(), thumbType.Width, thumbType.Height, thumbType.Options...)
-		}
+		thumbnail, err = ThumbFromFile(conf, fileName, f, thumbType)
 
-		if err != nil {
+		if err == thumb.ErrThumbNotCached && f.Remote() {
+			log.Debugf("%s: %s not available for remote file %s", labelCover, typeName, txt.Quote(f.FileName))
+			c.Data(http.StatusNotFound, "image/svg+xml", labelIconSvg)
+			return
+		} else if err != nil {
 			log.Errorf("%s: %s", labelCover, err)
 			c.Data(http.StatusOK, "image/svg+xml", labelIconSvg)
 			return
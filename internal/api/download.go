@@ -1,6 +1,7 @@
 package api
 
 import (
+	"mime"
 	"net/http"
 
 	"github.com/photoprism/photoprism/internal/entity"
@@ -55,7 +56,10 @@ func GetDownload(router *gin.RouterGroup) {
 
 		fileName := photoprism.FileName(f.FileRoot, f.FileName)
 
-		if !fs.FileExists(fileName) {
+		if !fs.FileExists(fileName) && f.Remote() {
+			DownloadRemote(c, f, f.FileMime, f.DownloadName(DownloadName(c), 0))
+			return
+		} else if !fs.FileExists(fileName) {
 			log.Errorf("download: file %s is missing", txt.Quote(f.FileName))
 			c.Data(404, "image/svg+xml", brokenIconSvg)
 
@@ -68,3 +72,29 @@ func GetDownload(router *gin.RouterGroup) {
 		c.FileAttachment(fileName, f.DownloadName(DownloadName(c), 0))
 	})
 }
+
+// DownloadRemote streams the original of a file in a remote library, as attachment if a download name is provided.
+func DownloadRemote(c *gin.Context, f entity.File, contentType, downloadName string) {
+	reader, err := photoprism.OpenRemote(f)
+
+	if err != nil {
+		log.Errorf("download: %s", err)
+		c.Data(http.StatusNotFound, "image/svg+xml", brokenIconSvg)
+		return
+	}
+
+	defer reader.Close()
+
+	var headers map[string]string
+
+	if downloadName != "" {
+		// Quote and encode the file name as needed, see RFC 6266.
+		headers = map[string]string{"Content-Disposition": mime.FormatMediaType("attachment", map[string]string{"filename": downloadName})}
+	}
+
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	c.DataFromReader(http.StatusOK, f.FileSize, contentType, reader, headers)
+}
@@ -50,6 +50,9 @@ func DeleteFile(router *gin.RouterGroup) {
 			log.Errorf("photo: can't delete primary file")
 			AbortDeleteFailed(c)
 			return
+		} else if conf.RootReadOnly(file.FileRoot) {
+			Abort(c, http.StatusForbidden, i18n.ErrReadOnly)
+			return
 		}
 
 		fileName := photoprism.FileName(file.FileRoot, file.FileName)
@@ -73,6 +76,8 @@ func DeleteFile(router *gin.RouterGroup) {
 			return
 		}
 
+		Audit(c, s.User, "files.delete", file.FileUID, file, nil)
+
 		// Notify clients by publishing events.
 		PublishPhotoEvent(EntityUpdated, photoUID, c)
 
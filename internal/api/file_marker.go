@@ -1,18 +1,160 @@
 package api
 
 import (
+	"encoding/json"
+	"fmt"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/event"
 	"github.com/photoprism/photoprism/internal/form"
 	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/photoprism"
 	"github.com/photoprism/photoprism/internal/query"
 	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/pkg/fs"
 	"github.com/photoprism/photoprism/pkg/txt"
 )
 
+// markerFile returns the primary file of a photo for editing markers.
+func markerFile(photoUID, fileUID string) (file entity.File, err error) {
+	if photoUID == "" || fileUID == "" {
+		return file, fmt.Errorf("photo and file uid must not be empty")
+	}
+
+	file, err = query.FileByUID(fileUID)
+
+	if err != nil {
+		return file, err
+	}
+
+	if !file.FilePrimary {
+		return file, fmt.Errorf("can't update markers for non-primary files")
+	} else if file.PhotoUID != photoUID {
+		return file, fmt.Errorf("file uid doesn't match")
+	}
+
+	return file, nil
+}
+
+// updateMarkerEmbedding computes the embedding of a face marker, e.g. after it was drawn or moved manually.
+func updateMarkerEmbedding(file entity.File, marker *entity.Marker) {
+	if marker.MarkerType != entity.MarkerFace || marker.MarkerInvalid || marker.Embedding != "" {
+		return
+	}
+
+	if service.Config().DisableTensorFlow() {
+		return
+	}
+
+	fileName := photoprism.FileName(file.FileRoot, file.FileName)
+
+	if !fs.FileExists(fileName) {
+		log.Errorf("photo: file %s is missing (update face embedding)", txt.Quote(file.FileName))
+		return
+	}
+
+	mf, err := photoprism.NewMediaFile(fileName)
+
+	if err != nil {
+		log.Errorf("photo: %s (update face embedding)", err)
+		return
+	}
+
+	embedding, err := service.Index().FaceEmbedding(mf, marker.X, marker.Y, marker.W, marker.H)
+
+	if err != nil {
+		log.Errorf("photo: %s (update face embedding)", err)
+		return
+	}
+
+	if b, err := json.Marshal(embedding); err != nil {
+		log.Errorf("photo: %s (update face embedding)", err)
+	} else if err := marker.Update("Embedding", string(b)); err != nil {
+		log.Errorf("photo: %s (update face embedding)", err)
+	} else {
+		marker.Embedding = string(b)
+	}
+}
+
+// updateMarkerPhoto updates the face count after markers have been changed and returns the photo.
+func updateMarkerPhoto(c *gin.Context, photoUID string, file entity.File) {
+	if p, err := query.PhotoPreloadByUID(photoUID); err != nil {
+		AbortEntityNotFound(c)
+		return
+	} else {
+		if faceCount := file.FaceCount(); p.PhotoFaces == faceCount {
+			// Do nothing.
+		} else if err := p.Update("PhotoFaces", faceCount); err != nil {
+			log.Errorf("photo: %s (update face count)", err)
+		} else {
+			// Notify clients by publishing events.
+			PublishPhotoEvent(EntityUpdated, photoUID, c)
+
+			p.PhotoFaces = faceCount
+		}
+
+		c.JSON(http.StatusOK, p)
+	}
+}
+
+// POST /api/v1/photos/:uid/files/:file_uid/markers
+//
+// Parameters:
+//   uid: string Photo UID as returned by the API
+//   file_uid: string File UID as returned by the API
+func CreateFileMarker(router *gin.RouterGroup) {
+	router.POST("/photos/:uid/files/:file_uid/markers", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourceFiles, acl.ActionUpdate)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		conf := service.Config()
+
+		if !conf.Settings().Features.Edit {
+			AbortFeatureDisabled(c)
+			return
+		}
+
+		photoUID := c.Param("uid")
+
+		file, err := markerFile(photoUID, c.Param("file_uid"))
+
+		if err != nil {
+			log.Errorf("photo: %s (create marker)", err)
+			AbortBadRequest(c)
+			return
+		}
+
+		var markerForm form.Marker
+
+		if err := c.BindJSON(&markerForm); err != nil {
+			log.Errorf("photo: %s (create marker form)", err)
+			AbortBadRequest(c)
+			return
+		}
+
+		marker, err := entity.CreateMarker(file.ID, markerForm)
+
+		if err != nil {
+			log.Errorf("photo: %s (create marker)", err)
+			AbortBadRequest(c)
+			return
+		}
+
+		updateMarkerEmbedding(file, marker)
+
+		event.SuccessMsg(i18n.MsgChangesSaved)
+
+		updateMarkerPhoto(c, photoUID, file)
+	})
+}
+
 // PUT /api/v1/photos/:uid/files/:file_uid/markers/:id
 //
 // Parameters:
@@ -36,36 +178,25 @@ func UpdateFileMarker(router *gin.RouterGroup) {
 		}
 
 		photoUID := c.Param("uid")
-		fileUID := c.Param("file_uid")
 		markerID := txt.UInt(c.Param("id"))
 
-		if photoUID == "" || fileUID == "" || markerID < 1 {
+		if markerID < 1 {
 			AbortBadRequest(c)
 			return
 		}
 
-		file, err := query.FileByUID(fileUID)
+		file, err := markerFile(photoUID, c.Param("file_uid"))
 
 		if err != nil {
 			log.Errorf("photo: %s (update marker)", err)
-			AbortEntityNotFound(c)
-			return
-		}
-
-		if !file.FilePrimary {
-			log.Errorf("photo: can't update markers for non-primary files")
-			AbortBadRequest(c)
-			return
-		} else if file.PhotoUID != photoUID {
-			log.Errorf("photo: file uid doesn't match")
 			AbortBadRequest(c)
 			return
 		}
 
 		marker, err := query.MarkerByID(markerID)
 
-		if err != nil {
-			log.Errorf("photo: %s (update marker)", err)
+		if err != nil || marker.FileID != file.ID {
+			log.Errorf("photo: marker %d not found (update marker)", markerID)
 			AbortEntityNotFound(c)
 			return
 		}
@@ -84,30 +215,86 @@ func UpdateFileMarker(router *gin.RouterGroup) {
 			return
 		}
 
-		if err := marker.SaveForm(markerForm); err != nil {
+		if err := marker.SaveForm(markerForm); err == entity.ErrInvalidMarkerSize || err == entity.ErrInvalidMarkerPosition {
+			log.Errorf("photo: %s (save marker form)", err)
+			AbortBadRequest(c)
+			return
+		} else if err != nil {
 			log.Errorf("photo: %s (save marker form)", err)
 			AbortSaveFailed(c)
 			return
 		}
 
+		updateMarkerEmbedding(file, &marker)
+
 		event.SuccessMsg(i18n.MsgChangesSaved)
 
-		if p, err := query.PhotoPreloadByUID(photoUID); err != nil {
+		updateMarkerPhoto(c, photoUID, file)
+	})
+}
+
+// DELETE /api/v1/photos/:uid/files/:file_uid/markers/:id
+//
+// Parameters:
+//   uid: string Photo UID as returned by the API
+//   file_uid: string File UID as returned by the API
+//   id: int Marker ID as returned by the API
+func DeleteFileMarker(router *gin.RouterGroup) {
+	router.DELETE("/photos/:uid/files/:file_uid/markers/:id", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourceFiles, acl.ActionUpdate)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		conf := service.Config()
+
+		if !conf.Settings().Features.Edit {
+			AbortFeatureDisabled(c)
+			return
+		}
+
+		photoUID := c.Param("uid")
+		markerID := txt.UInt(c.Param("id"))
+
+		if markerID < 1 {
+			AbortBadRequest(c)
+			return
+		}
+
+		file, err := markerFile(photoUID, c.Param("file_uid"))
+
+		if err != nil {
+			log.Errorf("photo: %s (delete marker)", err)
+			AbortBadRequest(c)
+			return
+		}
+
+		marker, err := query.MarkerByID(markerID)
+
+		if err != nil || marker.FileID != file.ID {
+			log.Errorf("photo: marker %d not found (delete marker)", markerID)
 			AbortEntityNotFound(c)
 			return
-		} else {
-			if faceCount := file.FaceCount(); p.PhotoFaces == faceCount {
-				// Do nothing.
-			} else if err := p.Update("PhotoFaces", faceCount); err != nil {
-				log.Errorf("photo: %s (update face count)", err)
-			} else {
-				// Notify clients by publishing events.
-				PublishPhotoEvent(EntityUpdated, photoUID, c)
+		}
 
-				p.PhotoFaces = faceCount
-			}
+		// Markers found by automatic detection are flagged as invalid, also if they have been
+		// moved manually, so that indexing doesn't add them again.
+		if marker.MarkerDrawn {
+			err = marker.Delete()
+		} else {
+			err = marker.Invalidate()
+		}
 
-			c.JSON(http.StatusOK, p)
+		if err != nil {
+			log.Errorf("photo: %s (delete marker)", err)
+			AbortDeleteFailed(c)
+			return
 		}
+
+		event.SuccessMsg(i18n.MsgChangesSaved)
+
+		updateMarkerPhoto(c, photoUID, file)
 	})
 }
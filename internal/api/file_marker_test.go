@@ -8,6 +8,8 @@ import (
 
 	"github.com/tidwall/gjson"
 
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/query"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -60,3 +62,197 @@ func TestUpdateFileMarker(t *testing.T) {
 		assert.Equal(t, http.StatusOK, r.Code)
 	})
 }
+
+func TestCreateFileMarker(t *testing.T) {
+	t.Run("LabelMarker", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+
+		CreateFileMarker(router)
+
+		r := PerformRequestWithBody(app, "POST", "/api/v1/photos/pt9jtdre2lvl0y12/files/ft3es39w45bnlqdw/markers",
+			`{"Type": "Label", "Label": "Railing", "X": 0.4, "Y": 0.6, "W": 0.2, "H": 0.1}`)
+
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		marker := gjson.Get(r.Body.String(), `Files.#(UID=="ft3es39w45bnlqdw").Markers.#(Label=="Railing")`)
+
+		assert.Equal(t, "manual", marker.Get("Src").String())
+		assert.NotEmpty(t, marker.Get("RefUID").String())
+	})
+	t.Run("FaceMarker", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+
+		CreateFileMarker(router)
+
+		r := PerformRequestWithBody(app, "POST", "/api/v1/photos/pt9jtdre2lvl0y12/files/ft3es39w45bnlqdw/markers",
+			`{"Type": "Face", "Label": "jane doe", "X": 0.7, "Y": 0.3, "W": 0.1, "H": 0.15}`)
+
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		marker := gjson.Get(r.Body.String(), `Files.#(UID=="ft3es39w45bnlqdw").Markers.#(Label=="Jane Doe")`)
+
+		assert.Equal(t, "Face", marker.Get("Type").String())
+		assert.Equal(t, "manual", marker.Get("Src").String())
+	})
+	t.Run("InvalidType", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+
+		CreateFileMarker(router)
+
+		r := PerformRequestWithBody(app, "POST", "/api/v1/photos/pt9jtdre2lvl0y12/files/ft3es39w45bnlqdw/markers",
+			`{"Type": "Foo", "X": 0.4, "Y": 0.6, "W": 0.2, "H": 0.1}`)
+
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("InvalidSize", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+
+		CreateFileMarker(router)
+
+		r := PerformRequestWithBody(app, "POST", "/api/v1/photos/pt9jtdre2lvl0y12/files/ft3es39w45bnlqdw/markers",
+			`{"Type": "Face", "X": 0.4, "Y": 0.6, "W": 0, "H": 0}`)
+
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("WrongPhoto", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+
+		CreateFileMarker(router)
+
+		r := PerformRequestWithBody(app, "POST", "/api/v1/photos/pt9jtdre2lvl0y13/files/ft3es39w45bnlqdw/markers",
+			`{"Type": "Face", "X": 0.4, "Y": 0.6, "W": 0.2, "H": 0.1}`)
+
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
+
+func TestUpdateFileMarker_Move(t *testing.T) {
+	app, router, _ := NewApiTest()
+
+	CreateFileMarker(router)
+	UpdateFileMarker(router)
+
+	r := PerformRequestWithBody(app, "POST", "/api/v1/photos/pt9jtdre2lvl0y12/files/ft3es39w45bnlqdw/markers",
+		`{"Type": "Face", "Label": "Move Test", "X": 0.2, "Y": 0.3, "W": 0.1, "H": 0.15}`)
+
+	assert.Equal(t, http.StatusOK, r.Code)
+
+	markerID := gjson.Get(r.Body.String(), `Files.#(UID=="ft3es39w45bnlqdw").Markers.#(Label=="Move Test").ID`).String()
+
+	assert.NotEmpty(t, markerID)
+
+	u := fmt.Sprintf("/api/v1/photos/pt9jtdre2lvl0y12/files/ft3es39w45bnlqdw/markers/%s", markerID)
+
+	t.Run("Resize", func(t *testing.T) {
+		r := PerformRequestWithBody(app, "PUT", u, `{"X": 0.25, "Y": 0.35, "W": 0.2, "H": 0.3}`)
+
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		marker := gjson.Get(r.Body.String(), `Files.#(UID=="ft3es39w45bnlqdw").Markers.#(Label=="Move Test")`)
+
+		assert.Equal(t, 0.25, marker.Get("X").Float())
+		assert.Equal(t, 0.3, marker.Get("H").Float())
+	})
+	t.Run("InvalidSize", func(t *testing.T) {
+		r := PerformRequestWithBody(app, "PUT", u, `{"W": 2}`)
+
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("NoFace", func(t *testing.T) {
+		r := PerformRequestWithBody(app, "PUT", u, `{"NoFace": true}`)
+
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.False(t, gjson.Get(r.Body.String(), `Files.#(UID=="ft3es39w45bnlqdw").Markers.#(Label=="Move Test")`).Exists())
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/photos/pt9jtdre2lvl0y12/files/ft3es39w45bnlqdw/markers/99999", `{"Invalid": true}`)
+
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
+
+func TestDeleteFileMarker(t *testing.T) {
+	app, router, _ := NewApiTest()
+
+	CreateFileMarker(router)
+	DeleteFileMarker(router)
+
+	r := PerformRequestWithBody(app, "POST", "/api/v1/photos/pt9jtdre2lvl0y12/files/ft3es39w45bnlqdw/markers",
+		`{"Type": "Label", "Label": "Delete Test", "X": 0.2, "Y": 0.3, "W": 0.1, "H": 0.15}`)
+
+	assert.Equal(t, http.StatusOK, r.Code)
+
+	markerID := gjson.Get(r.Body.String(), `Files.#(UID=="ft3es39w45bnlqdw").Markers.#(Label=="Delete Test").ID`).String()
+
+	assert.NotEmpty(t, markerID)
+
+	u := fmt.Sprintf("/api/v1/photos/pt9jtdre2lvl0y12/files/ft3es39w45bnlqdw/markers/%s", markerID)
+
+	t.Run("Success", func(t *testing.T) {
+		r := PerformRequest(app, "DELETE", u)
+
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.False(t, gjson.Get(r.Body.String(), `Files.#(UID=="ft3es39w45bnlqdw").Markers.#(Label=="Delete Test")`).Exists())
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		r := PerformRequest(app, "DELETE", u)
+
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("Detected", func(t *testing.T) {
+		file, err := query.FileByUID("ft3es39w45bnlqdw")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		m := entity.NewMarker(file.ID, "", entity.SrcImage, entity.MarkerLabel, 0.6, 0.7, 0.1, 0.15)
+		m.MarkerLabel = "Detected Test"
+
+		if err := m.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		r := PerformRequest(app, "DELETE", fmt.Sprintf("/api/v1/photos/pt9jtdre2lvl0y12/files/ft3es39w45bnlqdw/markers/%d", m.ID))
+
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		// Markers found by detection are kept as invalid, so that indexing doesn't add them again.
+		marker := gjson.Get(r.Body.String(), fmt.Sprintf(`Files.#(UID=="ft3es39w45bnlqdw").Markers.#(ID==%d)`, m.ID))
+
+		assert.True(t, marker.Get("Invalid").Bool())
+		assert.Equal(t, entity.SrcManual, marker.Get("Src").String())
+	})
+	t.Run("MovedDetected", func(t *testing.T) {
+		UpdateFileMarker(router)
+
+		file, err := query.FileByUID("ft3es39w45bnlqdw")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		m := entity.NewMarker(file.ID, "", entity.SrcImage, entity.MarkerLabel, 0.6, 0.2, 0.1, 0.15)
+		m.MarkerLabel = "Moved Detected Test"
+
+		if err := m.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		u := fmt.Sprintf("/api/v1/photos/pt9jtdre2lvl0y12/files/ft3es39w45bnlqdw/markers/%d", m.ID)
+
+		r := PerformRequestWithBody(app, "PUT", u, `{"X": 0.65, "Y": 0.25, "W": 0.1, "H": 0.15}`)
+
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		r = PerformRequest(app, "DELETE", u)
+
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		// Markers found by detection are kept as invalid, also if they have been moved manually.
+		marker := gjson.Get(r.Body.String(), fmt.Sprintf(`Files.#(UID=="ft3es39w45bnlqdw").Markers.#(ID==%d)`, m.ID))
+
+		assert.True(t, marker.Get("Invalid").Bool())
+		assert.False(t, marker.Get("Drawn").Bool())
+	})
+}
@@ -103,6 +103,15 @@ func GetFoldersOriginals(router *gin.RouterGroup) {
 	GetFolders(router, "originals", entity.RootOriginals, conf.OriginalsPath())
 }
 
+// GET /api/v1/folders/library/:name
+func GetFoldersLibraries(router *gin.RouterGroup) {
+	conf := service.Config()
+
+	for _, lib := range conf.Libraries() {
+		GetFolders(router, "library/"+lib.Name, lib.Name, lib.Path)
+	}
+}
+
 // GET /api/v1/folders/import
 func GetFoldersImport(router *gin.RouterGroup) {
 	conf := service.Config()
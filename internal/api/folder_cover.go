@@ -90,7 +90,7 @@ func GetFolderCover(router *gin.RouterGroup) {
 
 		fileName := photoprism.FileName(f.FileRoot, f.FileName)
 
-		if !fs.FileExists(fileName) {
+		if !fs.FileExists(fileName) && !f.Remote() {
 			log.Errorf("%s: could not find original for %s", folderCover, fileName)
 			c.Data(http.StatusOK, "image/svg+xml", folderIconSvg)
 
@@ -101,7 +101,7 @@ func GetFolderCover(router *gin.RouterGroup) {
 		}
 
 		// Use original file if thumb size exceeds limit, see https://github.com/photoprism/photoprism/issues/157
-		if thumbType.ExceedsSizeUncached() && !download {
+		if thumbType.ExceedsSizeUncached() && !download && !f.Remote() {
 			log.Debugf("%s: using original, size exceeds limit (width %d, height %d)", folderCover, thumbType.Width, thumbType.Height)
 			AddCoverCacheHeader(c)
 			c.File(fileName)
@@ -110,13 +110,13 @@ func GetFolderCover(router *gin.RouterGroup) {
 
 		var thumbnail string
 
-		if conf.ThumbUncached() || thumbType.OnDemand() {
-			thumbnail, err = thumb.FromFile(fileName, f.FileHash, conf.ThumbPath(), thumbType.Width, thumbType.Height, f.FileOrientation, thumbType.Options...)
-		} else {
-			thumbnail, err = thumb.FromCache(fileName, f.FileHash, conf.ThumbPath(), thumbType.Width, thumbType.Height, thumbType.Options...)
-		}
+		thumbnail, err = ThumbFromFile(conf, fileName, f, thumbType)
 
-		if err != nil {
+		if err == thumb.ErrThumbNotCached && f.Remote() {
+			log.Debugf("%s: %s not available for remote file %s", folderCover, typeName, txt.Quote(f.FileName))
+			c.Data(http.StatusNotFound, "image/svg+xml", folderIconSvg)
+			return
+		} else if err != nil {
 			log.Errorf("%s: %s", folderCover, err)
 			c.Data(http.StatusOK, "image/svg+xml", folderIconSvg)
 			return
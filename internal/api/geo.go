@@ -2,10 +2,15 @@ package api
 
 import (
 	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/photoprism/photoprism/internal/acl"
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/pkg/mvt"
 	"github.com/photoprism/photoprism/pkg/txt"
 
 	"github.com/gin-gonic/gin"
@@ -104,3 +109,132 @@ func GetGeo(router *gin.RouterGroup) {
 		c.Data(http.StatusOK, "application/json", resp)
 	})
 }
+
+// GET /api/v1/geo/tiles/:z/:x/:y
+//
+// Returns geotagged photos within a map tile, clustered by S2 cell.
+//
+// Parameters:
+//   z: int Zoom level
+//   x: int Tile column
+//   y: int Tile row, optionally followed by .json (GeoJSON, default) or .mvt (Mapbox Vector Tile)
+func GetGeoTile(router *gin.RouterGroup) {
+	router.GET("/geo/tiles/:z/:x/:y", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourcePhotos, acl.ActionSearch)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		var f form.GeoSearch
+
+		if err := c.MustBindWith(&f, binding.Form); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		y := c.Param("y")
+		format := strings.ToLower(strings.TrimPrefix(filepath.Ext(y), "."))
+
+		switch format {
+		case "", "json", "geojson":
+			format = "json"
+		case "mvt", "pbf":
+			format = "mvt"
+		default:
+			AbortBadRequest(c)
+			return
+		}
+
+		tile, err := mvt.NewTile(txt.Int(c.Param("z")), txt.Int(c.Param("x")), txt.Int(strings.TrimSuffix(y, filepath.Ext(y))))
+
+		if err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		start := time.Now()
+		cache := service.TileCache()
+		cacheKey := CacheKey("geo-tile", tile.String()+"."+format, f.SerializeAll())
+
+		AddTokenHeaders(c)
+
+		if cacheData, ok := cache.Get(cacheKey); ok {
+			log.Debugf("api: cache hit for %s [%s]", cacheKey, time.Since(start))
+			c.Data(http.StatusOK, geoTileContentType(format), cacheData.(ByteCache).Data)
+			return
+		}
+
+		clusters, err := query.GeoTile(f, tile)
+
+		if err != nil {
+			c.AbortWithStatusJSON(400, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		var resp []byte
+
+		if format == "mvt" {
+			resp = geoTileMvt(tile, clusters)
+		} else if resp, err = geoTileJson(clusters); err != nil {
+			c.AbortWithStatusJSON(400, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		cache.SetDefault(cacheKey, ByteCache{resp})
+
+		c.Data(http.StatusOK, geoTileContentType(format), resp)
+	})
+}
+
+// geoTileContentType returns the content type of a map tile format.
+func geoTileContentType(format string) string {
+	if format == "mvt" {
+		return mvt.ContentType
+	}
+
+	return "application/json"
+}
+
+// geoTileJson returns photo clusters as GeoJSON feature collection.
+func geoTileJson(clusters query.GeoClusters) ([]byte, error) {
+	fc := geojson.NewFeatureCollection()
+
+	for _, cluster := range clusters {
+		feat := geojson.NewPointFeature([]float64{cluster.Lng, cluster.Lat})
+		feat.ID = cluster.Cell
+		feat.Properties = gin.H{
+			"Cell":  cluster.Cell,
+			"Count": cluster.Count,
+			"UID":   cluster.PhotoUID,
+			"Hash":  cluster.FileHash,
+		}
+		fc.AddFeature(feat)
+	}
+
+	return fc.MarshalJSON()
+}
+
+// geoTileMvt returns photo clusters as Mapbox Vector Tile with a single "photos" layer.
+func geoTileMvt(tile mvt.Tile, clusters query.GeoClusters) []byte {
+	layer := mvt.Layer{Name: "photos", Extent: mvt.DefaultExtent}
+
+	for i, cluster := range clusters {
+		x, y := tile.Pixel(cluster.Lat, cluster.Lng, layer.Extent)
+
+		layer.Features = append(layer.Features, mvt.Feature{
+			ID: uint64(i + 1),
+			X:  x,
+			Y:  y,
+			Properties: map[string]interface{}{
+				"Cell":  cluster.Cell,
+				"Count": cluster.Count,
+				"UID":   cluster.PhotoUID,
+				"Hash":  cluster.FileHash,
+			},
+		})
+	}
+
+	return mvt.Encode(layer)
+}
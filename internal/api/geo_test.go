@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
 )
 
 func TestGetGeo(t *testing.T) {
@@ -17,3 +18,47 @@ func TestGetGeo(t *testing.T) {
 		assert.Equal(t, http.StatusOK, result.Code)
 	})
 }
+
+func TestGetGeoTile(t *testing.T) {
+	t.Run("geojson", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+
+		GetGeoTile(router)
+
+		r := PerformRequest(app, "GET", "/api/v1/geo/tiles/0/0/0.json")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "FeatureCollection", gjson.Get(r.Body.String(), "type").String())
+		assert.LessOrEqual(t, int64(1), gjson.Get(r.Body.String(), "features.#").Int())
+		assert.LessOrEqual(t, int64(1), gjson.Get(r.Body.String(), "features.0.properties.Count").Int())
+
+		// Cached.
+		cached := PerformRequest(app, "GET", "/api/v1/geo/tiles/0/0/0.json")
+		assert.Equal(t, r.Body.String(), cached.Body.String())
+	})
+	t.Run("mvt", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+
+		GetGeoTile(router)
+
+		r := PerformRequest(app, "GET", "/api/v1/geo/tiles/0/0/0.mvt")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "application/vnd.mapbox-vector-tile", r.Header().Get("Content-Type"))
+		assert.Contains(t, r.Body.String(), "photos")
+	})
+	t.Run("invalid tile", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+
+		GetGeoTile(router)
+
+		r := PerformRequest(app, "GET", "/api/v1/geo/tiles/1/2/0")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("invalid format", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+
+		GetGeoTile(router)
+
+		r := PerformRequest(app, "GET", "/api/v1/geo/tiles/0/0/0.png")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
@@ -44,9 +44,19 @@ func StartIndexing(router *gin.RouterGroup) {
 
 		path := conf.OriginalsPath()
 
+		if f.Root != "" && f.Root != entity.RootOriginals {
+			if lib, ok := conf.Library(f.Root); !ok {
+				Abort(c, http.StatusNotFound, i18n.ErrNotFound)
+				return
+			} else {
+				path = lib.Path
+			}
+		}
+
 		ind := service.Index()
 
 		indOpt := photoprism.IndexOptions{
+			Root:    f.Root,
 			Rescan:  f.Rescan,
 			Convert: conf.Settings().Index.Convert && conf.SidecarWritable(),
 			Path:    filepath.Clean(f.Path),
@@ -61,7 +71,7 @@ func StartIndexing(router *gin.RouterGroup) {
 
 		indexed := ind.Start(indOpt)
 
-		RemoveFromFolderCache(entity.RootOriginals)
+		RemoveFromFolderCache(indOpt.RootName())
 
 		prg := service.Purge()
 
@@ -0,0 +1,164 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// GET /api/v1/index/reports
+//
+// Parameters:
+//   type: string Report type, index or import (optional)
+//   count: int Max result count
+//   offset: int Result offset
+func GetIndexReports(router *gin.RouterGroup) {
+	router.GET("/index/reports", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourceReports, acl.ActionSearch)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		limit := txt.Int(c.Query("count"))
+		offset := txt.Int(c.Query("offset"))
+
+		result, err := query.IndexReports(c.Query("type"), limit, offset)
+
+		if err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		AddCountHeader(c, len(result))
+		AddLimitHeader(c, limit)
+		AddOffsetHeader(c, offset)
+
+		c.JSON(http.StatusOK, result)
+	})
+}
+
+// GET /api/v1/index/reports/:id
+//
+// Parameters:
+//   id: int Report ID
+func GetIndexReport(router *gin.RouterGroup) {
+	router.GET("/index/reports/:id", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourceReports, acl.ActionRead)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		report := entity.FindIndexReport(ParseUint(c.Param("id")))
+
+		if report == nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		c.JSON(http.StatusOK, report)
+	})
+}
+
+// GET /api/v1/index/reports/:id/failures
+//
+// Returns the files that failed with reason and stage.
+//
+// Parameters:
+//   id: int Report ID
+//   count: int Max result count
+//   offset: int Result offset
+func GetIndexFailures(router *gin.RouterGroup) {
+	router.GET("/index/reports/:id/failures", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourceReports, acl.ActionRead)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		report := entity.FindIndexReport(ParseUint(c.Param("id")))
+
+		if report == nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		limit := txt.Int(c.Query("count"))
+		offset := txt.Int(c.Query("offset"))
+
+		result, err := query.IndexFailures(report.ID, limit, offset)
+
+		if err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		AddCountHeader(c, len(result))
+		AddLimitHeader(c, limit)
+		AddOffsetHeader(c, offset)
+
+		c.JSON(http.StatusOK, result)
+	})
+}
+
+// POST /api/v1/index/reports/:id/retry
+//
+// Indexes or imports only the files that failed again and returns the new report.
+//
+// Parameters:
+//   id: int Report ID
+func RetryIndexReport(router *gin.RouterGroup) {
+	router.POST("/index/reports/:id/retry", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourceReports, acl.ActionUpdate)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		conf := service.Config()
+
+		if !conf.Settings().Features.Library {
+			AbortFeatureDisabled(c)
+			return
+		}
+
+		id := ParseUint(c.Param("id"))
+
+		report, err := service.Import().Retry(id)
+
+		switch err {
+		case nil:
+		case photoprism.ErrReportNotFound:
+			AbortEntityNotFound(c)
+			return
+		case photoprism.ErrNoFailedFiles:
+			AbortBadRequest(c)
+			return
+		case photoprism.ErrBusy:
+			Abort(c, http.StatusConflict, i18n.ErrBusy)
+			return
+		default:
+			Error(c, http.StatusInternalServerError, err, i18n.ErrUnexpected)
+			return
+		}
+
+		Audit(c, s.User, "index.retry", fmt.Sprintf("%d", id), nil, nil)
+
+		UpdateClientConfig()
+
+		c.JSON(http.StatusOK, report)
+	})
+}
@@ -0,0 +1,62 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestGetIndexReports(t *testing.T) {
+	m := entity.NewIndexReport(entity.ReportIndex, entity.RootOriginals, "/")
+
+	if err := m.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := entity.NewIndexFailure(m.ID, entity.RootOriginals, "api/broken.jpg", "convert", errors.New("failed")).Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("list", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetIndexReports(router)
+		r := PerformRequest(app, "GET", "/api/v1/index/reports?type=index&count=10")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "index", gjson.Get(r.Body.String(), "0.Type").String())
+	})
+	t.Run("report", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetIndexReport(router)
+		r := PerformRequest(app, "GET", fmt.Sprintf("/api/v1/index/reports/%d", m.ID))
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, int64(m.ID), gjson.Get(r.Body.String(), "ID").Int())
+	})
+	t.Run("failures", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetIndexFailures(router)
+		r := PerformRequest(app, "GET", fmt.Sprintf("/api/v1/index/reports/%d/failures", m.ID))
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "api/broken.jpg", gjson.Get(r.Body.String(), "0.Name").String())
+		assert.Equal(t, "convert", gjson.Get(r.Body.String(), "0.Stage").String())
+	})
+	t.Run("not found", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetIndexFailures(router)
+		r := PerformRequest(app, "GET", "/api/v1/index/reports/999999/failures")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
+
+func TestRetryIndexReport(t *testing.T) {
+	t.Run("not found", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		RetryIndexReport(router)
+		r := PerformRequest(app, "POST", "/api/v1/index/reports/999999/retry")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
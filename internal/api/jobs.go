@@ -0,0 +1,161 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/internal/workers"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// abortJob aborts the request with a matching status code for job errors.
+func abortJob(c *gin.Context, err error) {
+	switch err {
+	case workers.ErrJobNotFound:
+		AbortEntityNotFound(c)
+	case workers.ErrJobRunning:
+		Abort(c, http.StatusConflict, i18n.ErrBusy)
+	default:
+		Error(c, http.StatusInternalServerError, err, i18n.ErrUnexpected)
+	}
+}
+
+// GET /api/v1/jobs
+//
+// Returns the schedule, state and last run of all background jobs.
+func GetJobs(router *gin.RouterGroup) {
+	router.GET("/jobs", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourceJobs, acl.ActionSearch)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		c.JSON(http.StatusOK, workers.Jobs(service.Config()))
+	})
+}
+
+// GET /api/v1/jobs/:name/runs
+//
+// Parameters:
+//   name: string Job name, e.g. index
+//   count: int Max result count
+//   offset: int Result offset
+func GetJobRuns(router *gin.RouterGroup) {
+	router.GET("/jobs/:name/runs", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourceJobs, acl.ActionRead)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		name := c.Param("name")
+
+		if _, err := workers.Job(service.Config(), name); err != nil {
+			abortJob(c, err)
+			return
+		}
+
+		limit := txt.Int(c.Query("count"))
+		offset := txt.Int(c.Query("offset"))
+
+		result, err := query.JobRuns(name, limit, offset)
+
+		if err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		AddCountHeader(c, len(result))
+		AddLimitHeader(c, limit)
+		AddOffsetHeader(c, offset)
+
+		c.JSON(http.StatusOK, result)
+	})
+}
+
+// POST /api/v1/jobs/:name/run
+//
+// Starts a background job in the background, even if it is paused.
+//
+// Parameters:
+//   name: string Job name, e.g. index
+func RunJob(router *gin.RouterGroup) {
+	router.POST("/jobs/:name/run", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourceJobs, acl.ActionUpdate)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		conf := service.Config()
+		name := c.Param("name")
+
+		if err := workers.StartJob(conf, name, entity.JobTriggerManual); err != nil {
+			abortJob(c, err)
+			return
+		}
+
+		Audit(c, s.User, "jobs.run", name, nil, nil)
+
+		job, _ := workers.Job(conf, name)
+
+		c.JSON(http.StatusOK, job)
+	})
+}
+
+// POST /api/v1/jobs/:name/pause
+//
+// Parameters:
+//   name: string Job name, e.g. index
+func PauseJob(router *gin.RouterGroup) {
+	router.POST("/jobs/:name/pause", func(c *gin.Context) {
+		setJobPaused(c, true)
+	})
+}
+
+// DELETE /api/v1/jobs/:name/pause
+//
+// Parameters:
+//   name: string Job name, e.g. index
+func ResumeJob(router *gin.RouterGroup) {
+	router.DELETE("/jobs/:name/pause", func(c *gin.Context) {
+		setJobPaused(c, false)
+	})
+}
+
+// setJobPaused pauses or resumes scheduled runs of a background job.
+func setJobPaused(c *gin.Context, paused bool) {
+	s := Auth(SessionID(c), acl.ResourceJobs, acl.ActionUpdate)
+
+	if s.Invalid() {
+		AbortUnauthorized(c)
+		return
+	}
+
+	conf := service.Config()
+	name := c.Param("name")
+
+	if err := workers.PauseJob(name, paused); err != nil {
+		abortJob(c, err)
+		return
+	}
+
+	if paused {
+		Audit(c, s.User, "jobs.pause", name, nil, nil)
+	} else {
+		Audit(c, s.User, "jobs.resume", name, nil, nil)
+	}
+
+	job, _ := workers.Job(conf, name)
+
+	c.JSON(http.StatusOK, job)
+}
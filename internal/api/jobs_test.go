@@ -0,0 +1,61 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestGetJobs(t *testing.T) {
+	app, router, _ := NewApiTest()
+	GetJobs(router)
+
+	r := PerformRequest(app, "GET", "/api/v1/jobs")
+	assert.Equal(t, http.StatusOK, r.Code)
+	assert.Equal(t, "moments", gjson.Get(r.Body.String(), "#(Name==\"moments\").Name").String())
+	assert.Contains(t, gjson.Get(r.Body.String(), "#(Name==\"sync\").Schedule").String(), "@every")
+}
+
+func TestGetJobRuns(t *testing.T) {
+	t.Run("not found", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetJobRuns(router)
+		r := PerformRequest(app, "GET", "/api/v1/jobs/xxx/runs")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("success", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetJobRuns(router)
+		r := PerformRequest(app, "GET", "/api/v1/jobs/moments/runs?count=5")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.True(t, gjson.Get(r.Body.String(), "#").Exists())
+	})
+}
+
+func TestRunJob(t *testing.T) {
+	t.Run("not found", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		RunJob(router)
+		r := PerformRequest(app, "POST", "/api/v1/jobs/xxx/run")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
+
+func TestPauseJob(t *testing.T) {
+	app, router, _ := NewApiTest()
+	PauseJob(router)
+	ResumeJob(router)
+
+	r := PerformRequest(app, "POST", "/api/v1/jobs/backup/pause")
+	assert.Equal(t, http.StatusOK, r.Code)
+	assert.True(t, gjson.Get(r.Body.String(), "Paused").Bool())
+
+	r = PerformRequest(app, "DELETE", "/api/v1/jobs/backup/pause")
+	assert.Equal(t, http.StatusOK, r.Code)
+	assert.False(t, gjson.Get(r.Body.String(), "Paused").Bool())
+
+	r = PerformRequest(app, "POST", "/api/v1/jobs/xxx/pause")
+	assert.Equal(t, http.StatusNotFound, r.Code)
+}
@@ -0,0 +1,200 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/classify"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/service"
+)
+
+// labelRulesMutex serializes changes to the custom label rules, so that concurrent updates don't get lost.
+var labelRulesMutex = sync.Mutex{}
+
+// ruleName returns the normalized label rule name from the request path.
+func ruleName(c *gin.Context) string {
+	return strings.ToLower(strings.TrimSpace(c.Param("name")))
+}
+
+// saveLabelRules writes custom label rules to the rules file in the config path and activates them,
+// the caller must hold labelRulesMutex.
+func saveLabelRules(c *gin.Context, custom classify.LabelRules) bool {
+	if err := classify.ValidateCustomRules(custom); err != nil {
+		Error(c, http.StatusBadRequest, err, i18n.ErrBadRequest)
+		return false
+	}
+
+	if err := custom.Save(service.Config().LabelRulesFile()); err != nil {
+		Error(c, http.StatusInternalServerError, err, i18n.ErrSaveFailed)
+		return false
+	}
+
+	// Rules that passed validation are only activated once they have been saved.
+	if err := classify.SetCustomRules(custom); err != nil {
+		Error(c, http.StatusInternalServerError, err, i18n.ErrUnexpected)
+		return false
+	}
+
+	return true
+}
+
+// GET /api/v1/labels/rules
+//
+// Returns the active label rules, custom rules are merged over the built-in defaults.
+//
+// Parameters:
+//   custom: bool Return custom rules only
+func GetLabelRules(router *gin.RouterGroup) {
+	router.GET("/labels/rules", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourceLabels, acl.ActionRead)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		if c.Query("custom") == "true" {
+			c.JSON(http.StatusOK, classify.CustomRules())
+		} else {
+			c.JSON(http.StatusOK, classify.Rules())
+		}
+	})
+}
+
+// GET /api/v1/labels/rules/:name
+//
+// Parameters:
+//   name: string Label name as returned by image classification, e.g. tabby cat
+func GetLabelRule(router *gin.RouterGroup) {
+	router.GET("/labels/rules/:name", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourceLabels, acl.ActionRead)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		rule, ok := classify.FindRule(ruleName(c))
+
+		if !ok {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		c.JSON(http.StatusOK, rule)
+	})
+}
+
+// PUT /api/v1/labels/rules/:name
+//
+// Adds or replaces a custom label rule and saves it to the rules file.
+//
+// Parameters:
+//   name: string Label name as returned by image classification, e.g. tabby cat
+func UpdateLabelRule(router *gin.RouterGroup) {
+	router.PUT("/labels/rules/:name", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourceLabels, acl.ActionUpdate)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		var rule classify.LabelRule
+
+		if err := c.BindJSON(&rule); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		labelRulesMutex.Lock()
+		defer labelRulesMutex.Unlock()
+
+		name := ruleName(c)
+		custom := classify.CustomRules()
+		before, _ := classify.FindRule(name)
+		custom[name] = rule
+
+		if !saveLabelRules(c, custom) {
+			return
+		}
+
+		result, _ := classify.FindRule(name)
+
+		Audit(c, s.User, "labels.rule.update", name, before, result)
+
+		c.JSON(http.StatusOK, result)
+	})
+}
+
+// DELETE /api/v1/labels/rules/:name
+//
+// Removes a custom label rule, so that the built-in default applies again.
+//
+// Parameters:
+//   name: string Label name as returned by image classification, e.g. tabby cat
+func DeleteLabelRule(router *gin.RouterGroup) {
+	router.DELETE("/labels/rules/:name", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourceLabels, acl.ActionUpdate)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		labelRulesMutex.Lock()
+		defer labelRulesMutex.Unlock()
+
+		name := ruleName(c)
+		custom := classify.CustomRules()
+		before, ok := custom[name]
+
+		if !ok {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		delete(custom, name)
+
+		if !saveLabelRules(c, custom) {
+			return
+		}
+
+		Audit(c, s.User, "labels.rule.delete", name, before, nil)
+
+		c.JSON(http.StatusOK, classify.CustomRules())
+	})
+}
+
+// POST /api/v1/labels/rules/apply
+//
+// Applies the active label rules to existing labels found by image classification.
+func ApplyLabelRules(router *gin.RouterGroup) {
+	router.POST("/labels/rules/apply", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourceLabels, acl.ActionUpdate)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		result, err := photoprism.ApplyLabelRules()
+
+		if err == photoprism.ErrBusy {
+			Abort(c, http.StatusConflict, i18n.ErrBusy)
+			return
+		} else if err != nil {
+			Error(c, http.StatusInternalServerError, err, i18n.ErrUnexpected)
+			return
+		}
+
+		Audit(c, s.User, "labels.rules.apply", "", nil, result)
+
+		c.JSON(http.StatusOK, result)
+	})
+}
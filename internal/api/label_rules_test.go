@@ -0,0 +1,145 @@
+package api
+
+import (
+	"net/http"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/classify"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestGetLabelRules(t *testing.T) {
+	t.Run("all", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetLabelRules(router)
+		r := PerformRequest(app, "GET", "/api/v1/labels/rules")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "cat", gjson.Get(r.Body.String(), "tabby cat.label").String())
+	})
+	t.Run("rule", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetLabelRule(router)
+		r := PerformRequest(app, "GET", "/api/v1/labels/rules/cat")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "animal", gjson.Get(r.Body.String(), "categories.0").String())
+	})
+	t.Run("not found", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetLabelRule(router)
+		r := PerformRequest(app, "GET", "/api/v1/labels/rules/xxx")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
+
+func TestUpdateLabelRule(t *testing.T) {
+	fileName := service.Config().LabelRulesFile()
+
+	defer func() {
+		_ = classify.SetCustomRules(classify.LabelRules{})
+		_ = os.Remove(fileName)
+	}()
+
+	t.Run("update", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		UpdateLabelRule(router)
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/labels/rules/kitten", `{"label": "cat", "threshold": 0.4, "priority": 3, "categories": ["animal"]}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, int64(3), gjson.Get(r.Body.String(), "priority").Int())
+		assert.FileExists(t, fileName)
+
+		rule, ok := classify.FindRule("kitten")
+		assert.True(t, ok)
+		assert.Equal(t, "cat", rule.Label)
+	})
+	t.Run("invalid threshold", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		UpdateLabelRule(router)
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/labels/rules/kitten", `{"label": "cat", "threshold": 5}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+
+		// Invalid rules are neither saved nor activated.
+		rule, _ := classify.FindRule("kitten")
+		assert.Equal(t, float32(0.4), rule.Threshold)
+
+		saved, err := classify.LoadRules(fileName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, float32(0.4), saved["kitten"].Threshold)
+	})
+	t.Run("delete", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		DeleteLabelRule(router)
+		r := PerformRequest(app, "DELETE", "/api/v1/labels/rules/kitten")
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		_, ok := classify.FindRule("kitten")
+		assert.False(t, ok)
+	})
+	t.Run("delete default", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		DeleteLabelRule(router)
+		r := PerformRequest(app, "DELETE", "/api/v1/labels/rules/cat")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("concurrent", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		UpdateLabelRule(router)
+
+		names := []string{"kitten", "puppy", "foal", "calf"}
+
+		var wg sync.WaitGroup
+
+		for _, name := range names {
+			wg.Add(1)
+
+			go func(name string) {
+				defer wg.Done()
+				PerformRequestWithBody(app, "PUT", "/api/v1/labels/rules/"+name, `{"label": "animal"}`)
+			}(name)
+		}
+
+		wg.Wait()
+
+		saved, err := classify.LoadRules(fileName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// No update gets lost.
+		for _, name := range names {
+			assert.Contains(t, saved, name)
+			assert.Contains(t, classify.CustomRules(), name)
+		}
+	})
+}
+
+func TestApplyLabelRules(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		ApplyLabelRules(router)
+		r := PerformRequest(app, "POST", "/api/v1/labels/rules/apply")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.True(t, gjson.Get(r.Body.String(), "labels").Exists())
+	})
+	t.Run("busy", func(t *testing.T) {
+		if err := mutex.MainWorker.Start(); err != nil {
+			t.Fatal(err)
+		}
+
+		defer mutex.MainWorker.Stop()
+
+		app, router, _ := NewApiTest()
+		ApplyLabelRules(router)
+		r := PerformRequest(app, "POST", "/api/v1/labels/rules/apply")
+		assert.Equal(t, http.StatusConflict, r.Code)
+	})
+}
@@ -0,0 +1,221 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// LabelTaxonomy represents the parents, children and synonyms of a label.
+type LabelTaxonomy struct {
+	Parents  entity.Labels        `json:"Parents"`
+	Children entity.Labels        `json:"Children"`
+	Synonyms entity.LabelSynonyms `json:"Synonyms"`
+}
+
+// labelTaxonomy returns the taxonomy of a label.
+func labelTaxonomy(m *entity.Label) LabelTaxonomy {
+	return LabelTaxonomy{
+		Parents:  m.Parents(),
+		Children: m.Children(),
+		Synonyms: m.Synonyms(),
+	}
+}
+
+// GET /api/v1/labels/:uid/taxonomy
+//
+// Parameters:
+//   uid: string Label UID
+func GetLabelTaxonomy(router *gin.RouterGroup) {
+	router.GET("/labels/:uid/taxonomy", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourceLabels, acl.ActionRead)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		m, err := query.LabelByUID(c.Param("uid"))
+
+		if err != nil {
+			Abort(c, http.StatusNotFound, i18n.ErrLabelNotFound)
+			return
+		}
+
+		c.JSON(http.StatusOK, labelTaxonomy(&m))
+	})
+}
+
+// POST /api/v1/labels/:uid/parents
+//
+// Adds a parent label by name, it is created if it doesn't exist yet.
+//
+// Parameters:
+//   uid: string Label UID
+func AddLabelParent(router *gin.RouterGroup) {
+	router.POST("/labels/:uid/parents", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourceLabels, acl.ActionUpdate)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		var f form.Label
+
+		if err := c.BindJSON(&f); err != nil || txt.Clip(f.LabelName, txt.ClipDefault) == "" {
+			AbortBadRequest(c)
+			return
+		}
+
+		m, err := query.LabelByUID(c.Param("uid"))
+
+		if err != nil {
+			Abort(c, http.StatusNotFound, i18n.ErrLabelNotFound)
+			return
+		}
+
+		parent := entity.FindLabel(f.LabelName)
+
+		if parent == nil {
+			parent = entity.FirstOrCreateLabel(entity.NewLabel(f.LabelName, -3))
+		}
+
+		if err := m.AddParent(parent); err != nil {
+			Error(c, http.StatusBadRequest, err, i18n.ErrBadRequest)
+			return
+		}
+
+		Audit(c, s.User, "labels.parent.add", m.LabelUID, nil, parent.LabelName)
+
+		c.JSON(http.StatusOK, labelTaxonomy(&m))
+	})
+}
+
+// DELETE /api/v1/labels/:uid/parents/:parent
+//
+// Parameters:
+//   uid: string Label UID
+//   parent: string Parent label UID
+func RemoveLabelParent(router *gin.RouterGroup) {
+	router.DELETE("/labels/:uid/parents/:parent", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourceLabels, acl.ActionUpdate)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		m, err := query.LabelByUID(c.Param("uid"))
+
+		if err != nil {
+			Abort(c, http.StatusNotFound, i18n.ErrLabelNotFound)
+			return
+		}
+
+		parent, err := query.LabelByUID(c.Param("parent"))
+
+		if err != nil {
+			Abort(c, http.StatusNotFound, i18n.ErrLabelNotFound)
+			return
+		}
+
+		if err := m.RemoveParent(&parent); err != nil {
+			Error(c, http.StatusInternalServerError, err, i18n.ErrSaveFailed)
+			return
+		}
+
+		Audit(c, s.User, "labels.parent.remove", m.LabelUID, parent.LabelName, nil)
+
+		c.JSON(http.StatusOK, labelTaxonomy(&m))
+	})
+}
+
+// POST /api/v1/labels/:uid/synonyms
+//
+// Adds a synonym, e.g. a translation, that is also found by search.
+//
+// Parameters:
+//   uid: string Label UID
+func AddLabelSynonym(router *gin.RouterGroup) {
+	router.POST("/labels/:uid/synonyms", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourceLabels, acl.ActionUpdate)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		var f form.LabelSynonym
+
+		if err := c.BindJSON(&f); err != nil || txt.Clip(f.SynonymName, txt.ClipDefault) == "" {
+			AbortBadRequest(c)
+			return
+		}
+
+		m, err := query.LabelByUID(c.Param("uid"))
+
+		if err != nil {
+			Abort(c, http.StatusNotFound, i18n.ErrLabelNotFound)
+			return
+		}
+
+		synonym := entity.NewLabelSynonym(m.ID, f.SynonymName, f.SynonymLocale)
+
+		if err := synonym.Create(); err != nil {
+			Error(c, http.StatusBadRequest, err, i18n.ErrAlreadyExists, txt.Quote(synonym.SynonymName))
+			return
+		}
+
+		Audit(c, s.User, "labels.synonym.add", m.LabelUID, nil, synonym)
+
+		c.JSON(http.StatusOK, labelTaxonomy(&m))
+	})
+}
+
+// DELETE /api/v1/labels/:uid/synonyms/:id
+//
+// Parameters:
+//   uid: string Label UID
+//   id: int Synonym ID
+func RemoveLabelSynonym(router *gin.RouterGroup) {
+	router.DELETE("/labels/:uid/synonyms/:id", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourceLabels, acl.ActionUpdate)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		m, err := query.LabelByUID(c.Param("uid"))
+
+		if err != nil {
+			Abort(c, http.StatusNotFound, i18n.ErrLabelNotFound)
+			return
+		}
+
+		id, _ := strconv.Atoi(c.Param("id"))
+		synonym := entity.FindLabelSynonym(uint(id))
+
+		if synonym == nil || synonym.LabelID != m.ID {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		if err := synonym.Delete(); err != nil {
+			Error(c, http.StatusInternalServerError, err, i18n.ErrSaveFailed)
+			return
+		}
+
+		Audit(c, s.User, "labels.synonym.remove", m.LabelUID, synonym, nil)
+
+		c.JSON(http.StatusOK, labelTaxonomy(&m))
+	})
+}
@@ -0,0 +1,77 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestGetLabelTaxonomy(t *testing.T) {
+	t.Run("flower", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetLabelTaxonomy(router)
+		r := PerformRequest(app, "GET", "/api/v1/labels/lt9k3pw1wowuy3c3/taxonomy")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "landscape", gjson.Get(r.Body.String(), "Parents.0.Slug").String())
+	})
+	t.Run("not found", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetLabelTaxonomy(router)
+		r := PerformRequest(app, "GET", "/api/v1/labels/xxx/taxonomy")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
+
+func TestAddLabelParent(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		AddLabelParent(router)
+		RemoveLabelParent(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/labels/lt9k3pw1wowuy3c4/parents", `{"Name": "Api Food"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		parent := gjson.Get(r.Body.String(), "Parents.0")
+		assert.Equal(t, "Api Food", parent.Get("Name").String())
+		r = PerformRequest(app, "DELETE", fmt.Sprintf("/api/v1/labels/lt9k3pw1wowuy3c4/parents/%s", parent.Get("UID").String()))
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, int64(0), gjson.Get(r.Body.String(), "Parents.#").Int())
+	})
+	t.Run("cycle", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		AddLabelParent(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/labels/lt9k3pw1wowuy3c2/parents", `{"Name": "Flower"}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("bad request", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		AddLabelParent(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/labels/lt9k3pw1wowuy3c4/parents", `{"Name": ""}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
+
+func TestAddLabelSynonym(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		AddLabelSynonym(router)
+		RemoveLabelSynonym(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/labels/lt9k3pw1wowuy3c5/synonyms", `{"Name": "Kuh", "Locale": "de"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		synonym := gjson.Get(r.Body.String(), `Synonyms.#(Name=="Kuh")`)
+		assert.Equal(t, "Kuh", synonym.Get("Name").String())
+		assert.Equal(t, "de", synonym.Get("Locale").String())
+		r = PerformRequestWithBody(app, "POST", "/api/v1/labels/lt9k3pw1wowuy3c5/synonyms", `{"Name": "Kuh", "Locale": "de"}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+		r = PerformRequest(app, "DELETE", fmt.Sprintf("/api/v1/labels/lt9k3pw1wowuy3c5/synonyms/%d", synonym.Get("ID").Int()))
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.False(t, gjson.Get(r.Body.String(), `Synonyms.#(Name=="Kuh")`).Exists())
+	})
+	t.Run("not found", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		RemoveLabelSynonym(router)
+		r := PerformRequest(app, "DELETE", "/api/v1/labels/lt9k3pw1wowuy3c5/synonyms/999999")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
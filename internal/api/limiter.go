@@ -0,0 +1,97 @@
+package api
+
+import (
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// IPKey returns the rate limiter key for a client IP address.
+func IPKey(ip string) string {
+	return "ip:" + ip
+}
+
+// UserKey returns the rate limiter key for a user name.
+func UserKey(userName string) string {
+	return "user:" + strings.ToLower(strings.TrimSpace(userName))
+}
+
+// LoginBlocked returns how long login attempts from the client IP or for the user name are blocked.
+func LoginBlocked(ip, userName string) time.Duration {
+	l := service.LoginLimiter()
+
+	wait := l.Blocked(IPKey(ip))
+
+	if userName != "" {
+		if userWait := l.Blocked(UserKey(userName)); userWait > wait {
+			wait = userWait
+		}
+	}
+
+	return wait
+}
+
+// LoginFailed records a failed login attempt and publishes audit events.
+func LoginFailed(c *gin.Context, userName, reason string) {
+	l := service.LoginLimiter()
+	ip := c.ClientIP()
+
+	data := event.Data{"ip": ip, "username": userName, "reason": reason}
+
+	log.Warnf("session: login failed for %s from %s (%s)", txt.Quote(userName), ip, reason)
+
+	event.Publish("audit.login.failed", data)
+
+	Audit(c, entity.User{UserName: userName}, "login.failed", "", nil, event.Data{"Reason": reason})
+
+	if wait, locked := l.Fail(IPKey(ip)); locked {
+		event.Publish("audit.login.locked", event.Data{"ip": ip, "seconds": int(wait.Seconds())})
+	}
+
+	if userName == "" {
+		return
+	}
+
+	if wait, locked := l.Fail(UserKey(userName)); locked {
+		event.Publish("audit.login.locked", event.Data{"username": userName, "seconds": int(wait.Seconds())})
+	}
+}
+
+// LoginSucceeded resets failed login attempts and publishes an audit event.
+func LoginSucceeded(c *gin.Context, user entity.User) {
+	l := service.LoginLimiter()
+	ip := c.ClientIP()
+
+	l.Reset(IPKey(ip))
+	l.Reset(UserKey(user.UserName))
+
+	event.Publish("audit.login.succeeded", event.Data{"ip": ip, "username": user.UserName})
+
+	Audit(c, user, "login.succeeded", user.UserUID, nil, nil)
+}
+
+// ShareBlocked aborts the request and returns true if share link tokens from the client IP are blocked.
+func ShareBlocked(c *gin.Context) bool {
+	if wait := service.ShareLimiter().Blocked(IPKey(c.ClientIP())); wait > 0 {
+		AbortTooManyRequests(c, wait)
+		return true
+	}
+
+	return false
+}
+
+// ShareFailed records an invalid share link token and publishes audit events.
+func ShareFailed(c *gin.Context) {
+	ip := c.ClientIP()
+
+	event.Publish("audit.share.failed", event.Data{"ip": ip, "path": c.Request.URL.Path})
+
+	if wait, locked := service.ShareLimiter().Fail(IPKey(ip)); locked {
+		event.Publish("audit.share.locked", event.Data{"ip": ip, "seconds": int(wait.Seconds())})
+	}
+}
@@ -0,0 +1,59 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/limiter"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIPKey(t *testing.T) {
+	assert.Equal(t, "ip:127.0.0.1", IPKey("127.0.0.1"))
+}
+
+func TestUserKey(t *testing.T) {
+	assert.Equal(t, "user:admin", UserKey(" Admin"))
+}
+
+func TestLoginFailed(t *testing.T) {
+	t.Run("too many requests", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		CreateSession(router)
+
+		defer service.LoginLimiter().Reset(UserKey("nobody"))
+
+		for i := 0; i <= limiter.FreeAttempts; i++ {
+			r := PerformRequestWithBody(app, "POST", "/api/v1/session", `{"username": "nobody", "password": "xxx"}`)
+			assert.Equal(t, http.StatusBadRequest, r.Code)
+		}
+
+		r := PerformRequestWithBody(app, "POST", "/api/v1/session", `{"username": "nobody", "password": "xxx"}`)
+		assert.Equal(t, http.StatusTooManyRequests, r.Code)
+		assert.NotEmpty(t, r.Header().Get("Retry-After"))
+
+		// Reset the client IP, so that other users are not blocked (empty in tests).
+		service.LoginLimiter().Reset(IPKey(""))
+
+		r = PerformRequestWithBody(app, "POST", "/api/v1/session", `{"username": "admin", "password": "photoprism"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+}
+
+func TestShareFailed(t *testing.T) {
+	t.Run("too many requests", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		CreateSession(router)
+
+		defer service.ShareLimiter().Reset(IPKey(""))
+
+		for i := 0; i <= limiter.FreeAttempts; i++ {
+			r := PerformRequestWithBody(app, "POST", "/api/v1/session", `{"token": "xxx"}`)
+			assert.Equal(t, http.StatusBadRequest, r.Code)
+		}
+
+		r := PerformRequestWithBody(app, "POST", "/api/v1/session", `{"token": "xxx"}`)
+		assert.Equal(t, http.StatusTooManyRequests, r.Code)
+	})
+}
@@ -31,6 +31,7 @@ func UpdateLink(c *gin.Context) {
 	}
 
 	link := entity.FindLink(c.Param("link"))
+	before := *link
 
 	link.SetSlug(f.ShareSlug)
 	link.MaxViews = f.MaxViews
@@ -52,6 +53,8 @@ func UpdateLink(c *gin.Context) {
 		return
 	}
 
+	Audit(c, s.User, "links.update", link.LinkUID, before, link)
+
 	UpdateClientConfig()
 
 	event.SuccessMsg(i18n.MsgAlbumSaved)
@@ -77,6 +80,8 @@ func DeleteLink(c *gin.Context) {
 		return
 	}
 
+	Audit(c, s.User, "links.delete", link.LinkUID, link, nil)
+
 	UpdateClientConfig()
 
 	event.SuccessMsg(i18n.MsgAlbumSaved)
@@ -120,6 +125,8 @@ func CreateLink(c *gin.Context) {
 		return
 	}
 
+	Audit(c, s.User, "links.create", link.LinkUID, nil, link)
+
 	UpdateClientConfig()
 
 	event.SuccessMsg(i18n.MsgAlbumSaved)
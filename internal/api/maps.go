@@ -0,0 +1,69 @@
+package api
+
+import (
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/maps/tiles"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// GET /api/v1/maps/tiles/:z/:x/:y
+//
+// Returns a basemap tile from the local MBTiles or PMTiles file, if configured.
+// Tiles don't contain private data, so they can be requested by map libraries without authentication.
+//
+// Parameters:
+//   z: int Zoom level
+//   x: int Tile column
+//   y: int Tile row, an optional file extension is ignored
+func GetMapTile(router *gin.RouterGroup) {
+	router.GET(config.MapTilesUri+"/:z/:x/:y", func(c *gin.Context) {
+		conf := service.Config()
+
+		if conf.DisablePlaces() {
+			AbortFeatureDisabled(c)
+			return
+		}
+
+		source := service.MapTiles()
+
+		if source == nil {
+			AbortFeatureDisabled(c)
+			return
+		}
+
+		y := c.Param("y")
+		z, x := txt.Int(c.Param("z")), txt.Int(c.Param("x"))
+		y = strings.TrimSuffix(y, filepath.Ext(y))
+
+		if z < source.MinZoom() || z > source.MaxZoom() || !txt.IsUInt(c.Param("x")) || !txt.IsUInt(y) {
+			c.Status(http.StatusNoContent)
+			return
+		}
+
+		data, err := source.Tile(z, x, txt.Int(y))
+
+		if err == tiles.ErrNotFound {
+			AddCacheHeader(c, MapTileTTL)
+			c.Status(http.StatusNoContent)
+			return
+		} else if err != nil {
+			log.Errorf("maps: %s", err)
+			AbortBadRequest(c)
+			return
+		}
+
+		if source.Gzip() {
+			c.Header("Content-Encoding", "gzip")
+		}
+
+		AddCacheHeader(c, MapTileTTL)
+
+		c.Data(http.StatusOK, tiles.ContentType(source), data)
+	})
+}
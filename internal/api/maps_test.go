@@ -0,0 +1,19 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetMapTile(t *testing.T) {
+	t.Run("not configured", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+
+		GetMapTile(router)
+
+		r := PerformRequest(app, "GET", "/api/v1/maps/tiles/0/0/0.pbf")
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+}
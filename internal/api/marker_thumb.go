@@ -0,0 +1,77 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/internal/thumb"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// GET /api/v1/markers/:id/t/:token/:type
+//
+// Returns a square thumbnail cropped to the marker area, e.g. of a detected object.
+//
+// Parameters:
+//   id: int Marker ID as returned by the API
+//   token: string url security token, see config
+//   type: string square thumb type, e.g. tile_224
+func GetMarkerThumb(router *gin.RouterGroup) {
+	router.GET("/markers/:id/t/:token/:type", func(c *gin.Context) {
+		if InvalidPreviewToken(c) {
+			c.Data(http.StatusForbidden, "image/svg+xml", brokenIconSvg)
+			return
+		}
+
+		conf := service.Config()
+		typeName := c.Param("type")
+
+		thumbType, ok := thumb.Types[typeName]
+
+		if !ok || thumbType.Width != thumbType.Height {
+			log.Errorf("thumbs: invalid type %s", txt.Quote(typeName))
+			c.Data(http.StatusOK, "image/svg+xml", photoIconSvg)
+			return
+		}
+
+		marker, err := query.MarkerByID(txt.UInt(c.Param("id")))
+
+		if err != nil || marker.MarkerInvalid {
+			c.Data(http.StatusOK, "image/svg+xml", photoIconSvg)
+			return
+		}
+
+		f, err := query.FileByID(marker.FileID)
+
+		if err != nil {
+			c.Data(http.StatusOK, "image/svg+xml", photoIconSvg)
+			return
+		}
+
+		fileName := photoprism.FileName(f.FileRoot, f.FileName)
+
+		if !fs.FileExists(fileName) {
+			log.Errorf("thumbs: file %s is missing", txt.Quote(f.FileName))
+			c.Data(http.StatusOK, "image/svg+xml", brokenIconSvg)
+			return
+		}
+
+		area := thumb.NewArea(marker.X, marker.Y, marker.W, marker.H)
+
+		thumbnail, err := thumb.CropFromFile(fileName, f.FileHash, conf.ThumbPath(), area, thumbType.Width, f.FileOrientation)
+
+		if err != nil {
+			log.Errorf("thumbs: %s", err)
+			c.Data(http.StatusOK, "image/svg+xml", brokenIconSvg)
+			return
+		}
+
+		AddThumbCacheHeader(c)
+
+		c.File(thumbnail)
+	})
+}
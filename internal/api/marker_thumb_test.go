@@ -0,0 +1,31 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetMarkerThumb(t *testing.T) {
+	t.Run("invalid token", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetMarkerThumb(router)
+		r := PerformRequest(app, "GET", "/api/v1/markers/1/t/xxx/tile_224")
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+	t.Run("invalid type", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetMarkerThumb(router)
+		r := PerformRequest(app, "GET", "/api/v1/markers/1/t/"+conf.PreviewToken()+"/fit_720")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "image/svg+xml", r.Header().Get("Content-Type"))
+	})
+	t.Run("marker not found", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetMarkerThumb(router)
+		r := PerformRequest(app, "GET", "/api/v1/markers/999999/t/"+conf.PreviewToken()+"/tile_224")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "image/svg+xml", r.Header().Get("Content-Type"))
+	})
+}
@@ -0,0 +1,82 @@
+package api
+
+import (
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/metrics"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/service"
+)
+
+// GET /metrics
+//
+// Returns application metrics in Prometheus exposition format. Clients must send
+// the configured metrics token as bearer token, see config.MetricsToken().
+func GetMetrics(router *gin.RouterGroup) {
+	handler := metrics.Handler()
+
+	router.GET("/metrics", func(c *gin.Context) {
+		if service.Config().InvalidMetricsToken(bearerToken(c)) {
+			c.Header("WWW-Authenticate", `Bearer realm="metrics"`)
+			AbortUnauthorized(c)
+			return
+		}
+
+		updateMetrics()
+
+		handler.ServeHTTP(c.Writer, c.Request)
+	})
+}
+
+// bearerToken returns the token from the Authorization request header, if any.
+func bearerToken(c *gin.Context) string {
+	const prefix = "Bearer "
+
+	if h := c.GetHeader("Authorization"); len(h) > len(prefix) && strings.EqualFold(h[:len(prefix)], prefix) {
+		return strings.TrimSpace(h[len(prefix):])
+	}
+
+	return ""
+}
+
+// updateMetrics sets gauges that are calculated when metrics are requested.
+func updateMetrics() {
+	counts := query.Counts{}
+	counts.Refresh()
+
+	metrics.LibraryItems.WithLabelValues("photos").Set(float64(counts.Photos))
+	metrics.LibraryItems.WithLabelValues("videos").Set(float64(counts.Videos))
+	metrics.LibraryItems.WithLabelValues("hidden").Set(float64(counts.Hidden))
+	metrics.LibraryItems.WithLabelValues("favorites").Set(float64(counts.Favorites))
+	metrics.LibraryItems.WithLabelValues("private").Set(float64(counts.Private))
+	metrics.LibraryItems.WithLabelValues("review").Set(float64(counts.Review))
+	metrics.LibraryItems.WithLabelValues("albums").Set(float64(counts.Albums))
+	metrics.LibraryItems.WithLabelValues("moments").Set(float64(counts.Moments))
+	metrics.LibraryItems.WithLabelValues("folders").Set(float64(counts.Folders))
+	metrics.LibraryItems.WithLabelValues("files").Set(float64(counts.Files))
+	metrics.LibraryItems.WithLabelValues("labels").Set(float64(counts.Labels))
+	metrics.LibraryItems.WithLabelValues("places").Set(float64(counts.Places))
+	metrics.LibraryItems.WithLabelValues("countries").Set(float64(counts.Countries))
+	metrics.LibraryItems.WithLabelValues("cameras").Set(float64(counts.Cameras))
+	metrics.LibraryItems.WithLabelValues("lenses").Set(float64(counts.Lenses))
+
+	if queues, err := query.QueueCounts(); err != nil {
+		log.Errorf("metrics: %s", err)
+	} else {
+		for queueName, n := range queues {
+			metrics.QueueDepth.WithLabelValues(queueName).Set(float64(n))
+		}
+	}
+
+	if db := service.Config().Db(); db != nil {
+		stats := db.DB().Stats()
+
+		metrics.DbConnections.WithLabelValues("open").Set(float64(stats.OpenConnections))
+		metrics.DbConnections.WithLabelValues("in_use").Set(float64(stats.InUse))
+		metrics.DbConnections.WithLabelValues("idle").Set(float64(stats.Idle))
+		metrics.DbConnections.WithLabelValues("max_open").Set(float64(stats.MaxOpenConnections))
+		metrics.DbWaits.Set(float64(stats.WaitCount))
+		metrics.DbWaitDuration.Set(stats.WaitDuration.Seconds())
+	}
+}
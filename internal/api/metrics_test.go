@@ -0,0 +1,54 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetMetrics(t *testing.T) {
+	app, router, conf := NewApiTest()
+	GetMetrics(router)
+
+	conf.Options().MetricsToken = "metrics-secret"
+	defer func() { conf.Options().MetricsToken = "" }()
+
+	request := func(authorization string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("GET", "/api/v1/metrics", nil)
+
+		if authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("successful request", func(t *testing.T) {
+		r := request("Bearer metrics-secret")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Contains(t, r.Header().Get("Content-Type"), "text/plain")
+		assert.Contains(t, r.Body.String(), "# TYPE photoprism_library_items gauge\n")
+		assert.Contains(t, r.Body.String(), "photoprism_library_items{type=\"photos\"} ")
+		assert.Contains(t, r.Body.String(), "photoprism_db_connections{state=\"open\"} ")
+	})
+	t.Run("no token", func(t *testing.T) {
+		r := request("")
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+		assert.Equal(t, `Bearer realm="metrics"`, r.Header().Get("WWW-Authenticate"))
+	})
+	t.Run("invalid token", func(t *testing.T) {
+		r := request("Bearer xxx")
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+	})
+	t.Run("disabled", func(t *testing.T) {
+		conf.Options().MetricsToken = ""
+		defer func() { conf.Options().MetricsToken = "metrics-secret" }()
+
+		r := request("Bearer ")
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+	})
+}
@@ -86,6 +86,8 @@ func UpdatePhoto(router *gin.RouterGroup) {
 			return
 		}
 
+		before := f
+
 		// 2) Update form with values from request
 		if err := c.BindJSON(&f); err != nil {
 			Abort(c, http.StatusBadRequest, i18n.ErrBadRequest)
@@ -100,8 +102,14 @@ func UpdatePhoto(router *gin.RouterGroup) {
 			return
 		}
 
+		if _, err := entity.SavePhotoChanges(uid, entity.NewBatchUID(), s.User, before, f); err != nil {
+			log.Errorf("photo: %s (save changes)", err)
+		}
+
 		PublishPhotoEvent(EntityUpdated, uid, c)
 
+		Audit(c, s.User, "photos.update", uid, before, f)
+
 		event.SuccessMsg(i18n.MsgChangesSaved)
 
 		p, err := query.PhotoPreloadByUID(uid)
@@ -139,7 +147,10 @@ func GetPhotoDownload(router *gin.RouterGroup) {
 
 		fileName := photoprism.FileName(f.FileRoot, f.FileName)
 
-		if !fs.FileExists(fileName) {
+		if !fs.FileExists(fileName) && f.Remote() {
+			DownloadRemote(c, f, f.FileMime, f.DownloadName(DownloadName(c), 0))
+			return
+		} else if !fs.FileExists(fileName) {
 			log.Errorf("photo: file %s is missing", txt.Quote(f.FileName))
 			c.Data(http.StatusNotFound, "image/svg+xml", photoIconSvg)
 
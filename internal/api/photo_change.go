@@ -0,0 +1,186 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// GET /api/v1/photos/:uid/changes
+//
+// Parameters:
+//   uid: string PhotoUID as returned by the API
+//   count: int Max result count
+//   offset: int Result offset
+func GetPhotoChanges(router *gin.RouterGroup) {
+	router.GET("/photos/:uid/changes", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourcePhotos, acl.ActionUpdate)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		limit := txt.Int(c.Query("count"))
+		offset := txt.Int(c.Query("offset"))
+
+		result, err := query.PhotoChanges(c.Param("uid"), limit, offset)
+
+		if err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		AddCountHeader(c, len(result))
+		AddLimitHeader(c, limit)
+		AddOffsetHeader(c, offset)
+
+		c.JSON(http.StatusOK, result)
+	})
+}
+
+// POST /api/v1/photos/:uid/changes/:id/revert
+//
+// Parameters:
+//   uid: string PhotoUID as returned by the API
+//   id: int Change ID as returned by the API
+func RevertPhotoChange(router *gin.RouterGroup) {
+	router.POST("/photos/:uid/changes/:id/revert", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourcePhotos, acl.ActionUpdate)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		uid := c.Param("uid")
+		change := entity.FindPhotoChange(ParseUint(c.Param("id")))
+
+		if change == nil || change.PhotoUID != uid {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		m, err := query.PhotoByUID(uid)
+
+		if err != nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		reverted, conflicts, err := entity.RevertPhotoChanges(m, entity.PhotoChanges{*change}, s.User)
+
+		if err != nil {
+			Error(c, http.StatusInternalServerError, err, i18n.ErrSaveFailed)
+			return
+		} else if len(conflicts) > 0 {
+			Abort(c, http.StatusConflict, i18n.ErrModifiedSince)
+			return
+		}
+
+		if len(reverted) > 0 {
+			Audit(c, s.User, "photos.revert", uid, nil, nil)
+			afterRevert(c, uid)
+		}
+
+		event.SuccessMsg(i18n.MsgChangesReverted, len(reverted))
+
+		p, err := query.PhotoPreloadByUID(uid)
+
+		if err != nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		c.JSON(http.StatusOK, p)
+	})
+}
+
+// POST /api/v1/changes/:batch/undo
+//
+// Reverts all photo changes made together, e.g. by a batch edit. Fields that were modified
+// again since are skipped and returned as conflicts.
+//
+// Parameters:
+//   batch: string Batch UID as returned by the API
+func UndoPhotoChanges(router *gin.RouterGroup) {
+	router.POST("/changes/:batch/undo", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourcePhotos, acl.ActionUpdate)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		batch := c.Param("batch")
+		changes, err := query.BatchChanges(batch)
+
+		if err != nil || len(changes) == 0 {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		// Group changes by photo.
+		var photoUIDs []string
+		photoChanges := make(map[string]entity.PhotoChanges)
+
+		for _, change := range changes {
+			if _, ok := photoChanges[change.PhotoUID]; !ok {
+				photoUIDs = append(photoUIDs, change.PhotoUID)
+			}
+
+			photoChanges[change.PhotoUID] = append(photoChanges[change.PhotoUID], change)
+		}
+
+		reverted := 0
+		conflicts := entity.PhotoChanges{}
+
+		for _, uid := range photoUIDs {
+			m, err := query.PhotoByUID(uid)
+
+			if err != nil {
+				log.Warnf("undo: %s (photo %s)", err, txt.Quote(uid))
+				conflicts = append(conflicts, photoChanges[uid]...)
+				continue
+			}
+
+			r, skipped, err := entity.RevertPhotoChanges(m, photoChanges[uid], s.User)
+
+			if err != nil {
+				log.Errorf("undo: %s (photo %s)", err, txt.Quote(uid))
+				conflicts = append(conflicts, photoChanges[uid]...)
+				continue
+			}
+
+			conflicts = append(conflicts, skipped...)
+
+			if len(r) > 0 {
+				reverted += len(r)
+				afterRevert(c, uid)
+			}
+		}
+
+		Audit(c, s.User, "photos.undo", batch, nil, nil)
+
+		UpdateClientConfig()
+
+		c.JSON(http.StatusOK, gin.H{"code": http.StatusOK, "message": i18n.Msg(i18n.MsgChangesReverted, reverted), "reverted": reverted, "conflicts": conflicts})
+	})
+}
+
+// afterRevert notifies clients and updates the YAML backup of a reverted photo.
+func afterRevert(c *gin.Context, uid string) {
+	PublishPhotoEvent(EntityUpdated, uid, c)
+
+	if p, err := query.PhotoPreloadByUID(uid); err != nil {
+		log.Errorf("undo: %s", err)
+	} else {
+		SavePhotoAsYaml(p)
+	}
+}
@@ -0,0 +1,70 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestPhotoChanges(t *testing.T) {
+	app, router, _ := NewApiTest()
+	GetPhoto(router)
+	UpdatePhoto(router)
+	GetPhotoChanges(router)
+	RevertPhotoChange(router)
+	UndoPhotoChanges(router)
+
+	r := PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0y45")
+	assert.Equal(t, http.StatusOK, r.Code)
+	oldTitle := gjson.Get(r.Body.String(), "Title").String()
+
+	update := func(title string) {
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/photos/pt9jtdre2lvl0y45", fmt.Sprintf(`{"Title": "%s", "TitleSrc": "manual"}`, title))
+		assert.Equal(t, http.StatusOK, r.Code)
+	}
+
+	title := fmt.Sprintf("Changed %d", time.Now().UnixNano())
+	update(title)
+
+	r = PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0y45/changes?count=1")
+	assert.Equal(t, http.StatusOK, r.Code)
+	assert.Equal(t, "Title", gjson.Get(r.Body.String(), "0.Field").String())
+	assert.Equal(t, title, gjson.Get(r.Body.String(), "0.New").String())
+
+	id := gjson.Get(r.Body.String(), "0.ID").String()
+	batch := gjson.Get(r.Body.String(), "0.Batch").String()
+
+	t.Run("revert", func(t *testing.T) {
+		r := PerformRequest(app, "POST", "/api/v1/photos/pt9jtdre2lvl0y45/changes/"+id+"/revert")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, oldTitle, gjson.Get(r.Body.String(), "Title").String())
+	})
+	t.Run("conflict", func(t *testing.T) {
+		update(title)
+
+		r := PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0y45/changes?count=1")
+		id := gjson.Get(r.Body.String(), "0.ID").String()
+
+		update(title + " Again")
+
+		r = PerformRequest(app, "POST", "/api/v1/photos/pt9jtdre2lvl0y45/changes/"+id+"/revert")
+		assert.Equal(t, http.StatusConflict, r.Code)
+	})
+	t.Run("undo reverted batch", func(t *testing.T) {
+		r := PerformRequest(app, "POST", "/api/v1/changes/"+batch+"/undo")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, int64(0), gjson.Get(r.Body.String(), "reverted").Int())
+	})
+	t.Run("undo batch not found", func(t *testing.T) {
+		r := PerformRequest(app, "POST", "/api/v1/changes/bxxxxxxxxxxxxxxx/undo")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("change not found", func(t *testing.T) {
+		r := PerformRequest(app, "POST", "/api/v1/photos/pt9jtdre2lvl0y45/changes/999999/revert")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
@@ -6,6 +6,8 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/photoprism"
 	"github.com/photoprism/photoprism/internal/query"
 	"github.com/photoprism/photoprism/internal/service"
@@ -110,7 +112,7 @@ func GetThumb(router *gin.RouterGroup) {
 
 		fileName := photoprism.FileName(f.FileRoot, f.FileName)
 
-		if !fs.FileExists(fileName) {
+		if !fs.FileExists(fileName) && !f.Remote() {
 			log.Errorf("thumbs: file %s is missing", txt.Quote(f.FileName))
 			c.Data(http.StatusOK, "image/svg+xml", brokenIconSvg)
 
@@ -127,7 +129,7 @@ func GetThumb(router *gin.RouterGroup) {
 		}
 
 		// Use original file if thumb size exceeds limit, see https://github.com/photoprism/photoprism/issues/157
-		if thumbType.ExceedsSizeUncached() && c.Query("download") == "" {
+		if thumbType.ExceedsSizeUncached() && c.Query("download") == "" && !f.Remote() {
 			log.Debugf("thumbs: using original, size exceeds limit (width %d, height %d)", thumbType.Width, thumbType.Height)
 
 			AddThumbCacheHeader(c)
@@ -138,13 +140,13 @@ func GetThumb(router *gin.RouterGroup) {
 
 		var thumbnail string
 
-		if conf.ThumbUncached() || thumbType.OnDemand() {
-			thumbnail, err = thumb.FromFile(fileName, f.FileHash, conf.ThumbPath(), thumbType.Width, thumbType.Height, f.FileOrientation, thumbType.Options...)
-		} else {
-			thumbnail, err = thumb.FromCache(fileName, f.FileHash, conf.ThumbPath(), thumbType.Width, thumbType.Height, thumbType.Options...)
-		}
+		thumbnail, err = ThumbFromFile(conf, fileName, f, thumbType)
 
-		if err != nil {
+		if err == thumb.ErrThumbNotCached && f.Remote() {
+			log.Debugf("thumbs: %s not available for remote file %s", typeName, txt.Quote(f.FileName))
+			c.Data(http.StatusNotFound, "image/svg+xml", brokenIconSvg)
+			return
+		} else if err != nil {
 			log.Errorf("thumbs: %s", err)
 			c.Data(http.StatusOK, "image/svg+xml", brokenIconSvg)
 			return
@@ -166,3 +168,15 @@ func GetThumb(router *gin.RouterGroup) {
 		}
 	})
 }
+
+// ThumbFromFile returns the thumbnail file name for the given type, and creates it if needed.
+// Originals in remote libraries are not stored locally, so only the thumbnails created while indexing are available.
+func ThumbFromFile(conf *config.Config, fileName string, f entity.File, thumbType thumb.Type) (string, error) {
+	if f.Remote() && !fs.FileExists(fileName) {
+		return thumb.FromCache(fileName, f.FileHash, conf.ThumbPath(), thumbType.Width, thumbType.Height, thumbType.Options...)
+	} else if conf.ThumbUncached() || thumbType.OnDemand() {
+		return thumb.FromFile(fileName, f.FileHash, conf.ThumbPath(), thumbType.Width, thumbType.Height, f.FileOrientation, thumbType.Options...)
+	}
+
+	return thumb.FromCache(fileName, f.FileHash, conf.ThumbPath(), thumbType.Width, thumbType.Height, thumbType.Options...)
+}
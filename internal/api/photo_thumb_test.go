@@ -4,6 +4,10 @@ import (
 	"net/http"
 	"testing"
 
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/internal/thumb"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -47,3 +51,16 @@ func TestGetThumb(t *testing.T) {
 		assert.Equal(t, http.StatusOK, r.Code)
 	})
 }
+
+func TestThumbFromFile(t *testing.T) {
+	t.Run("remote file", func(t *testing.T) {
+		conf := service.Config()
+		f := entity.File{FileRoot: entity.RemoteRoot(1000), FileName: "2021/remote.jpg", FileHash: "c3f1ab7e2dd5e4a0b3c7e3d1ef0bb1d2c5e3f9a1"}
+		thumbType := thumb.Types["fit_720"]
+
+		// The original is not stored locally, so thumbnails are not rendered on demand.
+		_, err := ThumbFromFile(conf, photoprism.FileName(f.FileRoot, f.FileName), f, thumbType)
+
+		assert.Equal(t, thumb.ErrThumbNotCached, err)
+	})
+}
@@ -35,11 +35,19 @@ func CreateSession(router *gin.RouterGroup) {
 
 		conf := service.Config()
 
+		var recoveryCodes []string
+
 		if f.HasToken() {
+			if ShareBlocked(c) {
+				return
+			}
+
 			links := entity.FindValidLinks(f.Token, "")
 
 			if len(links) == 0 {
+				ShareFailed(c)
 				c.AbortWithStatusJSON(400, gin.H{"error": i18n.Msg(i18n.ErrInvalidLink)})
+				return
 			}
 
 			data.Tokens = []string{f.Token}
@@ -53,19 +61,85 @@ func CreateSession(router *gin.RouterGroup) {
 			if data.User.Anonymous() {
 				data.User = entity.Guest
 			}
+		} else if f.HasChallenge() {
+			user := entity.FindUserByUID(service.Session().Challenged(f.Challenge))
+
+			if user == nil {
+				LoginFailed(c, "", "invalid challenge")
+				c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": i18n.Msg(i18n.ErrInvalidCredentials)})
+				return
+			}
+
+			if wait := LoginBlocked(c.ClientIP(), user.UserName); wait > 0 {
+				AbortTooManyRequests(c, wait)
+				return
+			}
+
+			if !f.HasPasscode() {
+				c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": i18n.Msg(i18n.ErrPasscodeRequired), "challenge": f.Challenge})
+				return
+			}
+
+			if user.TwoFactor() {
+				if user.InvalidPasscode(f.Passcode) {
+					LoginFailed(c, user.UserName, "invalid passcode")
+					c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": i18n.Msg(i18n.ErrInvalidPasscode), "challenge": f.Challenge})
+					return
+				}
+			} else if codes, err := user.ActivateTotp(f.Passcode); err != nil {
+				log.Debugf("session: %s", err)
+				LoginFailed(c, user.UserName, "invalid passcode")
+				c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": i18n.Msg(i18n.ErrInvalidPasscode), "challenge": f.Challenge})
+				return
+			} else {
+				recoveryCodes = codes
+			}
+
+			service.Session().DeleteChallenge(f.Challenge)
+			LoginSucceeded(c, *user)
+
+			data.User = *user
 		} else if f.HasCredentials() {
+			if wait := LoginBlocked(c.ClientIP(), f.UserName); wait > 0 {
+				AbortTooManyRequests(c, wait)
+				return
+			}
+
 			user := entity.FindUserByName(f.UserName)
 
 			if user == nil {
+				LoginFailed(c, f.UserName, "unknown user")
 				c.AbortWithStatusJSON(400, gin.H{"error": i18n.Msg(i18n.ErrInvalidCredentials)})
 				return
 			}
 
 			if user.InvalidPassword(f.Password) {
+				LoginFailed(c, f.UserName, "invalid password")
 				c.AbortWithStatusJSON(400, gin.H{"error": i18n.Msg(i18n.ErrInvalidCredentials)})
 				return
 			}
 
+			// Require a second factor if enabled by the user or enforced for the user role.
+			if user.TwoFactor() || conf.TwoFactorRequired(user.Role()) {
+				resp := gin.H{"error": i18n.Msg(i18n.ErrPasscodeRequired), "challenge": service.Session().Challenge(user.UserUID)}
+
+				if !user.TwoFactor() {
+					secret, err := user.SetupTotp()
+
+					if err != nil {
+						Error(c, http.StatusInternalServerError, err, i18n.ErrUnexpected)
+						return
+					}
+
+					resp["setup"] = gin.H{"secret": secret, "uri": user.TotpURI(conf.SiteTitle())}
+				}
+
+				c.AbortWithStatusJSON(http.StatusUnauthorized, resp)
+				return
+			}
+
+			LoginSucceeded(c, *user)
+
 			data.User = *user
 		} else {
 			c.AbortWithStatusJSON(400, gin.H{"error": i18n.Msg(i18n.ErrInvalidPassword)})
@@ -80,6 +154,8 @@ func CreateSession(router *gin.RouterGroup) {
 
 		if data.User.Anonymous() {
 			c.JSON(http.StatusOK, gin.H{"status": "ok", "id": id, "data": data, "config": conf.GuestConfig()})
+		} else if len(recoveryCodes) > 0 {
+			c.JSON(http.StatusOK, gin.H{"status": "ok", "id": id, "data": data, "config": conf.UserConfig(), "recovery_codes": recoveryCodes})
 		} else {
 			c.JSON(http.StatusOK, gin.H{"status": "ok", "id": id, "data": data, "config": conf.UserConfig()})
 		}
@@ -3,8 +3,11 @@ package api
 import (
 	"net/http"
 	"testing"
+	"time"
 
+	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/pkg/totp"
 	"github.com/stretchr/testify/assert"
 	"github.com/tidwall/gjson"
 )
@@ -44,6 +47,60 @@ func TestCreateSession(t *testing.T) {
 		assert.Equal(t, i18n.Msg(i18n.ErrInvalidCredentials), val.String())
 		assert.Equal(t, http.StatusBadRequest, r.Code)
 	})
+	t.Run("two-factor", func(t *testing.T) {
+		m := entity.FindUserByName("admin")
+
+		if m == nil {
+			t.Fatal("user should not be nil")
+		}
+
+		secret, err := m.SetupTotp()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		passcode, err := totp.Passcode(secret, time.Now())
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := m.ActivateTotp(passcode); err != nil {
+			t.Fatal(err)
+		}
+
+		defer m.DisableTotp()
+
+		app, router, _ := NewApiTest()
+		CreateSession(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/session", `{"username": "admin", "password": "photoprism"}`)
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+		assert.Equal(t, i18n.Msg(i18n.ErrPasscodeRequired), gjson.Get(r.Body.String(), "error").String())
+		challenge := gjson.Get(r.Body.String(), "challenge").String()
+		assert.NotEmpty(t, challenge)
+
+		r = PerformRequestWithBody(app, "POST", "/api/v1/session", `{"challenge": "`+challenge+`", "passcode": "xxx"}`)
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+		assert.Equal(t, i18n.Msg(i18n.ErrInvalidPasscode), gjson.Get(r.Body.String(), "error").String())
+
+		// The passcode used for activation must not be accepted again.
+		r = PerformRequestWithBody(app, "POST", "/api/v1/session", `{"challenge": "`+challenge+`", "passcode": "`+passcode+`"}`)
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+
+		next, err := totp.Passcode(secret, time.Now().Add(totp.Period))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		r = PerformRequestWithBody(app, "POST", "/api/v1/session", `{"challenge": "`+challenge+`", "passcode": "`+next+`"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.NotEmpty(t, gjson.Get(r.Body.String(), "id").String())
+
+		r = PerformRequestWithBody(app, "POST", "/api/v1/session", `{"challenge": "`+challenge+`", "passcode": "`+next+`"}`)
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+	})
 }
 
 func TestDeleteSession(t *testing.T) {
@@ -15,12 +15,17 @@ func Shares(router *gin.RouterGroup) {
 	router.GET("/:token", func(c *gin.Context) {
 		conf := service.Config()
 
+		if ShareBlocked(c) {
+			return
+		}
+
 		token := c.Param("token")
 
 		links := entity.FindValidLinks(token, "")
 
 		if len(links) == 0 {
 			log.Warn("share: invalid token")
+			ShareFailed(c)
 			c.Redirect(http.StatusTemporaryRedirect, "/")
 			return
 		}
@@ -34,6 +39,10 @@ func Shares(router *gin.RouterGroup) {
 	router.GET("/:token/:share", func(c *gin.Context) {
 		conf := service.Config()
 
+		if ShareBlocked(c) {
+			return
+		}
+
 		token := c.Param("token")
 		share := c.Param("share")
 
@@ -41,6 +50,7 @@ func Shares(router *gin.RouterGroup) {
 
 		if len(links) < 1 {
 			log.Warn("share: invalid token or share")
+			ShareFailed(c)
 			c.Redirect(http.StatusTemporaryRedirect, "/")
 			return
 		}
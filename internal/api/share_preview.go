@@ -27,12 +27,17 @@ func SharePreview(router *gin.RouterGroup) {
 	router.GET("/:token/:share/preview", func(c *gin.Context) {
 		conf := service.Config()
 
+		if ShareBlocked(c) {
+			return
+		}
+
 		token := c.Param("token")
 		share := c.Param("share")
 		links := entity.FindLinks(token, share)
 
 		if len(links) != 1 {
 			log.Warn("share: invalid token (preview)")
+			ShareFailed(c)
 			c.Redirect(http.StatusTemporaryRedirect, conf.SitePreview())
 			return
 		}
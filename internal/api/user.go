@@ -53,6 +53,52 @@ func ChangePassword(router *gin.RouterGroup) {
 			return
 		}
 
+		Audit(c, s.User, "users.password", m.UserUID, nil, nil)
+
 		c.JSON(http.StatusOK, i18n.NewResponse(http.StatusOK, i18n.MsgPasswordChanged))
 	})
 }
+
+// PUT /api/v1/users/:uid/webdav
+func UpdateUserWebDAV(router *gin.RouterGroup) {
+	router.PUT("/users/:uid/webdav", func(c *gin.Context) {
+		conf := service.Config()
+
+		if conf.Public() || conf.DisableSettings() {
+			Abort(c, http.StatusForbidden, i18n.ErrPublic)
+			return
+		}
+
+		// Only admins may change the WebDAV settings of users.
+		s := Auth(SessionID(c), acl.ResourcePeople, acl.ActionUpdate)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		uid := c.Param("uid")
+		m := entity.FindUserByUID(uid)
+
+		if m == nil {
+			Abort(c, http.StatusNotFound, i18n.ErrUserNotFound)
+			return
+		}
+
+		f := form.UserWebDAV{}
+
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		if err := m.SetWebDAV(f.WebDAV, f.ReadOnly, f.StoragePath); err != nil {
+			Error(c, http.StatusBadRequest, err, i18n.ErrSaveFailed)
+			return
+		}
+
+		Audit(c, s.User, "users.webdav", m.UserUID, nil, nil)
+
+		c.JSON(http.StatusOK, i18n.NewResponse(http.StatusOK, i18n.MsgChangesSaved))
+	})
+}
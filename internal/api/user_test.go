@@ -15,3 +15,12 @@ func TestChangePassword(t *testing.T) {
 		assert.Equal(t, http.StatusForbidden, r.Code)
 	})
 }
+
+func TestUpdateUserWebDAV(t *testing.T) {
+	t.Run("public mode", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		UpdateUserWebDAV(router)
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/users/uqxetse3cy5eo9z2/webdav", `{"WebDAV": true, "StoragePath": "alice"}`)
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+}
@@ -0,0 +1,133 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/internal/session"
+)
+
+// totpUser returns the user for a two-factor authentication request if permitted, or nil otherwise.
+func totpUser(c *gin.Context) (*entity.User, session.Data) {
+	conf := service.Config()
+
+	if conf.Public() || conf.DisableSettings() {
+		Abort(c, http.StatusForbidden, i18n.ErrPublic)
+		return nil, session.Data{}
+	}
+
+	s := Auth(SessionID(c), acl.ResourcePeople, acl.ActionUpdateSelf)
+
+	if s.Invalid() {
+		AbortUnauthorized(c)
+		return nil, s
+	}
+
+	uid := c.Param("uid")
+
+	if s.User.UserUID != uid && !s.User.Admin() {
+		AbortUnauthorized(c)
+		return nil, s
+	}
+
+	m := entity.FindUserByUID(uid)
+
+	if m == nil {
+		Abort(c, http.StatusNotFound, i18n.ErrUserNotFound)
+		return nil, s
+	}
+
+	return m, s
+}
+
+// POST /api/v1/users/:uid/2fa
+//
+// Creates a new shared secret and returns the provisioning uri for authenticator apps.
+func SetupTwoFactor(router *gin.RouterGroup) {
+	router.POST("/users/:uid/2fa", func(c *gin.Context) {
+		m, _ := totpUser(c)
+
+		if m == nil {
+			return
+		}
+
+		secret, err := m.SetupTotp()
+
+		if err != nil {
+			Error(c, http.StatusBadRequest, err, i18n.ErrBadRequest)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"secret": secret, "uri": m.TotpURI(service.Config().SiteTitle())})
+	})
+}
+
+// POST /api/v1/users/:uid/2fa/activate
+//
+// Enables two-factor authentication and returns new recovery codes.
+func ActivateTwoFactor(router *gin.RouterGroup) {
+	router.POST("/users/:uid/2fa/activate", func(c *gin.Context) {
+		m, s := totpUser(c)
+
+		if m == nil {
+			return
+		}
+
+		f := form.Passcode{}
+
+		if err := c.BindJSON(&f); err != nil {
+			Error(c, http.StatusBadRequest, err, i18n.ErrInvalidPasscode)
+			return
+		}
+
+		codes, err := m.ActivateTotp(f.Passcode)
+
+		if err != nil {
+			Error(c, http.StatusBadRequest, err, i18n.ErrInvalidPasscode)
+			return
+		}
+
+		Audit(c, s.User, "users.2fa.enable", m.UserUID, nil, nil)
+
+		c.JSON(http.StatusOK, gin.H{"code": http.StatusOK, "message": i18n.Msg(i18n.MsgTwoFactorEnabled), "recovery_codes": codes})
+	})
+}
+
+// DELETE /api/v1/users/:uid/2fa
+//
+// Disables two-factor authentication, admins may do so for other users without their password.
+func DisableTwoFactor(router *gin.RouterGroup) {
+	router.DELETE("/users/:uid/2fa", func(c *gin.Context) {
+		m, s := totpUser(c)
+
+		if m == nil {
+			return
+		}
+
+		f := form.Passcode{}
+
+		if err := c.BindJSON(&f); err != nil {
+			Error(c, http.StatusBadRequest, err, i18n.ErrInvalidPassword)
+			return
+		}
+
+		if s.User.UserUID == m.UserUID && m.InvalidPassword(f.Password) {
+			Abort(c, http.StatusBadRequest, i18n.ErrInvalidPassword)
+			return
+		}
+
+		if err := m.DisableTotp(); err != nil {
+			Error(c, http.StatusInternalServerError, err, i18n.ErrSaveFailed)
+			return
+		}
+
+		Audit(c, s.User, "users.2fa.disable", m.UserUID, nil, nil)
+
+		c.JSON(http.StatusOK, i18n.NewResponse(http.StatusOK, i18n.MsgTwoFactorDisabled))
+	})
+}
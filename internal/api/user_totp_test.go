@@ -0,0 +1,35 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetupTwoFactor(t *testing.T) {
+	t.Run("public mode", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		SetupTwoFactor(router)
+		r := PerformRequest(app, "POST", "/api/v1/users/uqxetse3cy5eo9z2/2fa")
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+}
+
+func TestActivateTwoFactor(t *testing.T) {
+	t.Run("public mode", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		ActivateTwoFactor(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/users/uqxetse3cy5eo9z2/2fa/activate", `{"passcode": "123456"}`)
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+}
+
+func TestDisableTwoFactor(t *testing.T) {
+	t.Run("public mode", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		DisableTwoFactor(router)
+		r := PerformRequestWithBody(app, "DELETE", "/api/v1/users/uqxetse3cy5eo9z2/2fa", `{"password": "photoprism"}`)
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+}
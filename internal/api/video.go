@@ -9,6 +9,7 @@ import (
 	"github.com/photoprism/photoprism/internal/photoprism"
 	"github.com/photoprism/photoprism/internal/query"
 	"github.com/photoprism/photoprism/internal/video"
+	"github.com/photoprism/photoprism/pkg/fs"
 	"github.com/photoprism/photoprism/pkg/txt"
 )
 
@@ -61,6 +62,20 @@ func GetVideo(router *gin.RouterGroup) {
 
 		fileName := photoprism.FileName(f.FileRoot, f.FileName)
 
+		// Stream originals in remote libraries, they can't be transcoded on demand.
+		if !fs.FileExists(fileName) && f.Remote() {
+			if f.FileCodec != string(videoType.Codec) {
+				log.Errorf("video: can't transcode remote file %s", txt.Quote(f.FileName))
+				c.Data(http.StatusOK, "image/svg+xml", videoIconSvg)
+			} else if c.Query("download") != "" {
+				DownloadRemote(c, f, ContentTypeAvc, f.DownloadName(DownloadName(c), 0))
+			} else {
+				DownloadRemote(c, f, ContentTypeAvc, "")
+			}
+
+			return
+		}
+
 		if mf, err := photoprism.NewMediaFile(fileName); err != nil {
 			log.Errorf("video: file %s is missing", txt.Quote(f.FileName))
 			c.Data(http.StatusOK, "image/svg+xml", videoIconSvg)
@@ -14,6 +14,7 @@ import (
 	"github.com/photoprism/photoprism/pkg/rnd"
 
 	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/form"
 	"github.com/photoprism/photoprism/internal/i18n"
 	"github.com/photoprism/photoprism/internal/photoprism"
@@ -118,6 +119,12 @@ func CreateZip(router *gin.RouterGroup) {
 					return
 				}
 				log.Infof("download: added %s as %s", txt.Quote(file.FileName), txt.Quote(alias))
+			} else if file.Remote() {
+				if err := addRemoteToZip(zipWriter, file, alias); err != nil {
+					Error(c, http.StatusInternalServerError, err, i18n.ErrZipFailed)
+					return
+				}
+				log.Infof("download: added remote file %s as %s", txt.Quote(file.FileName), txt.Quote(alias))
 			} else {
 				log.Warnf("download: file %s is missing", txt.Quote(file.FileName))
 				logError("download", file.Update("FileMissing", true))
@@ -190,3 +197,25 @@ func addFileToZip(zipWriter *zip.Writer, fileName, fileAlias string) error {
 	_, err = io.Copy(writer, fileToZip)
 	return err
 }
+
+// addRemoteToZip adds the original of a remote library file to the zip archive.
+func addRemoteToZip(zipWriter *zip.Writer, file entity.File, fileAlias string) error {
+	reader, err := photoprism.OpenRemote(file)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	header := &zip.FileHeader{
+		Name:     fileAlias,
+		Method:   zip.Deflate,
+		Modified: time.Unix(file.ModTime, 0),
+	}
+
+	writer, err := zipWriter.CreateHeader(header)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(writer, reader)
+	return err
+}
@@ -45,6 +45,9 @@ var stop = make(chan bool, 1)
 
 // Wait starts waiting for indexing & importing opportunities.
 func Start(conf *config.Config) {
+	// Watch originals and import folders if enabled.
+	Watch(conf)
+
 	// Don't start ticker if both are disabled.
 	if conf.AutoIndex().Seconds() <= 0 && conf.AutoImport().Seconds() <= 0 {
 		return
@@ -79,5 +82,6 @@ func Start(conf *config.Config) {
 
 // Stop stops waiting for indexing & importing opportunities.
 func Stop() {
+	StopWatching()
 	stop <- true
 }
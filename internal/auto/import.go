@@ -48,14 +48,21 @@ func mustImport(delay time.Duration) bool {
 
 // Import starts importing originals e.g. after WebDAV uploads.
 func Import() error {
+	_, err := importFiles()
+
+	return err
+}
+
+// importFiles imports new files and returns the number of imported files.
+func importFiles() (counts entity.JobCounts, err error) {
 	if mutex.MainWorker.Busy() {
-		return nil
+		return nil, nil
 	}
 
 	conf := service.Config()
 
 	if conf.ReadOnly() || !conf.Settings().Features.Import {
-		return nil
+		return nil, nil
 	}
 
 	start := time.Now()
@@ -78,8 +85,10 @@ func Import() error {
 
 	imported := imp.Start(opt)
 
+	counts = entity.JobCounts{"imported": imported.Processed()}
+
 	if len(imported) == 0 {
-		return nil
+		return counts, nil
 	}
 
 	moments := service.Moments()
@@ -98,5 +107,5 @@ func Import() error {
 
 	api.UpdateClientConfig()
 
-	return nil
+	return counts, nil
 }
@@ -47,8 +47,15 @@ func mustIndex(delay time.Duration) bool {
 
 // Index starts indexing originals e.g. after WebDAV uploads.
 func Index() error {
+	_, err := index()
+
+	return err
+}
+
+// index indexes originals and returns the number of indexed and purged files.
+func index() (counts entity.JobCounts, err error) {
 	if mutex.MainWorker.Busy() {
-		return nil
+		return nil, nil
 	}
 
 	conf := service.Config()
@@ -68,8 +75,10 @@ func Index() error {
 
 	indexed := ind.Start(indOpt)
 
+	counts = entity.JobCounts{"indexed": indexed.Processed()}
+
 	if len(indexed) == 0 {
-		return nil
+		return counts, nil
 	}
 
 	api.RemoveFromFolderCache(entity.RootOriginals)
@@ -82,8 +91,9 @@ func Index() error {
 	}
 
 	if files, photos, err := prg.Start(prgOpt); err != nil {
-		return err
+		return counts, err
 	} else if len(files) > 0 || len(photos) > 0 {
+		counts["purged"] = len(files)
 		event.InfoMsg(i18n.MsgRemovedFilesAndPhotos, len(files), len(photos))
 	}
 
@@ -106,5 +116,5 @@ func Index() error {
 
 	api.UpdateClientConfig()
 
-	return nil
+	return counts, nil
 }
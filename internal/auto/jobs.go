@@ -0,0 +1,35 @@
+package auto
+
+import (
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/workers"
+)
+
+func init() {
+	workers.RegisterJob(workers.JobIndex, indexJob)
+	workers.RegisterJob(workers.JobImport, importJob)
+}
+
+// indexJob indexes new and changed originals as scheduled background job.
+func indexJob(conf *config.Config) (entity.JobCounts, error) {
+	if mutex.MainWorker.Busy() {
+		return nil, workers.ErrJobBusy
+	}
+
+	ResetIndex()
+
+	return index()
+}
+
+// importJob imports new files as scheduled background job.
+func importJob(conf *config.Config) (entity.JobCounts, error) {
+	if mutex.MainWorker.Busy() {
+		return nil, workers.ErrJobBusy
+	}
+
+	ResetImport()
+
+	return importFiles()
+}
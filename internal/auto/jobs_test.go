@@ -0,0 +1,20 @@
+package auto
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/workers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJobsRegistered(t *testing.T) {
+	conf := config.TestConfig()
+
+	for _, name := range []string{workers.JobIndex, workers.JobImport} {
+		job, err := workers.Job(conf, name)
+
+		assert.NoError(t, err)
+		assert.Equal(t, name, job.Name)
+	}
+}
@@ -0,0 +1,488 @@
+package auto
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+
+	"github.com/photoprism/photoprism/internal/api"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// ScanInterval is used for periodic scanning if the file system can't be watched, e.g. because of watch limits.
+var ScanInterval = 15 * time.Minute
+
+var stopWatch = make(chan bool, 1)
+
+// Watcher indexes changed originals and imports new files shortly after they were added.
+type Watcher struct {
+	conf        *config.Config
+	notify      *fsnotify.Watcher
+	delay       time.Duration
+	originals   string
+	libraries   map[string]string
+	imports     string
+	ignore      map[string]*fs.IgnoreList
+	changedDirs map[string]map[string]bool
+	importFiles bool
+	exceeded    bool
+}
+
+// NewWatcher returns a new file system watcher for originals, additional libraries and,
+// if auto importing is enabled, the import folder.
+func NewWatcher(conf *config.Config) *Watcher {
+	w := &Watcher{
+		conf:        conf,
+		delay:       conf.WatchDelay(),
+		originals:   filepath.Clean(conf.OriginalsPath()),
+		libraries:   make(map[string]string),
+		ignore:      make(map[string]*fs.IgnoreList),
+		changedDirs: make(map[string]map[string]bool),
+	}
+
+	for _, lib := range conf.Libraries() {
+		w.libraries[filepath.Clean(lib.Path)] = lib.Name
+	}
+
+	if conf.AutoImport() > 0 && conf.ImportPath() != "" {
+		w.imports = filepath.Clean(conf.ImportPath())
+	}
+
+	return w
+}
+
+// Watch starts watching originals and import folders for changes.
+func Watch(conf *config.Config) {
+	if conf.WatchDelay() <= 0 {
+		return
+	}
+
+	w := NewWatcher(conf)
+
+	if err := w.Start(); err != nil {
+		log.Errorf("watch: %s", err)
+		return
+	}
+
+	go w.Run()
+}
+
+// StopWatching stops watching originals and import folders.
+func StopWatching() {
+	select {
+	case stopWatch <- true:
+	default:
+	}
+}
+
+// Start adds watches for all folders that are not ignored.
+func (w *Watcher) Start() (err error) {
+	if w.notify, err = fsnotify.NewWatcher(); err != nil {
+		return err
+	}
+
+	for _, root := range w.roots() {
+		if !fs.PathExists(root) {
+			log.Warnf("watch: %s not found", txt.Quote(root))
+			continue
+		}
+
+		w.ignore[root] = fs.NewIgnoreList(fs.IgnoreFile, true, false)
+
+		if err := w.add(root); err != nil {
+			w.fallback(err)
+			return nil
+		}
+	}
+
+	log.Infof("watch: indexing changes after %s", w.delay)
+
+	return nil
+}
+
+// Run processes file system events until StopWatching is called.
+func (w *Watcher) Run() {
+	timer := time.NewTimer(w.delay)
+	timer.Stop()
+
+	var ticker *time.Ticker
+	var scan <-chan time.Time
+
+	defer func() {
+		if ticker != nil {
+			ticker.Stop()
+		}
+
+		w.close()
+	}()
+
+	for {
+		// Switch to periodic scanning once watch limits were exceeded.
+		if w.exceeded && ticker == nil {
+			ticker = time.NewTicker(ScanInterval)
+			scan = ticker.C
+		}
+
+		select {
+		case <-stopWatch:
+			return
+		case <-scan:
+			w.scan()
+		case ev, ok := <-w.notify.Events:
+			if !ok {
+				return
+			} else if w.event(ev) {
+				resetTimer(timer, w.delay)
+			}
+		case err, ok := <-w.notify.Errors:
+			if !ok {
+				return
+			} else if err == fsnotify.ErrEventOverflow {
+				// Events were lost, so all originals are indexed again.
+				log.Warnf("watch: %s, indexing all originals", err)
+
+				for _, root := range w.roots() {
+					if root != w.imports {
+						w.changed(w.rootName(root), entity.RootPath)
+					}
+				}
+
+				w.importFiles = w.imports != ""
+				resetTimer(timer, w.delay)
+			} else {
+				log.Errorf("watch: %s", err)
+			}
+		case <-timer.C:
+			if !w.flush() {
+				resetTimer(timer, w.delay)
+			}
+		}
+	}
+}
+
+// roots returns the absolute paths to be watched.
+func (w *Watcher) roots() (result []string) {
+	result = append(result, w.originals)
+
+	var libraries []string
+
+	for path := range w.libraries {
+		libraries = append(libraries, path)
+	}
+
+	sort.Strings(libraries)
+
+	result = append(result, libraries...)
+
+	if w.imports != "" {
+		result = append(result, w.imports)
+	}
+
+	return result
+}
+
+// root returns the watched root path that contains the file name, or an empty string if there is none.
+func (w *Watcher) root(fileName string) string {
+	roots := w.roots()
+
+	// Check import first in case it is a subfolder of originals.
+	for i := len(roots) - 1; i >= 0; i-- {
+		if root := roots[i]; fileName == root || strings.HasPrefix(fileName, root+string(filepath.Separator)) {
+			return root
+		}
+	}
+
+	return ""
+}
+
+// rootName returns the file root name used in the index for a watched root path.
+func (w *Watcher) rootName(root string) string {
+	if name, ok := w.libraries[root]; ok {
+		return name
+	}
+
+	return entity.RootOriginals
+}
+
+// changed registers a changed folder relative to the file root.
+func (w *Watcher) changed(rootName, dir string) {
+	if w.changedDirs[rootName] == nil {
+		w.changedDirs[rootName] = make(map[string]bool)
+	}
+
+	w.changedDirs[rootName][dir] = true
+}
+
+// add recursively watches a directory and all subdirectories that are not ignored.
+func (w *Watcher) add(dir string) error {
+	root := w.root(dir)
+	ignore := w.ignore[root]
+
+	return filepath.Walk(dir, func(fileName string, info os.FileInfo, err error) error {
+		if err != nil || !info.IsDir() {
+			return nil
+		}
+
+		// Skip the import folder if it is a subfolder of originals.
+		if fileName == w.imports && root != w.imports {
+			return filepath.SkipDir
+		}
+
+		if fileName != root && ignore.Ignore(fileName) {
+			return filepath.SkipDir
+		}
+
+		_ = ignore.Dir(fileName)
+
+		if err := w.notify.Add(fileName); watchLimit(err) {
+			return err
+		} else if err != nil {
+			log.Warnf("watch: %s", err)
+		}
+
+		return nil
+	})
+}
+
+// event registers a file system event and returns true if it needs to be processed.
+func (w *Watcher) event(ev fsnotify.Event) bool {
+	if ev.Op == fsnotify.Chmod {
+		return false
+	}
+
+	root := w.root(ev.Name)
+
+	if root == "" || w.ignore[root].Ignore(ev.Name) {
+		return false
+	}
+
+	dir := filepath.Dir(ev.Name)
+
+	if ev.Op&fsnotify.Create == fsnotify.Create {
+		if info, err := os.Stat(ev.Name); err == nil && info.IsDir() {
+			dir = ev.Name
+
+			if err := w.add(ev.Name); err != nil {
+				w.fallback(err)
+			}
+		}
+	}
+
+	if root == w.imports {
+		w.importFiles = true
+	} else {
+		w.changed(w.rootName(root), watchPath(fs.RelName(dir, root)))
+	}
+
+	return true
+}
+
+// flush indexes changed folders and imports new files, returns false if it needs to be retried later.
+func (w *Watcher) flush() bool {
+	if mutex.MainWorker.Busy() {
+		return false
+	}
+
+	defer func() {
+		for _, ignore := range w.ignore {
+			ignore.Reset()
+		}
+	}()
+
+	changed := w.changedDirs
+	w.changedDirs = make(map[string]map[string]bool)
+
+	for _, root := range w.roots() {
+		rootName := w.rootName(root)
+
+		if dirs := watchDirs(changed[rootName]); len(dirs) > 0 {
+			if err := IndexPaths(rootName, dirs); err != nil {
+				log.Errorf("watch: %s", err)
+			}
+		}
+	}
+
+	if w.importFiles {
+		w.importFiles = false
+
+		if err := Import(); err != nil {
+			log.Errorf("watch: %s", err)
+		}
+	}
+
+	return true
+}
+
+// scan periodically indexes originals and imports new files if the file system can't be watched.
+func (w *Watcher) scan() {
+	if mutex.MainWorker.Busy() {
+		return
+	}
+
+	if err := Index(); err != nil {
+		log.Errorf("watch: %s", err)
+	}
+
+	for _, lib := range w.conf.Libraries() {
+		if err := IndexPaths(lib.Name, []string{entity.RootPath}); err != nil {
+			log.Errorf("watch: %s", err)
+		}
+	}
+
+	if w.imports == "" {
+		return
+	}
+
+	if err := Import(); err != nil {
+		log.Errorf("watch: %s", err)
+	}
+}
+
+// fallback logs the error and switches to periodic scanning if watch limits were exceeded.
+// Existing watches are kept, so that changes in these folders are still indexed instantly.
+func (w *Watcher) fallback(err error) {
+	if !watchLimit(err) {
+		log.Errorf("watch: %s", err)
+		return
+	} else if w.exceeded {
+		return
+	}
+
+	log.Warnf("watch: limit exceeded, scanning every %s (%s)", ScanInterval, err)
+
+	w.exceeded = true
+}
+
+// close releases all watches.
+func (w *Watcher) close() {
+	if err := w.notify.Close(); err != nil {
+		log.Errorf("watch: %s", err)
+	}
+}
+
+// IndexPaths indexes changed originals in the given folders of a file root and flags removed files as missing.
+func IndexPaths(root string, paths []string) error {
+	if mutex.MainWorker.Busy() {
+		return nil
+	}
+
+	conf := service.Config()
+
+	rootPath := conf.OriginalsPath()
+
+	if lib, ok := conf.Library(root); ok {
+		rootPath = lib.Path
+	}
+
+	start := time.Now()
+
+	ind := service.Index()
+	prg := service.Purge()
+
+	for _, path := range paths {
+		log.Infof("watch: indexing %s", txt.Quote(path))
+
+		indexed := ind.Start(photoprism.IndexOptions{
+			Root:    root,
+			Rescan:  false,
+			Convert: conf.Settings().Index.Convert && conf.SidecarWritable(),
+			Path:    path,
+			Stack:   true,
+		})
+
+		prgOpt := photoprism.PurgeOptions{
+			Path:   path,
+			Ignore: indexed,
+		}
+
+		if files, photos, err := prg.Start(prgOpt); err != nil {
+			return err
+		} else if len(files) > 0 || len(photos) > 0 {
+			event.InfoMsg(i18n.MsgRemovedFilesAndPhotos, len(files), len(photos))
+		}
+	}
+
+	api.RemoveFromFolderCache(root)
+
+	moments := service.Moments()
+
+	if err := moments.Start(); err != nil {
+		log.Warnf("moments: %s", err)
+	}
+
+	elapsed := int(time.Since(start).Seconds())
+
+	event.Publish("index.completed", event.Data{"path": rootPath, "seconds": elapsed})
+
+	api.UpdateClientConfig()
+
+	return nil
+}
+
+// watchLimit tests if the error indicates that the number of watches or open files is exhausted.
+func watchLimit(err error) bool {
+	return errors.Is(err, syscall.ENOSPC) || errors.Is(err, syscall.EMFILE)
+}
+
+// watchPath returns the index path for a folder relative to the originals path.
+func watchPath(rel string) string {
+	if rel == "" || rel == "." {
+		return entity.RootPath
+	}
+
+	return filepath.Clean(rel)
+}
+
+// watchDirs returns the changed folders in sorted order without subfolders of other changed folders.
+func watchDirs(changed map[string]bool) (result []string) {
+	if changed[entity.RootPath] {
+		return []string{entity.RootPath}
+	}
+
+	for dir := range changed {
+		if !watchParentChanged(dir, changed) {
+			result = append(result, dir)
+		}
+	}
+
+	sort.Strings(result)
+
+	return result
+}
+
+// watchParentChanged tests if a parent folder is also in the list of changed folders.
+func watchParentChanged(dir string, changed map[string]bool) bool {
+	for parent := filepath.Dir(dir); parent != "." && parent != string(filepath.Separator); parent = filepath.Dir(parent) {
+		if changed[parent] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// resetTimer stops the timer and drains its channel before resetting it.
+func resetTimer(t *time.Timer, d time.Duration) {
+	if !t.Stop() {
+		select {
+		case <-t.C:
+		default:
+		}
+	}
+
+	t.Reset(d)
+}
@@ -0,0 +1,126 @@
+package auto
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+func TestWatchDirs(t *testing.T) {
+	t.Run("subfolders", func(t *testing.T) {
+		result := watchDirs(map[string]bool{"2021/05": true, "2021": true, "2020/12": true, "2021-old": true})
+		assert.Equal(t, []string{"2020/12", "2021", "2021-old"}, result)
+	})
+	t.Run("root", func(t *testing.T) {
+		result := watchDirs(map[string]bool{"2021/05": true, "/": true})
+		assert.Equal(t, []string{"/"}, result)
+	})
+	t.Run("empty", func(t *testing.T) {
+		assert.Empty(t, watchDirs(map[string]bool{}))
+	})
+}
+
+func TestWatchPath(t *testing.T) {
+	assert.Equal(t, "/", watchPath(""))
+	assert.Equal(t, "/", watchPath("."))
+	assert.Equal(t, "2021/05", watchPath("2021/05/"))
+}
+
+func TestWatchLimit(t *testing.T) {
+	assert.True(t, watchLimit(syscall.ENOSPC))
+	assert.True(t, watchLimit(syscall.EMFILE))
+	assert.False(t, watchLimit(syscall.ENOENT))
+	assert.False(t, watchLimit(nil))
+}
+
+func TestWatcher_Event(t *testing.T) {
+	dir, err := ioutil.TempDir("", "watch")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	if err := os.MkdirAll(filepath.Join(dir, "2021"), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ioutil.WriteFile(filepath.Join(dir, fs.IgnoreFile), []byte("*.tmp\n"), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	w := NewWatcher(config.TestConfig())
+	w.originals = dir
+	w.imports = filepath.Join(dir, "import")
+
+	if err := os.MkdirAll(w.imports, os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := w.Start(); err != nil {
+		t.Fatal(err)
+	}
+
+	defer w.close()
+
+	assert.True(t, w.event(fsnotify.Event{Name: filepath.Join(dir, "2021", "photo.jpg"), Op: fsnotify.Create}))
+	assert.False(t, w.event(fsnotify.Event{Name: filepath.Join(dir, "2021", "photo.jpg"), Op: fsnotify.Chmod}))
+	assert.False(t, w.event(fsnotify.Event{Name: filepath.Join(dir, "2021", "photo.tmp"), Op: fsnotify.Write}))
+	assert.False(t, w.event(fsnotify.Event{Name: filepath.Join(dir, "2021", ".hidden.jpg"), Op: fsnotify.Write}))
+	assert.False(t, w.event(fsnotify.Event{Name: "/somewhere/else.jpg", Op: fsnotify.Write}))
+	assert.True(t, w.event(fsnotify.Event{Name: filepath.Join(dir, "photo.jpg"), Op: fsnotify.Remove}))
+	assert.Equal(t, map[string]bool{"2021": true, "/": true}, w.changedDirs[entity.RootOriginals])
+	assert.False(t, w.importFiles)
+
+	assert.True(t, w.event(fsnotify.Event{Name: filepath.Join(w.imports, "photo.jpg"), Op: fsnotify.Write}))
+	assert.True(t, w.importFiles)
+	assert.Equal(t, 2, len(w.changedDirs[entity.RootOriginals]))
+}
+
+func TestWatcher_Libraries(t *testing.T) {
+	dir, err := ioutil.TempDir("", "watch")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	originals := filepath.Join(dir, "originals")
+	archive := filepath.Join(dir, "archive")
+
+	if err := os.MkdirAll(filepath.Join(archive, "2020"), os.ModePerm); err != nil {
+		t.Fatal(err)
+	} else if err := os.MkdirAll(originals, os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	w := NewWatcher(config.TestConfig())
+	w.originals = originals
+	w.libraries = map[string]string{archive: "archive"}
+	w.imports = ""
+
+	assert.Equal(t, []string{originals, archive}, w.roots())
+	assert.Equal(t, "archive", w.rootName(w.root(filepath.Join(archive, "2020", "photo.jpg"))))
+	assert.Equal(t, entity.RootOriginals, w.rootName(w.root(filepath.Join(originals, "photo.jpg"))))
+
+	if err := w.Start(); err != nil {
+		t.Fatal(err)
+	}
+
+	defer w.close()
+
+	assert.True(t, w.event(fsnotify.Event{Name: filepath.Join(archive, "2020", "photo.jpg"), Op: fsnotify.Create}))
+	assert.Equal(t, map[string]bool{"2020": true}, w.changedDirs["archive"])
+	assert.Empty(t, w.changedDirs[entity.RootOriginals])
+}
@@ -31,7 +31,7 @@ func LocationLabel(name string, uncertainty int) Label {
 
 	var categories []string
 
-	if rule, ok := rules.Find(name); ok {
+	if rule, ok := FindRule(name); ok {
 		priority = rule.Priority
 		categories = rule.Categories
 	}
@@ -59,9 +59,9 @@ func FaceLabels(faces face.Faces, src string) Labels {
 	if count < 1 {
 		return Labels{}
 	} else if count == 1 {
-		r = rules["portrait"]
+		r, _ = FindRule("portrait")
 	} else {
-		r = rules["people"]
+		r, _ = FindRule("people")
 	}
 
 	return Labels{Label{
@@ -2,10 +2,11 @@ package classify
 
 // LabelRule defines the rule for a given Label
 type LabelRule struct {
-	Label      string
-	Threshold  float32
-	Categories []string
-	Priority   int
+	Label      string   `json:"label" yaml:"label,omitempty"`
+	See        string   `json:"see,omitempty" yaml:"see,omitempty"`
+	Threshold  float32  `json:"threshold" yaml:"threshold,omitempty"`
+	Categories []string `json:"categories" yaml:"categories,omitempty"`
+	Priority   int      `json:"priority" yaml:"priority,omitempty"`
 }
 
 // LabelRules is a map of rules with label name as index
@@ -0,0 +1,60 @@
+package classify
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/detect"
+)
+
+// ObjectLabel returns the label of an object found by object detection, based on the current label rules.
+func ObjectLabel(obj detect.Object, src string) (Label, bool) {
+	labelText := obj.Name
+
+	rule, _ := FindRule(labelText)
+
+	// Discard objects that don't meet the threshold.
+	if obj.Score < rule.Threshold {
+		return Label{}, false
+	}
+
+	// Get rule label name instead of the model label name if it exists.
+	if rule.Label != "" {
+		labelText = rule.Label
+	}
+
+	labelText = strings.TrimSpace(labelText)
+
+	if labelText == "" {
+		return Label{}, false
+	}
+
+	uncertainty := 100 - int(math.Round(float64(obj.Score*100)))
+
+	return Label{Name: labelText, Source: src, Uncertainty: uncertainty, Priority: rule.Priority, Categories: rule.Categories}, true
+}
+
+// ObjectLabels returns one label per object type found in the image, using the best score.
+func ObjectLabels(objects detect.Objects, src string) (result Labels) {
+	found := make(map[string]int)
+
+	for _, obj := range objects {
+		l, ok := ObjectLabel(obj, src)
+
+		if !ok {
+			continue
+		}
+
+		if i, exists := found[l.Name]; !exists {
+			found[l.Name] = len(result)
+			result = append(result, l)
+		} else if l.Uncertainty < result[i].Uncertainty {
+			result[i].Uncertainty = l.Uncertainty
+		}
+	}
+
+	sort.Sort(result)
+
+	return result
+}
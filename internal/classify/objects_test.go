@@ -0,0 +1,46 @@
+package classify
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/detect"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestObjectLabel(t *testing.T) {
+	t.Run("dog", func(t *testing.T) {
+		l, ok := ObjectLabel(detect.Object{Name: "dog", Score: 0.8}, SrcImage)
+
+		assert.True(t, ok)
+		assert.Equal(t, "dog", l.Name)
+		assert.Equal(t, 20, l.Uncertainty)
+		assert.Equal(t, 5, l.Priority)
+		assert.Equal(t, []string{"animal"}, l.Categories)
+	})
+	t.Run("rule label", func(t *testing.T) {
+		l, ok := ObjectLabel(detect.Object{Name: "tabby cat", Score: 0.9}, SrcImage)
+
+		assert.True(t, ok)
+		assert.Equal(t, "cat", l.Name)
+	})
+	t.Run("threshold", func(t *testing.T) {
+		_, ok := ObjectLabel(detect.Object{Name: "car", Score: 0.2}, SrcImage)
+
+		assert.False(t, ok)
+	})
+}
+
+func TestObjectLabels(t *testing.T) {
+	objects := detect.Objects{
+		{Name: "dog", Score: 0.6},
+		{Name: "person", Score: 0.7},
+		{Name: "dog", Score: 0.9},
+	}
+
+	result := ObjectLabels(objects, SrcImage)
+
+	assert.Len(t, result, 2)
+	assert.Equal(t, "dog", result[0].Name)
+	assert.Equal(t, 10, result[0].Uncertainty)
+	assert.Equal(t, "person", result[1].Name)
+}
@@ -0,0 +1,93 @@
+package classify
+
+import (
+	"math"
+	"sort"
+	"strings"
+)
+
+// PredictionsMax is the max number of raw predictions kept per image.
+const PredictionsMax = 10
+
+// PredictionsMin is the min probability of raw predictions that are kept.
+const PredictionsMin = 0.01
+
+// Prediction represents a raw model output with the original label name and its probability.
+type Prediction struct {
+	Name        string  `json:"n"`
+	Probability float32 `json:"p"`
+}
+
+// Predictions represents the raw model outputs for an image, sorted by probability.
+type Predictions []Prediction
+
+// NewPredictions returns the most probable raw predictions with rounded probabilities.
+func NewPredictions(labels []string, probabilities []float32) (result Predictions) {
+	for i, p := range probabilities {
+		if i >= len(labels) {
+			// break if probabilities and labels does not match
+			break
+		}
+
+		if p < PredictionsMin {
+			continue
+		}
+
+		result = append(result, Prediction{
+			Name:        strings.ToLower(labels[i]),
+			Probability: float32(math.Round(float64(p)*1000) / 1000),
+		})
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Probability > result[j].Probability
+	})
+
+	if len(result) > PredictionsMax {
+		return result[:PredictionsMax]
+	}
+
+	return result
+}
+
+// Labels returns the best 5 labels (if enough high probability labels) based on the current label rules.
+func (p Predictions) Labels() Labels {
+	var result Labels
+
+	for _, prediction := range p {
+		// discard labels with low probabilities
+		if prediction.Probability < 0.1 {
+			continue
+		}
+
+		labelText := prediction.Name
+
+		rule, _ := FindRule(labelText)
+
+		// discard labels that don't met the threshold
+		if prediction.Probability < rule.Threshold {
+			continue
+		}
+
+		// Get rule label name instead of the model label name if it exists
+		if rule.Label != "" {
+			labelText = rule.Label
+		}
+
+		labelText = strings.TrimSpace(labelText)
+
+		uncertainty := 100 - int(math.Round(float64(prediction.Probability*100)))
+
+		result = append(result, Label{Name: labelText, Source: SrcImage, Uncertainty: uncertainty, Priority: rule.Priority, Categories: rule.Categories})
+	}
+
+	// Sort by probability
+	sort.Sort(result)
+
+	// Return the best labels only.
+	if l := len(result); l < 5 {
+		return result[:l]
+	} else {
+		return result[:5]
+	}
+}
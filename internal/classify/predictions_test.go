@@ -0,0 +1,45 @@
+package classify
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPredictions(t *testing.T) {
+	t.Run("sorted", func(t *testing.T) {
+		result := NewPredictions([]string{"Tabby Cat", "Dog", "Abacus"}, []float32{0.12345, 0.005, 0.8})
+
+		assert.Equal(t, Predictions{{Name: "abacus", Probability: 0.8}, {Name: "tabby cat", Probability: 0.123}}, result)
+	})
+	t.Run("max", func(t *testing.T) {
+		labels := make([]string, 20)
+		probabilities := make([]float32, 20)
+
+		for i := range labels {
+			labels[i] = "cat"
+			probabilities[i] = 0.05
+		}
+
+		assert.Len(t, NewPredictions(labels, probabilities), PredictionsMax)
+	})
+	t.Run("mismatch", func(t *testing.T) {
+		assert.Len(t, NewPredictions([]string{"cat"}, []float32{0.5, 0.5}), 1)
+	})
+}
+
+func TestPredictions_Labels(t *testing.T) {
+	p := Predictions{
+		{Name: "tabby cat", Probability: 0.8},
+		{Name: "abacus", Probability: 0.5},
+		{Name: "chameleon", Probability: 0.09},
+	}
+
+	result := p.Labels()
+
+	if assert.Len(t, result, 1) {
+		assert.Equal(t, "cat", result[0].Name)
+		assert.Equal(t, 20, result[0].Uncertainty)
+		assert.Equal(t, SrcImage, result[0].Source)
+	}
+}
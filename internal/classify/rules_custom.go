@@ -0,0 +1,171 @@
+package classify
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/txt"
+	"gopkg.in/yaml.v2"
+)
+
+var rulesMutex = sync.RWMutex{}
+
+// activeRules contains the built-in rules merged with custom rules, if any.
+var activeRules = rules
+
+// customRules contains the rules loaded from a user-supplied file.
+var customRules = LabelRules{}
+
+// FindRule returns the active rule for a label, custom rules take precedence over built-in defaults.
+func FindRule(label string) (rule LabelRule, ok bool) {
+	rulesMutex.RLock()
+	defer rulesMutex.RUnlock()
+
+	return activeRules.Find(label)
+}
+
+// Rules returns a copy of the active label rules.
+func Rules() LabelRules {
+	rulesMutex.RLock()
+	defer rulesMutex.RUnlock()
+
+	return activeRules.Copy()
+}
+
+// DefaultRules returns a copy of the built-in label rules.
+func DefaultRules() LabelRules {
+	return rules.Copy()
+}
+
+// CustomRules returns a copy of the custom label rules.
+func CustomRules() LabelRules {
+	rulesMutex.RLock()
+	defer rulesMutex.RUnlock()
+
+	return customRules.Copy()
+}
+
+// SetCustomRules validates custom label rules and merges them over the built-in defaults.
+// A custom rule replaces the default rule with the same name, "see" refers to another rule.
+func SetCustomRules(custom LabelRules) error {
+	normalized, merged, err := mergeRules(custom)
+
+	if err != nil {
+		return err
+	}
+
+	rulesMutex.Lock()
+	defer rulesMutex.Unlock()
+
+	customRules = normalized
+	activeRules = merged
+
+	return nil
+}
+
+// ValidateCustomRules returns an error if the custom label rules are invalid, without activating them.
+func ValidateCustomRules(custom LabelRules) error {
+	_, _, err := mergeRules(custom)
+
+	return err
+}
+
+// mergeRules validates and normalizes custom label rules, and merges them over the built-in defaults.
+func mergeRules(custom LabelRules) (normalized, merged LabelRules, err error) {
+	merged = rules.Copy()
+	normalized = make(LabelRules, len(custom))
+
+	for name, rule := range custom {
+		name = strings.ToLower(strings.TrimSpace(name))
+
+		if name == "" {
+			return nil, nil, fmt.Errorf("label rule name must not be empty")
+		} else if rule.Threshold < 0 || rule.Threshold > 1 {
+			return nil, nil, fmt.Errorf("threshold of %s must be between 0 and 1", txt.Quote(name))
+		}
+
+		rule.Label = strings.ToLower(strings.TrimSpace(rule.Label))
+		rule.See = strings.ToLower(strings.TrimSpace(rule.See))
+
+		if rule.Categories == nil {
+			rule.Categories = []string{}
+		}
+
+		normalized[name] = rule
+		merged[name] = rule
+	}
+
+	// Resolve references to other rules.
+	unresolved := merged.Copy()
+
+	for name, rule := range normalized {
+		if rule.See == "" {
+			continue
+		} else if rule.See == name {
+			return nil, nil, fmt.Errorf("%s must not refer to itself", txt.Quote(name))
+		}
+
+		see, ok := unresolved[rule.See]
+
+		if !ok {
+			return nil, nil, fmt.Errorf("missing label %s in %s", txt.Quote(rule.See), txt.Quote(name))
+		} else if see.See != "" {
+			return nil, nil, fmt.Errorf("%s must not refer to %s, which refers to another label", txt.Quote(name), txt.Quote(rule.See))
+		}
+
+		see.See = rule.See
+		merged[name] = see
+	}
+
+	return normalized, merged, nil
+}
+
+// LoadRules reads label rules from a YAML file.
+func LoadRules(fileName string) (result LabelRules, err error) {
+	if !fs.FileExists(fileName) {
+		return result, fmt.Errorf("label rules not found in %s", txt.Quote(fileName))
+	}
+
+	yamlConfig, err := ioutil.ReadFile(fileName)
+
+	if err != nil {
+		return result, err
+	}
+
+	if err := yaml.Unmarshal(yamlConfig, &result); err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
+
+// Copy returns a copy of the rules.
+func (rules LabelRules) Copy() LabelRules {
+	result := make(LabelRules, len(rules))
+
+	for name, rule := range rules {
+		result[name] = rule
+	}
+
+	return result
+}
+
+// Save writes the rules to a YAML file.
+func (rules LabelRules) Save(fileName string) error {
+	data, err := yaml.Marshal(rules)
+
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(fileName), os.ModePerm); err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(fileName, data, os.ModePerm)
+}
@@ -0,0 +1,95 @@
+package classify
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetCustomRules(t *testing.T) {
+	defer func() {
+		if err := SetCustomRules(LabelRules{}); err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	t.Run("success", func(t *testing.T) {
+		err := SetCustomRules(LabelRules{
+			"Cat":       {Label: "cat", Threshold: 0.5, Priority: 7, Categories: []string{"pet"}},
+			"kitten":    {See: "cat"},
+			"tabby cat": {See: "dog"},
+		})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		cat, ok := FindRule("cat")
+		assert.True(t, ok)
+		assert.Equal(t, float32(0.5), cat.Threshold)
+		assert.Equal(t, 7, cat.Priority)
+		assert.Equal(t, []string{"pet"}, cat.Categories)
+
+		kitten, ok := FindRule("kitten")
+		assert.True(t, ok)
+		assert.Equal(t, "cat", kitten.Label)
+		assert.Equal(t, 7, kitten.Priority)
+
+		tabby, _ := FindRule("tabby cat")
+		dog, _ := rules.Find("dog")
+		assert.Equal(t, dog.Label, tabby.Label)
+
+		assert.Len(t, CustomRules(), 3)
+		assert.Len(t, Rules(), len(rules)+1)
+
+		// Built-in rules are not modified.
+		def, _ := rules.Find("cat")
+		assert.Equal(t, 5, def.Priority)
+	})
+	t.Run("invalid threshold", func(t *testing.T) {
+		assert.Error(t, SetCustomRules(LabelRules{"cat": {Threshold: 2}}))
+	})
+	t.Run("missing label", func(t *testing.T) {
+		assert.Error(t, SetCustomRules(LabelRules{"cat": {See: "unknown label"}}))
+	})
+	t.Run("chained reference", func(t *testing.T) {
+		assert.Error(t, SetCustomRules(LabelRules{"cat": {See: "kitten"}, "kitten": {See: "dog"}}))
+	})
+}
+
+func TestValidateCustomRules(t *testing.T) {
+	assert.NoError(t, ValidateCustomRules(LabelRules{"kitten": {See: "cat"}}))
+	assert.Error(t, ValidateCustomRules(LabelRules{"cat": {Threshold: 2}}))
+
+	// Rules are not activated.
+	_, ok := FindRule("kitten")
+	assert.False(t, ok)
+}
+
+func TestLabelRules_Save(t *testing.T) {
+	fileName := filepath.Join(os.TempDir(), "photoprism-test-labels.yml")
+
+	defer os.Remove(fileName)
+
+	custom := LabelRules{
+		"cat":    {Label: "cat", Threshold: 0.3, Priority: 2, Categories: []string{"animal"}},
+		"kitten": {See: "cat"},
+	}
+
+	if err := custom.Save(fileName); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := LoadRules(fileName)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, custom, result)
+
+	_, err = LoadRules(fileName + ".missing")
+	assert.Error(t, err)
+}
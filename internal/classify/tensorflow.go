@@ -6,13 +6,10 @@ import (
 	"fmt"
 	"image"
 	"io/ioutil"
-	"math"
 	"os"
 	"path"
 	"path/filepath"
 	"runtime/debug"
-	"sort"
-	"strings"
 
 	"github.com/disintegration/imaging"
 	"github.com/photoprism/photoprism/pkg/txt"
@@ -45,6 +42,17 @@ func (t *TensorFlow) Init() (err error) {
 
 // File returns matching labels for a jpeg media file.
 func (t *TensorFlow) File(filename string) (result Labels, err error) {
+	predictions, err := t.FilePredictions(filename)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return predictions.Labels(), nil
+}
+
+// FilePredictions returns the raw model predictions for a jpeg media file.
+func (t *TensorFlow) FilePredictions(filename string) (result Predictions, err error) {
 	if t.disabled {
 		return result, nil
 	}
@@ -55,11 +63,28 @@ func (t *TensorFlow) File(filename string) (result Labels, err error) {
 		return nil, err
 	}
 
-	return t.Labels(imageBuffer)
+	return t.Predictions(imageBuffer)
 }
 
 // Labels returns matching labels for a jpeg media string.
 func (t *TensorFlow) Labels(img []byte) (result Labels, err error) {
+	predictions, err := t.Predictions(img)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result = predictions.Labels()
+
+	if len(result) > 0 {
+		log.Tracef("classify: image classified as %+v", result)
+	}
+
+	return result, nil
+}
+
+// Predictions returns the raw model predictions for a jpeg media string.
+func (t *TensorFlow) Predictions(img []byte) (result Predictions, err error) {
 	defer func() {
 		if r := recover(); r != nil {
 			err = fmt.Errorf("classify: %s (inference panic)\nstack: %s", r, debug.Stack())
@@ -99,14 +124,7 @@ func (t *TensorFlow) Labels(img []byte) (result Labels, err error) {
 		return result, fmt.Errorf("classify: inference failed, no output")
 	}
 
-	// Return best labels
-	result = t.bestLabels(output[0].Value().([][]float32)[0])
-
-	if len(result) > 0 {
-		log.Tracef("classify: image classified as %+v", result)
-	}
-
-	return result, nil
+	return NewPredictions(t.labels, output[0].Value().([][]float32)[0]), nil
 }
 
 func (t *TensorFlow) loadLabels(path string) error {
@@ -137,6 +155,11 @@ func (t *TensorFlow) loadLabels(path string) error {
 	return nil
 }
 
+// ModelName returns the name of the classification model.
+func (t *TensorFlow) ModelName() string {
+	return t.modelName
+}
+
 // ModelLoaded tests if the TensorFlow model is loaded.
 func (t *TensorFlow) ModelLoaded() bool {
 	return t.model != nil
@@ -165,49 +188,7 @@ func (t *TensorFlow) loadModel() error {
 
 // bestLabels returns the best 5 labels (if enough high probability labels) from the prediction of the model
 func (t *TensorFlow) bestLabels(probabilities []float32) Labels {
-	var result Labels
-
-	for i, p := range probabilities {
-		if i >= len(t.labels) {
-			// break if probabilities and labels does not match
-			break
-		}
-
-		// discard labels with low probabilities
-		if p < 0.1 {
-			continue
-		}
-
-		labelText := strings.ToLower(t.labels[i])
-
-		rule, _ := rules.Find(labelText)
-
-		// discard labels that don't met the threshold
-		if p < rule.Threshold {
-			continue
-		}
-
-		// Get rule label name instead of t.labels name if it exists
-		if rule.Label != "" {
-			labelText = rule.Label
-		}
-
-		labelText = strings.TrimSpace(labelText)
-
-		uncertainty := 100 - int(math.Round(float64(p*100)))
-
-		result = append(result, Label{Name: labelText, Source: SrcImage, Uncertainty: uncertainty, Priority: rule.Priority, Categories: rule.Categories})
-	}
-
-	// Sort by probability
-	sort.Sort(result)
-
-	// Return the best labels only.
-	if l := len(result); l < 5 {
-		return result[:l]
-	} else {
-		return result[:5]
-	}
+	return NewPredictions(t.labels, probabilities).Labels()
 }
 
 // createTensor converts bytes jpeg image in a tensor object required as tensorflow model input
@@ -1,13 +1,10 @@
 package commands
 
 import (
-	"bytes"
 	"context"
-	"errors"
 	"fmt"
 	"io/ioutil"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"time"
 
@@ -119,48 +116,20 @@ func backupAction(ctx *cli.Context) error {
 			log.Infof("backing up database to %s", txt.Quote(indexFileName))
 		}
 
-		var cmd *exec.Cmd
-
-		switch conf.DatabaseDriver() {
-		case config.MySQL, config.MariaDB:
-			cmd = exec.Command(
-				conf.MysqldumpBin(),
-				"--protocol", "tcp",
-				"-h", conf.DatabaseHost(),
-				"-P", conf.DatabasePortString(),
-				"-u", conf.DatabaseUser(),
-				"-p"+conf.DatabasePassword(),
-				conf.DatabaseName(),
-			)
-		case config.SQLite:
-			cmd = exec.Command(
-				conf.SqliteBin(),
-				conf.DatabaseDsn(),
-				".dump",
-			)
-		default:
-			return fmt.Errorf("unsupported database type: %s", conf.DatabaseDriver())
-		}
+		photoprism.SetConfig(conf)
 
-		// Fetch command output.
-		var out bytes.Buffer
-		var stderr bytes.Buffer
-		cmd.Stdout = &out
-		cmd.Stderr = &stderr
+		out, err := photoprism.DumpIndex()
 
-		// Run backup command.
-		if err := cmd.Run(); err != nil {
-			if stderr.String() != "" {
-				return errors.New(stderr.String())
-			}
+		if err != nil {
+			return err
 		}
 
 		if indexFileName == "-" {
 			// Return output via stdout.
-			fmt.Println(out.String())
+			fmt.Println(string(out))
 		} else {
 			// Write output to file.
-			if err := ioutil.WriteFile(indexFileName, []byte(out.String()), os.ModePerm); err != nil {
+			if err := ioutil.WriteFile(indexFileName, out, os.ModePerm); err != nil {
 				return err
 			}
 		}
@@ -30,15 +30,25 @@ func configAction(ctx *cli.Context) error {
 	fmt.Printf("%-25s %t\n", "public", conf.Public())
 	fmt.Printf("%-25s %t\n", "read-only", conf.ReadOnly())
 	fmt.Printf("%-25s %t\n", "experimental", conf.Experimental())
+	fmt.Printf("%-25s %s\n", "two-factor-roles", conf.Options().TwoFactorRoles)
+	fmt.Printf("%-25s %d\n", "login-attempts", conf.LoginAttempts())
+	fmt.Printf("%-25s %d\n", "login-lockout", conf.LoginLockout()/time.Second)
 
 	// Config path and main file.
 	fmt.Printf("%-25s %s\n", "config-file", conf.ConfigFile())
 	fmt.Printf("%-25s %s\n", "config-path", conf.ConfigPath())
 	fmt.Printf("%-25s %s\n", "settings-file", conf.SettingsFile())
+	fmt.Printf("%-25s %s\n", "libraries-file", conf.LibrariesFile())
+	fmt.Printf("%-25s %s\n", "jobs-file", conf.JobsFile())
 
 	// Main directories.
 	fmt.Printf("%-25s %s\n", "originals-path", conf.OriginalsPath())
 	fmt.Printf("%-25s %d\n", "originals-limit", conf.OriginalsLimit())
+
+	for _, lib := range conf.Libraries() {
+		fmt.Printf("%-25s %s\n", "library-"+lib.Name, lib.Path)
+	}
+
 	fmt.Printf("%-25s %s\n", "import-path", conf.ImportPath())
 	fmt.Printf("%-25s %s\n", "storage-path", conf.StoragePath())
 	fmt.Printf("%-25s %s\n", "sidecar-path", conf.SidecarPath())
@@ -56,14 +66,18 @@ func configAction(ctx *cli.Context) error {
 
 	// Background workers.
 	fmt.Printf("%-25s %d\n", "workers", conf.Workers())
+	fmt.Printf("%-25s %d\n", "thumb-workers", conf.ThumbWorkers())
+	fmt.Printf("%-25s %d\n", "classify-workers", conf.ClassifyWorkers())
 	fmt.Printf("%-25s %d\n", "wakeup-interval", conf.WakeupInterval()/time.Second)
 	fmt.Printf("%-25s %d\n", "auto-index", conf.AutoIndex()/time.Second)
 	fmt.Printf("%-25s %d\n", "auto-import", conf.AutoImport()/time.Second)
+	fmt.Printf("%-25s %d\n", "watch-delay", conf.WatchDelay()/time.Second)
 
 	// Disable features.
 	fmt.Printf("%-25s %t\n", "disable-backups", conf.DisableBackups())
 	fmt.Printf("%-25s %t\n", "disable-settings", conf.DisableSettings())
 	fmt.Printf("%-25s %t\n", "disable-places", conf.DisablePlaces())
+	fmt.Printf("%-25s %s\n", "map-tiles", conf.MapTiles())
 	fmt.Printf("%-25s %t\n", "disable-exiftool", conf.DisableExifTool())
 	fmt.Printf("%-25s %t\n", "disable-tensorflow", conf.DisableTensorFlow())
 	fmt.Printf("%-25s %t\n", "disable-darktable", conf.DisableDarktable())
@@ -71,11 +85,17 @@ func configAction(ctx *cli.Context) error {
 	fmt.Printf("%-25s %t\n", "disable-sips", conf.DisableSips())
 	fmt.Printf("%-25s %t\n", "disable-heifconvert", conf.DisableHeifConvert())
 	fmt.Printf("%-25s %t\n", "disable-ffmpeg", conf.DisableFFmpeg())
+	fmt.Printf("%-25s %t\n", "disable-tesseract", conf.DisableTesseract())
 
 	// Everything related to TensorFlow.
 	fmt.Printf("%-25s %s\n", "tensorflow-version", conf.TensorFlowVersion())
 	fmt.Printf("%-25s %s\n", "tensorflow-model-path", conf.TensorFlowModelPath())
 	fmt.Printf("%-25s %t\n", "detect-nsfw", conf.DetectNSFW())
+	fmt.Printf("%-25s %t\n", "detect-objects", conf.DetectObjects())
+	fmt.Printf("%-25s %s\n", "detect-model-path", conf.DetectModelPath())
+	fmt.Printf("%-25s %d\n", "face-size", conf.FaceSize())
+	fmt.Printf("%-25s %d\n", "face-score", conf.FaceScore())
+	fmt.Printf("%-25s %d\n", "face-max", conf.FaceMax())
 	fmt.Printf("%-25s %t\n", "upload-nsfw", conf.UploadNSFW())
 
 	// Site information.
@@ -126,10 +146,14 @@ func configAction(ctx *cli.Context) error {
 	fmt.Printf("%-25s %d\n", "ffmpeg-bitrate", conf.FFmpegBitrate())
 	fmt.Printf("%-25s %d\n", "ffmpeg-buffers", conf.FFmpegBuffers())
 	fmt.Printf("%-25s %s\n", "exiftool-bin", conf.ExifToolBin())
+	fmt.Printf("%-25s %s\n", "tesseract-bin", conf.TesseractBin())
+	fmt.Printf("%-25s %s\n", "tesseract-lang", conf.TesseractLang())
+	fmt.Printf("%-25s %t\n", "ocr-all", conf.OcrAll())
 
 	// Thumbs, resampling and download security token.
 	fmt.Printf("%-25s %s\n", "download-token", conf.DownloadToken())
 	fmt.Printf("%-25s %s\n", "preview-token", conf.PreviewToken())
+	fmt.Printf("%-25s %s\n", "metrics-token", conf.MetricsToken())
 	fmt.Printf("%-25s %s\n", "thumb-filter", conf.ThumbFilter())
 	fmt.Printf("%-25s %t\n", "thumb-uncached", conf.ThumbUncached())
 	fmt.Printf("%-25s %d\n", "thumb-size", conf.ThumbSize())
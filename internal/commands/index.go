@@ -2,6 +2,7 @@ package commands
 
 import (
 	"context"
+	"fmt"
 	"path/filepath"
 	"strings"
 	"time"
@@ -31,6 +32,10 @@ var indexFlags = []cli.Flag{
 		Name:  "cleanup",
 		Usage: "removes orphan index entries and thumbnails",
 	},
+	cli.StringFlag{
+		Name:  "root, r",
+		Usage: "library `NAME` to index instead of originals",
+	},
 }
 
 // indexAction indexes all photos in originals directory (photo library)
@@ -51,11 +56,21 @@ func indexAction(ctx *cli.Context) error {
 
 	// Use first argument to limit scope if set.
 	subPath := strings.TrimSpace(ctx.Args().First())
+	rootName := strings.TrimSpace(ctx.String("root"))
+	rootPath := conf.OriginalsPath()
+
+	if rootName != "" {
+		if lib, ok := conf.Library(rootName); !ok {
+			return fmt.Errorf("library %s not found", txt.Quote(rootName))
+		} else {
+			rootPath = lib.Path
+		}
+	}
 
 	if subPath == "" {
-		log.Infof("indexing originals in %s", txt.Quote(conf.OriginalsPath()))
+		log.Infof("indexing originals in %s", txt.Quote(rootPath))
 	} else {
-		log.Infof("indexing originals in %s", txt.Quote(filepath.Join(conf.OriginalsPath(), subPath)))
+		log.Infof("indexing originals in %s", txt.Quote(filepath.Join(rootPath, subPath)))
 	}
 
 	if conf.ReadOnly() {
@@ -65,6 +80,7 @@ func indexAction(ctx *cli.Context) error {
 	ind := service.Index()
 
 	indOpt := photoprism.IndexOptions{
+		Root:    rootName,
 		Path:    subPath,
 		Rescan:  ctx.Bool("all"),
 		Convert: conf.Settings().Index.Convert && conf.SidecarWritable(),
@@ -0,0 +1,232 @@
+package commands
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/internal/workers"
+	"github.com/urfave/cli"
+)
+
+// JobsCommand registers the jobs cli command.
+var JobsCommand = cli.Command{
+	Name:   "jobs",
+	Usage:  "Lists, runs and pauses scheduled background jobs",
+	Action: jobsListAction,
+	Subcommands: []cli.Command{
+		{
+			Name:   "ls",
+			Usage:  "Lists background jobs with their schedule and last run",
+			Action: jobsListAction,
+		},
+		{
+			Name:      "run",
+			Usage:     "Runs a background job now",
+			ArgsUsage: "[name]",
+			Action:    jobsRunAction,
+		},
+		{
+			Name:      "pause",
+			Usage:     "Pauses scheduled runs of a background job",
+			ArgsUsage: "[name]",
+			Action:    jobsPauseAction,
+		},
+		{
+			Name:      "resume",
+			Usage:     "Resumes scheduled runs of a background job",
+			ArgsUsage: "[name]",
+			Action:    jobsResumeAction,
+		},
+		{
+			Name:      "history",
+			Usage:     "Shows the run history of a background job",
+			ArgsUsage: "[name]",
+			Flags: []cli.Flag{
+				cli.IntFlag{
+					Name:  "count, n",
+					Usage: "max `NUMBER` of runs",
+					Value: 20,
+				},
+			},
+			Action: jobsHistoryAction,
+		},
+	},
+}
+
+// jobsConfig initializes the config and database for job commands.
+func jobsConfig(ctx *cli.Context) (*config.Config, error) {
+	conf := config.NewConfig(ctx)
+	service.SetConfig(conf)
+
+	if err := conf.Init(); err != nil {
+		return conf, err
+	}
+
+	conf.InitDb()
+
+	return conf, nil
+}
+
+// jobsListAction lists background jobs with their schedule and last run.
+func jobsListAction(ctx *cli.Context) error {
+	conf, err := jobsConfig(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	defer conf.Shutdown()
+
+	fmt.Printf("%-10s %-20s %-8s %-20s %s\n", "NAME", "SCHEDULE", "PAUSED", "LAST RUN", "RESULT")
+
+	for _, job := range workers.Jobs(conf) {
+		schedule := job.Schedule
+		lastRun := "-"
+		result := "-"
+
+		if schedule == "" {
+			schedule = "-"
+		}
+
+		if job.LastRun != nil {
+			lastRun = job.LastRun.StartedAt.Local().Format("2006-01-02 15:04:05")
+			result = jobRunResult(*job.LastRun)
+		}
+
+		fmt.Printf("%-10s %-20s %-8t %-20s %s\n", job.Name, schedule, job.Paused, lastRun, result)
+	}
+
+	return nil
+}
+
+// jobsRunAction runs a background job now.
+func jobsRunAction(ctx *cli.Context) error {
+	name := ctx.Args().First()
+
+	if name == "" {
+		return cli.ShowSubcommandHelp(ctx)
+	}
+
+	start := time.Now()
+
+	conf, err := jobsConfig(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	defer conf.Shutdown()
+
+	run, err := workers.RunJob(conf, name, entity.JobTriggerManual)
+
+	if err != nil {
+		return err
+	}
+
+	log.Infof("jobs: %s completed in %s (%s)", name, time.Since(start), jobRunResult(*run))
+
+	return nil
+}
+
+// jobsPauseAction pauses scheduled runs of a background job.
+func jobsPauseAction(ctx *cli.Context) error {
+	return jobsSetPaused(ctx, true)
+}
+
+// jobsResumeAction resumes scheduled runs of a background job.
+func jobsResumeAction(ctx *cli.Context) error {
+	return jobsSetPaused(ctx, false)
+}
+
+// jobsSetPaused pauses or resumes scheduled runs of a background job.
+func jobsSetPaused(ctx *cli.Context, paused bool) error {
+	name := ctx.Args().First()
+
+	if name == "" {
+		return cli.ShowSubcommandHelp(ctx)
+	}
+
+	conf, err := jobsConfig(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	defer conf.Shutdown()
+
+	if err := workers.PauseJob(name, paused); err != nil {
+		return err
+	}
+
+	if paused {
+		log.Infof("jobs: %s paused", name)
+	} else {
+		log.Infof("jobs: %s resumed", name)
+	}
+
+	return nil
+}
+
+// jobsHistoryAction shows the run history of a background job.
+func jobsHistoryAction(ctx *cli.Context) error {
+	name := ctx.Args().First()
+
+	if name == "" {
+		return cli.ShowSubcommandHelp(ctx)
+	}
+
+	conf, err := jobsConfig(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	defer conf.Shutdown()
+
+	if _, err := workers.Job(conf, name); err != nil {
+		return err
+	}
+
+	runs, err := query.JobRuns(name, ctx.Int("count"), 0)
+
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%-20s %-10s %-12s %s\n", "STARTED", "TRIGGER", "DURATION", "RESULT")
+
+	for _, run := range runs {
+		duration := "running"
+
+		if !run.Running() {
+			duration = run.Duration().String()
+		}
+
+		fmt.Printf("%-20s %-10s %-12s %s\n", run.StartedAt.Local().Format("2006-01-02 15:04:05"), run.RunTrigger, duration, jobRunResult(run))
+	}
+
+	return nil
+}
+
+// jobRunResult returns the counts or error of a job run as string.
+func jobRunResult(run entity.JobRun) string {
+	if run.RunError != "" {
+		return "error: " + run.RunError
+	} else if run.Running() {
+		return "running"
+	} else if len(run.RunCounts) == 0 {
+		return "ok"
+	}
+
+	result := "ok"
+
+	for _, name := range run.RunCounts.Names() {
+		result += fmt.Sprintf(", %d %s", run.RunCounts[name], name)
+	}
+
+	return result
+}
@@ -0,0 +1,126 @@
+package commands
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/classify"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/urfave/cli"
+)
+
+// LabelsCommand registers the labels cli command.
+var LabelsCommand = cli.Command{
+	Name:  "labels",
+	Usage: "Shows label rules and applies them to existing labels",
+	Subcommands: []cli.Command{
+		{
+			Name:  "rules",
+			Usage: "Lists the active label rules, custom rules are merged over the built-in defaults",
+			Flags: []cli.Flag{
+				cli.BoolFlag{
+					Name:  "custom, c",
+					Usage: "list custom rules only",
+				},
+			},
+			Action: labelsRulesAction,
+		},
+		{
+			Name:   "apply",
+			Usage:  "Applies the active label rules to existing labels without running TensorFlow again",
+			Action: labelsApplyAction,
+		},
+		{
+			Name:   "rebuild",
+			Usage:  "Recomputes labels from stored image classification results with the active label rules",
+			Action: labelsRebuildAction,
+		},
+	},
+}
+
+// labelsRulesAction lists the active label rules.
+func labelsRulesAction(ctx *cli.Context) error {
+	conf, err := jobsConfig(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	defer conf.Shutdown()
+
+	rules := classify.Rules()
+
+	if ctx.Bool("custom") {
+		rules = classify.CustomRules()
+		fmt.Printf("custom rules in %s\n\n", conf.LabelRulesFile())
+	}
+
+	names := make([]string, 0, len(rules))
+
+	for name := range rules {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	fmt.Printf("%-30s %-20s %-10s %-9s %s\n", "NAME", "LABEL", "THRESHOLD", "PRIORITY", "CATEGORIES")
+
+	for _, name := range names {
+		rule := rules[name]
+		label := rule.Label
+
+		if label == "" {
+			label = "-"
+		}
+
+		fmt.Printf("%-30s %-20s %-10.2f %-9d %s\n", name, label, rule.Threshold, rule.Priority, strings.Join(rule.Categories, ", "))
+	}
+
+	return nil
+}
+
+// labelsApplyAction applies the active label rules to existing labels.
+func labelsApplyAction(ctx *cli.Context) error {
+	conf, err := jobsConfig(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	defer conf.Shutdown()
+
+	result, err := photoprism.ApplyLabelRules()
+
+	if err != nil {
+		return err
+	}
+
+	log.Infof("labels: %d labels match a rule, %d photo labels moved, %d removed, %d synonyms added", result.Labels, result.Moved, result.Removed, result.Synonyms)
+
+	return nil
+}
+
+// labelsRebuildAction recomputes labels from stored image classification results.
+func labelsRebuildAction(ctx *cli.Context) error {
+	start := time.Now()
+
+	conf, err := jobsConfig(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	defer conf.Shutdown()
+
+	count, err := photoprism.RebuildLabels()
+
+	if err != nil {
+		return err
+	}
+
+	log.Infof("labels: rebuilt labels of %d photos in %s", count, time.Since(start))
+
+	return nil
+}
@@ -2,9 +2,12 @@ package commands
 
 import (
 	"context"
+	"fmt"
+	"os"
 	"time"
 
 	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/urfave/cli"
 )
 
@@ -13,6 +16,44 @@ var MigrateCommand = cli.Command{
 	Name:   "migrate",
 	Usage:  "Initializes the index database if needed",
 	Action: migrateAction,
+	Subcommands: []cli.Command{
+		{
+			Name:   "status",
+			Usage:  "Lists versioned migrations and whether they have been applied",
+			Action: migrateStatusAction,
+		},
+		{
+			Name:  "up",
+			Usage: "Applies pending versioned migrations",
+			Flags: []cli.Flag{
+				cli.IntFlag{
+					Name:  "target",
+					Usage: "latest `VERSION` to apply, all if 0",
+				},
+				cli.BoolFlag{
+					Name:  "dry",
+					Usage: "dry run, print SQL statements without executing them",
+				},
+			},
+			Action: migrateUpAction,
+		},
+		{
+			Name:  "down",
+			Usage: "Rolls back the most recently applied versioned migrations",
+			Flags: []cli.Flag{
+				cli.IntFlag{
+					Name:  "steps",
+					Usage: "`NUMBER` of migrations to roll back",
+					Value: 1,
+				},
+				cli.BoolFlag{
+					Name:  "dry",
+					Usage: "dry run, print SQL statements without executing them",
+				},
+			},
+			Action: migrateDownAction,
+		},
+	},
 }
 
 // migrateAction initializes and migrates the database.
@@ -40,3 +81,103 @@ func migrateAction(ctx *cli.Context) error {
 
 	return nil
 }
+
+// migrateConfig connects to the database without applying migrations.
+func migrateConfig(ctx *cli.Context) (*config.Config, error) {
+	conf := config.NewConfig(ctx)
+
+	if err := conf.Init(); err != nil {
+		return conf, err
+	}
+
+	conf.SetDbOptions()
+	entity.SetDbProvider(conf)
+
+	return conf, nil
+}
+
+// migrateStatusAction lists versioned migrations and their status.
+func migrateStatusAction(ctx *cli.Context) error {
+	conf, err := migrateConfig(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	defer conf.Shutdown()
+
+	applied, err := entity.SchemaVersions()
+
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%-8s %-20s %s\n", "VERSION", "APPLIED", "NAME")
+
+	for _, m := range entity.MigrationList {
+		status := "pending"
+
+		if v, ok := applied[m.Version]; ok {
+			status = v.AppliedAt.Format("2006-01-02 15:04:05")
+			delete(applied, m.Version)
+		}
+
+		fmt.Printf("%-8d %-20s %s\n", m.Version, status, m.Name)
+	}
+
+	for _, v := range applied {
+		fmt.Printf("%-8d %-20s %s (unknown)\n", v.Version, v.AppliedAt.Format("2006-01-02 15:04:05"), v.Name)
+	}
+
+	return nil
+}
+
+// migrateUpAction applies pending versioned migrations.
+func migrateUpAction(ctx *cli.Context) error {
+	conf, err := migrateConfig(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	defer conf.Shutdown()
+
+	result, err := entity.MigrationList.MigrateUp(ctx.Int("target"), ctx.Bool("dry"), os.Stdout)
+
+	if err != nil {
+		return err
+	}
+
+	if len(result) == 0 {
+		log.Infof("migrate: database is up to date")
+	} else if ctx.Bool("dry") {
+		log.Infof("migrate: %d migrations pending", len(result))
+	}
+
+	return nil
+}
+
+// migrateDownAction rolls back versioned migrations.
+func migrateDownAction(ctx *cli.Context) error {
+	conf, err := migrateConfig(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	defer conf.Shutdown()
+
+	result, err := entity.MigrationList.MigrateDown(ctx.Int("steps"), ctx.Bool("dry"), os.Stdout)
+
+	if err != nil {
+		return err
+	}
+
+	if len(result) == 0 {
+		log.Infof("migrate: nothing to roll back")
+	} else if ctx.Bool("dry") {
+		log.Infof("migrate: %d migrations would be rolled back", len(result))
+	}
+
+	return nil
+}
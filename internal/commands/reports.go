@@ -0,0 +1,183 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/urfave/cli"
+)
+
+// ReportsCommand registers the reports cli command.
+var ReportsCommand = cli.Command{
+	Name:   "reports",
+	Usage:  "Lists index and import runs and retries files that failed",
+	Action: reportsListAction,
+	Subcommands: []cli.Command{
+		{
+			Name:  "ls",
+			Usage: "Lists index and import runs with their result counts",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "type, t",
+					Usage: "report `TYPE`, index or import",
+				},
+				cli.IntFlag{
+					Name:  "count, n",
+					Usage: "max `NUMBER` of runs",
+					Value: 20,
+				},
+			},
+			Action: reportsListAction,
+		},
+		{
+			Name:      "show",
+			Usage:     "Shows the files that failed with reason and stage",
+			ArgsUsage: "[id]",
+			Action:    reportsShowAction,
+		},
+		{
+			Name:      "retry",
+			Usage:     "Indexes or imports only the files that failed again",
+			ArgsUsage: "[id]",
+			Action:    reportsRetryAction,
+		},
+	},
+}
+
+// reportsListAction lists index and import runs with their result counts.
+func reportsListAction(ctx *cli.Context) error {
+	conf, err := jobsConfig(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	defer conf.Shutdown()
+
+	count := ctx.Int("count")
+
+	if count == 0 {
+		count = 20
+	}
+
+	reports, err := query.IndexReports(ctx.String("type"), count, 0)
+
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%-6s %-7s %-20s %-12s %-7s %s\n", "ID", "TYPE", "STARTED", "DURATION", "FAILED", "RESULT")
+
+	for _, report := range reports {
+		duration := "running"
+
+		if !report.Running() {
+			duration = report.Duration().String()
+		}
+
+		fmt.Printf("%-6d %-7s %-20s %-12s %-7d %s\n", report.ID, report.ReportType, report.StartedAt.Local().Format("2006-01-02 15:04:05"), duration, report.ReportFailed, reportResult(report))
+	}
+
+	return nil
+}
+
+// reportsShowAction shows the files that failed with reason and stage.
+func reportsShowAction(ctx *cli.Context) error {
+	id, err := strconv.ParseUint(ctx.Args().First(), 10, 32)
+
+	if err != nil {
+		return cli.ShowSubcommandHelp(ctx)
+	}
+
+	conf, err := jobsConfig(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	defer conf.Shutdown()
+
+	report := entity.FindIndexReport(uint(id))
+
+	if report == nil {
+		return fmt.Errorf("report %d not found", id)
+	}
+
+	failures, err := query.IndexFailures(report.ID, 0, 0)
+
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s run %d started %s: %s\n\n", report.ReportType, report.ID, report.StartedAt.Local().Format("2006-01-02 15:04:05"), reportResult(*report))
+
+	if len(failures) == 0 {
+		fmt.Println("no files failed")
+		return nil
+	}
+
+	fmt.Printf("%-10s %-11s %-40s %s\n", "ROOT", "STAGE", "FILE", "ERROR")
+
+	for _, failure := range failures {
+		fmt.Printf("%-10s %-11s %-40s %s\n", failure.FileRoot, failure.FileStage, failure.FileName, failure.FileError)
+	}
+
+	return nil
+}
+
+// reportsRetryAction indexes or imports only the files that failed again.
+func reportsRetryAction(ctx *cli.Context) error {
+	id, err := strconv.ParseUint(ctx.Args().First(), 10, 32)
+
+	if err != nil {
+		return cli.ShowSubcommandHelp(ctx)
+	}
+
+	conf, err := jobsConfig(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	defer conf.Shutdown()
+
+	if conf.ReadOnly() {
+		log.Infof("retry: read-only mode enabled")
+	}
+
+	report, err := service.Import().Retry(uint(id))
+
+	if err != nil {
+		return err
+	}
+
+	log.Infof("retry: completed in %s, see report %d (%s)", report.Duration(), report.ID, reportResult(*report))
+
+	return nil
+}
+
+// reportResult returns the result counts of a report as string.
+func reportResult(report entity.IndexReport) string {
+	if report.ReportError != "" {
+		return "error: " + report.ReportError
+	} else if report.Running() {
+		return "running"
+	} else if len(report.ReportCounts) == 0 {
+		return "no files"
+	}
+
+	result := ""
+
+	for i, name := range report.ReportCounts.Names() {
+		if i > 0 {
+			result += ", "
+		}
+
+		result += fmt.Sprintf("%d %s", report.ReportCounts[name], name)
+	}
+
+	return result
+}
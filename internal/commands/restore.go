@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"os"
 	"os/exec"
 	"path/filepath"
 	"regexp"
@@ -149,6 +150,16 @@ func restoreAction(ctx *cli.Context) error {
 				"-f",
 				conf.DatabaseName(),
 			)
+		case config.Postgres:
+			cmd = exec.Command(
+				conf.PsqlBin(),
+				"-h", conf.DatabaseHost(),
+				"-p", conf.DatabasePortString(),
+				"-U", conf.DatabaseUser(),
+				"-q",
+				conf.DatabaseName(),
+			)
+			cmd.Env = append(os.Environ(), "PGPASSWORD="+conf.DatabasePassword())
 		case config.SQLite:
 			log.Infoln("dropping existing tables")
 			tables.Drop()
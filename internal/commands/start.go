@@ -117,6 +117,9 @@ func startAction(ctx *cli.Context) error {
 		log.Infof("%d albums restored", count)
 	}
 
+	// resume indexing files left in the work queues
+	go service.Index().Resume()
+
 	// start share & sync workers
 	workers.Start(conf)
 	auto.Start(conf)
@@ -1,8 +1,12 @@
 package config
 
 import (
+	"crypto/subtle"
 	"regexp"
+	"strings"
+	"time"
 
+	"github.com/photoprism/photoprism/internal/acl"
 	"github.com/photoprism/photoprism/pkg/rnd"
 	"golang.org/x/crypto/bcrypt"
 )
@@ -27,6 +31,48 @@ func (c *Config) CheckPassword(p string) bool {
 	return ap == p
 }
 
+// TwoFactorRoles returns the user roles for which two-factor authentication is required.
+func (c *Config) TwoFactorRoles() (roles []acl.Role) {
+	for _, s := range strings.Split(c.options.TwoFactorRoles, ",") {
+		if s = strings.ToLower(strings.TrimSpace(s)); s != "" {
+			roles = append(roles, acl.Role(s))
+		}
+	}
+
+	return roles
+}
+
+// TwoFactorRequired tests if users with the given role must log in with a second factor.
+func (c *Config) TwoFactorRequired(role acl.Role) bool {
+	for _, r := range c.TwoFactorRoles() {
+		if r == role || r == acl.RoleDefault {
+			return true
+		}
+	}
+
+	return false
+}
+
+// LoginAttempts returns the number of failed login attempts before a temporary lockout, or 0 if disabled.
+func (c *Config) LoginAttempts() int {
+	if c.options.LoginAttempts < 0 {
+		return 0
+	} else if c.options.LoginAttempts == 0 {
+		return 10
+	}
+
+	return c.options.LoginAttempts
+}
+
+// LoginLockout returns the lockout duration after too many failed login attempts.
+func (c *Config) LoginLockout() time.Duration {
+	if c.options.LoginLockout <= 0 || c.options.LoginLockout > 86400 {
+		return 15 * time.Minute
+	}
+
+	return time.Duration(c.options.LoginLockout) * time.Second
+}
+
 // InvalidDownloadToken tests if the token is invalid.
 func (c *Config) InvalidDownloadToken(t string) bool {
 	return c.DownloadToken() != t
@@ -58,3 +104,17 @@ func (c *Config) PreviewToken() string {
 
 	return c.options.PreviewToken
 }
+
+// MetricsToken returns the bearer token for the Prometheus metrics endpoint, which is disabled if empty.
+func (c *Config) MetricsToken() string {
+	return c.options.MetricsToken
+}
+
+// InvalidMetricsToken tests if the metrics token is invalid.
+func (c *Config) InvalidMetricsToken(t string) bool {
+	if c.options.MetricsToken == "" {
+		return true
+	}
+
+	return subtle.ConstantTimeCompare([]byte(c.options.MetricsToken), []byte(t)) != 1
+}
@@ -2,7 +2,9 @@ package config
 
 import (
 	"testing"
+	"time"
 
+	"github.com/photoprism/photoprism/internal/acl"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -53,3 +55,58 @@ func TestConfig_InvalidPreviewToken(t *testing.T) {
 
 	assert.True(t, c.InvalidPreviewToken("xxx"))
 }
+
+func TestConfig_InvalidMetricsToken(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, "", c.MetricsToken())
+	assert.True(t, c.InvalidMetricsToken(""))
+
+	c.options.MetricsToken = "secret"
+	assert.Equal(t, "secret", c.MetricsToken())
+	assert.False(t, c.InvalidMetricsToken("secret"))
+	assert.True(t, c.InvalidMetricsToken("xxx"))
+	assert.True(t, c.InvalidMetricsToken(""))
+
+	c.options.MetricsToken = ""
+}
+
+func TestConfig_TwoFactorRequired(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	c.options.TwoFactorRoles = ""
+	assert.Empty(t, c.TwoFactorRoles())
+	assert.False(t, c.TwoFactorRequired(acl.RoleAdmin))
+
+	c.options.TwoFactorRoles = " Admin, family"
+	assert.Equal(t, []acl.Role{acl.RoleAdmin, acl.RoleFamily}, c.TwoFactorRoles())
+	assert.True(t, c.TwoFactorRequired(acl.RoleAdmin))
+	assert.True(t, c.TwoFactorRequired(acl.RoleFamily))
+	assert.False(t, c.TwoFactorRequired(acl.RoleGuest))
+
+	c.options.TwoFactorRoles = "*"
+	assert.True(t, c.TwoFactorRequired(acl.RoleGuest))
+}
+
+func TestConfig_LoginAttempts(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	c.options.LoginAttempts = 0
+	assert.Equal(t, 10, c.LoginAttempts())
+
+	c.options.LoginAttempts = 5
+	assert.Equal(t, 5, c.LoginAttempts())
+
+	c.options.LoginAttempts = -1
+	assert.Equal(t, 0, c.LoginAttempts())
+}
+
+func TestConfig_LoginLockout(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	c.options.LoginLockout = 0
+	assert.Equal(t, 15*time.Minute, c.LoginLockout())
+
+	c.options.LoginLockout = 60
+	assert.Equal(t, time.Minute, c.LoginLockout())
+}
@@ -43,6 +43,8 @@ type ClientConfig struct {
 	Thumbs          []Thumb             `json:"thumbs"`
 	Status          string              `json:"status"`
 	MapKey          string              `json:"mapKey"`
+	MapTiles        string              `json:"mapTiles"`
+	Libraries       Libraries           `json:"libraries,omitempty"`
 	DownloadToken   string              `json:"downloadToken"`
 	PreviewToken    string              `json:"previewToken"`
 	JSHash          string              `json:"jsHash"`
@@ -257,6 +259,7 @@ func (c *Config) GuestConfig() ClientConfig {
 		Thumbs:          Thumbs,
 		Status:          c.Hub().Status,
 		MapKey:          c.Hub().MapKey(),
+		MapTiles:        c.MapTilesUri(),
 		DownloadToken:   c.DownloadToken(),
 		PreviewToken:    c.PreviewToken(),
 		JSHash:          fs.Checksum(c.BuildPath() + "/share.js"),
@@ -312,6 +315,8 @@ func (c *Config) UserConfig() ClientConfig {
 		Thumbs:          Thumbs,
 		Status:          c.Hub().Status,
 		MapKey:          c.Hub().MapKey(),
+		MapTiles:        c.MapTilesUri(),
+		Libraries:       c.Libraries(),
 		DownloadToken:   c.DownloadToken(),
 		PreviewToken:    c.PreviewToken(),
 		JSHash:          fs.Checksum(c.BuildPath() + "/app.js"),
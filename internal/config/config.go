@@ -23,9 +23,12 @@ import (
 	_ "github.com/jinzhu/gorm/dialects/sqlite"
 	"github.com/klauspost/cpuid/v2"
 	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/face"
 	"github.com/photoprism/photoprism/internal/hub"
 	"github.com/photoprism/photoprism/internal/hub/places"
+	"github.com/photoprism/photoprism/internal/maps/tiles"
 	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/query"
 	"github.com/photoprism/photoprism/internal/thumb"
 	"github.com/photoprism/photoprism/pkg/rnd"
 	"github.com/sirupsen/logrus"
@@ -37,16 +40,21 @@ var once sync.Once
 
 const ApiUri = "/api/v1"
 const StaticUri = "/static"
+const MapTilesUri = "/maps/tiles"
 
 // Config holds database, cache and all parameters of photoprism
 type Config struct {
-	once     sync.Once
-	db       *gorm.DB
-	options  *Options
-	settings *Settings
-	hub      *hub.Config
-	token    string
-	serial   string
+	once         sync.Once
+	db           *gorm.DB
+	options      *Options
+	settings     *Settings
+	hub          *hub.Config
+	libraries    Libraries
+	jobs         JobSchedules
+	token        string
+	serial       string
+	mapTiles     tiles.Source
+	mapTilesOnce sync.Once
 }
 
 func init() {
@@ -114,8 +122,12 @@ func (c *Config) Propagate() {
 	thumb.SizeUncached = c.ThumbSizeUncached()
 	thumb.Filter = c.ThumbFilter()
 	thumb.JpegQuality = c.JpegQuality()
+	face.MinSize = c.FaceSize()
+	face.ScoreThreshold = float64(c.FaceScore())
+	face.MaxFaces = c.FaceMax()
 	places.UserAgent = c.UserAgent()
 	entity.GeoApi = c.GeoApi()
+	query.DetectObjects = c.DetectObjects()
 
 	c.Settings().Propagate()
 	c.Hub().Propagate()
@@ -144,6 +156,8 @@ func (c *Config) Init() error {
 
 	c.initSettings()
 	c.initHub()
+	c.initLibraries()
+	c.initLabelRules()
 
 	c.Propagate()
 
@@ -343,6 +357,11 @@ func (c *Config) DetectNSFW() bool {
 	return c.options.DetectNSFW
 }
 
+// DetectObjects tests if objects should be detected and added as label markers.
+func (c *Config) DetectObjects() bool {
+	return c.options.DetectObjects && !c.DisableTensorFlow()
+}
+
 // UploadNSFW tests if NSFW photos can be uploaded.
 func (c *Config) UploadNSFW() bool {
 	return c.options.UploadNSFW
@@ -378,6 +397,12 @@ func (c *Config) Shutdown() {
 	} else {
 		log.Info("closed database connection")
 	}
+
+	if c.mapTiles != nil {
+		if err := c.mapTiles.Close(); err != nil {
+			log.Errorf("maps: %s", err)
+		}
+	}
 }
 
 // Workers returns the number of workers e.g. for indexing files.
@@ -410,6 +435,29 @@ func (c *Config) Workers() int {
 	return 1
 }
 
+// ThumbWorkers returns the number of background thumbnail workers, or 0 if thumbnails are created while indexing.
+func (c *Config) ThumbWorkers() int {
+	if c.options.ThumbWorkers <= 0 {
+		return 0
+	} else if c.options.ThumbWorkers > runtime.NumCPU() {
+		return runtime.NumCPU()
+	}
+
+	return c.options.ThumbWorkers
+}
+
+// ClassifyWorkers returns the number of background image classification and face detection workers,
+// or 0 if images are classified while indexing.
+func (c *Config) ClassifyWorkers() int {
+	if c.options.ClassifyWorkers <= 0 || c.DisableTensorFlow() {
+		return 0
+	} else if c.options.ClassifyWorkers > runtime.NumCPU() {
+		return runtime.NumCPU()
+	}
+
+	return c.options.ClassifyWorkers
+}
+
 // WakeupInterval returns the background worker wakeup interval duration.
 func (c *Config) WakeupInterval() time.Duration {
 	if c.options.WakeupInterval <= 0 || c.options.WakeupInterval > 86400 {
@@ -441,6 +489,17 @@ func (c *Config) AutoImport() time.Duration {
 	return time.Duration(c.options.AutoImport) * time.Second
 }
 
+// WatchDelay returns the safety delay after which file system changes are indexed, or zero if the watcher is disabled.
+func (c *Config) WatchDelay() time.Duration {
+	if c.options.WatchDelay <= 0 {
+		return time.Duration(0)
+	} else if c.options.WatchDelay > 3600 {
+		return time.Hour
+	}
+
+	return time.Duration(c.options.WatchDelay) * time.Second
+}
+
 // GeoApi returns the preferred geo coding api (none or places).
 func (c *Config) GeoApi() string {
 	if c.options.DisablePlaces {
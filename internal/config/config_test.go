@@ -2,6 +2,7 @@ package config
 
 import (
 	"os"
+	"runtime"
 	"strings"
 	"testing"
 	"time"
@@ -181,6 +182,18 @@ func TestConfig_DetectNSFW(t *testing.T) {
 	assert.Equal(t, true, result)
 }
 
+func TestConfig_DetectObjects(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.False(t, c.DetectObjects())
+
+	c.options.DetectObjects = true
+	assert.True(t, c.DetectObjects())
+
+	c.options.DisableTensorFlow = true
+	assert.False(t, c.DetectObjects())
+}
+
 func TestConfig_AdminPassword(t *testing.T) {
 	c := NewConfig(CliTestContext())
 
@@ -194,6 +207,12 @@ func TestConfig_NSFWModelPath(t *testing.T) {
 	assert.Contains(t, c.NSFWModelPath(), "/assets/nsfw")
 }
 
+func TestConfig_DetectModelPath(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Contains(t, c.DetectModelPath(), "/assets/ssd")
+}
+
 func TestConfig_FaceNetModelPath(t *testing.T) {
 	c := NewConfig(CliTestContext())
 
@@ -267,6 +286,33 @@ func TestConfig_Workers(t *testing.T) {
 	assert.GreaterOrEqual(t, c.Workers(), 1)
 }
 
+func TestConfig_ThumbWorkers(t *testing.T) {
+	c := NewConfig(CliTestContext())
+	assert.Equal(t, 0, c.ThumbWorkers())
+
+	c.options.ThumbWorkers = 1
+	assert.Equal(t, 1, c.ThumbWorkers())
+
+	c.options.ThumbWorkers = 100000
+	assert.Equal(t, runtime.NumCPU(), c.ThumbWorkers())
+
+	c.options.ThumbWorkers = 0
+}
+
+func TestConfig_ClassifyWorkers(t *testing.T) {
+	c := NewConfig(CliTestContext())
+	assert.Equal(t, 0, c.ClassifyWorkers())
+
+	c.options.ClassifyWorkers = 1
+	assert.Equal(t, 1, c.ClassifyWorkers())
+
+	c.options.DisableTensorFlow = true
+	assert.Equal(t, 0, c.ClassifyWorkers())
+
+	c.options.DisableTensorFlow = false
+	c.options.ClassifyWorkers = 0
+}
+
 func TestConfig_WakeupInterval(t *testing.T) {
 	c := NewConfig(CliTestContext())
 	assert.Equal(t, time.Duration(900000000000), c.WakeupInterval())
@@ -277,6 +323,17 @@ func TestConfig_AutoIndex(t *testing.T) {
 	assert.Equal(t, time.Duration(0), c.AutoIndex())
 }
 
+func TestConfig_WatchDelay(t *testing.T) {
+	c := NewConfig(CliTestContext())
+	assert.Equal(t, time.Duration(0), c.WatchDelay())
+
+	c.options.WatchDelay = 10
+	assert.Equal(t, 10*time.Second, c.WatchDelay())
+
+	c.options.WatchDelay = 100000
+	assert.Equal(t, time.Hour, c.WatchDelay())
+}
+
 func TestConfig_AutoImport(t *testing.T) {
 	c := NewConfig(CliTestContext())
 	assert.Equal(t, 2*time.Hour, c.AutoImport())
@@ -29,6 +29,8 @@ func (c *Config) DatabaseDriver() string {
 	switch strings.ToLower(c.options.DatabaseDriver) {
 	case MySQL, MariaDB:
 		c.options.DatabaseDriver = MySQL
+	case Postgres, "postgresql", "pgsql", "pg":
+		c.options.DatabaseDriver = Postgres
 	case SQLite, "sqlite", "sqllite", "test", "file", "":
 		c.options.DatabaseDriver = SQLite
 	case "tidb":
@@ -121,7 +123,11 @@ func (c *Config) DatabaseHost() string {
 
 // DatabasePort the database server port.
 func (c *Config) DatabasePort() int {
-	const defaultPort = 3306
+	defaultPort := 3306
+
+	if c.DatabaseDriver() == Postgres {
+		defaultPort = 5432
+	}
 
 	if s := strings.Split(c.DatabaseServer(), ":"); len(s) != 2 {
 		return defaultPort
@@ -226,7 +232,7 @@ func (c *Config) SetDbOptions() {
 	case MySQL, MariaDB:
 		c.Db().Set("gorm:table_options", "ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci")
 	case Postgres:
-		// Ignore for now.
+		// Not required as unicode is default.
 	case SQLite:
 		// Not required as unicode is default.
 	}
@@ -270,10 +276,14 @@ func (c *Config) connectDb() error {
 		return errors.New("config: database DSN not specified")
 	}
 
-	db, err := gorm.Open(dbDriver, dbDsn)
+	if dbDriver == Postgres {
+		log.Warnf("config: postgres support is experimental and not tested yet, use mysql or sqlite for your library")
+	}
+
+	db, err := entity.OpenDb(dbDriver, dbDsn)
 	if err != nil || db == nil {
 		for i := 1; i <= 12; i++ {
-			db, err = gorm.Open(dbDriver, dbDsn)
+			db, err = entity.OpenDb(dbDriver, dbDsn)
 
 			if db != nil && err == nil {
 				break
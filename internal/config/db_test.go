@@ -11,6 +11,11 @@ func TestConfig_DatabaseDriver(t *testing.T) {
 
 	driver := c.DatabaseDriver()
 	assert.Equal(t, SQLite, driver)
+
+	c.options.DatabaseDriver = "PostgreSQL"
+	assert.Equal(t, Postgres, c.DatabaseDriver())
+	c.options.DatabaseDriver = "pgsql"
+	assert.Equal(t, Postgres, c.DatabaseDriver())
 }
 
 func TestConfig_ParseDatabaseDsn(t *testing.T) {
@@ -43,6 +48,9 @@ func TestConfig_DatabasePort(t *testing.T) {
 	c := NewConfig(CliTestContext())
 
 	assert.Equal(t, 3306, c.DatabasePort())
+
+	c.options.DatabaseDriver = Postgres
+	assert.Equal(t, 5432, c.DatabasePort())
 }
 
 func TestConfig_DatabasePortString(t *testing.T) {
@@ -80,7 +88,7 @@ func TestConfig_DatabaseDsn(t *testing.T) {
 	c.options.DatabaseDriver = "tidb"
 	assert.Equal(t, "/go/src/github.com/photoprism/photoprism/storage/testdata/index.db", c.DatabaseDsn())
 	c.options.DatabaseDriver = "Postgres"
-	assert.Equal(t, "/go/src/github.com/photoprism/photoprism/storage/testdata/index.db", c.DatabaseDsn())
+	assert.Equal(t, "user=photoprism password= dbname=photoprism host=localhost port=5432 sslmode=disable TimeZone=UTC", c.DatabaseDsn())
 	c.options.DatabaseDriver = "SQLite"
 	assert.Equal(t, "/go/src/github.com/photoprism/photoprism/storage/testdata/index.db", c.DatabaseDsn())
 	c.options.DatabaseDriver = ""
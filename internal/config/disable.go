@@ -62,6 +62,11 @@ func (c *Config) DisableHeifConvert() bool {
 	return c.options.DisableHeifConvert || c.HeifConvertBin() == ""
 }
 
+// DisableTesseract tests if Tesseract is disabled for text recognition.
+func (c *Config) DisableTesseract() bool {
+	return c.options.DisableTesseract || c.TesseractBin() == ""
+}
+
 // DisableFFmpeg tests if FFmpeg is disabled for video transcoding.
 func (c *Config) DisableFFmpeg() bool {
 	return c.options.DisableFFmpeg || c.FFmpegBin() == ""
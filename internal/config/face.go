@@ -0,0 +1,30 @@
+package config
+
+// FaceSize returns the min face size in pixels, relative to the thumbnail used for face detection.
+func (c *Config) FaceSize() int {
+	if c.options.FaceSize < 20 {
+		return 20
+	} else if c.options.FaceSize > 10000 {
+		return 10000
+	}
+
+	return c.options.FaceSize
+}
+
+// FaceScore returns the min face detection score.
+func (c *Config) FaceScore() int {
+	if c.options.FaceScore < 1 || c.options.FaceScore > 1000 {
+		return 9
+	}
+
+	return c.options.FaceScore
+}
+
+// FaceMax returns the max number of faces detected per image, 0 for no limit.
+func (c *Config) FaceMax() int {
+	if c.options.FaceMax < 0 || c.options.FaceMax > 1000 {
+		return 20
+	}
+
+	return c.options.FaceMax
+}
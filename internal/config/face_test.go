@@ -0,0 +1,41 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_FaceSize(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, 20, c.FaceSize())
+	c.options.FaceSize = 30
+	assert.Equal(t, 30, c.FaceSize())
+	c.options.FaceSize = 1
+	assert.Equal(t, 20, c.FaceSize())
+	c.options.FaceSize = 20000
+	assert.Equal(t, 10000, c.FaceSize())
+}
+
+func TestConfig_FaceScore(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, 9, c.FaceScore())
+	c.options.FaceScore = 20
+	assert.Equal(t, 20, c.FaceScore())
+	c.options.FaceScore = -1
+	assert.Equal(t, 9, c.FaceScore())
+}
+
+func TestConfig_FaceMax(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, 0, c.FaceMax())
+	c.options.FaceMax = 5
+	assert.Equal(t, 5, c.FaceMax())
+	c.options.FaceMax = 0
+	assert.Equal(t, 0, c.FaceMax())
+	c.options.FaceMax = -1
+	assert.Equal(t, 20, c.FaceMax())
+}
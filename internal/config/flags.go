@@ -49,6 +49,23 @@ var GlobalFlags = []cli.Flag{
 		Usage:  "initial admin `PASSWORD`, min 4 characters",
 		EnvVar: "PHOTOPRISM_ADMIN_PASSWORD",
 	},
+	cli.StringFlag{
+		Name:   "two-factor-roles",
+		Usage:  "require two-factor authentication for users with these `ROLES`, separated by commas (e.g. admin,family)",
+		EnvVar: "PHOTOPRISM_TWO_FACTOR_ROLES",
+	},
+	cli.IntFlag{
+		Name:   "login-attempts",
+		Value:  10,
+		Usage:  "failed login `ATTEMPTS` per user name or client IP before a temporary lockout, -1 to disable",
+		EnvVar: "PHOTOPRISM_LOGIN_ATTEMPTS",
+	},
+	cli.IntFlag{
+		Name:   "login-lockout",
+		Value:  900,
+		Usage:  "login lockout duration in `SECONDS` after too many failed attempts",
+		EnvVar: "PHOTOPRISM_LOGIN_LOCKOUT",
+	},
 	cli.StringFlag{
 		Name:   "config-file, c",
 		Usage:  "load initial config options from `FILENAME`",
@@ -111,6 +128,16 @@ var GlobalFlags = []cli.Flag{
 		EnvVar: "PHOTOPRISM_WORKERS",
 		Value:  cpuid.CPU.PhysicalCores / 2,
 	},
+	cli.IntFlag{
+		Name:   "thumb-workers",
+		Usage:  "`MAX` number of background thumbnail workers, 0 to create thumbnails while indexing",
+		EnvVar: "PHOTOPRISM_THUMB_WORKERS",
+	},
+	cli.IntFlag{
+		Name:   "classify-workers",
+		Usage:  "`MAX` number of background image classification and face detection workers, 0 to classify while indexing",
+		EnvVar: "PHOTOPRISM_CLASSIFY_WORKERS",
+	},
 	cli.IntFlag{
 		Name:   "wakeup-interval",
 		Usage:  "background worker wakeup interval in `SECONDS`",
@@ -126,6 +153,11 @@ var GlobalFlags = []cli.Flag{
 		Usage:  "auto importing safety delay in `SECONDS` (WebDAV)",
 		EnvVar: "PHOTOPRISM_AUTO_IMPORT",
 	},
+	cli.IntFlag{
+		Name:   "watch-delay",
+		Usage:  "watch originals and import folders, indexing changes after a safety delay in `SECONDS` (0 to disable)",
+		EnvVar: "PHOTOPRISM_WATCH_DELAY",
+	},
 	cli.BoolFlag{
 		Name:   "disable-backups",
 		Usage:  "don't backup photo and album metadata to YAML files",
@@ -146,6 +178,11 @@ var GlobalFlags = []cli.Flag{
 		Usage:  "disables reverse geocoding and maps",
 		EnvVar: "PHOTOPRISM_DISABLE_PLACES",
 	},
+	cli.StringFlag{
+		Name:   "map-tiles",
+		Usage:  "local MBTiles or PMTiles `FILE` for serving map tiles without external tile servers",
+		EnvVar: "PHOTOPRISM_MAP_TILES",
+	},
 	cli.BoolFlag{
 		Name:   "disable-exiftool",
 		Usage:  "don't use ExifTool to extract metadata from image and video files",
@@ -181,11 +218,44 @@ var GlobalFlags = []cli.Flag{
 		Usage:  "don't transcode videos with FFmpeg",
 		EnvVar: "PHOTOPRISM_DISABLE_FFMPEG",
 	},
+	cli.BoolFlag{
+		Name:   "disable-tesseract",
+		Usage:  "don't recognize text in document scans with Tesseract",
+		EnvVar: "PHOTOPRISM_DISABLE_TESSERACT",
+	},
 	cli.BoolFlag{
 		Name:   "detect-nsfw",
 		Usage:  "flag photos as private that may be offensive (requires TensorFlow)",
 		EnvVar: "PHOTOPRISM_DETECT_NSFW",
 	},
+	cli.BoolFlag{
+		Name:   "detect-objects",
+		Usage:  "add label markers for objects like people, animals and vehicles (requires TensorFlow)",
+		EnvVar: "PHOTOPRISM_DETECT_OBJECTS",
+	},
+	cli.BoolFlag{
+		Name:   "ocr-all",
+		Usage:  "recognize text in all photos, not only document scans (requires Tesseract)",
+		EnvVar: "PHOTOPRISM_OCR_ALL",
+	},
+	cli.IntFlag{
+		Name:   "face-size",
+		Value:  20,
+		Usage:  "min face size in `PIXELS` on the fit_720 or fit_1280 thumbnail used for detection, smaller faces are ignored",
+		EnvVar: "PHOTOPRISM_FACE_SIZE",
+	},
+	cli.IntFlag{
+		Name:   "face-score",
+		Value:  9,
+		Usage:  "min face detection `SCORE`, higher values reduce false positives",
+		EnvVar: "PHOTOPRISM_FACE_SCORE",
+	},
+	cli.IntFlag{
+		Name:   "face-max",
+		Value:  20,
+		Usage:  "`MAX` number of faces detected per image, 0 for no limit",
+		EnvVar: "PHOTOPRISM_FACE_MAX",
+	},
 	cli.BoolFlag{
 		Name:   "upload-nsfw",
 		Usage:  "allow uploads that may be offensive",
@@ -269,7 +339,7 @@ var GlobalFlags = []cli.Flag{
 	},
 	cli.StringFlag{
 		Name:   "database-driver",
-		Usage:  "database driver `NAME` (sqlite or mysql)",
+		Usage:  "database driver `NAME` (sqlite or mysql, postgres is experimental)",
 		Value:  "sqlite",
 		EnvVar: "PHOTOPRISM_DATABASE_DRIVER",
 	},
@@ -369,6 +439,18 @@ var GlobalFlags = []cli.Flag{
 		Value:  "exiftool",
 		EnvVar: "PHOTOPRISM_EXIFTOOL_BIN",
 	},
+	cli.StringFlag{
+		Name:   "tesseract-bin",
+		Usage:  "Tesseract `COMMAND` for text recognition",
+		Value:  "tesseract",
+		EnvVar: "PHOTOPRISM_TESSERACT_BIN",
+	},
+	cli.StringFlag{
+		Name:   "tesseract-lang",
+		Usage:  "Tesseract `LANGUAGES` for text recognition, e.g. eng+deu",
+		Value:  "eng",
+		EnvVar: "PHOTOPRISM_TESSERACT_LANG",
+	},
 	cli.StringFlag{
 		Name:   "download-token",
 		Usage:  "optional static `SECRET` url token for file downloads",
@@ -379,6 +461,11 @@ var GlobalFlags = []cli.Flag{
 		Usage:  "optional static `SECRET` url token for preview images and video streaming",
 		EnvVar: "PHOTOPRISM_PREVIEW_TOKEN",
 	},
+	cli.StringFlag{
+		Name:   "metrics-token",
+		Usage:  "static `SECRET` bearer token for the Prometheus metrics endpoint, which is disabled if empty",
+		EnvVar: "PHOTOPRISM_METRICS_TOKEN",
+	},
 	cli.StringFlag{
 		Name:   "thumb-filter, f",
 		Usage:  "downscaling filter `NAME` (best to worst: blackman, lanczos, cubic, linear)",
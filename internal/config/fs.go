@@ -268,6 +268,11 @@ func (c *Config) CachePath() string {
 	return fs.Abs(c.options.CachePath)
 }
 
+// RemotePath returns the path for temporary copies of remote library files while they are indexed.
+func (c *Config) RemotePath() string {
+	return filepath.Join(c.CachePath(), "remote")
+}
+
 // StoragePath returns the path for generated files like cache and index.
 func (c *Config) StoragePath() string {
 	if c.options.StoragePath == "" {
@@ -354,6 +359,16 @@ func (c *Config) MysqldumpBin() string {
 	return findExecutable("", "mysqldump")
 }
 
+// PsqlBin returns the psql executable file name.
+func (c *Config) PsqlBin() string {
+	return findExecutable("", "psql")
+}
+
+// PgDumpBin returns the pg_dump executable file name.
+func (c *Config) PgDumpBin() string {
+	return findExecutable("", "pg_dump")
+}
+
 // SqliteBin returns the sqlite executable file name.
 func (c *Config) SqliteBin() string {
 	return findExecutable("", "sqlite3")
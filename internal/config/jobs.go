@@ -0,0 +1,60 @@
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/txt"
+	"gopkg.in/yaml.v2"
+)
+
+// JobSchedules maps background job names to cron expressions, an empty expression disables the job.
+type JobSchedules map[string]string
+
+// LoadJobSchedules reads job schedules from a YAML file.
+func LoadJobSchedules(fileName string) (result JobSchedules, err error) {
+	if !fs.FileExists(fileName) {
+		return result, fmt.Errorf("jobs file not found: %s", txt.Quote(fileName))
+	}
+
+	yamlConfig, err := ioutil.ReadFile(fileName)
+
+	if err != nil {
+		return result, err
+	}
+
+	if err := yaml.Unmarshal(yamlConfig, &result); err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
+
+// JobsFile returns the file name for custom background job schedules.
+func (c *Config) JobsFile() string {
+	return filepath.Join(c.ConfigPath(), "jobs.yml")
+}
+
+// JobSchedules returns custom background job schedules, if any.
+func (c *Config) JobSchedules() JobSchedules {
+	if c.jobs != nil {
+		return c.jobs
+	}
+
+	c.jobs = JobSchedules{}
+	fileName := c.JobsFile()
+
+	if !fs.FileExists(fileName) {
+		return c.jobs
+	}
+
+	if jobs, err := LoadJobSchedules(fileName); err != nil {
+		log.Errorf("config: %s in %s", err, txt.Quote(filepath.Base(fileName)))
+	} else if jobs != nil {
+		c.jobs = jobs
+	}
+
+	return c.jobs
+}
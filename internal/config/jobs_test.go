@@ -0,0 +1,38 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadJobSchedules(t *testing.T) {
+	t.Run("existing filename", func(t *testing.T) {
+		jobs, err := LoadJobSchedules("testdata/jobs.yml")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, jobs, 3)
+		assert.Equal(t, "0 3 * * *", jobs["index"])
+		assert.Equal(t, "@daily", jobs["moments"])
+
+		schedule, ok := jobs["sync"]
+		assert.True(t, ok)
+		assert.Equal(t, "", schedule)
+	})
+	t.Run("not existing filename", func(t *testing.T) {
+		jobs, err := LoadJobSchedules("testdata/jobs_123.yml")
+
+		assert.Error(t, err)
+		assert.Empty(t, jobs)
+	})
+}
+
+func TestConfig_JobsFile(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Contains(t, c.JobsFile(), "/jobs.yml")
+	assert.NotNil(t, c.JobSchedules())
+}
@@ -0,0 +1,31 @@
+package config
+
+import (
+	"path/filepath"
+
+	"github.com/photoprism/photoprism/internal/classify"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// LabelRulesFile returns the file name for custom label rules, which are merged with the built-in defaults.
+func (c *Config) LabelRulesFile() string {
+	return filepath.Join(c.ConfigPath(), "labels.yml")
+}
+
+// initLabelRules merges custom label rules with the built-in defaults.
+func (c *Config) initLabelRules() {
+	fileName := c.LabelRulesFile()
+
+	if !fs.FileExists(fileName) {
+		return
+	}
+
+	if custom, err := classify.LoadRules(fileName); err != nil {
+		log.Errorf("config: %s in %s", err, txt.Quote(filepath.Base(fileName)))
+	} else if err := classify.SetCustomRules(custom); err != nil {
+		log.Errorf("config: %s in %s", err, txt.Quote(filepath.Base(fileName)))
+	} else {
+		log.Infof("config: loaded %d custom label rules", len(custom))
+	}
+}
@@ -0,0 +1,16 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_LabelRulesFile(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Contains(t, c.LabelRulesFile(), "/labels.yml")
+
+	// Missing files are ignored.
+	c.initLabelRules()
+}
@@ -0,0 +1,170 @@
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/txt"
+	"gopkg.in/yaml.v2"
+)
+
+// LibraryNameRegexp matches valid library names, which are also used as file root in the index.
+var LibraryNameRegexp = regexp.MustCompile("^[a-z0-9][a-z0-9_-]{0,15}$")
+
+// Library represents an additional originals root with its own path, ignore rules, and read-only flag.
+type Library struct {
+	Name     string   `yaml:"Name" json:"name"`
+	Path     string   `yaml:"Path" json:"-"`
+	Ignore   []string `yaml:"Ignore,omitempty" json:"-"`
+	ReadOnly bool     `yaml:"ReadOnly,omitempty" json:"readonly"`
+}
+
+// Libraries represents a list of additional originals roots.
+type Libraries []Library
+
+// Names returns the library names.
+func (l Libraries) Names() []string {
+	result := make([]string, len(l))
+
+	for i, lib := range l {
+		result[i] = lib.Name
+	}
+
+	return result
+}
+
+// ValidLibraryName checks if the name can be used as library name.
+func ValidLibraryName(name string) bool {
+	switch name {
+	case entity.RootOriginals, entity.RootExamples, entity.RootSidecar, entity.RootImport, "originals", "remote":
+		return false
+	}
+
+	return LibraryNameRegexp.MatchString(name)
+}
+
+// pathsOverlap checks if one of the paths contains the other.
+func pathsOverlap(a, b string) bool {
+	a = filepath.Clean(a)
+	b = filepath.Clean(b)
+
+	return a == b || strings.HasPrefix(a, b+string(filepath.Separator)) || strings.HasPrefix(b, a+string(filepath.Separator))
+}
+
+// LoadLibraries reads library definitions from a YAML file.
+func LoadLibraries(fileName string) (result Libraries, err error) {
+	if !fs.FileExists(fileName) {
+		return result, fmt.Errorf("libraries file not found: %s", txt.Quote(fileName))
+	}
+
+	yamlConfig, err := ioutil.ReadFile(fileName)
+
+	if err != nil {
+		return result, err
+	}
+
+	if err := yaml.Unmarshal(yamlConfig, &result); err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
+
+// LibrariesFile returns the file name for additional originals roots.
+func (c *Config) LibrariesFile() string {
+	return filepath.Join(c.ConfigPath(), "libraries.yml")
+}
+
+// initLibraries initializes additional originals roots from a config file.
+func (c *Config) initLibraries() {
+	c.libraries = Libraries{}
+	fileName := c.LibrariesFile()
+
+	if !fs.FileExists(fileName) {
+		return
+	}
+
+	libraries, err := LoadLibraries(fileName)
+
+	if err != nil {
+		log.Errorf("config: %s in %s", err, txt.Quote(filepath.Base(fileName)))
+		return
+	}
+
+	originalsPath := c.OriginalsPath()
+	found := make(map[string]bool)
+
+	for _, lib := range libraries {
+		lib.Name = strings.TrimSpace(lib.Name)
+
+		if !ValidLibraryName(lib.Name) {
+			log.Errorf("config: invalid library name %s", txt.Quote(lib.Name))
+			continue
+		} else if found[lib.Name] {
+			log.Errorf("config: library %s already exists", txt.Quote(lib.Name))
+			continue
+		} else if lib.Path == "" {
+			log.Errorf("config: library %s has no path", txt.Quote(lib.Name))
+			continue
+		}
+
+		lib.Path = fs.Abs(lib.Path)
+
+		// Files must not be indexed twice.
+		if pathsOverlap(lib.Path, originalsPath) {
+			log.Errorf("config: library %s must not overlap with originals", txt.Quote(lib.Name))
+			continue
+		}
+
+		if !fs.PathExists(lib.Path) {
+			log.Warnf("config: library path %s not found", txt.Quote(lib.Path))
+		}
+
+		found[lib.Name] = true
+		c.libraries = append(c.libraries, lib)
+	}
+
+	log.Debugf("config: %d libraries loaded from %s", len(c.libraries), fileName)
+}
+
+// Libraries returns additional originals roots, if any.
+func (c *Config) Libraries() Libraries {
+	if c.libraries == nil {
+		c.initLibraries()
+	}
+
+	return c.libraries
+}
+
+// Library returns the library with the given name.
+func (c *Config) Library(name string) (lib Library, ok bool) {
+	if name == "" || name == entity.RootOriginals {
+		return lib, false
+	}
+
+	for _, lib = range c.Libraries() {
+		if lib.Name == name {
+			return lib, true
+		}
+	}
+
+	return Library{}, false
+}
+
+// RootReadOnly checks if files in the given root must not be modified.
+func (c *Config) RootReadOnly(root string) bool {
+	if c.ReadOnly() {
+		return true
+	}
+
+	if lib, ok := c.Library(root); ok {
+		return lib.ReadOnly
+	}
+
+	return false
+}
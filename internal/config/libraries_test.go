@@ -0,0 +1,67 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadLibraries(t *testing.T) {
+	t.Run("existing filename", func(t *testing.T) {
+		libs, err := LoadLibraries("testdata/libraries.yml")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, libs, 2)
+		assert.Equal(t, []string{"family", "scans"}, libs.Names())
+		assert.Equal(t, "/photos/family", libs[0].Path)
+		assert.Equal(t, []string{"*.tmp", "private"}, libs[0].Ignore)
+		assert.False(t, libs[0].ReadOnly)
+		assert.True(t, libs[1].ReadOnly)
+	})
+	t.Run("not existing filename", func(t *testing.T) {
+		libs, err := LoadLibraries("testdata/libraries_123.yml")
+
+		assert.Error(t, err)
+		assert.Empty(t, libs)
+	})
+}
+
+func TestValidLibraryName(t *testing.T) {
+	assert.True(t, ValidLibraryName("family"))
+	assert.True(t, ValidLibraryName("work-2021"))
+	assert.False(t, ValidLibraryName(""))
+	assert.False(t, ValidLibraryName("/"))
+	assert.False(t, ValidLibraryName("sidecar"))
+	assert.False(t, ValidLibraryName("originals"))
+	assert.False(t, ValidLibraryName("Family"))
+	assert.False(t, ValidLibraryName("remote:1"))
+	assert.False(t, ValidLibraryName("a-very-long-library-name"))
+}
+
+func TestPathsOverlap(t *testing.T) {
+	assert.True(t, pathsOverlap("/photos", "/photos/"))
+	assert.True(t, pathsOverlap("/photos", "/photos/family"))
+	assert.True(t, pathsOverlap("/photos/family", "/photos"))
+	assert.False(t, pathsOverlap("/photos", "/photos-family"))
+}
+
+func TestConfig_Library(t *testing.T) {
+	c := NewConfig(CliTestContext())
+	c.libraries = Libraries{{Name: "scans", Path: "/photos/scans", ReadOnly: true}}
+
+	lib, ok := c.Library("scans")
+	assert.True(t, ok)
+	assert.Equal(t, "/photos/scans", lib.Path)
+
+	_, ok = c.Library("family")
+	assert.False(t, ok)
+
+	_, ok = c.Library("/")
+	assert.False(t, ok)
+
+	assert.True(t, c.RootReadOnly("scans"))
+	assert.False(t, c.RootReadOnly("/"))
+}
@@ -0,0 +1,49 @@
+package config
+
+import (
+	"github.com/photoprism/photoprism/internal/maps/tiles"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// MapTiles returns the local MBTiles or PMTiles file name for serving map tiles, or an empty string if none exists.
+func (c *Config) MapTiles() string {
+	if c.options.MapTiles == "" {
+		return ""
+	}
+
+	fileName := fs.Abs(c.options.MapTiles)
+
+	if !fs.FileExists(fileName) {
+		return ""
+	}
+
+	return fileName
+}
+
+// MapTileSource returns the opened local map tile archive, or nil if none exists or it can't be opened.
+func (c *Config) MapTileSource() tiles.Source {
+	c.mapTilesOnce.Do(func() {
+		fileName := c.MapTiles()
+
+		if fileName == "" {
+			return
+		}
+
+		if s, err := tiles.Open(fileName); err != nil {
+			log.Errorf("maps: %s", err)
+		} else {
+			c.mapTiles = s
+		}
+	})
+
+	return c.mapTiles
+}
+
+// MapTilesUri returns the URL template for local map tiles, or an empty string if they are not available.
+func (c *Config) MapTilesUri() string {
+	if c.DisablePlaces() || c.MapTileSource() == nil {
+		return ""
+	}
+
+	return c.ContentUri() + MapTilesUri + "/{z}/{x}/{y}"
+}
@@ -0,0 +1,87 @@
+package config
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// createMapTiles creates an empty MBTiles archive and returns the file name.
+func createMapTiles(t *testing.T) string {
+	fileName := filepath.Join(t.TempDir(), "test.mbtiles")
+
+	db, err := sql.Open("sqlite3", fileName)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer db.Close()
+
+	for _, stmt := range []string{
+		"CREATE TABLE metadata (name TEXT, value TEXT)",
+		"CREATE TABLE tiles (zoom_level INTEGER, tile_column INTEGER, tile_row INTEGER, tile_data BLOB)",
+		"INSERT INTO metadata (name, value) VALUES ('format', 'png')",
+	} {
+		if _, err := db.Exec(stmt); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return fileName
+}
+
+func TestConfig_MapTiles(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, "", c.MapTiles())
+
+	c.options.MapTiles = "testdata/missing.pmtiles"
+	assert.Equal(t, "", c.MapTiles())
+
+	c.options.MapTiles = "testdata/config.yml"
+	assert.Contains(t, c.MapTiles(), "/internal/config/testdata/config.yml")
+}
+
+func TestConfig_MapTileSource(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		c := NewConfig(CliTestContext())
+		c.options.MapTiles = createMapTiles(t)
+
+		if s := c.MapTileSource(); assert.NotNil(t, s) {
+			assert.Equal(t, "png", s.Format())
+			assert.NoError(t, s.Close())
+		}
+	})
+	t.Run("invalid", func(t *testing.T) {
+		c := NewConfig(CliTestContext())
+		c.options.MapTiles = "testdata/config.yml"
+
+		assert.Nil(t, c.MapTileSource())
+	})
+}
+
+func TestConfig_MapTilesUri(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		c := NewConfig(CliTestContext())
+
+		assert.Equal(t, "", c.MapTilesUri())
+
+		c = NewConfig(CliTestContext())
+		c.options.MapTiles = createMapTiles(t)
+		assert.Equal(t, "/api/v1/maps/tiles/{z}/{x}/{y}", c.MapTilesUri())
+
+		c.options.DisablePlaces = true
+		assert.Equal(t, "", c.MapTilesUri())
+
+		assert.NoError(t, c.MapTileSource().Close())
+	})
+	t.Run("invalid", func(t *testing.T) {
+		c := NewConfig(CliTestContext())
+		c.options.MapTiles = "testdata/config.yml"
+
+		assert.Equal(t, "", c.MapTilesUri())
+	})
+}
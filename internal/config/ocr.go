@@ -0,0 +1,27 @@
+package config
+
+import "strings"
+
+// TesseractBin returns the tesseract executable file name.
+func (c *Config) TesseractBin() string {
+	return findExecutable(c.options.TesseractBin, "tesseract")
+}
+
+// TesseractEnabled tests if Tesseract is enabled for text recognition.
+func (c *Config) TesseractEnabled() bool {
+	return !c.DisableTesseract()
+}
+
+// TesseractLang returns the Tesseract language codes, e.g. eng+deu.
+func (c *Config) TesseractLang() string {
+	if lang := strings.TrimSpace(c.options.TesseractLang); lang != "" {
+		return lang
+	}
+
+	return "eng"
+}
+
+// OcrAll tests if text should be recognized in all photos, not only in document scans.
+func (c *Config) OcrAll() bool {
+	return c.options.OcrAll
+}
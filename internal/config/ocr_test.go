@@ -0,0 +1,34 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_TesseractBin(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	c.options.TesseractBin = "/usr/bin/xxx-tesseract"
+	assert.Equal(t, "", c.TesseractBin())
+	assert.True(t, c.DisableTesseract())
+	assert.False(t, c.TesseractEnabled())
+}
+
+func TestConfig_TesseractLang(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, "eng", c.TesseractLang())
+
+	c.options.TesseractLang = "eng+deu"
+	assert.Equal(t, "eng+deu", c.TesseractLang())
+}
+
+func TestConfig_OcrAll(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.False(t, c.OcrAll())
+
+	c.options.OcrAll = true
+	assert.True(t, c.OcrAll())
+}
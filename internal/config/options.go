@@ -18,7 +18,7 @@ const (
 	MySQL    = "mysql"
 	MariaDB  = "mariadb"
 	SQLite   = "sqlite3"
-	Postgres = "postgres" // TODO: Requires GORM 2.0 for generic column data types
+	Postgres = "postgres"
 )
 
 // Options provides a struct in which application configuration is stored.
@@ -43,6 +43,9 @@ type Options struct {
 	ConfigPath         string `yaml:"ConfigPath" json:"-" flag:"config-path"`
 	ConfigFile         string `json:"-"`
 	AdminPassword      string `yaml:"AdminPassword" json:"-" flag:"admin-password"`
+	TwoFactorRoles     string `yaml:"TwoFactorRoles" json:"-" flag:"two-factor-roles"`
+	LoginAttempts      int    `yaml:"LoginAttempts" json:"-" flag:"login-attempts"`
+	LoginLockout       int    `yaml:"LoginLockout" json:"-" flag:"login-lockout"`
 	OriginalsPath      string `yaml:"OriginalsPath" json:"-" flag:"originals-path"`
 	OriginalsLimit     int64  `yaml:"OriginalsLimit" json:"OriginalsLimit" flag:"originals-limit"`
 	ImportPath         string `yaml:"ImportPath" json:"-" flag:"import-path"`
@@ -53,13 +56,17 @@ type Options struct {
 	AssetsPath         string `yaml:"AssetsPath" json:"-" flag:"assets-path"`
 	CachePath          string `yaml:"CachePath" json:"-" flag:"cache-path"`
 	Workers            int    `yaml:"Workers" json:"Workers" flag:"workers"`
+	ThumbWorkers       int    `yaml:"ThumbWorkers" json:"ThumbWorkers" flag:"thumb-workers"`
+	ClassifyWorkers    int    `yaml:"ClassifyWorkers" json:"ClassifyWorkers" flag:"classify-workers"`
 	WakeupInterval     int    `yaml:"WakeupInterval" json:"WakeupInterval" flag:"wakeup-interval"`
 	AutoIndex          int    `yaml:"AutoIndex" json:"AutoIndex" flag:"auto-index"`
 	AutoImport         int    `yaml:"AutoImport" json:"AutoImport" flag:"auto-import"`
+	WatchDelay         int    `yaml:"WatchDelay" json:"WatchDelay" flag:"watch-delay"`
 	DisableBackups     bool   `yaml:"DisableBackups" json:"DisableBackups" flag:"disable-backups"`
 	DisableWebDAV      bool   `yaml:"DisableWebDAV" json:"DisableWebDAV" flag:"disable-webdav"`
 	DisableSettings    bool   `yaml:"DisableSettings" json:"-" flag:"disable-settings"`
 	DisablePlaces      bool   `yaml:"DisablePlaces" json:"DisablePlaces" flag:"disable-places"`
+	MapTiles           string `yaml:"MapTiles" json:"-" flag:"map-tiles"`
 	DisableExifTool    bool   `yaml:"DisableExifTool" json:"DisableExifTool" flag:"disable-exiftool"`
 	DisableTensorFlow  bool   `yaml:"DisableTensorFlow" json:"DisableTensorFlow" flag:"disable-tensorflow"`
 	DisableDarktable   bool   `yaml:"DisableDarktable" json:"DisableDarktable" flag:"disable-darktable"`
@@ -67,7 +74,13 @@ type Options struct {
 	DisableSips        bool   `yaml:"DisableSips" json:"DisableSips" flag:"disable-sips"`
 	DisableHeifConvert bool   `yaml:"DisableHeifConvert" json:"DisableHeifConvert" flag:"disable-heifconvert"`
 	DisableFFmpeg      bool   `yaml:"DisableFFmpeg" json:"DisableFFmpeg" flag:"disable-ffmpeg"`
+	DisableTesseract   bool   `yaml:"DisableTesseract" json:"DisableTesseract" flag:"disable-tesseract"`
 	DetectNSFW         bool   `yaml:"DetectNSFW" json:"DetectNSFW" flag:"detect-nsfw"`
+	DetectObjects      bool   `yaml:"DetectObjects" json:"DetectObjects" flag:"detect-objects"`
+	OcrAll             bool   `yaml:"OcrAll" json:"OcrAll" flag:"ocr-all"`
+	FaceSize           int    `yaml:"FaceSize" json:"-" flag:"face-size"`
+	FaceScore          int    `yaml:"FaceScore" json:"-" flag:"face-score"`
+	FaceMax            int    `yaml:"FaceMax" json:"-" flag:"face-max"`
 	UploadNSFW         bool   `yaml:"UploadNSFW" json:"-" flag:"upload-nsfw"`
 	LogLevel           string `yaml:"LogLevel" json:"-" flag:"log-level"`
 	LogFilename        string `yaml:"LogFilename" json:"-" flag:"log-filename"`
@@ -101,9 +114,12 @@ type Options struct {
 	FFmpegBitrate      int    `yaml:"FFmpegBitrate" json:"FFmpegBitrate" flag:"ffmpeg-bitrate"`
 	FFmpegBuffers      int    `yaml:"FFmpegBuffers" json:"FFmpegBuffers" flag:"ffmpeg-buffers"`
 	ExifToolBin        string `yaml:"ExifToolBin" json:"-" flag:"exiftool-bin"`
+	TesseractBin       string `yaml:"TesseractBin" json:"-" flag:"tesseract-bin"`
+	TesseractLang      string `yaml:"TesseractLang" json:"TesseractLang" flag:"tesseract-lang"`
 	DetachServer       bool   `yaml:"DetachServer" json:"-" flag:"detach-server"`
 	DownloadToken      string `yaml:"DownloadToken" json:"-" flag:"download-token"`
 	PreviewToken       string `yaml:"PreviewToken" json:"-" flag:"preview-token"`
+	MetricsToken       string `yaml:"MetricsToken" json:"-" flag:"metrics-token"`
 	ThumbFilter        string `yaml:"ThumbFilter" json:"ThumbFilter" flag:"thumb-filter"`
 	ThumbUncached      bool   `yaml:"ThumbUncached" json:"ThumbUncached" flag:"thumb-uncached"`
 	ThumbSize          int    `yaml:"ThumbSize" json:"ThumbSize" flag:"thumb-size"`
@@ -21,6 +21,11 @@ func (c *Config) NSFWModelPath() string {
 	return filepath.Join(c.AssetsPath(), "nsfw")
 }
 
+// DetectModelPath returns the object detection model path.
+func (c *Config) DetectModelPath() string {
+	return filepath.Join(c.AssetsPath(), "ssd")
+}
+
 // FaceNetModelPath returns the FaceNet model path.
 func (c *Config) FaceNetModelPath() string {
 	return filepath.Join(c.AssetsPath(), "facenet")
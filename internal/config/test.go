@@ -11,6 +11,7 @@ import (
 
 	_ "github.com/jinzhu/gorm/dialects/mysql"
 	_ "github.com/jinzhu/gorm/dialects/sqlite"
+	"github.com/photoprism/photoprism/internal/face"
 	"github.com/photoprism/photoprism/internal/thumb"
 	"github.com/photoprism/photoprism/pkg/capture"
 	"github.com/photoprism/photoprism/pkg/fs"
@@ -141,6 +142,9 @@ func NewTestConfig() *Config {
 	thumb.SizeUncached = c.ThumbSizeUncached()
 	thumb.Filter = c.ThumbFilter()
 	thumb.JpegQuality = c.JpegQuality()
+	face.MinSize = c.FaceSize()
+	face.ScoreThreshold = float64(c.FaceScore())
+	face.MaxFaces = c.FaceMax()
 
 	return c
 }
@@ -0,0 +1,188 @@
+package detect
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"image"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"sync"
+
+	"github.com/disintegration/imaging"
+	"github.com/photoprism/photoprism/pkg/txt"
+	tf "github.com/tensorflow/tensorflow/tensorflow/go"
+)
+
+// Detector uses a TensorFlow SSD model to find objects and their bounding boxes.
+type Detector struct {
+	model     *tf.SavedModel
+	modelPath string
+	disabled  bool
+	modelTags []string
+	labels    []string
+	mutex     sync.Mutex
+}
+
+// New returns a new detector instance.
+func New(modelPath string, disabled bool) *Detector {
+	return &Detector{modelPath: modelPath, disabled: disabled, modelTags: []string{"serve"}}
+}
+
+// Disabled tests if object detection is disabled.
+func (t *Detector) Disabled() bool {
+	return t.disabled
+}
+
+// File returns the objects found in a jpeg media file.
+func (t *Detector) File(fileName string) (result Objects, err error) {
+	if t.disabled {
+		return result, nil
+	}
+
+	imageBuffer, err := ioutil.ReadFile(fileName)
+
+	if err != nil {
+		return result, err
+	}
+
+	return t.Objects(imageBuffer)
+}
+
+// Objects returns the objects found in a jpeg media string.
+func (t *Detector) Objects(img []byte) (result Objects, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("detect: %s (inference panic)\nstack: %s", r, debug.Stack())
+		}
+	}()
+
+	if t.disabled {
+		return result, nil
+	}
+
+	if err := t.loadModel(); err != nil {
+		return result, err
+	}
+
+	tensor, err := createTensor(img)
+
+	if err != nil {
+		return result, fmt.Errorf("detect: %s", err)
+	}
+
+	graph := t.model.Graph
+
+	// Run inference.
+	output, err := t.model.Session.Run(
+		map[tf.Output]*tf.Tensor{
+			graph.Operation("image_tensor").Output(0): tensor,
+		},
+		[]tf.Output{
+			graph.Operation("detection_boxes").Output(0),
+			graph.Operation("detection_scores").Output(0),
+			graph.Operation("detection_classes").Output(0),
+		},
+		nil)
+
+	if err != nil {
+		return result, fmt.Errorf("detect: %s (run inference)", err.Error())
+	}
+
+	if len(output) < 3 {
+		return result, fmt.Errorf("detect: inference failed, no output")
+	}
+
+	boxes := output[0].Value().([][][]float32)[0]
+	scores := output[1].Value().([][]float32)[0]
+	classes := output[2].Value().([][]float32)[0]
+
+	result = NewObjects(t.labels, boxes, scores, classes)
+
+	log.Tracef("detect: found %d objects", len(result))
+
+	return result, nil
+}
+
+func (t *Detector) loadLabels(path string) error {
+	modelLabels := path + "/labels.txt"
+
+	log.Infof("detect: loading labels from labels.txt")
+
+	f, err := os.Open(modelLabels)
+
+	if err != nil {
+		return err
+	}
+
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+
+	// Labels are separated by newlines, the line number is the class index.
+	for scanner.Scan() {
+		t.labels = append(t.labels, scanner.Text())
+	}
+
+	return scanner.Err()
+}
+
+func (t *Detector) loadModel() error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if t.model != nil {
+		// Already loaded
+		return nil
+	}
+
+	log.Infof("detect: loading %s", txt.Quote(filepath.Base(t.modelPath)))
+
+	model, err := tf.LoadSavedModel(t.modelPath, t.modelTags, nil)
+
+	if err != nil {
+		return err
+	}
+
+	t.model = model
+
+	return t.loadLabels(t.modelPath)
+}
+
+// createTensor returns an uint8 image tensor as expected by SSD models.
+func createTensor(imageBuffer []byte) (*tf.Tensor, error) {
+	img, err := imaging.Decode(bytes.NewReader(imageBuffer), imaging.AutoOrientation(true))
+
+	if err != nil {
+		return nil, err
+	}
+
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+
+	if width <= 0 || height <= 0 {
+		return nil, fmt.Errorf("image width and height must be > 0")
+	}
+
+	tfImage := make([][][][3]uint8, 1)
+	tfImage[0] = make([][][3]uint8, height)
+
+	for y := 0; y < height; y++ {
+		tfImage[0][y] = make([][3]uint8, width)
+
+		for x := 0; x < width; x++ {
+			tfImage[0][y][x] = pixel(img, bounds.Min.X+x, bounds.Min.Y+y)
+		}
+	}
+
+	return tf.NewTensor(tfImage)
+}
+
+// pixel returns the 8-bit RGB values of a pixel.
+func pixel(img image.Image, x, y int) [3]uint8 {
+	r, g, b, _ := img.At(x, y).RGBA()
+
+	return [3]uint8{uint8(r >> 8), uint8(g >> 8), uint8(b >> 8)}
+}
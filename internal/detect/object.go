@@ -0,0 +1,99 @@
+package detect
+
+import (
+	"math"
+	"sort"
+	"strings"
+)
+
+// Object represents an object found in an image with its relative position.
+// X and Y are the center of the bounding box, W and H its size.
+type Object struct {
+	Name  string  `json:"name"`
+	Score float32 `json:"score"`
+	X     float32 `json:"x"`
+	Y     float32 `json:"y"`
+	W     float32 `json:"w"`
+	H     float32 `json:"h"`
+}
+
+// Objects represents a list of objects, sorted by score.
+type Objects []Object
+
+// NewObjects returns the objects found based on the raw model outputs. Boxes are
+// expected as relative [top, left, bottom, right] coordinates, classes as label indexes.
+func NewObjects(labels []string, boxes [][]float32, scores, classes []float32) (result Objects) {
+	for i, score := range scores {
+		if i >= len(boxes) || i >= len(classes) {
+			break
+		}
+
+		if score < MinScore {
+			continue
+		}
+
+		class := int(classes[i])
+
+		if class < 0 || class >= len(labels) || labels[class] == "" {
+			continue
+		}
+
+		box := boxes[i]
+
+		if len(box) != 4 {
+			continue
+		}
+
+		top, left, bottom, right := clip(box[0]), clip(box[1]), clip(box[2]), clip(box[3])
+
+		if bottom <= top || right <= left {
+			continue
+		}
+
+		result = append(result, Object{
+			Name:  strings.ToLower(strings.TrimSpace(labels[class])),
+			Score: round(score),
+			X:     round((left + right) / 2),
+			Y:     round((top + bottom) / 2),
+			W:     round(right - left),
+			H:     round(bottom - top),
+		})
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Score > result[j].Score
+	})
+
+	if len(result) > MaxObjects {
+		return result[:MaxObjects]
+	}
+
+	return result
+}
+
+// Count returns the number of objects with the given name.
+func (o Objects) Count(name string) (count int) {
+	for _, obj := range o {
+		if obj.Name == name {
+			count++
+		}
+	}
+
+	return count
+}
+
+// clip limits a relative coordinate to the range from 0 to 1.
+func clip(f float32) float32 {
+	if f < 0 {
+		return 0
+	} else if f > 1 {
+		return 1
+	}
+
+	return f
+}
+
+// round rounds a float to 3 decimal places.
+func round(f float32) float32 {
+	return float32(math.Round(float64(f)*1000) / 1000)
+}
@@ -0,0 +1,49 @@
+package detect
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewObjects(t *testing.T) {
+	labels := []string{"???", "person", "bicycle", "car", "", "dog"}
+
+	t.Run("dogs", func(t *testing.T) {
+		boxes := [][]float32{
+			{0.1, 0.2, 0.5, 0.4},
+			{0.5, 0.5, 0.9, 0.9},
+			{0, 0, 1, 1},
+			{0.2, 0.2, 0.4, 0.4},
+		}
+		scores := []float32{0.6, 0.9, 0.3, 0.8}
+		classes := []float32{5, 5, 1, 4}
+
+		result := NewObjects(labels, boxes, scores, classes)
+
+		assert.Len(t, result, 2)
+		assert.Equal(t, Object{Name: "dog", Score: 0.9, X: 0.7, Y: 0.7, W: 0.4, H: 0.4}, result[0])
+		assert.Equal(t, Object{Name: "dog", Score: 0.6, X: 0.3, Y: 0.3, W: 0.2, H: 0.4}, result[1])
+		assert.Equal(t, 2, result.Count("dog"))
+		assert.Equal(t, 0, result.Count("person"))
+	})
+	t.Run("invalid", func(t *testing.T) {
+		boxes := [][]float32{
+			{0.5, 0.5, 0.1, 0.1},
+			{0.1, 0.1},
+			{-0.5, 0.1, 1.5, 0.2},
+		}
+		scores := []float32{0.9, 0.9, 0.9, 0.9}
+		classes := []float32{1, 1, 99}
+
+		result := NewObjects(labels, boxes, scores, classes)
+
+		assert.Len(t, result, 0)
+	})
+	t.Run("clip", func(t *testing.T) {
+		result := NewObjects(labels, [][]float32{{-0.5, 0.1, 1.5, 0.3}}, []float32{0.7}, []float32{1})
+
+		assert.Len(t, result, 1)
+		assert.Equal(t, Object{Name: "person", Score: 0.7, X: 0.2, Y: 0.5, W: 0.2, H: 1}, result[0])
+	})
+}
@@ -2,7 +2,10 @@ package entity
 
 import (
 	"database/sql"
+	"fmt"
 	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/photoprism/photoprism/internal/form"
@@ -19,6 +22,9 @@ const (
 	AccountSyncStatusSynced   = "synced"
 )
 
+// RemoteRootPrefix is the file root prefix of remote library accounts, followed by the account ID.
+const RemoteRootPrefix = "remote:"
+
 type Accounts []Account
 
 // Account represents a remote service account for uploading, downloading or syncing media files.
@@ -47,6 +53,8 @@ type Account struct {
 	SyncDownload  bool
 	SyncFilenames bool
 	SyncRaw       bool
+	AccLibrary    bool
+	LibraryPath   string     `gorm:"type:VARBINARY(500);"`
 	CreatedAt     time.Time  `deepcopier:"skip"`
 	UpdatedAt     time.Time  `deepcopier:"skip"`
 	DeletedAt     *time.Time `deepcopier:"skip" sql:"index"`
@@ -78,6 +86,7 @@ func (m *Account) SaveForm(form form.Account) error {
 		// TODO: Only WebDAV supported at the moment
 		m.AccShare = false
 		m.AccSync = false
+		m.AccLibrary = false
 	}
 
 	// Set defaults
@@ -89,6 +98,10 @@ func (m *Account) SaveForm(form form.Account) error {
 		m.SyncPath = "/"
 	}
 
+	if m.LibraryPath == "" {
+		m.LibraryPath = "/"
+	}
+
 	// Refresh after performing changes
 	if m.AccSync && m.SyncStatus == AccountSyncStatusSynced {
 		m.SyncStatus = AccountSyncStatusRefresh
@@ -114,6 +127,36 @@ func (m *Account) Directories() (result fs.FileInfos, err error) {
 	return result, err
 }
 
+// LibraryRoot returns the file root name for indexing the account as read-only library.
+func (m *Account) LibraryRoot() string {
+	return RemoteRoot(m.ID)
+}
+
+// RemoteRoot returns the file root name of a remote library account.
+func RemoteRoot(accountID uint) string {
+	return fmt.Sprintf("%s%d", RemoteRootPrefix, accountID)
+}
+
+// IsRemoteRoot checks if the file root belongs to a remote library account.
+func IsRemoteRoot(fileRoot string) bool {
+	return RemoteRootID(fileRoot) > 0
+}
+
+// RemoteRootID returns the account ID of a remote library file root, or 0 if it is not a remote root.
+func RemoteRootID(fileRoot string) uint {
+	if !strings.HasPrefix(fileRoot, RemoteRootPrefix) {
+		return 0
+	}
+
+	id, err := strconv.ParseUint(strings.TrimPrefix(fileRoot, RemoteRootPrefix), 10, 32)
+
+	if err != nil {
+		return 0
+	}
+
+	return uint(id)
+}
+
 // Updates multiple columns in the database.
 func (m *Account) Updates(values interface{}) error {
 	return UnscopedDb().Model(m).UpdateColumns(values).Error
@@ -262,3 +262,17 @@ func TestAccount_Create(t *testing.T) {
 		}
 	})
 }
+
+func TestAccount_LibraryRoot(t *testing.T) {
+	m := Account{ID: 7}
+	assert.Equal(t, "remote:7", m.LibraryRoot())
+}
+
+func TestRemoteRootID(t *testing.T) {
+	assert.Equal(t, uint(7), RemoteRootID("remote:7"))
+	assert.Equal(t, uint(0), RemoteRootID("remote:"))
+	assert.Equal(t, uint(0), RemoteRootID("remote:abc"))
+	assert.Equal(t, uint(0), RemoteRootID(RootOriginals))
+	assert.True(t, IsRemoteRoot(RemoteRoot(3)))
+	assert.False(t, IsRemoteRoot(RootSidecar))
+}
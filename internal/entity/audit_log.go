@@ -0,0 +1,169 @@
+package entity
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+// AuditChange represents the old and new value of a changed field.
+type AuditChange struct {
+	Old interface{} `json:"old,omitempty"`
+	New interface{} `json:"new,omitempty"`
+}
+
+// AuditDiff maps field names to changes, stored as JSON.
+type AuditDiff map[string]AuditChange
+
+// Value implements the driver.Valuer interface.
+func (d AuditDiff) Value() (driver.Value, error) {
+	if len(d) == 0 {
+		return "", nil
+	}
+
+	b, err := json.Marshal(d)
+
+	return string(b), err
+}
+
+// Scan implements the sql.Scanner interface.
+func (d *AuditDiff) Scan(src interface{}) error {
+	var b []byte
+
+	switch v := src.(type) {
+	case nil:
+		return nil
+	case string:
+		b = []byte(v)
+	case []byte:
+		b = v
+	default:
+		return fmt.Errorf("audit: can't scan %T into diff", src)
+	}
+
+	if len(b) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(b, d)
+}
+
+// AuditRedacted is stored instead of secret values such as passwords and tokens.
+const AuditRedacted = "[redacted]"
+
+// auditValue returns the value to store in the audit log, secrets are redacted.
+func auditValue(name string, value interface{}) interface{} {
+	if s, ok := value.(string); !ok || s == "" {
+		return value
+	}
+
+	name = strings.ToLower(name)
+
+	if strings.Contains(name, "pass") || strings.Contains(name, "secret") ||
+		strings.Contains(name, "token") || strings.HasSuffix(name, "key") {
+		return AuditRedacted
+	}
+
+	return value
+}
+
+// fieldValues returns the exported field values of a struct or map as generic JSON values.
+func fieldValues(v interface{}) (result map[string]interface{}) {
+	result = make(map[string]interface{})
+
+	if v == nil || (reflect.ValueOf(v).Kind() == reflect.Ptr && reflect.ValueOf(v).IsNil()) {
+		return result
+	}
+
+	if b, err := json.Marshal(v); err != nil {
+		log.Errorf("audit: %s", err)
+	} else if err := json.Unmarshal(b, &result); err != nil {
+		log.Debugf("audit: %s", err)
+	}
+
+	return result
+}
+
+// NewAuditDiff compares the JSON fields of two values and returns the changes.
+// Either value may be nil, e.g. for created or deleted entities.
+func NewAuditDiff(before, after interface{}) AuditDiff {
+	oldValues := fieldValues(before)
+	newValues := fieldValues(after)
+
+	result := make(AuditDiff)
+
+	for name, oldValue := range oldValues {
+		if newValue, ok := newValues[name]; !ok {
+			if after == nil {
+				result[name] = AuditChange{Old: auditValue(name, oldValue)}
+			}
+		} else if !reflect.DeepEqual(oldValue, newValue) {
+			result[name] = AuditChange{Old: auditValue(name, oldValue), New: auditValue(name, newValue)}
+		}
+	}
+
+	for name, newValue := range newValues {
+		if _, ok := oldValues[name]; !ok {
+			result[name] = AuditChange{New: auditValue(name, newValue)}
+		}
+	}
+
+	return result
+}
+
+type AuditLogs []AuditLog
+
+// AuditLog represents an append-only record of a user or admin action.
+type AuditLog struct {
+	ID          uint      `gorm:"primary_key" json:"ID" yaml:"-"`
+	ActorUID    string    `gorm:"type:VARBINARY(42);index;" json:"ActorUID" yaml:"ActorUID,omitempty"`
+	ActorName   string    `gorm:"size:64;" json:"ActorName" yaml:"ActorName,omitempty"`
+	SessionHash string    `gorm:"type:VARBINARY(16);index;" json:"Session" yaml:"Session,omitempty"`
+	ClientIP    string    `gorm:"type:VARBINARY(64);index;" json:"IP" yaml:"IP,omitempty"`
+	Action      string    `gorm:"type:VARBINARY(64);index;" json:"Action" yaml:"Action"`
+	ResourceUID string    `gorm:"type:VARBINARY(42);index;" json:"UID" yaml:"UID,omitempty"`
+	Diff        AuditDiff `gorm:"type:LONGTEXT;" json:"Diff,omitempty" yaml:"Diff,omitempty"`
+	CreatedAt   time.Time `gorm:"index;" json:"CreatedAt" yaml:"CreatedAt"`
+}
+
+// TableName returns the entity database table name.
+func (AuditLog) TableName() string {
+	return "audit_log"
+}
+
+// NewAuditLog returns a new audit log entry for the given actor and action.
+func NewAuditLog(actor User, sessionHash, clientIP, action, resourceUID string, before, after interface{}) AuditLog {
+	return AuditLog{
+		ActorUID:    actor.UserUID,
+		ActorName:   actor.String(),
+		SessionHash: sessionHash,
+		ClientIP:    clientIP,
+		Action:      action,
+		ResourceUID: resourceUID,
+		Diff:        NewAuditDiff(before, after),
+	}
+}
+
+// Create inserts a new row to the database.
+func (m *AuditLog) Create() error {
+	if m.ID > 0 {
+		return fmt.Errorf("audit: entry %d already exists", m.ID)
+	}
+
+	return UnscopedDb().Create(m).Error
+}
+
+// BeforeUpdate prevents existing audit log entries from being changed.
+func (m *AuditLog) BeforeUpdate(scope *gorm.Scope) error {
+	return fmt.Errorf("audit: log is append-only")
+}
+
+// BeforeDelete prevents audit log entries from being deleted.
+func (m *AuditLog) BeforeDelete(scope *gorm.Scope) error {
+	return fmt.Errorf("audit: log is append-only")
+}
@@ -0,0 +1,90 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAuditDiff(t *testing.T) {
+	t.Run("changed", func(t *testing.T) {
+		before := Link{LinkUID: "ss62xpryd1ob7gtf", ShareSlug: "foo", MaxViews: 2}
+		after := Link{LinkUID: "ss62xpryd1ob7gtf", ShareSlug: "bar", MaxViews: 2}
+
+		diff := NewAuditDiff(before, after)
+
+		assert.Len(t, diff, 1)
+		assert.Equal(t, "foo", diff["Slug"].Old)
+		assert.Equal(t, "bar", diff["Slug"].New)
+	})
+	t.Run("created", func(t *testing.T) {
+		diff := NewAuditDiff(nil, map[string]interface{}{"Title": "Foo"})
+
+		assert.Len(t, diff, 1)
+		assert.Nil(t, diff["Title"].Old)
+		assert.Equal(t, "Foo", diff["Title"].New)
+	})
+	t.Run("deleted", func(t *testing.T) {
+		diff := NewAuditDiff(map[string]interface{}{"Title": "Foo"}, nil)
+
+		assert.Len(t, diff, 1)
+		assert.Equal(t, "Foo", diff["Title"].Old)
+		assert.Nil(t, diff["Title"].New)
+	})
+	t.Run("redacted", func(t *testing.T) {
+		diff := NewAuditDiff(map[string]string{"AccPass": "old"}, map[string]string{"AccPass": "new"})
+
+		assert.Equal(t, AuditRedacted, diff["AccPass"].Old)
+		assert.Equal(t, AuditRedacted, diff["AccPass"].New)
+	})
+	t.Run("unchanged", func(t *testing.T) {
+		diff := NewAuditDiff(Link{ShareSlug: "foo"}, Link{ShareSlug: "foo"})
+
+		assert.Empty(t, diff)
+	})
+}
+
+func TestAuditDiff_Scan(t *testing.T) {
+	diff := AuditDiff{"Title": AuditChange{Old: "Foo", New: "Bar"}}
+
+	value, err := diff.Value()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var result AuditDiff
+
+	if err := result.Scan(value); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, diff, result)
+	assert.Error(t, result.Scan(42))
+}
+
+func TestAuditLog_Create(t *testing.T) {
+	m := NewAuditLog(Admin, "abc", "127.0.0.1", "photos.update", "pt9jtdre2lvl0yh7", Photo{PhotoTitle: "Foo"}, Photo{PhotoTitle: "Bar"})
+
+	if err := m.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.NotEmpty(t, m.ID)
+	assert.Equal(t, "admin", m.ActorName)
+	assert.Equal(t, "Bar", m.Diff["Title"].New)
+
+	var found AuditLog
+
+	if err := Db().First(&found, m.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "Foo", found.Diff["Title"].Old)
+
+	t.Run("append only", func(t *testing.T) {
+		assert.Error(t, m.Create())
+		assert.Error(t, Db().Model(&found).Update("action", "photos.delete").Error)
+		assert.Error(t, Db().Delete(&found).Error)
+	})
+}
@@ -2,10 +2,11 @@ package entity
 
 // Category of labels regroups labels with the same or a similar meaning using a main/root label
 type Category struct {
-	LabelID    uint `gorm:"primary_key;auto_increment:false"`
-	CategoryID uint `gorm:"primary_key;auto_increment:false"`
-	Label      *Label
-	Category   *Label
+	LabelID     uint   `gorm:"primary_key;auto_increment:false"`
+	CategoryID  uint   `gorm:"primary_key;auto_increment:false"`
+	CategorySrc string `gorm:"type:VARBINARY(8);default:'';"`
+	Label       *Label
+	Category    *Label
 }
 
 // TableName returns Category table identifier "categories"
@@ -103,6 +103,11 @@ func (m *Cell) Find(api string) error {
 
 	if createErr := db.Create(m).Error; createErr == nil {
 		log.Debugf("location: added cell %s [%s]", m.ID, time.Since(start))
+
+		if err := m.Place.ExtendBounds(m.ID); err != nil {
+			log.Errorf("location: %s (update bounds of place %s)", err, m.PlaceID)
+		}
+
 		return nil
 	} else if findErr := db.Preload("Place").First(m, "id = ?", m.ID).Error; findErr != nil {
 		log.Errorf("location: %s (create cell %s)", createErr, m.ID)
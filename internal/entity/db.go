@@ -12,8 +12,9 @@ import (
 
 // Database drivers (sql dialects).
 const (
-	MySQL  = "mysql"
-	SQLite = "sqlite3"
+	MySQL    = "mysql"
+	Postgres = "postgres"
+	SQLite   = "sqlite3"
 )
 
 var dbProvider DbProvider
@@ -71,14 +72,23 @@ func (g *Gorm) Db() *gorm.DB {
 	return g.db
 }
 
+// OpenDb opens a new gorm db connection using the given sql dialect and data source name.
+func OpenDb(driver, dsn string) (*gorm.DB, error) {
+	if driver == Postgres {
+		return gorm.Open(driver, PostgresDriver, dsn)
+	}
+
+	return gorm.Open(driver, dsn)
+}
+
 // Connect creates a new gorm db connection.
 func (g *Gorm) Connect() {
-	db, err := gorm.Open(g.Driver, g.Dsn)
+	db, err := OpenDb(g.Driver, g.Dsn)
 
 	if err != nil || db == nil {
 		for i := 1; i <= 12; i++ {
 			fmt.Printf("gorm.Open(%s, %s) %d\n", g.Driver, g.Dsn, i)
-			db, err = gorm.Open(g.Driver, g.Dsn)
+			db, err = OpenDb(g.Driver, g.Dsn)
 
 			if db != nil && err == nil {
 				break
@@ -0,0 +1,153 @@
+package entity
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"reflect"
+	"regexp"
+
+	"github.com/jinzhu/gorm"
+	_ "github.com/jinzhu/gorm/dialects/postgres"
+	"github.com/lib/pq"
+)
+
+// PostgresDriver is the name of the sql driver used to connect to PostgreSQL, see OpenDb().
+const PostgresDriver = "photoprism-postgres"
+
+// postgresTypes maps MySQL column types used in entity tags to their PostgreSQL equivalent.
+var postgresTypes = []struct {
+	pattern *regexp.Regexp
+	replace string
+}{
+	{regexp.MustCompile(`(?i)^VARBINARY\((\d+)\)`), "VARCHAR($1)"},
+	{regexp.MustCompile(`(?i)^(LONG|MEDIUM|TINY)TEXT\b`), "TEXT"},
+	{regexp.MustCompile(`(?i)^(LONG|MEDIUM|TINY)?BLOB\b`), "BYTEA"},
+	{regexp.MustCompile(`(?i)^DATETIME\b`), "TIMESTAMP"},
+	{regexp.MustCompile(`(?i)^TINYINT(\(\d+\))?`), "SMALLINT"},
+	{regexp.MustCompile(`(?i)^FLOAT\b`), "REAL"},
+	{regexp.MustCompile(`(?i)^numeric\b`), "DOUBLE PRECISION"},
+	// Booleans are stored as numbers so that raw queries like "photo_private = 0" work with all dialects.
+	{regexp.MustCompile(`(?i)^boolean\b`), "SMALLINT"},
+}
+
+// postgresDialect wraps the default gorm dialect to translate MySQL column types.
+type postgresDialect struct {
+	gorm.Dialect
+}
+
+func init() {
+	dialect, ok := gorm.GetDialect(Postgres)
+
+	if !ok {
+		return
+	}
+
+	dialectType := reflect.TypeOf(dialect).Elem()
+
+	gorm.RegisterDialect(Postgres, &postgresDialect{})
+
+	newPostgresDialect = func() gorm.Dialect {
+		return reflect.New(dialectType).Interface().(gorm.Dialect)
+	}
+
+	sql.Register(PostgresDriver, &postgresDriver{})
+}
+
+// newPostgresDialect returns a new instance of the default gorm dialect.
+var newPostgresDialect func() gorm.Dialect
+
+// SetDB sets the db connection of a new dialect instance.
+func (d *postgresDialect) SetDB(db gorm.SQLCommon) {
+	if d.Dialect == nil {
+		d.Dialect = newPostgresDialect()
+	}
+
+	d.Dialect.SetDB(db)
+}
+
+// DataTypeOf returns the PostgreSQL column type of a struct field.
+func (d *postgresDialect) DataTypeOf(field *gorm.StructField) string {
+	return PostgresType(d.Dialect.DataTypeOf(field))
+}
+
+// PostgresType translates a MySQL column type definition to PostgreSQL.
+func PostgresType(s string) string {
+	for _, t := range postgresTypes {
+		if t.pattern.MatchString(s) {
+			return t.pattern.ReplaceAllString(s, t.replace)
+		}
+	}
+
+	return s
+}
+
+// postgresDriver wraps the pq driver to store boolean arguments as numbers, see postgresTypes.
+type postgresDriver struct {
+	pq.Driver
+}
+
+// Open returns a new connection to the database.
+func (d *postgresDriver) Open(name string) (driver.Conn, error) {
+	conn, err := d.Driver.Open(name)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &postgresConn{Conn: conn}, nil
+}
+
+// postgresConn wraps a pq connection.
+type postgresConn struct {
+	driver.Conn
+}
+
+// CheckNamedValue converts boolean arguments to numbers.
+func (c *postgresConn) CheckNamedValue(v *driver.NamedValue) error {
+	if b, ok := v.Value.(bool); !ok {
+		return driver.ErrSkip
+	} else if b {
+		v.Value = int64(1)
+	} else {
+		v.Value = int64(0)
+	}
+
+	return nil
+}
+
+// BeginTx starts a transaction with the given options.
+func (c *postgresConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if conn, ok := c.Conn.(driver.ConnBeginTx); ok {
+		return conn.BeginTx(ctx, opts)
+	}
+
+	return c.Conn.Begin()
+}
+
+// PrepareContext returns a prepared statement.
+func (c *postgresConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	if conn, ok := c.Conn.(driver.ConnPrepareContext); ok {
+		return conn.PrepareContext(ctx, query)
+	}
+
+	return c.Conn.Prepare(query)
+}
+
+// ExecContext executes a query without returning rows.
+func (c *postgresConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	if conn, ok := c.Conn.(driver.ExecerContext); ok {
+		return conn.ExecContext(ctx, query, args)
+	}
+
+	return nil, driver.ErrSkip
+}
+
+// QueryContext executes a query that may return rows.
+func (c *postgresConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	if conn, ok := c.Conn.(driver.QueryerContext); ok {
+		return conn.QueryContext(ctx, query, args)
+	}
+
+	return nil, driver.ErrSkip
+}
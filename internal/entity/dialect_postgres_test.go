@@ -0,0 +1,59 @@
+package entity
+
+import (
+	"database/sql/driver"
+	"testing"
+
+	"github.com/jinzhu/gorm"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPostgresType(t *testing.T) {
+	assert.Equal(t, "VARCHAR(42)", PostgresType("VARBINARY(42)"))
+	assert.Equal(t, "VARCHAR(42) DEFAULT 'zz'", PostgresType("VARBINARY(42) DEFAULT 'zz'"))
+	assert.Equal(t, "VARCHAR(255)", PostgresType("VARCHAR(255)"))
+	assert.Equal(t, "TEXT", PostgresType("LONGTEXT"))
+	assert.Equal(t, "TEXT", PostgresType("TEXT"))
+	assert.Equal(t, "BYTEA", PostgresType("MEDIUMBLOB"))
+	assert.Equal(t, "TIMESTAMP", PostgresType("datetime"))
+	assert.Equal(t, "REAL", PostgresType("FLOAT"))
+	assert.Equal(t, "DOUBLE PRECISION", PostgresType("numeric"))
+	assert.Equal(t, "SMALLINT", PostgresType("boolean"))
+	assert.Equal(t, "SMALLINT", PostgresType("SMALLINT"))
+	assert.Equal(t, "bigserial", PostgresType("bigserial"))
+}
+
+func TestPostgresConn_CheckNamedValue(t *testing.T) {
+	c := &postgresConn{}
+
+	// Booleans are stored as numbers, so that comparisons like "file_primary = 1" work.
+	v := &driver.NamedValue{Value: true}
+	assert.NoError(t, c.CheckNamedValue(v))
+	assert.Equal(t, int64(1), v.Value)
+
+	v = &driver.NamedValue{Value: false}
+	assert.NoError(t, c.CheckNamedValue(v))
+	assert.Equal(t, int64(0), v.Value)
+
+	// Other values are converted by the default driver.
+	assert.Equal(t, driver.ErrSkip, c.CheckNamedValue(&driver.NamedValue{Value: "true"}))
+}
+
+func TestPostgresDialect(t *testing.T) {
+	dialect, ok := gorm.GetDialect(Postgres)
+
+	assert.True(t, ok)
+	assert.IsType(t, &postgresDialect{}, dialect)
+}
+
+func TestOpenDb(t *testing.T) {
+	db, err := OpenDb(SQLite, ":memory:")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer db.Close()
+
+	assert.Equal(t, SQLite, db.Dialect().GetName())
+}
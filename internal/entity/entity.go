@@ -30,32 +30,45 @@ type Types map[string]interface{}
 
 // List of database entities and their table names.
 var Entities = Types{
-	"errors":          &Error{},
-	"addresses":       &Address{},
-	"users":           &User{},
-	"accounts":        &Account{},
-	"folders":         &Folder{},
-	"duplicates":      &Duplicate{},
-	"files":           &File{},
-	"files_share":     &FileShare{},
-	"files_sync":      &FileSync{},
-	"photos":          &Photo{},
-	"details":         &Details{},
-	"places":          &Place{},
-	"cells":           &Cell{},
-	"cameras":         &Camera{},
-	"lenses":          &Lens{},
-	"countries":       &Country{},
-	"albums":          &Album{},
-	"photos_albums":   &PhotoAlbum{},
-	"labels":          &Label{},
-	"categories":      &Category{},
-	"photos_labels":   &PhotoLabel{},
-	"keywords":        &Keyword{},
-	"photos_keywords": &PhotoKeyword{},
-	"passwords":       &Password{},
-	"links":           &Link{},
-	"markers_dev":     &Marker{},
+	"errors":            &Error{},
+	"audit_log":         &AuditLog{},
+	"addresses":         &Address{},
+	"users":             &User{},
+	"accounts":          &Account{},
+	"folders":           &Folder{},
+	"duplicates":        &Duplicate{},
+	"files":             &File{},
+	"files_share":       &FileShare{},
+	"files_sync":        &FileSync{},
+	"photos":            &Photo{},
+	"details":           &Details{},
+	"places":            &Place{},
+	"cells":             &Cell{},
+	"cameras":           &Camera{},
+	"lenses":            &Lens{},
+	"countries":         &Country{},
+	"albums":            &Album{},
+	"photos_albums":     &PhotoAlbum{},
+	"labels":            &Label{},
+	"categories":        &Category{},
+	"photos_labels":     &PhotoLabel{},
+	"keywords":          &Keyword{},
+	"photos_keywords":   &PhotoKeyword{},
+	"photos_changes":    &PhotoChange{},
+	"passwords":         &Password{},
+	"recovery_codes":    &RecoveryCode{},
+	"links":             &Link{},
+	"markers":           &Marker{},
+	"schema_versions":   &SchemaVersion{},
+	"webdav_locks":      &WebDAVLock{},
+	"jobs":              &Job{},
+	"job_runs":          &JobRun{},
+	"index_reports":     &IndexReport{},
+	"index_failures":    &IndexFailure{},
+	"queue_items":       &QueueItem{},
+	"files_predictions": &FilePredictions{},
+	"files_text":        &FileText{},
+	"label_synonyms":    &LabelSynonym{},
 }
 
 type RowCount struct {
@@ -131,11 +144,26 @@ func CreateDefaultFixtures() {
 	CreateUnknownLens()
 }
 
-// MigrateDb creates all tables and inserts default entities as needed.
+// MigrateDb applies pending versioned migrations, creates all tables and inserts default entities as needed.
 func MigrateDb() {
+	// New databases are created with the latest schema, so versioned migrations only need to be recorded.
+	newDb := !UnscopedDb().HasTable(&Photo{})
+
+	if !newDb {
+		if _, err := MigrationList.MigrateUp(0, false, nil); err != nil {
+			log.Errorf("migrate: %s", err)
+		}
+	}
+
 	Entities.Migrate()
 	Entities.WaitForMigration()
 
+	if newDb {
+		if err := MigrationList.Baseline(); err != nil {
+			log.Errorf("migrate: %s", err)
+		}
+	}
+
 	CreateDefaultFixtures()
 }
 
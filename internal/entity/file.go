@@ -6,6 +6,8 @@ import (
 	"strings"
 	"time"
 
+	"github.com/photoprism/photoprism/internal/classify"
+	"github.com/photoprism/photoprism/internal/detect"
 	"github.com/photoprism/photoprism/internal/face"
 
 	"github.com/photoprism/photoprism/pkg/txt"
@@ -199,6 +201,8 @@ func (m File) Missing() bool {
 func (m *File) DeletePermanently() error {
 	Db().Unscoped().Delete(FileShare{}, "file_id = ?", m.ID)
 	Db().Unscoped().Delete(FileSync{}, "file_id = ?", m.ID)
+	Db().Unscoped().Delete(FilePredictions{}, "file_id = ?", m.ID)
+	Db().Unscoped().Delete(FileText{}, "file_id = ?", m.ID)
 
 	return Db().Unscoped().Delete(m).Error
 }
@@ -265,7 +269,7 @@ func (m *File) Create() error {
 // ResolvePrimary ensures there is only one primary file for a photo..
 func (m *File) ResolvePrimary() error {
 	if m.FilePrimary {
-		return UnscopedDb().Exec("UPDATE `files` SET file_primary = (id = ?) WHERE photo_id = ?", m.ID, m.PhotoID).Error
+		return UnscopedDb().Exec("UPDATE files SET file_primary = (CASE WHEN id = ? THEN 1 ELSE 0 END) WHERE photo_id = ?", m.ID, m.PhotoID).Error
 	}
 
 	return nil
@@ -378,6 +382,11 @@ func (m *File) RelatedPhoto() *Photo {
 	return &photo
 }
 
+// Remote returns true if the file belongs to a remote library, so that the original is not stored locally.
+func (m *File) Remote() bool {
+	return IsRemoteRoot(m.FileRoot)
+}
+
 // NoJPEG returns true if the file is not a JPEG image file.
 func (m *File) NoJPEG() bool {
 	return m.FileType != string(fs.FormatJpeg)
@@ -412,9 +421,32 @@ func (m *File) AddFace(f face.Face, refUID string) {
 	}
 }
 
+// AddObjects adds label markers for objects found by object detection.
+func (m *File) AddObjects(objects detect.Objects) {
+	for _, obj := range objects {
+		l, ok := classify.ObjectLabel(obj, SrcImage)
+
+		if !ok {
+			continue
+		}
+
+		var refUID string
+
+		if label := FindLabel(l.Name); label != nil {
+			refUID = label.LabelUID
+		}
+
+		marker := NewObjectMarker(obj, m.ID, l.Title(), refUID)
+
+		if !m.Markers.Contains(*marker) {
+			m.Markers = append(m.Markers, *marker)
+		}
+	}
+}
+
 // FaceCount returns the current number of valid faces detected.
 func (m *File) FaceCount() (c int) {
-	if err := Db().Model(Marker{}).Where("marker_invalid = 0 AND file_id = ?", m.ID).
+	if err := Db().Model(Marker{}).Where("marker_invalid = 0 AND marker_type = ? AND file_id = ?", MarkerFace, m.ID).
 		Count(&c).Error; err != nil {
 		log.Errorf("file: %s (count faces)", err)
 		return 0
@@ -0,0 +1,99 @@
+package entity
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/classify"
+)
+
+// ImagePredictions contains the raw classifier predictions for each image crop, stored as JSON.
+type ImagePredictions []classify.Predictions
+
+// Value implements the driver.Valuer interface.
+func (p ImagePredictions) Value() (driver.Value, error) {
+	if len(p) == 0 {
+		return "", nil
+	}
+
+	b, err := json.Marshal(p)
+
+	return string(b), err
+}
+
+// Scan implements the sql.Scanner interface.
+func (p *ImagePredictions) Scan(src interface{}) error {
+	var b []byte
+
+	switch v := src.(type) {
+	case nil:
+		return nil
+	case string:
+		b = []byte(v)
+	case []byte:
+		b = v
+	default:
+		return fmt.Errorf("predictions: can't scan %T", src)
+	}
+
+	if len(b) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(b, p)
+}
+
+// Labels returns matching labels based on the current label rules, see classify.Predictions.
+func (p ImagePredictions) Labels() (result classify.Labels) {
+	for _, predictions := range p {
+		result = append(result, predictions.Labels()...)
+	}
+
+	return result
+}
+
+// FilePredictions represents the raw image classification results of a file,
+// so that labels can be rebuilt after label rules have changed without running TensorFlow again.
+type FilePredictions struct {
+	FileID      uint             `gorm:"primary_key;auto_increment:false" json:"FileID" yaml:"-"`
+	ModelName   string           `gorm:"type:VARBINARY(64);" json:"Model" yaml:"-"`
+	Predictions ImagePredictions `gorm:"type:TEXT;" json:"Predictions" yaml:"-"`
+	CreatedAt   time.Time        `json:"CreatedAt" yaml:"-"`
+	UpdatedAt   time.Time        `json:"UpdatedAt" yaml:"-"`
+}
+
+// TableName returns the entity database table name.
+func (FilePredictions) TableName() string {
+	return "files_predictions"
+}
+
+// NewFilePredictions returns new raw image classification results for a file.
+func NewFilePredictions(fileID uint, modelName string, predictions ImagePredictions) *FilePredictions {
+	return &FilePredictions{
+		FileID:      fileID,
+		ModelName:   modelName,
+		Predictions: predictions,
+	}
+}
+
+// Save inserts or updates the classification results in the database.
+func (m *FilePredictions) Save() error {
+	if m.FileID == 0 {
+		return fmt.Errorf("predictions: file id must not be empty")
+	}
+
+	return Db().Save(m).Error
+}
+
+// FindFilePredictions returns the raw image classification results of a file, if any.
+func FindFilePredictions(fileID uint) *FilePredictions {
+	result := FilePredictions{}
+
+	if err := Db().Where("file_id = ?", fileID).First(&result).Error; err != nil {
+		return nil
+	}
+
+	return &result
+}
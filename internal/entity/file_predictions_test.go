@@ -0,0 +1,52 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/classify"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilePredictions_TableName(t *testing.T) {
+	assert.Equal(t, "files_predictions", FilePredictions{}.TableName())
+}
+
+func TestFilePredictions_Save(t *testing.T) {
+	predictions := ImagePredictions{
+		{{Name: "tabby cat", Probability: 0.8}, {Name: "abacus", Probability: 0.1}},
+		{{Name: "dog", Probability: 0.6}},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		m := NewFilePredictions(999001, "nasnet", predictions)
+
+		if err := m.Save(); err != nil {
+			t.Fatal(err)
+		}
+
+		result := FindFilePredictions(999001)
+
+		if result == nil {
+			t.Fatal("result should not be nil")
+		}
+
+		assert.Equal(t, "nasnet", result.ModelName)
+		assert.Equal(t, predictions, result.Predictions)
+	})
+	t.Run("no file id", func(t *testing.T) {
+		assert.Error(t, NewFilePredictions(0, "nasnet", predictions).Save())
+	})
+	t.Run("not found", func(t *testing.T) {
+		assert.Nil(t, FindFilePredictions(999002))
+	})
+}
+
+func TestImagePredictions_Labels(t *testing.T) {
+	predictions := ImagePredictions{
+		{{Name: "tabby cat", Probability: 0.8}},
+		classify.Predictions{},
+		{{Name: "dog", Probability: 0.6}},
+	}
+
+	assert.Len(t, predictions.Labels(), 2)
+}
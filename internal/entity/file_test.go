@@ -4,6 +4,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/photoprism/photoprism/internal/detect"
 	"github.com/photoprism/photoprism/internal/face"
 
 	"github.com/photoprism/photoprism/pkg/fs"
@@ -258,6 +259,11 @@ func TestFile_Links(t *testing.T) {
 	})
 }
 
+func TestFile_Remote(t *testing.T) {
+	assert.True(t, (&File{FileRoot: RemoteRoot(2)}).Remote())
+	assert.False(t, (&File{FileRoot: RootOriginals}).Remote())
+}
+
 func TestFile_NoJPEG(t *testing.T) {
 	t.Run("true", func(t *testing.T) {
 		file := &File{Photo: nil, FileType: "xmp", FileSize: 500}
@@ -448,12 +454,53 @@ func TestFile_AddFaces(t *testing.T) {
 	})
 }
 
+func TestFile_AddObjects(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		label := FirstOrCreateLabel(NewLabel("Dog", 0))
+		file := &File{FileType: "jpg", FileWidth: 720, FileName: "ObjectsTest", PhotoID: 1000003}
+
+		objects := detect.Objects{
+			{Name: "dog", Score: 0.9, X: 0.3, Y: 0.4, W: 0.2, H: 0.3},
+			{Name: "dog", Score: 0.8, X: 0.7, Y: 0.6, W: 0.2, H: 0.3},
+			{Name: "car", Score: 0.1, X: 0.5, Y: 0.5, W: 0.2, H: 0.3},
+		}
+
+		file.AddObjects(objects)
+
+		if err := file.Save(); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, file.Markers, 2)
+		assert.Equal(t, 2, file.Markers.LabelCount("Dog"))
+		assert.Equal(t, label.LabelUID, file.Markers[0].RefUID)
+		assert.Equal(t, 90, file.Markers[0].MarkerScore)
+		assert.Equal(t, 0, file.FaceCount())
+	})
+}
+
 func TestFile_FaceCount(t *testing.T) {
 	t.Run("FileFixturesExampleBridge", func(t *testing.T) {
 		file := FileFixturesExampleBridge
 
 		result := file.FaceCount()
 
-		assert.GreaterOrEqual(t, result, 3)
+		// Label markers are not counted.
+		assert.Equal(t, 0, result)
+	})
+	t.Run("FaceMarker", func(t *testing.T) {
+		file := FileFixturesExampleBridge
+
+		m := NewMarker(file.ID, "", SrcImage, MarkerFace, 0.7, 0.7, 0.1, 0.1)
+
+		if err := m.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 1, file.FaceCount())
+
+		if err := Db().Delete(m).Error; err != nil {
+			t.Fatal(err)
+		}
 	})
 }
@@ -0,0 +1,94 @@
+package entity
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// FileTextMax is the max number of bytes of recognized text stored per file.
+const FileTextMax = 16384
+
+// FileText represents the text recognized in a file, e.g. a document scan, so that it can be searched.
+type FileText struct {
+	FileID      uint      `gorm:"primary_key;auto_increment:false" json:"FileID" yaml:"-"`
+	TextLang    string    `gorm:"type:VARBINARY(64);" json:"Lang" yaml:"-"`
+	TextContent string    `gorm:"type:TEXT;" json:"Content" yaml:"-"`
+	CreatedAt   time.Time `json:"CreatedAt" yaml:"-"`
+	UpdatedAt   time.Time `json:"UpdatedAt" yaml:"-"`
+}
+
+// TableName returns the entity database table name.
+func (FileText) TableName() string {
+	return "files_text"
+}
+
+// NewFileText returns new recognized text for a file, empty lines and surrounding whitespace are removed.
+func NewFileText(fileID uint, content, lang string) *FileText {
+	var lines []string
+
+	for _, line := range strings.Split(content, "\n") {
+		if line = strings.Join(strings.Fields(line), " "); line != "" {
+			lines = append(lines, line)
+		}
+	}
+
+	content = strings.Join(lines, "\n")
+
+	if len(content) > FileTextMax {
+		content = content[:FileTextMax]
+
+		// Don't cut multi-byte characters in half.
+		for !utf8.ValidString(content) {
+			content = content[:len(content)-1]
+		}
+	}
+
+	return &FileText{
+		FileID:      fileID,
+		TextLang:    lang,
+		TextContent: content,
+	}
+}
+
+// Empty tests if no text was recognized.
+func (m *FileText) Empty() bool {
+	return m.TextContent == ""
+}
+
+// Keywords returns the words of the recognized text that can be used as search keywords.
+func (m *FileText) Keywords() (result []string) {
+	for _, w := range txt.UniqueWords(txt.Keywords(m.TextContent)) {
+		// Skip short words, they are often recognition errors.
+		if utf8.RuneCountInString(w) < 3 {
+			continue
+		}
+
+		result = append(result, w)
+	}
+
+	return result
+}
+
+// Save inserts or updates the recognized text in the database.
+func (m *FileText) Save() error {
+	if m.FileID == 0 {
+		return fmt.Errorf("text: file id must not be empty")
+	}
+
+	return Db().Save(m).Error
+}
+
+// FindFileText returns the text recognized in a file, if any.
+func FindFileText(fileID uint) *FileText {
+	result := FileText{}
+
+	if err := Db().Where("file_id = ?", fileID).First(&result).Error; err != nil {
+		return nil
+	}
+
+	return &result
+}
@@ -0,0 +1,62 @@
+package entity
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileText_TableName(t *testing.T) {
+	assert.Equal(t, "files_text", FileText{}.TableName())
+}
+
+func TestNewFileText(t *testing.T) {
+	t.Run("whitespace", func(t *testing.T) {
+		m := NewFileText(1, "  GROCERY   STORE \n\n\t Total:  12.50 EUR \n\f", "eng")
+
+		assert.Equal(t, "GROCERY STORE\nTotal: 12.50 EUR", m.TextContent)
+		assert.Equal(t, "eng", m.TextLang)
+		assert.False(t, m.Empty())
+	})
+	t.Run("empty", func(t *testing.T) {
+		assert.True(t, NewFileText(1, " \n \f", "eng").Empty())
+	})
+	t.Run("max", func(t *testing.T) {
+		m := NewFileText(1, strings.Repeat("ä", FileTextMax), "deu")
+
+		assert.LessOrEqual(t, len(m.TextContent), FileTextMax)
+		assert.True(t, utf8.ValidString(m.TextContent))
+	})
+}
+
+func TestFileText_Keywords(t *testing.T) {
+	m := NewFileText(1, "Invoice No 42\nThe Grocery Store\nGrocery receipt", "eng")
+
+	assert.Equal(t, []string{"grocery", "invoice", "receipt", "store"}, m.Keywords())
+}
+
+func TestFileText_Save(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		m := NewFileText(999011, "Dear Sir or Madam", "eng")
+
+		if err := m.Save(); err != nil {
+			t.Fatal(err)
+		}
+
+		result := FindFileText(999011)
+
+		if result == nil {
+			t.Fatal("result should not be nil")
+		}
+
+		assert.Equal(t, "Dear Sir or Madam", result.TextContent)
+	})
+	t.Run("no file id", func(t *testing.T) {
+		assert.Error(t, NewFileText(0, "text", "eng").Save())
+	})
+	t.Run("not found", func(t *testing.T) {
+		assert.Nil(t, FindFileText(999012))
+	})
+}
@@ -7,6 +7,7 @@ import (
 var FolderFixtures = map[string]Folder{
 	"1990": {
 		FolderUID:     "dqo63pn35k2d495z",
+		Root:          RootOriginals,
 		Path:          "1990",
 		FolderYear:    1990,
 		FolderMonth:   0,
@@ -17,6 +18,7 @@ var FolderFixtures = map[string]Folder{
 	},
 	"1990/04": {
 		FolderUID:     "dqo63pn2f87f02xj",
+		Root:          RootOriginals,
 		Path:          "1990/04",
 		FolderYear:    1990,
 		FolderMonth:   4,
@@ -27,6 +29,7 @@ var FolderFixtures = map[string]Folder{
 	},
 	"2007/12": {
 		FolderUID:     "dqo63pn2f87f02oi",
+		Root:          RootOriginals,
 		Path:          "2007/12",
 		FolderYear:    2007,
 		FolderMonth:   12,
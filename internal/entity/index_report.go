@@ -0,0 +1,163 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// Index report types.
+const (
+	ReportIndex  = "index"
+	ReportImport = "import"
+)
+
+// IndexReportsKeep is the number of recent index and import reports that are kept.
+var IndexReportsKeep = 100
+
+type IndexReports []IndexReport
+
+// IndexReport represents the result of an index or import run, see IndexFailure for files that failed.
+type IndexReport struct {
+	ID           uint       `gorm:"primary_key" json:"ID" yaml:"-"`
+	ReportType   string     `gorm:"type:VARBINARY(16);index;" json:"Type" yaml:"-"`
+	ReportRoot   string     `gorm:"type:VARBINARY(16);" json:"Root" yaml:"-"`
+	ReportPath   string     `gorm:"type:VARBINARY(755);" json:"Path" yaml:"-"`
+	ReportMove   bool       `json:"Move" yaml:"-"`
+	ReportRetry  uint       `json:"Retry" yaml:"-"`
+	ReportCounts JobCounts  `gorm:"type:TEXT;" json:"Counts" yaml:"-"`
+	ReportFailed int        `json:"Failed" yaml:"-"`
+	ReportError  string     `gorm:"type:VARBINARY(512);" json:"Error" yaml:"-"`
+	StartedAt    time.Time  `json:"StartedAt" yaml:"-"`
+	FinishedAt   *time.Time `json:"FinishedAt" yaml:"-"`
+}
+
+// TableName returns the entity database table name.
+func (IndexReport) TableName() string {
+	return "index_reports"
+}
+
+// NewIndexReport returns a new index or import report that starts now.
+func NewIndexReport(reportType, rootName, path string) *IndexReport {
+	return &IndexReport{
+		ReportType:   reportType,
+		ReportRoot:   rootName,
+		ReportPath:   path,
+		ReportCounts: JobCounts{},
+		StartedAt:    Timestamp(),
+	}
+}
+
+// FindIndexReport returns the report with the given id, or nil if it was not found.
+func FindIndexReport(id uint) *IndexReport {
+	result := IndexReport{}
+
+	if err := Db().Where("id = ?", id).First(&result).Error; err != nil {
+		return nil
+	}
+
+	return &result
+}
+
+// Create inserts a new row to the database.
+func (m *IndexReport) Create() error {
+	return Db().Create(m).Error
+}
+
+// Finish saves the counts and the time when the run was finished.
+func (m *IndexReport) Finish(err error) error {
+	finishedAt := Timestamp()
+
+	m.FinishedAt = &finishedAt
+
+	if err != nil {
+		m.ReportError = txt.ClipBytes(err.Error(), txt.ClipError)
+	}
+
+	return Db().Save(m).Error
+}
+
+// PruneIndexReports deletes all but the most recent reports, together with their failures.
+func PruneIndexReports(keep int) error {
+	var ids []uint
+
+	if err := Db().Model(&IndexReport{}).Order("id DESC").Offset(keep).Limit(1).Pluck("id", &ids).Error; err != nil {
+		return err
+	} else if len(ids) == 0 {
+		return nil
+	}
+
+	if err := Db().Where("report_id <= ?", ids[0]).Delete(&IndexFailure{}).Error; err != nil {
+		return err
+	}
+
+	return Db().Where("id <= ?", ids[0]).Delete(&IndexReport{}).Error
+}
+
+// Running tests if the run has not finished yet.
+func (m *IndexReport) Running() bool {
+	return m.FinishedAt == nil
+}
+
+// Duration returns the run duration, or zero if it has not finished yet.
+func (m *IndexReport) Duration() time.Duration {
+	if m.FinishedAt == nil {
+		return 0
+	}
+
+	return m.FinishedAt.Sub(m.StartedAt)
+}
+
+type IndexFailures []IndexFailure
+
+// IndexFailure represents a file that could not be indexed or imported, including the reason and stage.
+type IndexFailure struct {
+	ID        uint      `gorm:"primary_key" json:"ID" yaml:"-"`
+	ReportID  uint      `gorm:"index;" json:"ReportID" yaml:"-"`
+	FileRoot  string    `gorm:"type:VARBINARY(16);" json:"Root" yaml:"-"`
+	FileName  string    `gorm:"type:VARBINARY(755);" json:"Name" yaml:"-"`
+	FileStage string    `gorm:"type:VARBINARY(16);" json:"Stage" yaml:"-"`
+	FileError string    `gorm:"type:VARBINARY(512);" json:"Error" yaml:"-"`
+	CreatedAt time.Time `json:"CreatedAt" yaml:"-"`
+}
+
+// TableName returns the entity database table name.
+func (IndexFailure) TableName() string {
+	return "index_failures"
+}
+
+// NewIndexFailure returns a new file failure for the given report.
+func NewIndexFailure(reportID uint, rootName, fileName, stage string, err error) *IndexFailure {
+	result := &IndexFailure{
+		ReportID:  reportID,
+		FileRoot:  rootName,
+		FileName:  fileName,
+		FileStage: stage,
+	}
+
+	if err != nil {
+		result.FileError = txt.ClipBytes(err.Error(), txt.ClipError)
+	}
+
+	return result
+}
+
+// Create inserts a new row to the database.
+func (m *IndexFailure) Create() error {
+	return Db().Create(m).Error
+}
+
+// AddIndexFailure adds a file that failed to a report that may already be finished,
+// e.g. in a deferred stage. Nothing is added if the report doesn't exist anymore.
+func AddIndexFailure(reportID uint, rootName, fileName, stage string, err error) error {
+	if FindIndexReport(reportID) == nil {
+		return nil
+	}
+
+	if err := NewIndexFailure(reportID, rootName, fileName, stage, err).Create(); err != nil {
+		return err
+	}
+
+	return Db().Model(&IndexReport{}).Where("id = ?", reportID).UpdateColumn("report_failed", gorm.Expr("report_failed + 1")).Error
+}
@@ -0,0 +1,126 @@
+package entity
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIndexReport_TableName(t *testing.T) {
+	assert.Equal(t, "index_reports", IndexReport{}.TableName())
+	assert.Equal(t, "index_failures", IndexFailure{}.TableName())
+}
+
+func TestIndexReport_Finish(t *testing.T) {
+	m := NewIndexReport(ReportImport, RootImport, "2021")
+	m.ReportMove = true
+
+	if err := m.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, m.Running())
+	assert.Equal(t, int64(0), int64(m.Duration()))
+
+	m.ReportCounts["added"] = 2
+
+	if err := m.Finish(errors.New("import canceled")); err != nil {
+		t.Fatal(err)
+	}
+
+	result := FindIndexReport(m.ID)
+
+	if result == nil {
+		t.Fatal("report not found")
+	}
+
+	assert.False(t, result.Running())
+	assert.True(t, result.ReportMove)
+	assert.Equal(t, "2021", result.ReportPath)
+	assert.Equal(t, JobCounts{"added": 2}, result.ReportCounts)
+	assert.Equal(t, "import canceled", result.ReportError)
+}
+
+func TestFindIndexReport(t *testing.T) {
+	assert.Nil(t, FindIndexReport(999999))
+}
+
+func TestNewIndexFailure(t *testing.T) {
+	m := NewIndexFailure(1, RootOriginals, "2021/broken.jpg", "database", errors.New("failed"))
+
+	assert.Equal(t, "failed", m.FileError)
+	assert.Equal(t, "database", m.FileStage)
+
+	if err := m.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.NotEmpty(t, m.ID)
+	assert.Empty(t, NewIndexFailure(1, RootOriginals, "2021/broken.jpg", "file", nil).FileError)
+
+	// Errors are clipped to the column size in bytes.
+	long := NewIndexFailure(1, RootOriginals, "2021/broken.jpg", "metadata", errors.New(strings.Repeat("ä", 300)))
+
+	assert.Equal(t, 512, len(long.FileError))
+	assert.True(t, utf8.ValidString(long.FileError))
+}
+
+func TestAddIndexFailure(t *testing.T) {
+	m := NewIndexReport(ReportIndex, RootOriginals, "/")
+
+	if err := m.Finish(nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := AddIndexFailure(m.ID, RootOriginals, "2021/deferred.jpg", "thumbnails", errors.New("failed")); err != nil {
+		t.Fatal(err)
+	}
+
+	if result := FindIndexReport(m.ID); assert.NotNil(t, result) {
+		assert.Equal(t, 1, result.ReportFailed)
+	}
+
+	// Reports that were pruned in the meantime are ignored.
+	assert.NoError(t, AddIndexFailure(999999, RootOriginals, "2021/deferred.jpg", "thumbnails", errors.New("failed")))
+}
+
+func TestPruneIndexReports(t *testing.T) {
+	var reports []*IndexReport
+
+	for i := 0; i < 3; i++ {
+		m := NewIndexReport(ReportIndex, RootOriginals, "/")
+
+		if err := m.Finish(nil); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := NewIndexFailure(m.ID, RootOriginals, "2021/broken.jpg", "file", nil).Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		reports = append(reports, m)
+	}
+
+	if err := PruneIndexReports(2); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Nil(t, FindIndexReport(reports[0].ID))
+	assert.NotNil(t, FindIndexReport(reports[1].ID))
+	assert.NotNil(t, FindIndexReport(reports[2].ID))
+
+	var count int
+
+	if err := Db().Model(&IndexFailure{}).Where("report_id = ?", reports[0].ID).Count(&count).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, 0, count)
+
+	// Nothing is deleted if there are fewer reports.
+	assert.NoError(t, PruneIndexReports(100))
+	assert.NotNil(t, FindIndexReport(reports[1].ID))
+}
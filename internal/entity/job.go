@@ -0,0 +1,177 @@
+package entity
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// Job run triggers.
+const (
+	JobTriggerSchedule = "schedule"
+	JobTriggerManual   = "manual"
+)
+
+// Job represents the persistent state of a scheduled background job.
+type Job struct {
+	JobName   string    `gorm:"type:VARBINARY(32);primary_key;auto_increment:false;" json:"Name" yaml:"-"`
+	JobPaused bool      `json:"Paused" yaml:"-"`
+	UpdatedAt time.Time `json:"UpdatedAt" yaml:"-"`
+}
+
+// TableName returns the entity database table name.
+func (Job) TableName() string {
+	return "jobs"
+}
+
+// FindJob returns the state of the job with the given name, the job is not paused if it was not found.
+func FindJob(name string) *Job {
+	result := Job{JobName: name}
+
+	if err := Db().Where("job_name = ?", name).First(&result).Error; err != nil {
+		return &Job{JobName: name}
+	}
+
+	return &result
+}
+
+// SetPaused pauses or resumes scheduled runs of the job.
+func (m *Job) SetPaused(paused bool) error {
+	m.JobPaused = paused
+
+	return Db().Save(m).Error
+}
+
+// JobCounts maps result names to counts, e.g. the number of indexed files, stored as JSON.
+type JobCounts map[string]int
+
+// Names returns the sorted result names.
+func (c JobCounts) Names() []string {
+	result := make([]string, 0, len(c))
+
+	for name := range c {
+		result = append(result, name)
+	}
+
+	sort.Strings(result)
+
+	return result
+}
+
+// Value implements the driver.Valuer interface.
+func (c JobCounts) Value() (driver.Value, error) {
+	if len(c) == 0 {
+		return "", nil
+	}
+
+	b, err := json.Marshal(c)
+
+	return string(b), err
+}
+
+// Scan implements the sql.Scanner interface.
+func (c *JobCounts) Scan(src interface{}) error {
+	var b []byte
+
+	switch v := src.(type) {
+	case nil:
+		return nil
+	case string:
+		b = []byte(v)
+	case []byte:
+		b = v
+	default:
+		return fmt.Errorf("job: can't scan %T into counts", src)
+	}
+
+	if len(b) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(b, c)
+}
+
+// JobRunsKeep is the number of recent runs that are kept in the history of each job.
+var JobRunsKeep = 100
+
+type JobRuns []JobRun
+
+// JobRun represents a single run of a background job in the job history.
+type JobRun struct {
+	ID         uint       `gorm:"primary_key" json:"ID" yaml:"-"`
+	JobName    string     `gorm:"type:VARBINARY(32);index;" json:"Name" yaml:"-"`
+	RunTrigger string     `gorm:"type:VARBINARY(16);" json:"Trigger" yaml:"-"`
+	RunCounts  JobCounts  `gorm:"type:TEXT;" json:"Counts" yaml:"-"`
+	RunError   string     `gorm:"type:VARBINARY(512);" json:"Error" yaml:"-"`
+	StartedAt  time.Time  `json:"StartedAt" yaml:"-"`
+	FinishedAt *time.Time `json:"FinishedAt" yaml:"-"`
+}
+
+// TableName returns the entity database table name.
+func (JobRun) TableName() string {
+	return "job_runs"
+}
+
+// NewJobRun returns a new job run that starts now.
+func NewJobRun(name, trigger string) *JobRun {
+	return &JobRun{
+		JobName:    name,
+		RunTrigger: trigger,
+		StartedAt:  Timestamp(),
+	}
+}
+
+// Create inserts a new row to the database.
+func (m *JobRun) Create() error {
+	return Db().Create(m).Error
+}
+
+// Finish saves the result and the time when the run was finished.
+func (m *JobRun) Finish(counts JobCounts, err error) error {
+	finishedAt := Timestamp()
+
+	m.FinishedAt = &finishedAt
+	m.RunCounts = counts
+
+	if err != nil {
+		m.RunError = txt.ClipBytes(err.Error(), txt.ClipError)
+	}
+
+	return Db().Save(m).Error
+}
+
+// Delete removes the run from the job history.
+func (m *JobRun) Delete() error {
+	return Db().Delete(m).Error
+}
+
+// PruneJobRuns deletes all but the most recent runs of a job from its history.
+func PruneJobRuns(name string, keep int) error {
+	var ids []uint
+
+	if err := Db().Model(&JobRun{}).Where("job_name = ?", name).Order("id DESC").Offset(keep).Limit(1).Pluck("id", &ids).Error; err != nil {
+		return err
+	} else if len(ids) == 0 {
+		return nil
+	}
+
+	return Db().Where("job_name = ? AND id <= ?", name, ids[0]).Delete(&JobRun{}).Error
+}
+
+// Running tests if the run has not finished yet.
+func (m *JobRun) Running() bool {
+	return m.FinishedAt == nil
+}
+
+// Duration returns the run duration, or zero if it has not finished yet.
+func (m *JobRun) Duration() time.Duration {
+	if m.FinishedAt == nil {
+		return 0
+	}
+
+	return m.FinishedAt.Sub(m.StartedAt)
+}
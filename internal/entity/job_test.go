@@ -0,0 +1,121 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJob_TableName(t *testing.T) {
+	assert.Equal(t, "jobs", Job{}.TableName())
+	assert.Equal(t, "job_runs", JobRun{}.TableName())
+}
+
+func TestFindJob(t *testing.T) {
+	t.Run("not found", func(t *testing.T) {
+		m := FindJob("job-not-found")
+		assert.Equal(t, "job-not-found", m.JobName)
+		assert.False(t, m.JobPaused)
+	})
+	t.Run("paused", func(t *testing.T) {
+		m := FindJob("job-paused")
+
+		if err := m.SetPaused(true); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, FindJob("job-paused").JobPaused)
+
+		if err := m.SetPaused(false); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.False(t, FindJob("job-paused").JobPaused)
+	})
+}
+
+func TestJobCounts_Scan(t *testing.T) {
+	var c JobCounts
+
+	assert.NoError(t, c.Scan(`{"indexed":5}`))
+	assert.Equal(t, JobCounts{"indexed": 5}, c)
+	assert.NoError(t, c.Scan(nil))
+	assert.Error(t, c.Scan(123))
+
+	v, err := JobCounts{}.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "", v)
+}
+
+func TestJobCounts_Names(t *testing.T) {
+	assert.Equal(t, []string{"files", "photos"}, JobCounts{"photos": 1, "files": 2}.Names())
+	assert.Empty(t, JobCounts{}.Names())
+}
+
+func TestJobRun_Finish(t *testing.T) {
+	m := NewJobRun("job-test", JobTriggerManual)
+
+	if err := m.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, m.Running())
+	assert.Equal(t, int64(0), int64(m.Duration()))
+
+	if err := m.Finish(JobCounts{"files": 3}, errors.New("failed")); err != nil {
+		t.Fatal(err)
+	}
+
+	var result JobRun
+
+	if err := Db().Where("id = ?", m.ID).First(&result).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	assert.False(t, result.Running())
+	assert.Equal(t, JobCounts{"files": 3}, result.RunCounts)
+	assert.Equal(t, "failed", result.RunError)
+	assert.Equal(t, JobTriggerManual, result.RunTrigger)
+}
+
+func TestPruneJobRuns(t *testing.T) {
+	var runs []*JobRun
+
+	for i := 0; i < 3; i++ {
+		m := NewJobRun("job-prune", JobTriggerSchedule)
+
+		if err := m.Finish(JobCounts{}, nil); err != nil {
+			t.Fatal(err)
+		}
+
+		runs = append(runs, m)
+	}
+
+	other := NewJobRun("job-other", JobTriggerSchedule)
+
+	if err := other.Finish(JobCounts{}, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := PruneJobRuns("job-prune", 2); err != nil {
+		t.Fatal(err)
+	}
+
+	var ids []uint
+
+	if err := Db().Model(&JobRun{}).Where("job_name = ?", "job-prune").Order("id").Pluck("id", &ids).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, []uint{runs[1].ID, runs[2].ID}, ids)
+
+	// Runs of other jobs are kept.
+	var count int
+
+	if err := Db().Model(&JobRun{}).Where("id = ?", other.ID).Count(&count).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, 1, count)
+}
@@ -24,6 +24,7 @@ type Label struct {
 	CustomSlug       string     `gorm:"type:VARBINARY(255);index;" json:"CustomSlug" yaml:"-"`
 	LabelName        string     `gorm:"type:VARCHAR(255);" json:"Name" yaml:"Name"`
 	LabelPriority    int        `json:"Priority" yaml:"Priority,omitempty"`
+	RulePriority     *int       `json:"-" yaml:"-"`
 	LabelFavorite    bool       `json:"Favorite" yaml:"Favorite,omitempty"`
 	LabelDescription string     `gorm:"type:TEXT;" json:"Description" yaml:"Description,omitempty"`
 	LabelNotes       string     `gorm:"type:TEXT;" json:"Notes" yaml:"Notes,omitempty"`
@@ -86,6 +87,7 @@ func (m *Label) Create() error {
 func (m *Label) Delete() error {
 	Db().Where("label_id = ? OR category_id = ?", m.ID, m.ID).Delete(&Category{})
 	Db().Where("label_id = ?", m.ID).Delete(&PhotoLabel{})
+	Db().Where("label_id = ?", m.ID).Delete(&LabelSynonym{})
 	return Db().Delete(m).Error
 }
 
@@ -169,9 +171,8 @@ func (m *Label) UpdateClassify(label classify.Label) error {
 	save := false
 	db := Db()
 
-	if m.LabelPriority != label.Priority {
-		m.LabelPriority = label.Priority
-		save = true
+	if err := m.SetRulePriority(label.Priority); err != nil {
+		return err
 	}
 
 	if m.CustomSlug == "" {
@@ -194,23 +195,39 @@ func (m *Label) UpdateClassify(label classify.Label) error {
 	}
 
 	// Add categories
-	for _, category := range label.Categories {
-		sn := FirstOrCreateLabel(NewLabel(txt.Title(category), -3))
+	return m.AddCategories(label.Categories)
+}
 
-		if sn == nil {
-			continue
-		}
+// SetRulePriority updates the priority as defined by a label rule, unless it has been changed
+// manually since the rule was last applied.
+func (m *Label) SetRulePriority(priority int) error {
+	values := make(map[string]interface{})
 
-		if sn.Deleted() {
-			continue
+	if m.RulePriority == nil || *m.RulePriority == m.LabelPriority {
+		if m.LabelPriority != priority {
+			values["LabelPriority"] = priority
 		}
+	}
 
-		if err := db.Model(m).Association("LabelCategories").Append(sn).Error; err != nil {
-			return err
-		}
+	if m.RulePriority == nil || *m.RulePriority != priority {
+		values["RulePriority"] = priority
 	}
 
-	return nil
+	if len(values) == 0 {
+		return nil
+	}
+
+	if p, ok := values["LabelPriority"]; ok {
+		m.LabelPriority = p.(int)
+	}
+
+	m.RulePriority = &priority
+
+	if m.ID == 0 {
+		return nil
+	}
+
+	return UnscopedDb().Model(m).UpdateColumns(values).Error
 }
 
 // Links returns all share links for this entity.
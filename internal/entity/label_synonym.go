@@ -0,0 +1,89 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gosimple/slug"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+type LabelSynonyms []LabelSynonym
+
+// LabelSynonym represents an alternative label name that is also found by search, e.g. the name of a
+// classifier label that a rule maps to another label, or a translation added with the API.
+type LabelSynonym struct {
+	ID            uint      `gorm:"primary_key" json:"ID" yaml:"-"`
+	LabelID       uint      `gorm:"index;" json:"LabelID" yaml:"-"`
+	SynonymName   string    `gorm:"type:VARCHAR(255);" json:"Name" yaml:"Name"`
+	SynonymSlug   string    `gorm:"type:VARBINARY(255);unique_index:idx_label_synonyms_slug;" json:"Slug" yaml:"-"`
+	SynonymLocale string    `gorm:"type:VARBINARY(16);unique_index:idx_label_synonyms_slug;" json:"Locale" yaml:"Locale,omitempty"`
+	CreatedAt     time.Time `json:"CreatedAt" yaml:"-"`
+}
+
+// TableName returns the entity database table name.
+func (LabelSynonym) TableName() string {
+	return "label_synonyms"
+}
+
+// NewLabelSynonym returns a new synonym for a label, the locale is optional.
+func NewLabelSynonym(labelID uint, name, locale string) *LabelSynonym {
+	name = txt.Clip(name, txt.ClipDefault)
+
+	if locale != "" {
+		locale = i18n.ParseLocale(locale).Locale()
+	}
+
+	return &LabelSynonym{
+		LabelID:       labelID,
+		SynonymName:   name,
+		SynonymSlug:   slug.Make(txt.Clip(name, txt.ClipSlug)),
+		SynonymLocale: locale,
+	}
+}
+
+// Create inserts the synonym to the database.
+func (m *LabelSynonym) Create() error {
+	if m.LabelID == 0 {
+		return fmt.Errorf("synonym: label id must not be empty")
+	} else if m.SynonymSlug == "" {
+		return fmt.Errorf("synonym: name must not be empty")
+	}
+
+	return Db().Create(m).Error
+}
+
+// Delete removes the synonym from the database.
+func (m *LabelSynonym) Delete() error {
+	return Db().Delete(m).Error
+}
+
+// FindLabelSynonym returns the synonym with the given id, if it exists.
+func FindLabelSynonym(id uint) *LabelSynonym {
+	result := LabelSynonym{}
+
+	if err := Db().Where("id = ?", id).First(&result).Error; err != nil {
+		return nil
+	}
+
+	return &result
+}
+
+// FindLabelSynonymSlug returns the synonym with the given slug and locale, if it exists.
+func FindLabelSynonymSlug(synonymSlug, locale string) *LabelSynonym {
+	result := LabelSynonym{}
+
+	if err := Db().Where("synonym_slug = ? AND synonym_locale = ?", synonymSlug, locale).First(&result).Error; err != nil {
+		return nil
+	}
+
+	return &result
+}
+
+// Synonyms returns the label synonyms sorted by locale and name.
+func (m *Label) Synonyms() (result LabelSynonyms) {
+	Db().Where("label_id = ?", m.ID).Order("synonym_locale, synonym_name").Find(&result)
+
+	return result
+}
@@ -0,0 +1,42 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewLabelSynonym(t *testing.T) {
+	m := NewLabelSynonym(1, "Katze", "DE")
+
+	assert.Equal(t, uint(1), m.LabelID)
+	assert.Equal(t, "Katze", m.SynonymName)
+	assert.Equal(t, "katze", m.SynonymSlug)
+	assert.Equal(t, "de", m.SynonymLocale)
+	assert.Equal(t, "", NewLabelSynonym(1, "Kitty", "").SynonymLocale)
+}
+
+func TestLabelSynonym_Create(t *testing.T) {
+	label := FirstOrCreateLabel(NewLabel("Synonym Cat", 0))
+
+	t.Run("success", func(t *testing.T) {
+		m := NewLabelSynonym(label.ID, "Synonym Katze", "de")
+
+		if err := m.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "Synonym Katze", FindLabelSynonym(m.ID).SynonymName)
+		assert.Len(t, label.Synonyms(), 1)
+
+		if err := m.Delete(); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Nil(t, FindLabelSynonym(m.ID))
+	})
+	t.Run("empty", func(t *testing.T) {
+		assert.Error(t, NewLabelSynonym(label.ID, "", "de").Create())
+		assert.Error(t, NewLabelSynonym(0, "Katze", "de").Create())
+	})
+}
@@ -0,0 +1,157 @@
+package entity
+
+import (
+	"fmt"
+
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// Labels are organized in a hierarchy using the categories table: the category is the parent
+// of the label. Search expands labels to all of their children, transitively.
+
+// LabelAncestorIDs returns the ids of all parent labels, transitively.
+func LabelAncestorIDs(ids ...uint) []uint {
+	return labelRelations(ids, "label_id", "category_id")
+}
+
+// LabelDescendantIDs returns the given label ids and the ids of all their children, transitively.
+func LabelDescendantIDs(ids ...uint) []uint {
+	return append(ids, labelRelations(ids, "category_id", "label_id")...)
+}
+
+// labelRelations follows relations in the categories table and returns the ids found, without duplicates.
+func labelRelations(ids []uint, from, to string) (result []uint) {
+	found := make(map[uint]bool, len(ids))
+
+	for _, id := range ids {
+		found[id] = true
+	}
+
+	for len(ids) > 0 {
+		var next []uint
+
+		if err := Db().Model(&Category{}).Where(from+" IN (?)", ids).Pluck(to, &next).Error; err != nil {
+			log.Errorf("label: %s", err)
+			return result
+		}
+
+		ids = nil
+
+		for _, id := range next {
+			if found[id] {
+				continue
+			}
+
+			found[id] = true
+			ids = append(ids, id)
+			result = append(result, id)
+		}
+	}
+
+	return result
+}
+
+// Parents returns the direct parent labels.
+func (m *Label) Parents() (result Labels) {
+	Db().Where("id IN (SELECT category_id FROM categories WHERE label_id = ?)", m.ID).Order("custom_slug").Find(&result)
+
+	return result
+}
+
+// Children returns the direct child labels.
+func (m *Label) Children() (result Labels) {
+	Db().Where("id IN (SELECT label_id FROM categories WHERE category_id = ?)", m.ID).Order("custom_slug").Find(&result)
+
+	return result
+}
+
+// AddParent adds a parent label, unless it would create a cycle in the hierarchy.
+func (m *Label) AddParent(parent *Label) error {
+	if parent == nil || parent.ID == 0 {
+		return fmt.Errorf("label: parent not found")
+	} else if parent.ID == m.ID {
+		return fmt.Errorf("label: %s can't be its own parent", txt.Quote(m.LabelName))
+	} else if m.IsAncestorOf(parent) {
+		return fmt.Errorf("label: %s is a child of %s", txt.Quote(parent.LabelName), txt.Quote(m.LabelName))
+	}
+
+	return m.addCategory(parent, SrcManual)
+}
+
+// AddCategories adds parent labels by name, e.g. as configured in the label rules.
+func (m *Label) AddCategories(categories []string) error {
+	_, err := m.addCategories(categories)
+
+	return err
+}
+
+// ReplaceCategories replaces the parent labels defined by label rules, e.g. after the rules have been changed.
+// Parent labels added manually are kept.
+func (m *Label) ReplaceCategories(categories []string) error {
+	ids, err := m.addCategories(categories)
+
+	if err != nil {
+		return err
+	}
+
+	stmt := Db().Where("label_id = ? AND category_src = ?", m.ID, SrcAuto)
+
+	if len(ids) > 0 {
+		stmt = stmt.Where("category_id NOT IN (?)", ids)
+	}
+
+	return stmt.Delete(&Category{}).Error
+}
+
+// addCategories adds parent labels by name and returns their ids.
+func (m *Label) addCategories(categories []string) (ids []uint, err error) {
+	for _, category := range categories {
+		sn := FirstOrCreateLabel(NewLabel(txt.Title(category), -3))
+
+		if sn == nil || sn.Deleted() {
+			continue
+		}
+
+		if sn.ID == m.ID || m.IsAncestorOf(sn) {
+			log.Warnf("label: %s can't be a category of %s", txt.Quote(sn.LabelName), txt.Quote(m.LabelName))
+			continue
+		}
+
+		if err := m.addCategory(sn, SrcAuto); err != nil {
+			return ids, err
+		}
+
+		ids = append(ids, sn.ID)
+	}
+
+	return ids, nil
+}
+
+// addCategory adds a parent label, parents added manually can't be replaced by label rules.
+func (m *Label) addCategory(parent *Label, src string) error {
+	result := Category{}
+
+	if err := Db().Where("label_id = ? AND category_id = ?", m.ID, parent.ID).First(&result).Error; err != nil {
+		return Db().Create(&Category{LabelID: m.ID, CategoryID: parent.ID, CategorySrc: src}).Error
+	} else if src == SrcManual && result.CategorySrc != SrcManual {
+		return Db().Model(&result).UpdateColumn("category_src", SrcManual).Error
+	}
+
+	return nil
+}
+
+// IsAncestorOf tests if the label is a parent of the other label, transitively.
+func (m *Label) IsAncestorOf(other *Label) bool {
+	for _, id := range LabelAncestorIDs(other.ID) {
+		if id == m.ID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// RemoveParent removes a parent label.
+func (m *Label) RemoveParent(parent *Label) error {
+	return Db().Where("label_id = ? AND category_id = ?", m.ID, parent.ID).Delete(&Category{}).Error
+}
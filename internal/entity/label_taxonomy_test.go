@@ -0,0 +1,73 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLabel_AddParent(t *testing.T) {
+	animal := FirstOrCreateLabel(NewLabel("Taxonomy Animal", 0))
+	cat := FirstOrCreateLabel(NewLabel("Taxonomy Cat", 0))
+	kitten := FirstOrCreateLabel(NewLabel("Taxonomy Kitten", 0))
+
+	if err := cat.AddParent(animal); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := kitten.AddParent(cat); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("descendants", func(t *testing.T) {
+		ids := LabelDescendantIDs(animal.ID)
+		assert.ElementsMatch(t, []uint{animal.ID, cat.ID, kitten.ID}, ids)
+	})
+	t.Run("ancestors", func(t *testing.T) {
+		ids := LabelAncestorIDs(kitten.ID)
+		assert.ElementsMatch(t, []uint{animal.ID, cat.ID}, ids)
+		assert.True(t, animal.IsAncestorOf(kitten))
+		assert.False(t, kitten.IsAncestorOf(animal))
+	})
+	t.Run("parents and children", func(t *testing.T) {
+		assert.Len(t, cat.Parents(), 1)
+		assert.Equal(t, "Taxonomy Kitten", cat.Children()[0].LabelName)
+	})
+	t.Run("self", func(t *testing.T) {
+		assert.Error(t, cat.AddParent(cat))
+	})
+	t.Run("cycle", func(t *testing.T) {
+		assert.Error(t, animal.AddParent(kitten))
+		assert.Len(t, animal.Parents(), 0)
+	})
+	t.Run("not found", func(t *testing.T) {
+		assert.Error(t, cat.AddParent(nil))
+	})
+	t.Run("remove", func(t *testing.T) {
+		if err := kitten.RemoveParent(cat); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.ElementsMatch(t, []uint{animal.ID, cat.ID}, LabelDescendantIDs(animal.ID))
+	})
+}
+
+func TestLabel_AddCategories(t *testing.T) {
+	label := FirstOrCreateLabel(NewLabel("Taxonomy Dog", 0))
+
+	if err := label.AddCategories([]string{"taxonomy pet", "taxonomy dog"}); err != nil {
+		t.Fatal(err)
+	}
+
+	parents := label.Parents()
+
+	assert.Len(t, parents, 1)
+	assert.Equal(t, "Taxonomy Pet", parents[0].LabelName)
+
+	// Cycles are skipped.
+	if err := parents[0].AddCategories([]string{"taxonomy dog"}); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(t, parents[0].Parents(), 0)
+}
@@ -245,3 +245,79 @@ func TestLabel_Update(t *testing.T) {
 	})
 
 }
+
+func TestLabel_SetRulePriority(t *testing.T) {
+	label := FirstOrCreateLabel(NewLabel("Rule Priority", 0))
+
+	if err := label.Update("RulePriority", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	label.RulePriority = nil
+
+	if err := label.SetRulePriority(2); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, 2, label.LabelPriority)
+
+	if err := label.SetRulePriority(3); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, 3, label.LabelPriority)
+
+	// Changed manually.
+	if err := label.Update("LabelPriority", 5); err != nil {
+		t.Fatal(err)
+	}
+
+	label = FindLabel("rule priority")
+
+	if err := label.SetRulePriority(4); err != nil {
+		t.Fatal(err)
+	}
+
+	label = FindLabel("rule priority")
+
+	assert.Equal(t, 5, label.LabelPriority)
+
+	if assert.NotNil(t, label.RulePriority) {
+		assert.Equal(t, 4, *label.RulePriority)
+	}
+}
+
+func TestLabel_ReplaceCategories(t *testing.T) {
+	label := FirstOrCreateLabel(NewLabel("Replace Categories", 0))
+
+	if err := label.ReplaceCategories([]string{"animal", "pet"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := label.ReplaceCategories([]string{"pet"}); err != nil {
+		t.Fatal(err)
+	}
+
+	var categories []*Label
+
+	if err := Db().Model(label).Association("LabelCategories").Find(&categories).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	if assert.Len(t, categories, 1) {
+		assert.Equal(t, "Pet", categories[0].LabelName)
+	}
+
+	// Parent labels added manually are kept.
+	if err := label.AddParent(FirstOrCreateLabel(NewLabel("Replace Manual", 0))); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := label.ReplaceCategories(nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if parents := label.Parents(); assert.Len(t, parents, 1) {
+		assert.Equal(t, "Replace Manual", parents[0].LabelName)
+	}
+}
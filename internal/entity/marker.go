@@ -1,13 +1,16 @@
 package entity
 
 import (
+	"errors"
 	"fmt"
+	"math"
 	"time"
 
 	"github.com/photoprism/photoprism/internal/form"
 	"github.com/photoprism/photoprism/pkg/txt"
 	"github.com/ulule/deepcopier"
 
+	"github.com/photoprism/photoprism/internal/detect"
 	"github.com/photoprism/photoprism/internal/face"
 )
 
@@ -17,6 +20,15 @@ const (
 	MarkerLabel   = "Label"
 )
 
+// Marker errors caused by invalid form data.
+var (
+	ErrInvalidMarkerPosition = errors.New("marker: invalid position")
+	ErrInvalidMarkerSize     = errors.New("marker: invalid size")
+)
+
+// MarkerOverlap is the minimum intersection over union (IoU) of label markers that show the same object.
+const MarkerOverlap = 0.5
+
 // Marker represents an image marker point.
 type Marker struct {
 	ID            uint    `gorm:"primary_key" json:"ID" yaml:"-"`
@@ -26,7 +38,9 @@ type Marker struct {
 	MarkerSrc     string  `gorm:"type:VARBINARY(8);default:'';" json:"Src" yaml:"Src,omitempty"`
 	MarkerType    string  `gorm:"type:VARBINARY(8);default:'';" json:"Type" yaml:"Type"`
 	MarkerScore   int     `gorm:"type:SMALLINT" json:"Score" yaml:"Score"`
+	MarkerQuality int     `gorm:"type:SMALLINT" json:"Quality" yaml:"Quality,omitempty"`
 	MarkerInvalid bool    `json:"Invalid" yaml:"Invalid,omitempty"`
+	MarkerDrawn   bool    `json:"Drawn" yaml:"Drawn,omitempty"`
 	MarkerLabel   string  `gorm:"type:VARCHAR(255);" json:"Label" yaml:"Label,omitempty"`
 	MarkerMeta    string  `gorm:"type:TEXT;" json:"Meta" yaml:"Meta,omitempty"`
 	Embedding     string  `gorm:"type:TEXT;" json:"Embedding" yaml:"Embedding,omitempty"`
@@ -43,7 +57,7 @@ var UnknownMarker = NewMarker(0, "", SrcAuto, MarkerUnknown, 0, 0, 0, 0)
 
 // TableName returns the entity database table name.
 func (Marker) TableName() string {
-	return "markers_dev"
+	return "markers"
 }
 
 // NewMarker creates a new entity.
@@ -69,12 +83,23 @@ func NewFaceMarker(f face.Face, fileID uint, refUID string) *Marker {
 	m := NewMarker(fileID, refUID, SrcImage, MarkerFace, pos.X, pos.Y, pos.W, pos.H)
 
 	m.MarkerScore = f.Score
+	m.MarkerQuality = f.QualityScore()
 	m.MarkerMeta = string(f.RelativeLandmarksJSON())
 	m.Embedding = string(f.EmbeddingJSON())
 
 	return m
 }
 
+// NewObjectMarker creates a new label marker for an object found by object detection.
+func NewObjectMarker(obj detect.Object, fileID uint, labelName, refUID string) *Marker {
+	m := NewMarker(fileID, refUID, SrcImage, MarkerLabel, obj.X, obj.Y, obj.W, obj.H)
+
+	m.MarkerScore = int(math.Round(float64(obj.Score * 100)))
+	m.MarkerLabel = labelName
+
+	return m
+}
+
 // Updates multiple columns in the database.
 func (m *Marker) Updates(values interface{}) error {
 	return UnscopedDb().Model(m).UpdateColumns(values).Error
@@ -85,16 +110,67 @@ func (m *Marker) Update(attr string, value interface{}) error {
 	return UnscopedDb().Model(m).UpdateColumn(attr, value).Error
 }
 
+// CreateMarker adds a new marker based on form data, e.g. for a face drawn manually.
+func CreateMarker(fileID uint, f form.Marker) (*Marker, error) {
+	switch f.MarkerType {
+	case MarkerFace, MarkerLabel:
+	default:
+		return nil, fmt.Errorf("marker: invalid type")
+	}
+
+	f.MarkerSrc = SrcManual
+
+	m := NewMarker(fileID, "", SrcManual, f.MarkerType, f.X, f.Y, f.W, f.H)
+
+	// Markers drawn manually can be deleted, other markers are flagged as invalid.
+	m.MarkerDrawn = true
+
+	if !m.ValidSize() {
+		return nil, ErrInvalidMarkerSize
+	}
+
+	if err := m.SaveForm(f); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
 // SaveForm updates the entity using form data and stores it in the database.
 func (m *Marker) SaveForm(f form.Marker) error {
+	moved := f.X != m.X || f.Y != m.Y || f.W != m.W || f.H != m.H
+	labelName := m.MarkerLabel
+
 	if err := deepcopier.Copy(m).From(f); err != nil {
 		return err
 	}
 
+	if moved {
+		if !m.ValidSize() {
+			return ErrInvalidMarkerSize
+		}
+
+		// Prevents the marker from being overwritten by automatic detection.
+		m.MarkerSrc = SrcManual
+
+		// Face embeddings must be updated for the new position.
+		if m.MarkerType == MarkerFace {
+			m.Embedding = ""
+		}
+	}
+
 	if f.MarkerLabel != "" {
 		m.MarkerLabel = txt.Title(txt.Clip(f.MarkerLabel, txt.ClipKeyword))
 	}
 
+	if f.NoFace {
+		m.SetNoFace()
+	} else if m.MarkerType == MarkerLabel && m.MarkerLabel != "" && (m.MarkerLabel != labelName || m.RefUID == "") {
+		if label := FirstOrCreateLabel(NewLabel(m.MarkerLabel, 0)); label != nil {
+			m.RefUID = label.LabelUID
+		}
+	}
+
 	if err := m.Save(); err != nil {
 		return err
 	}
@@ -102,10 +178,81 @@ func (m *Marker) SaveForm(f form.Marker) error {
 	return nil
 }
 
+// SetNoFace flags a face marker as invalid because it doesn't show a face.
+func (m *Marker) SetNoFace() {
+	if m.MarkerType != MarkerFace {
+		return
+	}
+
+	m.MarkerInvalid = true
+	m.MarkerSrc = SrcManual
+	m.MarkerLabel = ""
+	m.RefUID = ""
+	m.Embedding = ""
+}
+
+// Matches tests if the other marker is of the same type and shows the same face or object.
+// Label markers match if they have the same label, or were edited manually, and overlap.
+func (m *Marker) Matches(other Marker) bool {
+	const d = 0.07
+
+	if m.MarkerType != other.MarkerType {
+		return false
+	} else if m.MarkerType == MarkerLabel {
+		return (m.MarkerLabel == other.MarkerLabel || m.MarkerSrc == SrcManual) && m.Overlap(other) >= MarkerOverlap
+	}
+
+	return other.X > (m.X-d) && other.X < (m.X+d) && other.Y > (m.Y-d) && other.Y < (m.Y+d)
+}
+
+// Overlap returns the intersection over union (IoU) of both marker areas.
+func (m *Marker) Overlap(other Marker) float64 {
+	w := math.Min(float64(m.X+m.W/2), float64(other.X+other.W/2)) - math.Max(float64(m.X-m.W/2), float64(other.X-other.W/2))
+	h := math.Min(float64(m.Y+m.H/2), float64(other.Y+other.H/2)) - math.Max(float64(m.Y-m.H/2), float64(other.Y-other.H/2))
+
+	if w <= 0 || h <= 0 {
+		return 0
+	}
+
+	intersection := w * h
+	union := float64(m.W*m.H) + float64(other.W*other.H) - intersection
+
+	if union <= 0 {
+		return 0
+	}
+
+	return intersection / union
+}
+
+// ValidSize tests if the marker width and height are valid.
+func (m *Marker) ValidSize() bool {
+	return m.W > 0 && m.H > 0 && m.W <= 1 && m.H <= 1
+}
+
+// Invalidate flags the marker as invalid instead of deleting it, so that detection doesn't add it again.
+func (m *Marker) Invalidate() error {
+	m.MarkerInvalid = true
+	m.MarkerSrc = SrcManual
+
+	return m.Updates(map[string]interface{}{
+		"MarkerInvalid": true,
+		"MarkerSrc":     SrcManual,
+	})
+}
+
+// Delete removes the marker from the database.
+func (m *Marker) Delete() error {
+	if m.ID < 1 {
+		return fmt.Errorf("marker: id must not be empty")
+	}
+
+	return Db().Delete(m).Error
+}
+
 // Save updates the existing or inserts a new row.
 func (m *Marker) Save() error {
 	if m.X == 0 || m.Y == 0 || m.X > 1 || m.Y > 1 || m.X < -1 || m.Y < -1 {
-		return fmt.Errorf("marker: invalid position")
+		return ErrInvalidMarkerPosition
 	}
 
 	return Db().Save(m).Error
@@ -114,7 +261,7 @@ func (m *Marker) Save() error {
 // Create inserts a new row to the database.
 func (m *Marker) Create() error {
 	if m.X == 0 || m.Y == 0 || m.X > 1 || m.Y > 1 || m.X < -1 || m.Y < -1 {
-		return fmt.Errorf("marker: invalid position")
+		return ErrInvalidMarkerPosition
 	}
 
 	return Db().Create(m).Error
@@ -122,36 +269,31 @@ func (m *Marker) Create() error {
 
 // UpdateOrCreateMarker updates a marker in the database or creates a new one if needed.
 func UpdateOrCreateMarker(m *Marker) (*Marker, error) {
-	const d = 0.07
-
-	result := Marker{}
-
 	if m.ID > 0 {
 		err := m.Save()
 		log.Debugf("faces: saved marker %d for file %d", m.ID, m.FileID)
 		return m, err
-	} else if err := Db().Where(`file_id = ? AND x > ? AND x < ? AND y > ? AND y < ?`,
-		m.FileID, m.X-d, m.X+d, m.Y-d, m.Y+d).First(&result).Error; err == nil {
-
+	} else if result := findMatchingMarker(m); result != nil {
 		if SrcPriority[m.MarkerSrc] < SrcPriority[result.MarkerSrc] {
 			// Ignore.
-			return &result, nil
+			return result, nil
 		}
 
 		err := result.Updates(map[string]interface{}{
-			"X":           m.X,
-			"Y":           m.Y,
-			"W":           m.W,
-			"H":           m.H,
-			"MarkerScore": m.MarkerScore,
-			"MarkerMeta":  m.MarkerMeta,
-			"Embedding":   m.Embedding,
-			"RefUID":      m.RefUID,
+			"X":             m.X,
+			"Y":             m.Y,
+			"W":             m.W,
+			"H":             m.H,
+			"MarkerScore":   m.MarkerScore,
+			"MarkerQuality": m.MarkerQuality,
+			"MarkerMeta":    m.MarkerMeta,
+			"Embedding":     m.Embedding,
+			"RefUID":        m.RefUID,
 		})
 
 		log.Debugf("faces: updated existing marker %d for file %d", result.ID, result.FileID)
 
-		return &result, err
+		return result, err
 	} else if err := m.Create(); err != nil {
 		log.Debugf("faces: added marker %d for file %d", m.ID, m.FileID)
 		return m, err
@@ -159,3 +301,30 @@ func UpdateOrCreateMarker(m *Marker) (*Marker, error) {
 
 	return m, nil
 }
+
+// findMatchingMarker returns the existing marker of the same file that matches the marker, if any.
+func findMatchingMarker(m *Marker) *Marker {
+	const d = 0.07
+
+	var found Markers
+
+	stmt := Db().Where("file_id = ? AND marker_type = ?", m.FileID, m.MarkerType)
+
+	// Label markers are matched by overlap, so that objects next to each other are kept apart.
+	if m.MarkerType != MarkerLabel {
+		stmt = stmt.Where("x > ? AND x < ? AND y > ? AND y < ?", m.X-d, m.X+d, m.Y-d, m.Y+d)
+	}
+
+	if err := stmt.Order("id").Find(&found).Error; err != nil {
+		log.Errorf("marker: %s (find matching)", err)
+		return nil
+	}
+
+	for i := range found {
+		if found[i].Matches(*m) {
+			return &found[i]
+		}
+	}
+
+	return nil
+}
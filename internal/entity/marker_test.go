@@ -3,12 +3,15 @@ package entity
 import (
 	"testing"
 
+	"github.com/photoprism/photoprism/internal/detect"
+	"github.com/photoprism/photoprism/internal/face"
+	"github.com/photoprism/photoprism/internal/form"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestMarker_TableName(t *testing.T) {
 	fileSync := &Marker{}
-	assert.Equal(t, "markers_dev", fileSync.TableName())
+	assert.Equal(t, "markers", fileSync.TableName())
 }
 
 func TestNewMarker(t *testing.T) {
@@ -43,6 +46,36 @@ func TestUpdateOrCreateMarker(t *testing.T) {
 			t.Errorf("ID should be > 0")
 		}
 	})
+	t.Run("adjacent objects", func(t *testing.T) {
+		const fileID = 1000099
+
+		Db().Where("file_id = ?", fileID).Delete(&Marker{})
+
+		dog1 := NewMarker(fileID, "", SrcImage, MarkerLabel, 0.3, 0.5, 0.1, 0.2)
+		dog1.MarkerLabel = "Dog"
+		dog2 := NewMarker(fileID, "", SrcImage, MarkerLabel, 0.36, 0.5, 0.1, 0.2)
+		dog2.MarkerLabel = "Dog"
+		cat := NewMarker(fileID, "", SrcImage, MarkerLabel, 0.3, 0.5, 0.1, 0.2)
+		cat.MarkerLabel = "Cat"
+		moved := NewMarker(fileID, "", SrcImage, MarkerLabel, 0.31, 0.51, 0.1, 0.2)
+		moved.MarkerLabel = "Dog"
+
+		for _, m := range []*Marker{dog1, dog2, cat, moved} {
+			if _, err := UpdateOrCreateMarker(m); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		markers, err := FindMarkers(fileID)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, markers, 3)
+		assert.Equal(t, 2, markers.LabelCount("Dog"))
+		assert.Equal(t, 1, markers.LabelCount("Cat"))
+	})
 }
 
 func TestMarker_Updates(t *testing.T) {
@@ -68,6 +101,36 @@ func TestMarker_Updates(t *testing.T) {
 			t.Errorf("ID should be > 0")
 		}
 	})
+	t.Run("adjacent objects", func(t *testing.T) {
+		const fileID = 1000099
+
+		Db().Where("file_id = ?", fileID).Delete(&Marker{})
+
+		dog1 := NewMarker(fileID, "", SrcImage, MarkerLabel, 0.3, 0.5, 0.1, 0.2)
+		dog1.MarkerLabel = "Dog"
+		dog2 := NewMarker(fileID, "", SrcImage, MarkerLabel, 0.36, 0.5, 0.1, 0.2)
+		dog2.MarkerLabel = "Dog"
+		cat := NewMarker(fileID, "", SrcImage, MarkerLabel, 0.3, 0.5, 0.1, 0.2)
+		cat.MarkerLabel = "Cat"
+		moved := NewMarker(fileID, "", SrcImage, MarkerLabel, 0.31, 0.51, 0.1, 0.2)
+		moved.MarkerLabel = "Dog"
+
+		for _, m := range []*Marker{dog1, dog2, cat, moved} {
+			if _, err := UpdateOrCreateMarker(m); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		markers, err := FindMarkers(fileID)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, markers, 3)
+		assert.Equal(t, 2, markers.LabelCount("Dog"))
+		assert.Equal(t, 1, markers.LabelCount("Cat"))
+	})
 }
 
 func TestMarker_Update(t *testing.T) {
@@ -92,6 +155,36 @@ func TestMarker_Update(t *testing.T) {
 			t.Errorf("ID should be > 0")
 		}
 	})
+	t.Run("adjacent objects", func(t *testing.T) {
+		const fileID = 1000099
+
+		Db().Where("file_id = ?", fileID).Delete(&Marker{})
+
+		dog1 := NewMarker(fileID, "", SrcImage, MarkerLabel, 0.3, 0.5, 0.1, 0.2)
+		dog1.MarkerLabel = "Dog"
+		dog2 := NewMarker(fileID, "", SrcImage, MarkerLabel, 0.36, 0.5, 0.1, 0.2)
+		dog2.MarkerLabel = "Dog"
+		cat := NewMarker(fileID, "", SrcImage, MarkerLabel, 0.3, 0.5, 0.1, 0.2)
+		cat.MarkerLabel = "Cat"
+		moved := NewMarker(fileID, "", SrcImage, MarkerLabel, 0.31, 0.51, 0.1, 0.2)
+		moved.MarkerLabel = "Dog"
+
+		for _, m := range []*Marker{dog1, dog2, cat, moved} {
+			if _, err := UpdateOrCreateMarker(m); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		markers, err := FindMarkers(fileID)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, markers, 3)
+		assert.Equal(t, 2, markers.LabelCount("Dog"))
+		assert.Equal(t, 1, markers.LabelCount("Cat"))
+	})
 }
 
 func TestMarker_Save(t *testing.T) {
@@ -132,3 +225,203 @@ func TestMarker_Save(t *testing.T) {
 		t.Logf("FILES: %#v", p.Files)
 	})
 }
+
+func TestNewObjectMarker(t *testing.T) {
+	obj := detect.Object{Name: "dog", Score: 0.876, X: 0.3, Y: 0.4, W: 0.2, H: 0.1}
+
+	m := NewObjectMarker(obj, 1000003, "Dog", "lt9k3pw1wowuy3c5")
+
+	assert.Equal(t, uint(1000003), m.FileID)
+	assert.Equal(t, "lt9k3pw1wowuy3c5", m.RefUID)
+	assert.Equal(t, SrcImage, m.MarkerSrc)
+	assert.Equal(t, MarkerLabel, m.MarkerType)
+	assert.Equal(t, "Dog", m.MarkerLabel)
+	assert.Equal(t, 88, m.MarkerScore)
+	assert.Equal(t, float32(0.3), m.X)
+	assert.Equal(t, float32(0.1), m.H)
+}
+
+func TestNewFaceMarker(t *testing.T) {
+	f := face.Face{
+		Rows:    1000,
+		Cols:    1000,
+		Score:   150,
+		Quality: 85,
+		Face:    face.NewPoint("face", 400, 500, 200),
+	}
+
+	m := NewFaceMarker(f, 1000003, "")
+
+	assert.Equal(t, uint(1000003), m.FileID)
+	assert.Equal(t, MarkerFace, m.MarkerType)
+	assert.Equal(t, 150, m.MarkerScore)
+	assert.Equal(t, 85, m.MarkerQuality)
+	assert.Equal(t, float32(0.5), m.X)
+	assert.Equal(t, float32(0.4), m.Y)
+}
+
+func TestCreateMarker(t *testing.T) {
+	t.Run("Label", func(t *testing.T) {
+		f := form.Marker{MarkerType: MarkerLabel, MarkerLabel: "cow", X: 0.4, Y: 0.5, W: 0.2, H: 0.1}
+
+		m, err := CreateMarker(1000003, f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NotEmpty(t, m.ID)
+		assert.Equal(t, SrcManual, m.MarkerSrc)
+		assert.Equal(t, "Cow", m.MarkerLabel)
+		assert.Equal(t, LabelFixtures.Get("cow").LabelUID, m.RefUID)
+	})
+	t.Run("Face", func(t *testing.T) {
+		f := form.Marker{MarkerType: MarkerFace, MarkerLabel: "jane doe", X: 0.6, Y: 0.3, W: 0.1, H: 0.1}
+
+		m, err := CreateMarker(1000003, f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, m.MarkerDrawn)
+		assert.Equal(t, "Jane Doe", m.MarkerLabel)
+		assert.Equal(t, "", m.RefUID)
+	})
+	t.Run("InvalidType", func(t *testing.T) {
+		_, err := CreateMarker(1000003, form.Marker{MarkerType: "Foo", X: 0.4, Y: 0.5, W: 0.2, H: 0.1})
+
+		assert.Error(t, err)
+	})
+	t.Run("InvalidSize", func(t *testing.T) {
+		_, err := CreateMarker(1000003, form.Marker{MarkerType: MarkerFace, X: 0.4, Y: 0.5, W: 0, H: 0.1})
+
+		assert.Error(t, err)
+	})
+}
+
+func TestMarker_SaveForm(t *testing.T) {
+	m := NewMarker(1000003, "", SrcImage, MarkerFace, 0.3, 0.3, 0.1, 0.1)
+	m.Embedding = "[0.1]"
+
+	if err := m.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Move", func(t *testing.T) {
+		f, err := form.NewMarker(m)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		f.X = 0.35
+		f.W = 0.2
+
+		if err := m.SaveForm(f); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, SrcManual, m.MarkerSrc)
+		assert.Equal(t, float32(0.35), m.X)
+		assert.Equal(t, float32(0.2), m.W)
+		assert.Equal(t, "", m.Embedding)
+	})
+	t.Run("InvalidSize", func(t *testing.T) {
+		f, err := form.NewMarker(m)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		f.H = 1.5
+
+		assert.Equal(t, ErrInvalidMarkerSize, m.SaveForm(f))
+	})
+	t.Run("NoFace", func(t *testing.T) {
+		f, err := form.NewMarker(m)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		f.H = 0.1
+		f.MarkerLabel = "Foo"
+		f.NoFace = true
+
+		if err := m.SaveForm(f); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, m.MarkerInvalid)
+		assert.Equal(t, "", m.MarkerLabel)
+	})
+}
+
+func TestMarker_Overlap(t *testing.T) {
+	m1 := NewMarker(1000000, "", SrcImage, MarkerLabel, 0.3, 0.5, 0.2, 0.2)
+	m2 := NewMarker(1000000, "", SrcImage, MarkerLabel, 0.4, 0.5, 0.2, 0.2)
+	m3 := NewMarker(1000000, "", SrcImage, MarkerLabel, 0.6, 0.5, 0.2, 0.2)
+
+	assert.InDelta(t, 1, m1.Overlap(*m1), 0.0001)
+	assert.InDelta(t, 1.0/3.0, m1.Overlap(*m2), 0.0001)
+	assert.Equal(t, float64(0), m1.Overlap(*m3))
+}
+
+func TestMarker_Invalidate(t *testing.T) {
+	Db().Where("marker_label = ?", "Invalidate Test").Delete(&Marker{})
+
+	m := NewMarker(1000000, "", SrcImage, MarkerLabel, 0.7, 0.2, 0.1, 0.1)
+	m.MarkerLabel = "Invalidate Test"
+
+	if err := m.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := m.Invalidate(); err != nil {
+		t.Fatal(err)
+	}
+
+	found, err := FindMarkers(1000000)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, marker := range found {
+		if marker.ID == m.ID {
+			assert.True(t, marker.MarkerInvalid)
+			assert.Equal(t, SrcManual, marker.MarkerSrc)
+		}
+	}
+
+	// Detection doesn't add the marker again.
+	detected := NewMarker(1000000, "", SrcImage, MarkerLabel, 0.7, 0.2, 0.1, 0.1)
+	detected.MarkerLabel = "Invalidate Test"
+
+	if result, err := UpdateOrCreateMarker(detected); err != nil {
+		t.Fatal(err)
+	} else {
+		assert.Equal(t, m.ID, result.ID)
+		assert.True(t, result.MarkerInvalid)
+	}
+}
+
+func TestMarker_ValidSize(t *testing.T) {
+	assert.True(t, NewMarker(1, "", SrcManual, MarkerFace, 0.5, 0.5, 0.1, 1).ValidSize())
+	assert.False(t, NewMarker(1, "", SrcManual, MarkerFace, 0.5, 0.5, 0, 0.1).ValidSize())
+	assert.False(t, NewMarker(1, "", SrcManual, MarkerFace, 0.5, 0.5, 0.1, 1.1).ValidSize())
+}
+
+func TestMarker_Delete(t *testing.T) {
+	m := NewMarker(1000003, "", SrcManual, MarkerLabel, 0.7, 0.7, 0.1, 0.1)
+
+	assert.Error(t, m.Delete())
+
+	if err := m.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.NoError(t, m.Delete())
+	assert.Error(t, Db().Where("id = ?", m.ID).First(&Marker{}).Error)
+}
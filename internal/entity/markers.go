@@ -17,12 +17,10 @@ func (m Markers) Save(fileID uint) error {
 	return nil
 }
 
-// Contains returns true if a marker at the same position already exists.
+// Contains returns true if a marker of the same face or object already exists.
 func (m Markers) Contains(m2 Marker) bool {
-	const d = 0.07
-
 	for _, m1 := range m {
-		if m2.X > (m1.X-d) && m2.X < (m1.X+d) && m2.Y > (m1.Y-d) && m2.Y < (m1.Y+d) {
+		if m1.Matches(m2) {
 			return true
 		}
 	}
@@ -42,6 +40,18 @@ func (m Markers) FaceCount() int {
 	return result
 }
 
+// LabelCount returns the number of valid label markers with the given label name.
+func (m Markers) LabelCount(name string) int {
+	result := 0
+	for _, marker := range m {
+		if !marker.MarkerInvalid && marker.MarkerType == MarkerLabel && marker.MarkerLabel == name {
+			result++
+		}
+	}
+
+	return result
+}
+
 // FindMarkers returns all markers for a given file id.
 func FindMarkers(fileID uint) (Markers, error) {
 	m := Markers{}
@@ -15,6 +15,26 @@ func TestMarkers_Contains(t *testing.T) {
 
 	assert.True(t, m.Contains(m2))
 	assert.False(t, m.Contains(m3))
+
+	m4 := *NewMarker(1000000, "lt9k3pw1wowuy1c2", SrcImage, MarkerLabel, 0.308313, 0.206914, 0.655556, 0.655556)
+
+	assert.False(t, m.Contains(m4))
+
+	// Label markers are matched by label and overlap.
+	dog := *NewMarker(1000000, "", SrcImage, MarkerLabel, 0.3, 0.5, 0.1, 0.2)
+	dog.MarkerLabel = "Dog"
+	sameDog := *NewMarker(1000000, "", SrcImage, MarkerLabel, 0.31, 0.51, 0.1, 0.2)
+	sameDog.MarkerLabel = "Dog"
+	otherDog := *NewMarker(1000000, "", SrcImage, MarkerLabel, 0.36, 0.5, 0.1, 0.2)
+	otherDog.MarkerLabel = "Dog"
+	cat := *NewMarker(1000000, "", SrcImage, MarkerLabel, 0.3, 0.5, 0.1, 0.2)
+	cat.MarkerLabel = "Cat"
+
+	m = Markers{dog}
+
+	assert.True(t, m.Contains(sameDog))
+	assert.False(t, m.Contains(otherDog))
+	assert.False(t, m.Contains(cat))
 }
 
 func TestMarkers_FaceCount(t *testing.T) {
@@ -27,3 +47,17 @@ func TestMarkers_FaceCount(t *testing.T) {
 
 	assert.Equal(t, 2, m.FaceCount())
 }
+
+func TestMarkers_LabelCount(t *testing.T) {
+	m1 := *NewMarker(1000000, "", SrcImage, MarkerLabel, 0.3, 0.2, 0.3, 0.3)
+	m1.MarkerLabel = "Dog"
+	m2 := *NewMarker(1000000, "", SrcImage, MarkerLabel, 0.7, 0.6, 0.2, 0.2)
+	m2.MarkerLabel = "Dog"
+	m3 := *NewMarker(1000000, "", SrcImage, MarkerFace, 0.5, 0.5, 0.1, 0.1)
+	m3.MarkerLabel = "Dog"
+
+	m := Markers{m1, m2, m3}
+
+	assert.Equal(t, 2, m.LabelCount("Dog"))
+	assert.Equal(t, 0, m.LabelCount("Cat"))
+}
@@ -0,0 +1,419 @@
+package entity
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+// SchemaVersion represents a versioned migration that has been applied to the database.
+type SchemaVersion struct {
+	Version   int       `gorm:"primary_key;auto_increment:false" json:"Version" yaml:"Version"`
+	Name      string    `gorm:"type:VARCHAR(255);" json:"Name" yaml:"Name"`
+	AppliedAt time.Time `json:"AppliedAt" yaml:"AppliedAt"`
+}
+
+// TableName returns the entity database table name.
+func (SchemaVersion) TableName() string {
+	return "schema_versions"
+}
+
+// SchemaVersions returns the applied migrations by version.
+func SchemaVersions() (result map[int]SchemaVersion, err error) {
+	var versions []SchemaVersion
+
+	result = make(map[int]SchemaVersion)
+
+	if err := UnscopedDb().AutoMigrate(&SchemaVersion{}).Error; err != nil {
+		return result, err
+	}
+
+	if err := UnscopedDb().Order("version").Find(&versions).Error; err != nil {
+		return result, err
+	}
+
+	for _, v := range versions {
+		result[v.Version] = v
+	}
+
+	return result, nil
+}
+
+// MigrationFunc performs a Go data migration using the given database transaction.
+type MigrationFunc func(db *gorm.DB) error
+
+// MigrationDryRun writes the changes a Go data migration would make to w, without changing the database.
+type MigrationDryRun func(db *gorm.DB, w io.Writer) error
+
+// Migration represents a numbered database schema or data migration.
+type Migration struct {
+	Version    int
+	Name       string
+	Up         []string        // SQL statements executed when upgrading.
+	Down       []string        // SQL statements executed when rolling back.
+	UpFunc     MigrationFunc   // Go data migration executed after the Up statements.
+	DownFunc   MigrationFunc   // Go data migration executed before the Down statements.
+	UpDryRun   MigrationDryRun // Describes the changes of UpFunc in dry-run mode, required if UpFunc is set.
+	DownDryRun MigrationDryRun // Describes the changes of DownFunc in dry-run mode, required if DownFunc is set.
+}
+
+type Migrations []Migration
+
+// MigrationList contains all versioned migrations, new migrations must be appended using the next version number.
+var MigrationList = Migrations{
+	{
+		Version:    1,
+		Name:       "rename markers_dev table to markers",
+		UpFunc:     renameTable("markers_dev", "markers"),
+		DownFunc:   renameTable("markers", "markers_dev"),
+		UpDryRun:   renameTableDryRun("markers_dev", "markers"),
+		DownDryRun: renameTableDryRun("markers", "markers_dev"),
+	},
+	{
+		Version:  2,
+		Name:     "set photo root of existing photos to originals",
+		UpFunc:   setPhotoRoot,
+		UpDryRun: setPhotoRootDryRun,
+	},
+	{
+		Version:  3,
+		Name:     "set place bounds based on their cells",
+		UpFunc:   setPlaceBounds,
+		UpDryRun: setPlaceBoundsDryRun,
+	},
+}
+
+// setPhotoRootSQL updates the photo root of existing photos.
+const setPhotoRootSQL = "UPDATE photos SET photo_root = ? WHERE photo_root IS NULL OR photo_root = ''"
+
+// setPhotoRoot adds the photo_root column if needed and sets it to originals for existing photos.
+func setPhotoRoot(db *gorm.DB) error {
+	if !db.HasTable(&Photo{}) {
+		return nil
+	} else if err := db.AutoMigrate(&Photo{}).Error; err != nil {
+		return err
+	}
+
+	return db.Exec(setPhotoRootSQL, RootOriginals).Error
+}
+
+// setPhotoRootDryRun describes the changes of setPhotoRoot.
+func setPhotoRootDryRun(db *gorm.DB, w io.Writer) error {
+	if !db.HasTable(&Photo{}) {
+		_, err := fmt.Fprintln(w, "-- photos table does not exist, nothing to update")
+		return err
+	}
+
+	for _, s := range addColumnsSQL(db, &Photo{}) {
+		if _, err := fmt.Fprintf(w, "%s;\n", s); err != nil {
+			return err
+		}
+	}
+
+	_, err := fmt.Fprintf(w, "%s;\n", strings.Replace(setPhotoRootSQL, "?", fmt.Sprintf("'%s'", RootOriginals), 1))
+
+	return err
+}
+
+// setPlaceBounds adds the place bounds columns if needed and sets them based on the cells assigned to each place.
+func setPlaceBounds(db *gorm.DB) error {
+	if !db.HasTable(&Place{}) {
+		return nil
+	} else if err := db.AutoMigrate(&Place{}).Error; err != nil {
+		return err
+	}
+
+	return UpdatePlaceBounds(db)
+}
+
+// setPlaceBoundsDryRun describes the changes of setPlaceBounds.
+func setPlaceBoundsDryRun(db *gorm.DB, w io.Writer) error {
+	if !db.HasTable(&Place{}) {
+		_, err := fmt.Fprintln(w, "-- places table does not exist, nothing to update")
+		return err
+	}
+
+	for _, s := range addColumnsSQL(db, &Place{}) {
+		if _, err := fmt.Fprintf(w, "%s;\n", s); err != nil {
+			return err
+		}
+	}
+
+	_, err := fmt.Fprintln(w, "-- set place_south, place_west, place_north and place_east to the bounds of the cells assigned to each place")
+
+	return err
+}
+
+// addColumnsSQL returns the statements that AutoMigrate executes to add missing columns to an existing table.
+func addColumnsSQL(db *gorm.DB, value interface{}) (result []string) {
+	scope := db.NewScope(value)
+	tableName := scope.TableName()
+
+	for _, field := range scope.GetModelStruct().StructFields {
+		if field.IsNormal && !scope.Dialect().HasColumn(tableName, field.DBName) {
+			result = append(result, fmt.Sprintf("ALTER TABLE %s ADD %s %s", scope.QuotedTableName(), scope.Quote(field.DBName), scope.Dialect().DataTypeOf(field)))
+		}
+	}
+
+	return result
+}
+
+// renameTableSQL returns the statement that renames a table, or an empty string if there is nothing to rename.
+func renameTableSQL(db *gorm.DB, from, to string) string {
+	if !db.HasTable(from) || db.HasTable(to) {
+		return ""
+	}
+
+	return fmt.Sprintf("ALTER TABLE %s RENAME TO %s", from, to)
+}
+
+// renameTable returns a migration that renames a table if it exists.
+func renameTable(from, to string) MigrationFunc {
+	return func(db *gorm.DB) error {
+		if s := renameTableSQL(db, from, to); s != "" {
+			return db.Exec(s).Error
+		}
+
+		return nil
+	}
+}
+
+// renameTableDryRun describes the changes of renameTable.
+func renameTableDryRun(from, to string) MigrationDryRun {
+	return func(db *gorm.DB, w io.Writer) error {
+		var err error
+
+		if s := renameTableSQL(db, from, to); s != "" {
+			_, err = fmt.Fprintf(w, "%s;\n", s)
+		} else {
+			_, err = fmt.Fprintf(w, "-- table %s does not exist or %s exists already, nothing to rename\n", from, to)
+		}
+
+		return err
+	}
+}
+
+// Validate returns an error if the migration versions are not positive and in ascending order,
+// or if a Go data migration can't be described in dry-run mode.
+func (list Migrations) Validate() error {
+	for i, m := range list {
+		if m.Version < 1 {
+			return fmt.Errorf("migrate: invalid version %d", m.Version)
+		} else if i > 0 && m.Version <= list[i-1].Version {
+			return fmt.Errorf("migrate: version %d must be greater than %d", m.Version, list[i-1].Version)
+		} else if m.UpFunc != nil && m.UpDryRun == nil || m.DownFunc != nil && m.DownDryRun == nil {
+			return fmt.Errorf("migrate: version %d has no dry run for its go data migration", m.Version)
+		}
+	}
+
+	return nil
+}
+
+// Latest returns the latest migration version, or 0 if the list is empty.
+func (list Migrations) Latest() int {
+	if len(list) == 0 {
+		return 0
+	}
+
+	return list[len(list)-1].Version
+}
+
+// Baseline marks all migrations as applied without executing them,
+// e.g. for new databases that are created with the latest schema.
+func (list Migrations) Baseline() error {
+	applied, err := SchemaVersions()
+
+	if err != nil {
+		return err
+	}
+
+	for _, m := range list {
+		if _, ok := applied[m.Version]; ok {
+			continue
+		}
+
+		v := SchemaVersion{Version: m.Version, Name: m.Name, AppliedAt: Timestamp()}
+
+		if err := UnscopedDb().Create(&v).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// MigrateUp applies pending migrations up to the target version, or all if target is 0.
+// In dry-run mode, the SQL statements are written to w instead of being executed.
+func (list Migrations) MigrateUp(target int, dryRun bool, w io.Writer) (result Migrations, err error) {
+	if err := list.Validate(); err != nil {
+		return result, err
+	}
+
+	applied, err := SchemaVersions()
+
+	if err != nil {
+		return result, err
+	}
+
+	for _, m := range list {
+		if target > 0 && m.Version > target {
+			break
+		} else if _, ok := applied[m.Version]; ok {
+			continue
+		}
+
+		if err := m.apply(true, dryRun, w); err != nil {
+			return result, err
+		}
+
+		result = append(result, m)
+	}
+
+	return result, nil
+}
+
+// MigrateDown rolls back the given number of applied migrations, starting with the most recent one.
+// In dry-run mode, the SQL statements are written to w instead of being executed.
+func (list Migrations) MigrateDown(steps int, dryRun bool, w io.Writer) (result Migrations, err error) {
+	if err := list.Validate(); err != nil {
+		return result, err
+	}
+
+	applied, err := SchemaVersions()
+
+	if err != nil {
+		return result, err
+	}
+
+	versions := make([]int, 0, len(applied))
+
+	for v := range applied {
+		versions = append(versions, v)
+	}
+
+	sort.Sort(sort.Reverse(sort.IntSlice(versions)))
+
+	migrations := make(map[int]Migration, len(list))
+
+	for _, m := range list {
+		migrations[m.Version] = m
+	}
+
+	for _, v := range versions {
+		if len(result) >= steps {
+			break
+		}
+
+		m, ok := migrations[v]
+
+		if !ok {
+			return result, fmt.Errorf("migrate: unknown version %d can't be rolled back", v)
+		}
+
+		if err := m.apply(false, dryRun, w); err != nil {
+			return result, err
+		}
+
+		result = append(result, m)
+	}
+
+	return result, nil
+}
+
+// apply executes the migration in a transaction and updates the schema version table.
+func (m Migration) apply(up, dryRun bool, w io.Writer) error {
+	var statements []string
+	var fn MigrationFunc
+	var dry MigrationDryRun
+
+	if up {
+		statements, fn, dry = m.Up, m.UpFunc, m.UpDryRun
+	} else {
+		statements, fn, dry = m.Down, m.DownFunc, m.DownDryRun
+	}
+
+	if dryRun {
+		if w == nil {
+			return nil
+		}
+
+		if up {
+			fmt.Fprintf(w, "-- %d up: %s\n", m.Version, m.Name)
+		} else {
+			fmt.Fprintf(w, "-- %d down: %s\n", m.Version, m.Name)
+		}
+
+		// Go data migrations run before the statements when rolling back.
+		if !up && fn != nil {
+			if err := dry(UnscopedDb(), w); err != nil {
+				return err
+			}
+		}
+
+		for _, s := range statements {
+			fmt.Fprintf(w, "%s;\n", s)
+		}
+
+		if up && fn != nil {
+			if err := dry(UnscopedDb(), w); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	tx := UnscopedDb().Begin()
+
+	if err := tx.Error; err != nil {
+		return err
+	}
+
+	if err := m.exec(tx, up, statements, fn); err != nil {
+		tx.Rollback()
+		return fmt.Errorf("migrate: %s (version %d)", err, m.Version)
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return err
+	}
+
+	if up {
+		log.Infof("migrate: applied version %d, %s", m.Version, m.Name)
+	} else {
+		log.Infof("migrate: rolled back version %d, %s", m.Version, m.Name)
+	}
+
+	return nil
+}
+
+// exec runs the statements and Go data migration using the given transaction.
+func (m Migration) exec(tx *gorm.DB, up bool, statements []string, fn MigrationFunc) error {
+	if !up && fn != nil {
+		if err := fn(tx); err != nil {
+			return err
+		}
+	}
+
+	for _, s := range statements {
+		if err := tx.Exec(s).Error; err != nil {
+			return err
+		}
+	}
+
+	if up && fn != nil {
+		if err := fn(tx); err != nil {
+			return err
+		}
+	}
+
+	if up {
+		return tx.Create(&SchemaVersion{Version: m.Version, Name: m.Name, AppliedAt: Timestamp()}).Error
+	}
+
+	return tx.Delete(&SchemaVersion{}, "version = ?", m.Version).Error
+}
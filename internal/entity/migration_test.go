@@ -0,0 +1,238 @@
+package entity
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/jinzhu/gorm"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSchemaVersion_TableName(t *testing.T) {
+	assert.Equal(t, "schema_versions", SchemaVersion{}.TableName())
+}
+
+func TestMigrations_Validate(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		assert.NoError(t, MigrationList.Validate())
+		assert.NoError(t, Migrations{{Version: 1}, {Version: 3}}.Validate())
+	})
+	t.Run("invalid version", func(t *testing.T) {
+		assert.Error(t, Migrations{{Version: 0}}.Validate())
+	})
+	t.Run("not ascending", func(t *testing.T) {
+		assert.Error(t, Migrations{{Version: 2}, {Version: 1}}.Validate())
+		assert.Error(t, Migrations{{Version: 1}, {Version: 1}}.Validate())
+	})
+	t.Run("no dry run", func(t *testing.T) {
+		fn := func(db *gorm.DB) error { return nil }
+
+		assert.Error(t, Migrations{{Version: 1, UpFunc: fn}}.Validate())
+		assert.Error(t, Migrations{{Version: 1, DownFunc: fn}}.Validate())
+	})
+}
+
+func TestMigrations_Latest(t *testing.T) {
+	assert.Equal(t, 0, Migrations{}.Latest())
+	assert.Equal(t, 3, Migrations{{Version: 1}, {Version: 3}}.Latest())
+}
+
+func TestMigrations_MigrateUp(t *testing.T) {
+	list := Migrations{
+		{
+			Version: 900001,
+			Name:    "create test table",
+			Up:      []string{"CREATE TABLE migrations_test (id INTEGER, name VARCHAR(64))"},
+			Down:    []string{"DROP TABLE migrations_test"},
+		},
+		{
+			Version: 900002,
+			Name:    "insert test data",
+			UpFunc: func(db *gorm.DB) error {
+				return db.Exec("INSERT INTO migrations_test (id, name) VALUES (1, 'foo')").Error
+			},
+			DownFunc: func(db *gorm.DB) error {
+				return db.Exec("DELETE FROM migrations_test WHERE id = 1").Error
+			},
+			UpDryRun: func(db *gorm.DB, w io.Writer) error {
+				_, err := fmt.Fprintln(w, "-- insert foo")
+				return err
+			},
+			DownDryRun: func(db *gorm.DB, w io.Writer) error {
+				_, err := fmt.Fprintln(w, "-- delete foo")
+				return err
+			},
+		},
+	}
+
+	t.Run("dry run", func(t *testing.T) {
+		var out bytes.Buffer
+
+		result, err := list.MigrateUp(0, true, &out)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, result, 2)
+		assert.Contains(t, out.String(), "-- 900001 up: create test table\nCREATE TABLE migrations_test (id INTEGER, name VARCHAR(64));\n")
+		assert.Contains(t, out.String(), "-- 900002 up: insert test data\n-- insert foo\n")
+		assert.False(t, Db().HasTable("migrations_test"))
+	})
+	t.Run("target version", func(t *testing.T) {
+		result, err := list.MigrateUp(900001, false, nil)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, result, 1)
+		assert.True(t, Db().HasTable("migrations_test"))
+
+		applied, err := SchemaVersions()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "create test table", applied[900001].Name)
+		assert.NotContains(t, applied, 900002)
+	})
+	t.Run("all", func(t *testing.T) {
+		result, err := list.MigrateUp(0, false, nil)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, result, 1)
+		assert.Equal(t, 900002, result[0].Version)
+
+		count := RowCount{}
+		Db().Raw("SELECT COUNT(*) AS count FROM migrations_test").Scan(&count)
+		assert.Equal(t, 1, count.Count)
+
+		result, err = list.MigrateUp(0, false, nil)
+
+		assert.NoError(t, err)
+		assert.Len(t, result, 0)
+	})
+	t.Run("down dry run", func(t *testing.T) {
+		var out bytes.Buffer
+
+		result, err := list.MigrateDown(2, true, &out)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, result, 2)
+		assert.Equal(t, "-- 900002 down: insert test data\n-- delete foo\n-- 900001 down: create test table\nDROP TABLE migrations_test;\n", out.String())
+		assert.True(t, Db().HasTable("migrations_test"))
+	})
+	t.Run("down", func(t *testing.T) {
+		result, err := list.MigrateDown(2, false, nil)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, result, 2)
+		assert.Equal(t, 900002, result[0].Version)
+		assert.False(t, Db().HasTable("migrations_test"))
+
+		applied, err := SchemaVersions()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NotContains(t, applied, 900001)
+		assert.NotContains(t, applied, 900002)
+	})
+	t.Run("failed", func(t *testing.T) {
+		failing := Migrations{{Version: 900003, Name: "invalid", Up: []string{"CREATE TABLE migrations_test (id INTEGER)", "INVALID SQL"}}}
+
+		_, err := failing.MigrateUp(0, false, nil)
+
+		assert.Error(t, err)
+
+		applied, err := SchemaVersions()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NotContains(t, applied, 900003)
+	})
+}
+
+func TestMigrations_Baseline(t *testing.T) {
+	list := Migrations{{Version: 900010, Name: "baseline", Up: []string{"INVALID SQL"}}}
+
+	if err := list.Baseline(); err != nil {
+		t.Fatal(err)
+	}
+
+	applied, err := SchemaVersions()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Contains(t, applied, 900010)
+
+	result, err := list.MigrateUp(0, false, nil)
+
+	assert.NoError(t, err)
+	assert.Len(t, result, 0)
+
+	Db().Delete(&SchemaVersion{}, "version = ?", 900010)
+}
+
+func TestRenameTable(t *testing.T) {
+	if err := Db().Exec("CREATE TABLE rename_test_a (id INTEGER)").Error; err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+
+	assert.NoError(t, renameTableDryRun("rename_test_a", "rename_test_b")(Db(), &out))
+	assert.Equal(t, "ALTER TABLE rename_test_a RENAME TO rename_test_b;\n", out.String())
+
+	assert.NoError(t, renameTable("rename_test_a", "rename_test_b")(Db()))
+	assert.False(t, Db().HasTable("rename_test_a"))
+	assert.True(t, Db().HasTable("rename_test_b"))
+
+	// Tables that don't exist are skipped.
+	assert.NoError(t, renameTable("rename_test_a", "rename_test_b")(Db()))
+
+	Db().Exec("DROP TABLE rename_test_b")
+}
+
+func TestSetPhotoRootDryRun(t *testing.T) {
+	var out bytes.Buffer
+
+	assert.NoError(t, setPhotoRootDryRun(Db(), &out))
+	assert.Contains(t, out.String(), "UPDATE photos SET photo_root = '/' WHERE photo_root IS NULL OR photo_root = '';\n")
+}
+
+func TestAddColumnsSQL(t *testing.T) {
+	if err := Db().Exec("CREATE TABLE columns_tests (id INTEGER)").Error; err != nil {
+		t.Fatal(err)
+	}
+
+	defer Db().Exec("DROP TABLE columns_tests")
+
+	type ColumnsTest struct {
+		ID   uint
+		Name string `gorm:"type:VARCHAR(64);"`
+	}
+
+	result := addColumnsSQL(Db(), &ColumnsTest{})
+
+	assert.Equal(t, []string{"ALTER TABLE \"columns_tests\" ADD \"name\" VARCHAR(64)"}, result)
+	assert.Empty(t, addColumnsSQL(Db(), &Photo{}))
+}
@@ -54,6 +54,7 @@ type Photo struct {
 	TitleSrc         string       `gorm:"type:VARBINARY(8);" json:"TitleSrc" yaml:"TitleSrc,omitempty"`
 	PhotoDescription string       `gorm:"type:TEXT;" json:"Description" yaml:"Description,omitempty"`
 	DescriptionSrc   string       `gorm:"type:VARBINARY(8);" json:"DescriptionSrc" yaml:"DescriptionSrc,omitempty"`
+	PhotoRoot        string       `gorm:"type:VARBINARY(16);default:'/';" json:"Root" yaml:"-"`
 	PhotoPath        string       `gorm:"type:VARBINARY(500);index:idx_photos_path_name;" json:"Path" yaml:"-"`
 	PhotoName        string       `gorm:"type:VARBINARY(255);index:idx_photos_path_name;" json:"Name" yaml:"-"`
 	OriginalName     string       `gorm:"type:VARBINARY(755);" json:"OriginalName" yaml:"OriginalName,omitempty"`
@@ -353,7 +354,7 @@ func (m *Photo) BeforeCreate(scope *gorm.Scope) error {
 	return scope.SetColumn("PhotoUID", rnd.PPID('p'))
 }
 
-// BeforeSave ensures the existence of TakenAt properties before indexing or updating a photo
+// BeforeSave ensures the existence of TakenAt properties and the file root before indexing or updating a photo
 func (m *Photo) BeforeSave(scope *gorm.Scope) error {
 	if m.TakenAt.IsZero() || m.TakenAtLocal.IsZero() {
 		now := Timestamp()
@@ -367,6 +368,12 @@ func (m *Photo) BeforeSave(scope *gorm.Scope) error {
 		}
 	}
 
+	if m.PhotoRoot == "" {
+		if err := scope.SetColumn("PhotoRoot", RootOriginals); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -386,6 +393,7 @@ func (m *Photo) SyncKeywordLabels() error {
 	keywords := txt.UniqueKeywords(details.Keywords)
 
 	var labelIds []uint
+	keep := make(map[uint]bool)
 
 	for _, w := range keywords {
 		if label := FindLabel(w); label != nil {
@@ -394,11 +402,41 @@ func (m *Photo) SyncKeywordLabels() error {
 			}
 
 			labelIds = append(labelIds, label.ID)
+			keep[label.ID] = true
 			FirstOrCreatePhotoLabel(NewPhotoLabel(m.ID, label.ID, 25, classify.SrcKeyword))
 		}
 	}
 
-	return Db().Where("label_src = ? AND photo_id = ? AND label_id NOT IN (?)", classify.SrcKeyword, m.ID, labelIds).Delete(&PhotoLabel{}).Error
+	db := Db().Where("label_src = ? AND photo_id = ?", classify.SrcKeyword, m.ID)
+
+	if len(labelIds) > 0 {
+		db = db.Where("label_id NOT IN (?)", labelIds)
+	}
+
+	// Remove preloaded labels as well, so that they are not saved again.
+	labels := make([]PhotoLabel, 0, len(m.Labels))
+
+	for _, l := range m.Labels {
+		if l.LabelSrc != classify.SrcKeyword || keep[l.LabelID] {
+			labels = append(labels, l)
+		}
+	}
+
+	m.Labels = labels
+
+	return db.Delete(&PhotoLabel{}).Error
+}
+
+// RemoveClassifiedLabels removes labels found by image classification, except for labels that
+// were removed manually, so that they are not added again, and the label ids to keep.
+func (m *Photo) RemoveClassifiedLabels(keep []uint) error {
+	db := Db().Where("label_src = ? AND photo_id = ? AND uncertainty < 100", classify.SrcImage, m.ID)
+
+	if len(keep) > 0 {
+		db = db.Where("label_id NOT IN (?)", keep)
+	}
+
+	return db.Delete(&PhotoLabel{}).Error
 }
 
 // IndexKeywords adds given keywords to the photo entry
@@ -490,6 +528,15 @@ func (m *Photo) HasID() bool {
 	return m.ID > 0 && m.PhotoUID != ""
 }
 
+// Root returns the file root of the photo, e.g. the name of an additional library.
+func (m *Photo) Root() string {
+	if m.PhotoRoot == "" {
+		return RootOriginals
+	}
+
+	return m.PhotoRoot
+}
+
 // UnknownLocation tests if the photo has an unknown location.
 func (m *Photo) UnknownLocation() bool {
 	return m.CellID == "" || m.CellID == UnknownLocation.ID || m.NoLatLng()
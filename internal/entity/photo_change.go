@@ -0,0 +1,461 @@
+package entity
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/pkg/rnd"
+)
+
+// changeDetails is the field name prefix for photo details.
+const changeDetails = "Details."
+
+// changeLabel is the field name prefix for photo labels, followed by the label uid.
+const changeLabel = "Label."
+
+// changeSources maps form fields to the field containing their source, if it isn't <field>Src.
+var changeSources = map[string]string{
+	"TakenAt":      "TakenSrc",
+	"TakenAtLocal": "TakenSrc",
+	"TimeZone":     "TakenSrc",
+	"Year":         "TakenSrc",
+	"Month":        "TakenSrc",
+	"Day":          "TakenSrc",
+	"Altitude":     "PlaceSrc",
+	"Lat":          "PlaceSrc",
+	"Lng":          "PlaceSrc",
+	"Country":      "PlaceSrc",
+	"CellID":       "PlaceSrc",
+	"CellAccuracy": "PlaceSrc",
+	"PlaceID":      "PlaceSrc",
+	"LensID":       "CameraSrc",
+}
+
+type PhotoChanges []PhotoChange
+
+// PhotoChange represents a single field change in the photo edit history.
+type PhotoChange struct {
+	ID         uint            `gorm:"primary_key" json:"ID" yaml:"-"`
+	PhotoUID   string          `gorm:"type:VARBINARY(42);index;" json:"PhotoUID" yaml:"PhotoUID"`
+	BatchUID   string          `gorm:"type:VARBINARY(42);index;" json:"Batch" yaml:"Batch"`
+	FieldName  string          `gorm:"type:VARBINARY(64);" json:"Field" yaml:"Field"`
+	OldValue   json.RawMessage `gorm:"type:TEXT;" json:"Old" yaml:"Old,omitempty"`
+	NewValue   json.RawMessage `gorm:"type:TEXT;" json:"New" yaml:"New,omitempty"`
+	ChangeSrc  string          `gorm:"type:VARBINARY(8);" json:"Src" yaml:"Src,omitempty"`
+	PrevSrc    string          `gorm:"type:VARBINARY(8);" json:"PrevSrc" yaml:"PrevSrc,omitempty"`
+	UserUID    string          `gorm:"type:VARBINARY(42);index;" json:"UserUID" yaml:"UserUID,omitempty"`
+	UserName   string          `gorm:"size:64;" json:"UserName" yaml:"UserName,omitempty"`
+	CreatedAt  time.Time       `json:"CreatedAt" yaml:"CreatedAt"`
+	RevertedAt *time.Time      `json:"RevertedAt,omitempty" yaml:"RevertedAt,omitempty"`
+}
+
+// TableName returns the entity database table name.
+func (PhotoChange) TableName() string {
+	return "photos_changes"
+}
+
+// NewBatchUID returns a new unique id to group changes that can be undone as a unit.
+func NewBatchUID() string {
+	return rnd.PPID('b')
+}
+
+// Reverted returns true if the change has been reverted.
+func (m *PhotoChange) Reverted() bool {
+	return m.RevertedAt != nil
+}
+
+// Create inserts a new row to the database.
+func (m *PhotoChange) Create() error {
+	return Db().Create(m).Error
+}
+
+// FindPhotoChange returns the change with the given id or nil if it doesn't exist.
+func FindPhotoChange(id uint) *PhotoChange {
+	result := PhotoChange{}
+
+	if err := Db().Where("id = ?", id).First(&result).Error; err != nil {
+		log.Debugf("change: %s (not found)", err)
+		return nil
+	}
+
+	return &result
+}
+
+// photoFormValues returns the JSON encoded form values by field name, details are prefixed with "Details.".
+func photoFormValues(f form.Photo) (values map[string]json.RawMessage, err error) {
+	var details map[string]json.RawMessage
+
+	b, err := json.Marshal(f)
+
+	if err != nil {
+		return values, err
+	}
+
+	if err := json.Unmarshal(b, &values); err != nil {
+		return values, err
+	}
+
+	if err := json.Unmarshal(values["Details"], &details); err != nil {
+		return values, err
+	}
+
+	delete(values, "Details")
+
+	for name, value := range details {
+		if name != "PhotoID" {
+			values[changeDetails+name] = value
+		}
+	}
+
+	return values, nil
+}
+
+// photoForm updates the form with the JSON encoded values by field name.
+func photoForm(f form.Photo, values map[string]json.RawMessage) (form.Photo, error) {
+	photoValues := make(map[string]json.RawMessage)
+	detailValues := make(map[string]json.RawMessage)
+
+	for name, value := range values {
+		if strings.HasPrefix(name, changeDetails) {
+			detailValues[strings.TrimPrefix(name, changeDetails)] = value
+		} else {
+			photoValues[name] = value
+		}
+	}
+
+	b, err := json.Marshal(detailValues)
+
+	if err != nil {
+		return f, err
+	}
+
+	photoValues["Details"] = b
+
+	if b, err = json.Marshal(photoValues); err != nil {
+		return f, err
+	}
+
+	err = json.Unmarshal(b, &f)
+
+	return f, err
+}
+
+// changeSrcField returns the name of the field containing the source of a value.
+func changeSrcField(name string) string {
+	if src, ok := changeSources[name]; ok {
+		return src
+	}
+
+	return name + "Src"
+}
+
+// isSrcField returns true if the field contains the source of another field.
+func isSrcField(name string) bool {
+	return strings.HasSuffix(name, "Src")
+}
+
+// changeSrc returns the source of a value, or manual if the field has no source.
+func changeSrc(name string, values map[string]json.RawMessage) string {
+	var src string
+
+	if value, ok := values[changeSrcField(name)]; !ok {
+		return SrcManual
+	} else if err := json.Unmarshal(value, &src); err != nil {
+		return SrcManual
+	}
+
+	return src
+}
+
+// NewPhotoChanges compares two photo forms and returns the changed fields,
+// sources are not recorded as separate changes.
+func NewPhotoChanges(photoUID, batchUID string, user User, before, after form.Photo) (result PhotoChanges, err error) {
+	oldValues, err := photoFormValues(before)
+
+	if err != nil {
+		return result, err
+	}
+
+	newValues, err := photoFormValues(after)
+
+	if err != nil {
+		return result, err
+	}
+
+	names := make([]string, 0, len(newValues))
+
+	for name := range newValues {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		if isSrcField(name) || bytes.Equal(oldValues[name], newValues[name]) {
+			continue
+		}
+
+		result = append(result, PhotoChange{
+			PhotoUID:  photoUID,
+			BatchUID:  batchUID,
+			FieldName: name,
+			OldValue:  oldValues[name],
+			NewValue:  newValues[name],
+			ChangeSrc: changeSrc(name, newValues),
+			PrevSrc:   changeSrc(name, oldValues),
+			UserUID:   user.UserUID,
+			UserName:  user.String(),
+		})
+	}
+
+	return result, nil
+}
+
+// SavePhotoChanges compares two photo forms and adds the changed fields to the edit history.
+func SavePhotoChanges(photoUID, batchUID string, user User, before, after form.Photo) (PhotoChanges, error) {
+	changes, err := NewPhotoChanges(photoUID, batchUID, user, before, after)
+
+	if err != nil {
+		return changes, err
+	}
+
+	for i := range changes {
+		if err := changes[i].Create(); err != nil {
+			return changes, err
+		}
+	}
+
+	return changes, nil
+}
+
+// photoLabelValue returns the JSON encoded uncertainty and source of a photo label,
+// or null if the label is not assigned.
+func photoLabelValue(m *PhotoLabel) json.RawMessage {
+	if m == nil {
+		return json.RawMessage("null")
+	}
+
+	b, err := json.Marshal(struct {
+		Uncertainty int
+		Src         string
+	}{m.Uncertainty, m.LabelSrc})
+
+	if err != nil {
+		return json.RawMessage("null")
+	}
+
+	return b
+}
+
+// photoLabelSrc returns the source of a photo label, or manual if the label is not assigned.
+func photoLabelSrc(m *PhotoLabel) string {
+	if m == nil || m.LabelSrc == "" {
+		return SrcManual
+	}
+
+	return m.LabelSrc
+}
+
+// NewPhotoLabelChange returns the change of a label assigned to a photo,
+// before or after is nil if the label was added or removed.
+func NewPhotoLabelChange(photoUID, batchUID string, user User, label Label, before, after *PhotoLabel) PhotoChange {
+	return PhotoChange{
+		PhotoUID:  photoUID,
+		BatchUID:  batchUID,
+		FieldName: changeLabel + label.LabelUID,
+		OldValue:  photoLabelValue(before),
+		NewValue:  photoLabelValue(after),
+		ChangeSrc: photoLabelSrc(after),
+		PrevSrc:   photoLabelSrc(before),
+		UserUID:   user.UserUID,
+		UserName:  user.String(),
+	}
+}
+
+// SavePhotoLabelChange adds the change of a label assigned to a photo to the edit history,
+// nothing is added if the label remains unchanged.
+func SavePhotoLabelChange(photoUID, batchUID string, user User, label Label, before, after *PhotoLabel) error {
+	change := NewPhotoLabelChange(photoUID, batchUID, user, label, before, after)
+
+	if bytes.Equal(change.OldValue, change.NewValue) {
+		return nil
+	}
+
+	return change.Create()
+}
+
+// revertPhotoLabelChange restores the previous state of a label assigned to a photo,
+// returns false if the label was modified since.
+func revertPhotoLabelChange(photo Photo, change PhotoChange, batchUID string, user User) (bool, error) {
+	label := Label{}
+
+	if err := UnscopedDb().Where("label_uid = ?", strings.TrimPrefix(change.FieldName, changeLabel)).First(&label).Error; err != nil {
+		return false, nil
+	}
+
+	var current *PhotoLabel
+
+	if result := (PhotoLabel{}); Db().Where("photo_id = ? AND label_id = ?", photo.ID, label.ID).First(&result).Error == nil {
+		current = &result
+	}
+
+	if !bytes.Equal(photoLabelValue(current), change.NewValue) {
+		return false, nil
+	}
+
+	var restored *PhotoLabel
+
+	if string(change.OldValue) == "null" {
+		if current == nil {
+			return false, nil
+		} else if err := current.Delete(); err != nil {
+			return false, err
+		}
+	} else {
+		var old struct {
+			Uncertainty int
+			Src         string
+		}
+
+		if err := json.Unmarshal(change.OldValue, &old); err != nil {
+			return false, err
+		}
+
+		restored = NewPhotoLabel(photo.ID, label.ID, old.Uncertainty, old.Src)
+
+		if current == nil {
+			if err := restored.Create(); err != nil {
+				return false, err
+			}
+		} else if err := restored.Updates(map[string]interface{}{
+			"Uncertainty": old.Uncertainty,
+			"LabelSrc":    old.Src,
+		}); err != nil {
+			return false, err
+		}
+
+		if label.Deleted() {
+			if err := label.Restore(); err != nil {
+				log.Errorf("change: %s", err)
+			}
+		}
+	}
+
+	if err := SavePhotoLabelChange(photo.PhotoUID, batchUID, user, label, current, restored); err != nil {
+		log.Errorf("change: %s", err)
+	}
+
+	return true, nil
+}
+
+// RevertPhotoChanges restores the old values and sources of the given changes and saves the photo,
+// changes whose field was modified since are skipped and returned as conflicts.
+// Values that were generated automatically are restored with a manual source.
+// The restored values are recorded in the edit history as a new batch.
+func RevertPhotoChanges(photo Photo, changes PhotoChanges, user User) (reverted, conflicts PhotoChanges, err error) {
+	batch := NewBatchUID()
+	formChanged := false
+	labelsChanged := false
+
+	before, err := form.NewPhoto(photo)
+
+	if err != nil {
+		return reverted, conflicts, err
+	}
+
+	before.Details.PhotoID = photo.ID
+
+	values, err := photoFormValues(before)
+
+	if err != nil {
+		return reverted, conflicts, err
+	}
+
+	// Revert the most recent change first.
+	sort.Slice(changes, func(i, j int) bool { return changes[i].ID > changes[j].ID })
+
+	for _, change := range changes {
+		if change.PhotoUID != photo.PhotoUID {
+			return reverted, conflicts, fmt.Errorf("change: %d does not belong to photo %s", change.ID, photo.PhotoUID)
+		} else if change.Reverted() {
+			continue
+		} else if strings.HasPrefix(change.FieldName, changeLabel) {
+			if ok, err := revertPhotoLabelChange(photo, change, batch, user); err != nil {
+				return reverted, conflicts, err
+			} else if !ok {
+				conflicts = append(conflicts, change)
+			} else {
+				labelsChanged = true
+				reverted = append(reverted, change)
+			}
+
+			continue
+		} else if !bytes.Equal(values[change.FieldName], change.NewValue) {
+			conflicts = append(conflicts, change)
+			continue
+		}
+
+		values[change.FieldName] = change.OldValue
+
+		// Automatic values like titles would be generated again when saving,
+		// so restored values are marked as manual to keep them.
+		if _, ok := values[changeSrcField(change.FieldName)]; !ok {
+			// Field has no source.
+		} else if change.PrevSrc == SrcAuto {
+			values[changeSrcField(change.FieldName)], _ = json.Marshal(SrcManual)
+		} else {
+			values[changeSrcField(change.FieldName)], _ = json.Marshal(change.PrevSrc)
+		}
+
+		formChanged = true
+		reverted = append(reverted, change)
+	}
+
+	if len(reverted) == 0 {
+		return reverted, conflicts, nil
+	}
+
+	if formChanged {
+		after, err := photoForm(before, values)
+
+		if err != nil {
+			return nil, conflicts, err
+		}
+
+		if err := SavePhotoForm(photo, after); err != nil {
+			return nil, conflicts, err
+		}
+
+		if _, err := SavePhotoChanges(photo.PhotoUID, batch, user, before, after); err != nil {
+			log.Errorf("change: %s", err)
+		}
+	}
+
+	// Update title and keywords based on the restored labels.
+	if labelsChanged {
+		p := Photo{ID: photo.ID}
+
+		if err := p.Find(); err != nil {
+			return nil, conflicts, err
+		} else if err := p.SaveLabels(); err != nil {
+			return nil, conflicts, err
+		}
+	}
+
+	revertedAt := Timestamp()
+
+	for i := range reverted {
+		reverted[i].RevertedAt = &revertedAt
+
+		if err := Db().Model(&reverted[i]).UpdateColumn("reverted_at", revertedAt).Error; err != nil {
+			log.Errorf("change: %s", err)
+		}
+	}
+
+	return reverted, conflicts, nil
+}
@@ -0,0 +1,209 @@
+package entity
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPhotoChanges(t *testing.T) {
+	before := form.Photo{PhotoTitle: "Foo", TitleSrc: SrcAuto, PhotoLat: 1.5, Details: form.Details{Keywords: "cat"}}
+	after := form.Photo{PhotoTitle: "Bar", TitleSrc: SrcManual, PhotoLat: 1.5, Details: form.Details{Keywords: "cat, dog"}}
+
+	changes, err := NewPhotoChanges("pt9jtdre2lvl0y45", "b123", Admin, before, after)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(t, changes, 2)
+	assert.Equal(t, "Details.Keywords", changes[0].FieldName)
+	assert.Equal(t, `"cat"`, string(changes[0].OldValue))
+	assert.Equal(t, `"cat, dog"`, string(changes[0].NewValue))
+	assert.Equal(t, "Title", changes[1].FieldName)
+	assert.Equal(t, SrcManual, changes[1].ChangeSrc)
+	assert.Equal(t, "admin", changes[1].UserName)
+}
+
+func TestPhotoForm(t *testing.T) {
+	f := form.Photo{PhotoTitle: "Foo", PhotoLat: 1.5, Details: form.Details{PhotoID: 5, Keywords: "cat"}}
+
+	values, err := photoFormValues(f)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	values["Title"] = []byte(`"Bar"`)
+	values["Details.Keywords"] = []byte(`"dog"`)
+
+	result, err := photoForm(f, values)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "Bar", result.PhotoTitle)
+	assert.Equal(t, float32(1.5), result.PhotoLat)
+	assert.Equal(t, "dog", result.Details.Keywords)
+	assert.Equal(t, uint(5), result.Details.PhotoID)
+}
+
+func TestRevertPhotoChanges(t *testing.T) {
+	find := func() Photo {
+		var m Photo
+
+		if err := Db().Preload("Details").Where("photo_uid = ?", "pt9jtdre2lvl0y45").First(&m).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		return m
+	}
+
+	m := find()
+	oldTitle := m.PhotoTitle
+	newTitle := fmt.Sprintf("Changed %d", time.Now().UnixNano())
+
+	before, err := form.NewPhoto(m)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	after := before
+	after.PhotoTitle = newTitle
+	after.TitleSrc = SrcManual
+
+	if err := SavePhotoForm(m, after); err != nil {
+		t.Fatal(err)
+	}
+
+	changes, err := SavePhotoChanges(m.PhotoUID, NewBatchUID(), Admin, before, after)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(t, changes, 1)
+
+	t.Run("conflict", func(t *testing.T) {
+		other := changes[0]
+		other.NewValue = []byte(`"Modified Since"`)
+
+		reverted, conflicts, err := RevertPhotoChanges(find(), PhotoChanges{other}, Admin)
+
+		assert.NoError(t, err)
+		assert.Empty(t, reverted)
+		assert.Len(t, conflicts, 1)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		reverted, conflicts, err := RevertPhotoChanges(find(), changes, Admin)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, reverted, 1)
+		assert.Empty(t, conflicts)
+		assert.Equal(t, oldTitle, find().PhotoTitle)
+		assert.Equal(t, SrcManual, find().TitleSrc)
+		assert.True(t, FindPhotoChange(changes[0].ID).Reverted())
+	})
+
+	t.Run("already reverted", func(t *testing.T) {
+		reverted, conflicts, err := RevertPhotoChanges(find(), PhotoChanges{*FindPhotoChange(changes[0].ID)}, Admin)
+
+		assert.NoError(t, err)
+		assert.Empty(t, reverted)
+		assert.Empty(t, conflicts)
+	})
+}
+
+func TestRevertPhotoLabelChanges(t *testing.T) {
+	var m Photo
+
+	if err := Db().Where("photo_uid = ?", "pt9jtdre2lvl0y45").First(&m).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	label := FirstOrCreateLabel(NewLabel("Revert Label", 0))
+
+	if label == nil {
+		t.Fatal("label must not be nil")
+	}
+
+	find := func() *PhotoLabel {
+		result := PhotoLabel{}
+
+		if err := Db().Where("photo_id = ? AND label_id = ?", m.ID, label.ID).First(&result).Error; err != nil {
+			return nil
+		}
+
+		return &result
+	}
+
+	if existing := find(); existing != nil {
+		if err := existing.Delete(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Run("added", func(t *testing.T) {
+		added := FirstOrCreatePhotoLabel(NewPhotoLabel(m.ID, label.ID, 0, SrcManual))
+
+		change := NewPhotoLabelChange(m.PhotoUID, NewBatchUID(), Admin, *label, nil, added)
+
+		if err := change.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "null", string(change.OldValue))
+
+		reverted, conflicts, err := RevertPhotoChanges(m, PhotoChanges{change}, Admin)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, reverted, 1)
+		assert.Empty(t, conflicts)
+		assert.Nil(t, find())
+	})
+
+	t.Run("removed", func(t *testing.T) {
+		before := NewPhotoLabel(m.ID, label.ID, 20, SrcImage)
+
+		change := NewPhotoLabelChange(m.PhotoUID, NewBatchUID(), Admin, *label, before, nil)
+
+		if err := change.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		reverted, conflicts, err := RevertPhotoChanges(m, PhotoChanges{change}, Admin)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, reverted, 1)
+		assert.Empty(t, conflicts)
+
+		if restored := find(); assert.NotNil(t, restored) {
+			assert.Equal(t, 20, restored.Uncertainty)
+			assert.Equal(t, SrcImage, restored.LabelSrc)
+		}
+
+		// The label was modified since.
+		reverted, conflicts, err = RevertPhotoChanges(m, PhotoChanges{change}, Admin)
+
+		assert.NoError(t, err)
+		assert.Empty(t, reverted)
+		assert.Len(t, conflicts, 1)
+
+		assert.NoError(t, find().Delete())
+	})
+}
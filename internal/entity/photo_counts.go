@@ -110,7 +110,7 @@ func UpdatePhotoCounts() error {
 			) label_counts WHERE label_id = labels.id)`)).Error; err != nil {
 			return err
 		}
-	} else if IsDialect(SQLite) {
+	} else if IsDialect(SQLite) || IsDialect(Postgres) {
 		if err := Db().
 			Table("labels").
 			UpdateColumn("photo_count",
@@ -62,6 +62,11 @@ func (m *PhotoLabel) Create() error {
 	return Db().Create(m).Error
 }
 
+// Delete removes the row from the database.
+func (m *PhotoLabel) Delete() error {
+	return Db().Where("photo_id = ? AND label_id = ?", m.PhotoID, m.LabelID).Delete(&PhotoLabel{}).Error
+}
+
 // FirstOrCreatePhotoLabel returns the existing row, inserts a new row or nil in case of errors.
 func FirstOrCreatePhotoLabel(m *PhotoLabel) *PhotoLabel {
 	result := PhotoLabel{}
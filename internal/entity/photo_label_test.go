@@ -89,3 +89,17 @@ func TestPhotoLabel_Update(t *testing.T) {
 		assert.Equal(t, uint(0x8), photoLabel.LabelID)
 	})
 }
+
+func TestPhotoLabel_Delete(t *testing.T) {
+	photoLabel := NewPhotoLabel(999, 998, 20, SrcImage)
+
+	if err := photoLabel.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := photoLabel.Delete(); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Error(t, Db().Where("photo_id = ? AND label_id = ?", 999, 998).First(&PhotoLabel{}).Error)
+}
@@ -62,29 +62,29 @@ func (m *Photo) Identical(includeMeta, includeUuid, includePhash bool) (identica
 		if err := Db().
 			Where("(taken_at = ? AND taken_src = 'meta' AND photo_stack > -1 AND cell_id = ? AND camera_serial = ? AND camera_id = ?) "+
 				"OR (uuid = ? AND photo_stack > -1)"+
-				"OR (photo_path = ? AND photo_name = ?)",
-				m.TakenAt, m.CellID, m.CameraSerial, m.CameraID, m.UUID, m.PhotoPath, m.PhotoName).
+				"OR (photo_root = ? AND photo_path = ? AND photo_name = ?)",
+				m.TakenAt, m.CellID, m.CameraSerial, m.CameraID, m.UUID, m.Root(), m.PhotoPath, m.PhotoName).
 			Order("photo_quality DESC, id ASC").Find(&identical).Error; err != nil {
 			return identical, err
 		}
 	case includeMeta && m.HasLocation() && m.TakenSrc == SrcMeta:
 		if err := Db().
 			Where("(taken_at = ? AND taken_src = 'meta' AND photo_stack > -1 AND cell_id = ? AND camera_serial = ? AND camera_id = ?) "+
-				"OR (photo_path = ? AND photo_name = ?)",
-				m.TakenAt, m.CellID, m.CameraSerial, m.CameraID, m.PhotoPath, m.PhotoName).
+				"OR (photo_root = ? AND photo_path = ? AND photo_name = ?)",
+				m.TakenAt, m.CellID, m.CameraSerial, m.CameraID, m.Root(), m.PhotoPath, m.PhotoName).
 			Order("photo_quality DESC, id ASC").Find(&identical).Error; err != nil {
 			return identical, err
 		}
 	case includeUuid && rnd.IsUUID(m.UUID):
 		if err := Db().
-			Where("(uuid = ? AND photo_stack > -1) OR (photo_path = ? AND photo_name = ?)",
-				m.UUID, m.PhotoPath, m.PhotoName).
+			Where("(uuid = ? AND photo_stack > -1) OR (photo_root = ? AND photo_path = ? AND photo_name = ?)",
+				m.UUID, m.Root(), m.PhotoPath, m.PhotoName).
 			Order("photo_quality DESC, id ASC").Find(&identical).Error; err != nil {
 			return identical, err
 		}
 	default:
 		if err := Db().
-			Where("photo_path = ? AND photo_name = ?", m.PhotoPath, m.PhotoName).
+			Where("photo_root = ? AND photo_path = ? AND photo_name = ?", m.Root(), m.PhotoPath, m.PhotoName).
 			Order("photo_quality DESC, id ASC").Find(&identical).Error; err != nil {
 			return identical, err
 		}
@@ -122,18 +122,23 @@ func (m *Photo) Merge(mergeMeta, mergeUuid, mergePhash bool) (original Photo, me
 
 		deleted := Timestamp()
 
-		logResult(UnscopedDb().Exec("UPDATE `files` SET photo_id = ?, photo_uid = ?, file_primary = 0 WHERE photo_id = ?", original.ID, original.PhotoUID, merge.ID))
-		logResult(UnscopedDb().Exec("UPDATE `photos` SET photo_quality = -1, deleted_at = ? WHERE id = ?", Timestamp(), merge.ID))
+		logResult(UnscopedDb().Exec("UPDATE files SET photo_id = ?, photo_uid = ?, file_primary = 0 WHERE photo_id = ?", original.ID, original.PhotoUID, merge.ID))
+		logResult(UnscopedDb().Exec("UPDATE photos SET photo_quality = -1, deleted_at = ? WHERE id = ?", Timestamp(), merge.ID))
 
 		switch DbDialect() {
 		case MySQL:
-			logResult(UnscopedDb().Exec("UPDATE IGNORE `photos_keywords` SET `photo_id` = ? WHERE photo_id = ?", original.ID, merge.ID))
-			logResult(UnscopedDb().Exec("UPDATE IGNORE `photos_labels` SET `photo_id` = ? WHERE photo_id = ?", original.ID, merge.ID))
-			logResult(UnscopedDb().Exec("UPDATE IGNORE `photos_albums` SET `photo_uid` = ? WHERE photo_uid = ?", original.PhotoUID, merge.PhotoUID))
+			logResult(UnscopedDb().Exec("UPDATE IGNORE photos_keywords SET photo_id = ? WHERE photo_id = ?", original.ID, merge.ID))
+			logResult(UnscopedDb().Exec("UPDATE IGNORE photos_labels SET photo_id = ? WHERE photo_id = ?", original.ID, merge.ID))
+			logResult(UnscopedDb().Exec("UPDATE IGNORE photos_albums SET photo_uid = ? WHERE photo_uid = ?", original.PhotoUID, merge.PhotoUID))
 		case SQLite:
-			logResult(UnscopedDb().Exec("UPDATE OR IGNORE `photos_keywords` SET `photo_id` = ? WHERE photo_id = ?", original.ID, merge.ID))
-			logResult(UnscopedDb().Exec("UPDATE OR IGNORE `photos_labels` SET `photo_id` = ? WHERE photo_id = ?", original.ID, merge.ID))
-			logResult(UnscopedDb().Exec("UPDATE OR IGNORE `photos_albums` SET `photo_uid` = ? WHERE photo_uid = ?", original.PhotoUID, merge.PhotoUID))
+			logResult(UnscopedDb().Exec("UPDATE OR IGNORE photos_keywords SET photo_id = ? WHERE photo_id = ?", original.ID, merge.ID))
+			logResult(UnscopedDb().Exec("UPDATE OR IGNORE photos_labels SET photo_id = ? WHERE photo_id = ?", original.ID, merge.ID))
+			logResult(UnscopedDb().Exec("UPDATE OR IGNORE photos_albums SET photo_uid = ? WHERE photo_uid = ?", original.PhotoUID, merge.PhotoUID))
+		case Postgres:
+			// Skip rows that would violate the primary key, like UPDATE IGNORE.
+			logResult(UnscopedDb().Exec("UPDATE photos_keywords SET photo_id = ? WHERE photo_id = ? AND keyword_id NOT IN (SELECT keyword_id FROM photos_keywords WHERE photo_id = ?)", original.ID, merge.ID, original.ID))
+			logResult(UnscopedDb().Exec("UPDATE photos_labels SET photo_id = ? WHERE photo_id = ? AND label_id NOT IN (SELECT label_id FROM photos_labels WHERE photo_id = ?)", original.ID, merge.ID, original.ID))
+			logResult(UnscopedDb().Exec("UPDATE photos_albums SET photo_uid = ? WHERE photo_uid = ? AND album_uid NOT IN (SELECT album_uid FROM photos_albums WHERE photo_uid = ?)", original.PhotoUID, merge.PhotoUID, original.PhotoUID))
 		default:
 			log.Warnf("merge: unknown sql dialect")
 		}
@@ -183,6 +183,11 @@ func TestPhoto_PreloadMany(t *testing.T) {
 	})
 }
 
+func TestPhoto_Root(t *testing.T) {
+	assert.Equal(t, RootOriginals, (&Photo{}).Root())
+	assert.Equal(t, "family", (&Photo{PhotoRoot: "family"}).Root())
+}
+
 func TestPhoto_UnknownLocation(t *testing.T) {
 	t.Run("no_location", func(t *testing.T) {
 		m := PhotoFixtures.Get("Photo01")
@@ -945,6 +950,24 @@ func TestPhoto_RemoveKeyword(t *testing.T) {
 		}
 		assert.Equal(t, 2, len(photo.Keywords))
 	})
+	t.Run("no keywords", func(t *testing.T) {
+		label := Label{LabelName: "beaver", LabelSlug: "beaver"}
+		photo := &Photo{ID: 34568, Details: &Details{PhotoID: 34568}}
+		if err := photo.Save(); err != nil {
+			t.Fatal(err)
+		}
+		if err := label.Save(); err != nil {
+			t.Fatal(err)
+		}
+		photo.Labels = []PhotoLabel{*FirstOrCreatePhotoLabel(NewPhotoLabel(photo.ID, label.ID, 25, SrcKeyword))}
+		if err := photo.SyncKeywordLabels(); err != nil {
+			t.Fatal(err)
+		}
+		assert.Empty(t, photo.Labels)
+		var count int
+		Db().Model(&PhotoLabel{}).Where("photo_id = ? AND label_id = ?", photo.ID, label.ID).Count(&count)
+		assert.Equal(t, 0, count)
+	})
 }
 
 func TestPhoto_SyncKeywordLabels(t *testing.T) {
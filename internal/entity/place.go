@@ -5,7 +5,9 @@ import (
 	"sync"
 	"time"
 
+	"github.com/jinzhu/gorm"
 	"github.com/photoprism/photoprism/internal/maps"
+	"github.com/photoprism/photoprism/pkg/s2"
 )
 
 var placeMutex = sync.Mutex{}
@@ -19,6 +21,10 @@ type Place struct {
 	PlaceCountry  string    `gorm:"type:VARBINARY(2);" json:"Country" yaml:"Country,omitempty"`
 	PlaceKeywords string    `gorm:"type:VARCHAR(255);" json:"Keywords" yaml:"Keywords,omitempty"`
 	PlaceFavorite bool      `json:"Favorite" yaml:"Favorite,omitempty"`
+	PlaceSouth    float64   `json:"South" yaml:"South,omitempty"`
+	PlaceWest     float64   `json:"West" yaml:"West,omitempty"`
+	PlaceNorth    float64   `json:"North" yaml:"North,omitempty"`
+	PlaceEast     float64   `json:"East" yaml:"East,omitempty"`
 	PhotoCount    int       `gorm:"default:1" json:"PhotoCount" yaml:"-"`
 	CreatedAt     time.Time `json:"CreatedAt" yaml:"-"`
 	UpdatedAt     time.Time `json:"UpdatedAt" yaml:"-"`
@@ -105,6 +111,76 @@ func FirstOrCreatePlace(m *Place) *Place {
 	return nil
 }
 
+// Bounds returns the bounding box of the cells assigned to the place, or an empty box if unknown.
+func (m Place) Bounds() s2.Bounds {
+	return s2.Bounds{South: m.PlaceSouth, West: m.PlaceWest, North: m.PlaceNorth, East: m.PlaceEast}
+}
+
+// ExtendBounds extends the bounding box of the place so that it includes the cell.
+func (m *Place) ExtendBounds(cellID string) error {
+	if m.Unknown() {
+		return nil
+	}
+
+	cell, ok := s2.CellBounds(cellID)
+
+	if !ok {
+		return nil
+	}
+
+	placeMutex.Lock()
+	defer placeMutex.Unlock()
+
+	if err := m.Find(); err != nil {
+		return err
+	}
+
+	b := m.Bounds().Union(cell)
+
+	if b == m.Bounds() {
+		return nil
+	}
+
+	m.PlaceSouth, m.PlaceWest, m.PlaceNorth, m.PlaceEast = b.South, b.West, b.North, b.East
+
+	return UnscopedDb().Model(m).UpdateColumns(map[string]interface{}{
+		"place_south": b.South,
+		"place_west":  b.West,
+		"place_north": b.North,
+		"place_east":  b.East,
+	}).Error
+}
+
+// UpdatePlaceBounds sets the bounding boxes of all places based on the cells assigned to them.
+func UpdatePlaceBounds(db *gorm.DB) error {
+	var cells []Cell
+
+	if err := db.Select("id, place_id").Where("place_id <> ?", UnknownPlace.ID).Find(&cells).Error; err != nil {
+		return err
+	}
+
+	bounds := make(map[string]s2.Bounds)
+
+	for _, c := range cells {
+		if b, ok := s2.CellBounds(c.ID); ok {
+			bounds[c.PlaceID] = bounds[c.PlaceID].Union(b)
+		}
+	}
+
+	for id, b := range bounds {
+		if err := db.Model(&Place{}).Where("id = ?", id).UpdateColumns(map[string]interface{}{
+			"place_south": b.South,
+			"place_west":  b.West,
+			"place_north": b.North,
+			"place_east":  b.East,
+		}).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // Unknown returns true if this is an unknown place
 func (m Place) Unknown() bool {
 	return m.ID == "" || m.ID == UnknownPlace.ID
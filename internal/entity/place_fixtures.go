@@ -31,6 +31,10 @@ var PlaceFixtures = PlacesMap{
 		PlaceCountry:  "mx",
 		PlaceKeywords: "ancient, pyramid",
 		PlaceFavorite: false,
+		PlaceSouth:    19.67,
+		PlaceWest:     -98.86,
+		PlaceNorth:    19.70,
+		PlaceEast:     -98.83,
 		PhotoCount:    1,
 		CreatedAt:     Timestamp(),
 		UpdatedAt:     Timestamp(),
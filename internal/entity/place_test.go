@@ -128,3 +128,51 @@ func TestPlace_CityContains(t *testing.T) {
 		assert.False(t, p.CityContains("ich"))
 	})
 }
+
+func TestPlace_ExtendBounds(t *testing.T) {
+	t.Run("zinkwazi", func(t *testing.T) {
+		p := PlaceFixtures.Get("zinkwazi")
+
+		if err := p.ExtendBounds(s2.PrefixedToken(-29.28, 31.44)); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := p.ExtendBounds(s2.PrefixedToken(-29.30, 31.46)); err != nil {
+			t.Fatal(err)
+		}
+
+		result := Place{ID: p.ID}
+
+		if err := result.Find(); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.InDelta(t, -29.30, result.PlaceSouth, 0.001)
+		assert.InDelta(t, 31.44, result.PlaceWest, 0.001)
+		assert.InDelta(t, -29.28, result.PlaceNorth, 0.001)
+		assert.InDelta(t, 31.46, result.PlaceEast, 0.001)
+	})
+	t.Run("unknown", func(t *testing.T) {
+		p := UnknownPlace
+
+		assert.NoError(t, p.ExtendBounds(s2.PrefixedToken(-29.28, 31.44)))
+		assert.True(t, p.Bounds().Empty())
+	})
+}
+
+func TestUpdatePlaceBounds(t *testing.T) {
+	if err := UpdatePlaceBounds(Db()); err != nil {
+		t.Fatal(err)
+	}
+
+	result := Place{ID: PlaceFixtures.Get("mexico").ID}
+
+	if err := result.Find(); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.LessOrEqual(t, result.PlaceSouth, 19.681944)
+	assert.GreaterOrEqual(t, result.PlaceNorth, 19.681944)
+	assert.LessOrEqual(t, result.PlaceWest, -98.846588)
+	assert.GreaterOrEqual(t, result.PlaceEast, -98.846588)
+}
@@ -0,0 +1,130 @@
+package entity
+
+import (
+	"path"
+	"time"
+)
+
+// Persistent work queues.
+const (
+	QueueIndex    = "index"
+	QueueThumbs   = "thumbs"
+	QueueClassify = "classify"
+)
+
+// Work queue priorities, items with higher priority are processed first.
+const (
+	PriorityRescan = 0
+	PriorityNew    = 10
+	PriorityImport = 20
+)
+
+// QueueAttempts is the max number of times an item is started before it is dropped, e.g. if it crashes the process.
+const QueueAttempts = 3
+
+type QueueItems []QueueItem
+
+// QueueItem represents a pending file in a persistent work queue, items are removed once they have been processed.
+type QueueItem struct {
+	ID           uint       `gorm:"primary_key" json:"ID" yaml:"-"`
+	QueueName    string     `gorm:"type:VARBINARY(16);unique_index:idx_queue_items_file;" json:"Queue" yaml:"-"`
+	FileRoot     string     `gorm:"type:VARBINARY(16);unique_index:idx_queue_items_file;" json:"Root" yaml:"-"`
+	FileName     string     `gorm:"type:VARBINARY(755);unique_index:idx_queue_items_file;" json:"Name" yaml:"-"`
+	FileUID      string     `gorm:"type:VARBINARY(42);" json:"FileUID" yaml:"-"`
+	ReportID     uint       `json:"ReportID" yaml:"-"`
+	ItemPriority int        `gorm:"index;" json:"Priority" yaml:"-"`
+	ItemRescan   bool       `json:"Rescan" yaml:"-"`
+	ItemNew      bool       `json:"New" yaml:"-"`
+	ItemRequeued bool       `json:"Requeued" yaml:"-"`
+	ItemAttempts int        `json:"Attempts" yaml:"-"`
+	StartedAt    *time.Time `json:"StartedAt" yaml:"-"`
+	CreatedAt    time.Time  `json:"CreatedAt" yaml:"-"`
+}
+
+// TableName returns the entity database table name.
+func (QueueItem) TableName() string {
+	return "queue_items"
+}
+
+// NewQueueItem returns a new work queue item for a file.
+func NewQueueItem(queueName, fileRoot, fileName string, priority int) *QueueItem {
+	return &QueueItem{
+		QueueName:    queueName,
+		FileRoot:     fileRoot,
+		FileName:     fileName,
+		ItemPriority: priority,
+	}
+}
+
+// Enqueue adds the item to its queue, the priority and flags of an existing item are updated if needed.
+// Items that have already been started are flagged as requeued, so that they are processed again when done.
+func (m *QueueItem) Enqueue() error {
+	existing := QueueItem{}
+
+	if err := Db().Where("queue_name = ? AND file_root = ? AND file_name = ?", m.QueueName, m.FileRoot, m.FileName).First(&existing).Error; err != nil {
+		return Db().Create(m).Error
+	}
+
+	values := make(map[string]interface{})
+
+	if m.ItemPriority > existing.ItemPriority {
+		values["ItemPriority"] = m.ItemPriority
+	}
+
+	if m.ItemRescan && !existing.ItemRescan {
+		values["ItemRescan"] = true
+	}
+
+	if m.ItemNew && !existing.ItemNew {
+		values["ItemNew"] = true
+	}
+
+	// Failures are added to the report of the most recent run.
+	if m.ReportID > 0 && m.ReportID != existing.ReportID {
+		values["ReportID"] = m.ReportID
+	}
+
+	if existing.StartedAt != nil && !existing.ItemRequeued {
+		values["ItemRequeued"] = true
+	}
+
+	*m = existing
+
+	if len(values) == 0 {
+		return nil
+	}
+
+	return Db().Model(m).Updates(values).Error
+}
+
+// Start flags the item as started and counts the attempt.
+func (m *QueueItem) Start() error {
+	startedAt := Timestamp()
+
+	m.StartedAt = &startedAt
+	m.ItemAttempts++
+
+	return Db().Model(m).Updates(map[string]interface{}{"StartedAt": m.StartedAt, "ItemAttempts": m.ItemAttempts}).Error
+}
+
+// Done removes the item from its queue, unless it was requeued while being processed,
+// in which case it becomes pending again.
+func (m *QueueItem) Done() error {
+	if res := Db().Where("item_requeued = ?", false).Delete(m); res.Error != nil || res.RowsAffected > 0 {
+		return res.Error
+	}
+
+	return Db().Model(m).Updates(map[string]interface{}{"StartedAt": nil, "ItemRequeued": false, "ItemAttempts": 0}).Error
+}
+
+// String returns the item as string for logging.
+func (m *QueueItem) String() string {
+	return path.Join(m.FileRoot, m.FileName)
+}
+
+// ResetQueue makes items that were started but not completed, e.g. because of a restart, pending again.
+func ResetQueue(queueName string) error {
+	return Db().Model(&QueueItem{}).
+		Where("queue_name = ? AND started_at IS NOT NULL", queueName).
+		Update("StartedAt", nil).Error
+}
@@ -0,0 +1,126 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueueItem_TableName(t *testing.T) {
+	assert.Equal(t, "queue_items", QueueItem{}.TableName())
+}
+
+func TestQueueItem_Enqueue(t *testing.T) {
+	m := NewQueueItem(QueueThumbs, RootOriginals, "queue/enqueue.jpg", PriorityRescan)
+
+	if err := m.Enqueue(); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.NotEmpty(t, m.ID)
+
+	t.Run("lower priority", func(t *testing.T) {
+		m := NewQueueItem(QueueThumbs, RootOriginals, "queue/enqueue.jpg", PriorityRescan)
+
+		if err := m.Enqueue(); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, PriorityRescan, m.ItemPriority)
+	})
+	t.Run("higher priority", func(t *testing.T) {
+		item := NewQueueItem(QueueThumbs, RootOriginals, "queue/enqueue.jpg", PriorityImport)
+
+		if err := item.Enqueue(); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, m.ID, item.ID)
+		assert.Equal(t, PriorityImport, item.ItemPriority)
+	})
+
+	if err := m.Done(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestQueueItem_Start(t *testing.T) {
+	m := NewQueueItem(QueueClassify, RootOriginals, "queue/start.jpg", PriorityNew)
+
+	if err := m.Enqueue(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := m.Start(); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.NotNil(t, m.StartedAt)
+	assert.Equal(t, 1, m.ItemAttempts)
+	assert.Equal(t, "/queue/start.jpg", m.String())
+
+	if err := ResetQueue(QueueClassify); err != nil {
+		t.Fatal(err)
+	}
+
+	result := QueueItem{}
+
+	if err := Db().First(&result, m.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Nil(t, result.StartedAt)
+	assert.Equal(t, 1, result.ItemAttempts)
+
+	if err := m.Done(); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Error(t, Db().First(&result, m.ID).Error)
+}
+
+func TestQueueItem_Requeue(t *testing.T) {
+	m := NewQueueItem(QueueIndex, RootOriginals, "queue/requeue.jpg", PriorityRescan)
+
+	if err := m.Enqueue(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := m.Start(); err != nil {
+		t.Fatal(err)
+	}
+
+	// The file changed while it was being processed.
+	again := NewQueueItem(QueueIndex, RootOriginals, "queue/requeue.jpg", PriorityNew)
+	again.ItemNew = true
+
+	if err := again.Enqueue(); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, m.ID, again.ID)
+	assert.True(t, again.ItemRequeued)
+	assert.True(t, again.ItemNew)
+	assert.Equal(t, PriorityNew, again.ItemPriority)
+
+	// Requeued items become pending again when done.
+	if err := m.Done(); err != nil {
+		t.Fatal(err)
+	}
+
+	result := QueueItem{}
+
+	if err := Db().First(&result, m.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Nil(t, result.StartedAt)
+	assert.False(t, result.ItemRequeued)
+	assert.Equal(t, 0, result.ItemAttempts)
+
+	if err := result.Done(); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Error(t, Db().First(&result, m.ID).Error)
+}
@@ -0,0 +1,112 @@
+package entity
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/pkg/rnd"
+)
+
+// RecoveryCodeCount is the number of recovery codes created for each user.
+const RecoveryCodeCount = 10
+
+type RecoveryCodes []RecoveryCode
+
+// RecoveryCode represents a hashed one-time code for logging in without a second factor.
+type RecoveryCode struct {
+	ID        uint       `gorm:"primary_key" json:"-" yaml:"-"`
+	UserUID   string     `gorm:"type:VARBINARY(42);index;" json:"UserUID" yaml:"-"`
+	CodeHash  string     `gorm:"type:VARBINARY(64);" json:"-" yaml:"-"`
+	UsedAt    *time.Time `json:"UsedAt" yaml:"-"`
+	CreatedAt time.Time  `json:"CreatedAt" yaml:"-"`
+}
+
+// TableName returns the entity database table name.
+func (RecoveryCode) TableName() string {
+	return "recovery_codes"
+}
+
+// normalizeRecoveryCode removes spaces and dashes and converts the code to lowercase.
+func normalizeRecoveryCode(code string) string {
+	return strings.ToLower(strings.NewReplacer(" ", "", "-", "").Replace(code))
+}
+
+// hashRecoveryCode returns the SHA-256 hash of a normalized recovery code.
+func hashRecoveryCode(code string) string {
+	sum := sha256.Sum256([]byte(normalizeRecoveryCode(code)))
+	return hex.EncodeToString(sum[:])
+}
+
+// NewRecoveryCodes replaces all recovery codes of a user and returns the new codes in plain text.
+func NewRecoveryCodes(userUID string) (codes []string, err error) {
+	if userUID == "" {
+		return codes, fmt.Errorf("recovery codes: empty user uid")
+	}
+
+	if err := DeleteRecoveryCodes(userUID); err != nil {
+		return codes, err
+	}
+
+	for i := 0; i < RecoveryCodeCount; i++ {
+		code := fmt.Sprintf("%s-%s", rnd.Token(5), rnd.Token(5))
+
+		m := RecoveryCode{UserUID: userUID, CodeHash: hashRecoveryCode(code)}
+
+		if err := Db().Create(&m).Error; err != nil {
+			return codes, err
+		}
+
+		codes = append(codes, code)
+	}
+
+	return codes, nil
+}
+
+// DeleteRecoveryCodes removes all recovery codes of a user.
+func DeleteRecoveryCodes(userUID string) error {
+	return Db().Where("user_uid = ?", userUID).Delete(RecoveryCode{}).Error
+}
+
+// RedeemRecoveryCode marks a matching unused recovery code as used and returns true on success.
+func RedeemRecoveryCode(userUID, code string) bool {
+	if userUID == "" || normalizeRecoveryCode(code) == "" {
+		return false
+	}
+
+	var unused RecoveryCodes
+
+	if err := Db().Where("user_uid = ? AND used_at IS NULL", userUID).Find(&unused).Error; err != nil {
+		log.Errorf("recovery codes: %s", err)
+		return false
+	}
+
+	hash := []byte(hashRecoveryCode(code))
+
+	for _, m := range unused {
+		if subtle.ConstantTimeCompare([]byte(m.CodeHash), hash) != 1 {
+			continue
+		}
+
+		if err := Db().Model(&m).UpdateColumn("used_at", Timestamp()).Error; err != nil {
+			log.Errorf("recovery codes: %s", err)
+			return false
+		}
+
+		return true
+	}
+
+	return false
+}
+
+// CountRecoveryCodes returns the number of unused recovery codes of a user.
+func CountRecoveryCodes(userUID string) (count int) {
+	if err := Db().Model(&RecoveryCode{}).Where("user_uid = ? AND used_at IS NULL", userUID).Count(&count).Error; err != nil {
+		log.Errorf("recovery codes: %s", err)
+	}
+
+	return count
+}
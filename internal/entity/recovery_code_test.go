@@ -0,0 +1,52 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewRecoveryCodes(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		codes, err := NewRecoveryCodes("u000000000000020")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, RecoveryCodeCount, len(codes))
+		assert.Equal(t, RecoveryCodeCount, CountRecoveryCodes("u000000000000020"))
+
+		assert.True(t, RedeemRecoveryCode("u000000000000020", codes[0]))
+		assert.False(t, RedeemRecoveryCode("u000000000000020", codes[0]))
+		assert.Equal(t, RecoveryCodeCount-1, CountRecoveryCodes("u000000000000020"))
+
+		if err := DeleteRecoveryCodes("u000000000000020"); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 0, CountRecoveryCodes("u000000000000020"))
+		assert.False(t, RedeemRecoveryCode("u000000000000020", codes[1]))
+	})
+	t.Run("empty uid", func(t *testing.T) {
+		_, err := NewRecoveryCodes("")
+		assert.Error(t, err)
+	})
+}
+
+func TestRedeemRecoveryCode(t *testing.T) {
+	t.Run("normalized", func(t *testing.T) {
+		codes, err := NewRecoveryCodes("u000000000000021")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, RedeemRecoveryCode("u000000000000021", " "+codes[2]+" "))
+	})
+	t.Run("invalid", func(t *testing.T) {
+		assert.False(t, RedeemRecoveryCode("u000000000000021", "xxx"))
+		assert.False(t, RedeemRecoveryCode("u000000000000021", ""))
+		assert.False(t, RedeemRecoveryCode("", "xxx"))
+	})
+}
@@ -57,6 +57,7 @@ type User struct {
 	RoleFamily     bool       `json:"RoleFamily" yaml:"RoleFamily,omitempty"`
 	RoleFriend     bool       `json:"RoleFriend" yaml:"RoleFriend,omitempty"`
 	WebDAV         bool       `gorm:"column:webdav" json:"WebDAV" yaml:"WebDAV,omitempty"`
+	WebDAVReadOnly bool       `gorm:"column:webdav_readonly" json:"WebDAVReadOnly" yaml:"WebDAVReadOnly,omitempty"`
 	StoragePath    string     `gorm:"column:storage_path;type:VARBINARY(500);" json:"StoragePath" yaml:"StoragePath,omitempty"`
 	CanInvite      bool       `json:"CanInvite" yaml:"CanInvite,omitempty"`
 	InviteToken    string     `gorm:"type:VARBINARY(32);" json:"-" yaml:"-"`
@@ -65,6 +66,9 @@ type User struct {
 	ResetToken     string     `gorm:"type:VARBINARY(64);" json:"-" yaml:"-"`
 	ApiToken       string     `gorm:"column:api_token;type:VARBINARY(128);" json:"-" yaml:"-"`
 	ApiSecret      string     `gorm:"column:api_secret;type:VARBINARY(128);" json:"-" yaml:"-"`
+	TotpSecret     string     `gorm:"column:totp_secret;type:VARBINARY(64);" json:"-" yaml:"-"`
+	TotpEnabled    bool       `gorm:"column:totp_enabled" json:"TotpEnabled" yaml:"TotpEnabled,omitempty"`
+	TotpCounter    int64      `gorm:"column:totp_counter" json:"-" yaml:"-"`
 	LoginAttempts  int        `json:"-" yaml:"-"`
 	LoginAt        *time.Time `json:"-" yaml:"-"`
 	CreatedAt      time.Time  `json:"CreatedAt" yaml:"-"`
@@ -274,8 +278,6 @@ func (m *User) InvalidPassword(password string) bool {
 		return true
 	}
 
-	time.Sleep(time.Second * 5 * time.Duration(m.LoginAttempts))
-
 	pw := FindPassword(m.UserUID)
 
 	if pw == nil {
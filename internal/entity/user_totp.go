@@ -0,0 +1,129 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/photoprism/photoprism/pkg/totp"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// TwoFactor returns true if the user has enabled two-factor authentication.
+func (m *User) TwoFactor() bool {
+	return m.TotpEnabled && m.TotpSecret != ""
+}
+
+// SetupTotp creates a new shared secret that must be confirmed with ActivateTotp.
+// A pending secret is returned again until it has been confirmed, so that retries don't break enrollment.
+func (m *User) SetupTotp() (secret string, err error) {
+	if !m.Registered() {
+		return "", fmt.Errorf("only registered users can enable two-factor authentication")
+	}
+
+	if m.TwoFactor() {
+		return "", fmt.Errorf("two-factor authentication is already enabled for %s", txt.Quote(m.UserName))
+	}
+
+	if m.TotpSecret != "" {
+		return m.TotpSecret, nil
+	}
+
+	secret = totp.Secret()
+
+	if err := Db().Model(m).Updates(map[string]interface{}{"totp_secret": secret, "totp_enabled": false, "totp_counter": 0}).Error; err != nil {
+		return "", err
+	}
+
+	m.TotpSecret = secret
+	m.TotpEnabled = false
+	m.TotpCounter = 0
+
+	return secret, nil
+}
+
+// TotpURI returns the provisioning uri for authenticator apps, usually displayed as QR code.
+func (m *User) TotpURI(issuer string) string {
+	if m.TotpSecret == "" {
+		return ""
+	}
+
+	return totp.URI(issuer, m.UserName, m.TotpSecret)
+}
+
+// ActivateTotp enables two-factor authentication if the passcode matches the pending secret
+// and returns new recovery codes in plain text.
+func (m *User) ActivateTotp(passcode string) (codes []string, err error) {
+	if m.TwoFactor() {
+		return codes, fmt.Errorf("two-factor authentication is already enabled for %s", txt.Quote(m.UserName))
+	}
+
+	if m.TotpSecret == "" {
+		return codes, fmt.Errorf("two-factor authentication has not been set up for %s", txt.Quote(m.UserName))
+	}
+
+	if !m.useTotpPasscode(passcode) {
+		return codes, fmt.Errorf("invalid passcode for %s", txt.Quote(m.UserName))
+	}
+
+	if err := Db().Model(m).UpdateColumn("totp_enabled", true).Error; err != nil {
+		return codes, err
+	}
+
+	m.TotpEnabled = true
+
+	return NewRecoveryCodes(m.UserUID)
+}
+
+// DisableTotp disables two-factor authentication and removes all recovery codes.
+func (m *User) DisableTotp() error {
+	if err := Db().Model(m).Updates(map[string]interface{}{"totp_secret": "", "totp_enabled": false, "totp_counter": 0}).Error; err != nil {
+		return err
+	}
+
+	m.TotpSecret = ""
+	m.TotpEnabled = false
+	m.TotpCounter = 0
+
+	return DeleteRecoveryCodes(m.UserUID)
+}
+
+// InvalidPasscode returns true if the passcode is neither a valid one-time passcode nor an unused recovery code.
+func (m *User) InvalidPasscode(passcode string) bool {
+	if !m.TwoFactor() || passcode == "" {
+		return true
+	}
+
+	if m.useTotpPasscode(passcode) {
+		return false
+	}
+
+	if RedeemRecoveryCode(m.UserUID, passcode) {
+		log.Infof("user: %s logged in with a recovery code, %d left", txt.Quote(m.UserName), CountRecoveryCodes(m.UserUID))
+		return false
+	}
+
+	return true
+}
+
+// useTotpPasscode returns true if the one-time passcode is valid and has not been used before.
+func (m *User) useTotpPasscode(passcode string) bool {
+	counter, ok := totp.Match(m.TotpSecret, passcode, time.Now())
+
+	if !ok || counter <= m.TotpCounter {
+		return false
+	}
+
+	// Only succeeds once per time step, even if the same passcode is sent concurrently.
+	result := UnscopedDb().Model(&User{}).Where("id = ? AND totp_counter < ?", m.ID, counter).UpdateColumn("totp_counter", counter)
+
+	if result.Error != nil {
+		log.Errorf("user: %s (update passcode counter)", result.Error)
+		return false
+	} else if result.RowsAffected < 1 {
+		return false
+	}
+
+	m.TotpCounter = counter
+
+	return true
+}
@@ -0,0 +1,97 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/photoprism/photoprism/pkg/totp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUser_Totp(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		m := User{UserUID: "u000000000000030", UserName: "totp"}
+
+		if err := m.Save(); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.False(t, m.TwoFactor())
+		assert.Equal(t, "", m.TotpURI("PhotoPrism"))
+
+		secret, err := m.SetupTotp()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.False(t, m.TwoFactor())
+		assert.Contains(t, m.TotpURI("PhotoPrism"), secret)
+
+		// A pending secret is not replaced until it has been confirmed.
+		if pending, err := m.SetupTotp(); err != nil {
+			t.Fatal(err)
+		} else {
+			assert.Equal(t, secret, pending)
+		}
+
+		if _, err := m.ActivateTotp("000000x"); err == nil {
+			t.Fatal("error expected")
+		}
+
+		passcode, err := totp.Passcode(secret, time.Now())
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		codes, err := m.ActivateTotp(passcode)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, m.TwoFactor())
+		assert.Equal(t, RecoveryCodeCount, len(codes))
+
+		if _, err := m.SetupTotp(); err == nil {
+			t.Fatal("error expected")
+		}
+
+		// Passcodes must not be reused, not even the one used for activation.
+		assert.True(t, m.InvalidPasscode(passcode))
+
+		next, err := totp.Passcode(secret, time.Now().Add(totp.Period))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.False(t, m.InvalidPasscode(next))
+		assert.True(t, m.InvalidPasscode(next))
+		assert.False(t, m.InvalidPasscode(codes[0]))
+		assert.True(t, m.InvalidPasscode(codes[0]))
+		assert.True(t, m.InvalidPasscode(""))
+
+		if found := FindUserByUID(m.UserUID); found == nil {
+			t.Fatal("user should not be nil")
+		} else {
+			assert.True(t, found.TwoFactor())
+		}
+
+		if err := m.DisableTotp(); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.False(t, m.TwoFactor())
+		assert.True(t, m.InvalidPasscode(next))
+		assert.Equal(t, 0, CountRecoveryCodes(m.UserUID))
+	})
+	t.Run("not registered", func(t *testing.T) {
+		m := User{UserName: "totp"}
+
+		if _, err := m.SetupTotp(); err == nil {
+			t.Fatal("error expected")
+		}
+	})
+}
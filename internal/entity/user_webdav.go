@@ -0,0 +1,59 @@
+package entity
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// CanUseWebDAV returns true if the user may access files via WebDAV.
+func (m *User) CanUseWebDAV() bool {
+	if m == nil || !m.Registered() || m.UserDisabled {
+		return false
+	}
+
+	return m.Admin() || m.WebDAV
+}
+
+// WebDAVPath returns the directory the user is restricted to when using WebDAV, relative to the WebDAV root.
+// Admins without storage path have access to the root, other users default to a directory with their user name.
+func (m *User) WebDAVPath() string {
+	p := m.StoragePath
+
+	if p == "" && !m.Admin() {
+		p = m.UserName
+	}
+
+	return cleanStoragePath(p)
+}
+
+// cleanStoragePath returns a relative storage path that can't point outside the WebDAV root.
+func cleanStoragePath(p string) string {
+	p = strings.TrimPrefix(filepath.Clean("/"+filepath.ToSlash(p)), "/")
+
+	if p == "." {
+		return ""
+	}
+
+	return p
+}
+
+// SetWebDAV updates the WebDAV access settings of the user, the storage path is relative to the WebDAV root.
+func (m *User) SetWebDAV(enabled, readOnly bool, storagePath string) error {
+	if !m.Registered() {
+		return fmt.Errorf("only registered users can use webdav")
+	}
+
+	storagePath = cleanStoragePath(storagePath)
+	values := map[string]interface{}{"webdav": enabled, "webdav_readonly": readOnly, "storage_path": storagePath}
+
+	if err := Db().Model(m).Updates(values).Error; err != nil {
+		return err
+	}
+
+	m.WebDAV = enabled
+	m.WebDAVReadOnly = readOnly
+	m.StoragePath = storagePath
+
+	return nil
+}
@@ -0,0 +1,96 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUser_CanUseWebDAV(t *testing.T) {
+	t.Run("admin", func(t *testing.T) {
+		m := User{UserUID: "u000000000000008", UserName: "Hanna", RoleAdmin: true}
+		assert.True(t, m.CanUseWebDAV())
+	})
+	t.Run("webdav", func(t *testing.T) {
+		m := User{UserUID: "u000000000000008", UserName: "Hanna", WebDAV: true}
+		assert.True(t, m.CanUseWebDAV())
+	})
+	t.Run("no webdav", func(t *testing.T) {
+		m := User{UserUID: "u000000000000008", UserName: "Hanna"}
+		assert.False(t, m.CanUseWebDAV())
+	})
+	t.Run("disabled", func(t *testing.T) {
+		m := User{UserUID: "u000000000000008", UserName: "Hanna", WebDAV: true, UserDisabled: true}
+		assert.False(t, m.CanUseWebDAV())
+	})
+	t.Run("not registered", func(t *testing.T) {
+		m := User{UserUID: "u000000000000008", WebDAV: true}
+		assert.False(t, m.CanUseWebDAV())
+	})
+	t.Run("nil", func(t *testing.T) {
+		var m *User
+		assert.False(t, m.CanUseWebDAV())
+	})
+}
+
+func TestUser_WebDAVPath(t *testing.T) {
+	t.Run("admin", func(t *testing.T) {
+		m := User{UserUID: "u000000000000008", UserName: "Hanna", RoleAdmin: true}
+		assert.Equal(t, "", m.WebDAVPath())
+	})
+	t.Run("admin storage path", func(t *testing.T) {
+		m := User{UserUID: "u000000000000008", UserName: "Hanna", RoleAdmin: true, StoragePath: "/family/"}
+		assert.Equal(t, "family", m.WebDAVPath())
+	})
+	t.Run("user name", func(t *testing.T) {
+		m := User{UserUID: "u000000000000008", UserName: "hanna", WebDAV: true}
+		assert.Equal(t, "hanna", m.WebDAVPath())
+	})
+	t.Run("storage path", func(t *testing.T) {
+		m := User{UserUID: "u000000000000008", UserName: "hanna", WebDAV: true, StoragePath: "users/hanna"}
+		assert.Equal(t, "users/hanna", m.WebDAVPath())
+	})
+	t.Run("outside root", func(t *testing.T) {
+		m := User{UserUID: "u000000000000008", UserName: "hanna", WebDAV: true, StoragePath: "../../etc"}
+		assert.Equal(t, "etc", m.WebDAVPath())
+	})
+}
+
+func TestUser_SetWebDAV(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		m := User{UserUID: "u000000000000031", UserName: "webdav"}
+
+		if err := m.Save(); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := m.SetWebDAV(true, true, "../shared/./webdav/"); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, m.CanUseWebDAV())
+		assert.True(t, m.WebDAVReadOnly)
+		assert.Equal(t, "shared/webdav", m.StoragePath)
+
+		found := FindUserByUID(m.UserUID)
+
+		if found == nil {
+			t.Fatal("user not found")
+		}
+
+		assert.True(t, found.WebDAV)
+		assert.True(t, found.WebDAVReadOnly)
+		assert.Equal(t, "shared/webdav", found.WebDAVPath())
+
+		if err := m.SetWebDAV(false, false, ""); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.False(t, m.CanUseWebDAV())
+		assert.Equal(t, "webdav", m.WebDAVPath())
+	})
+	t.Run("not registered", func(t *testing.T) {
+		m := User{UserUID: "u000000000000031"}
+		assert.Error(t, m.SetWebDAV(true, false, ""))
+	})
+}
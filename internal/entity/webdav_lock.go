@@ -0,0 +1,77 @@
+package entity
+
+import (
+	"strings"
+	"time"
+)
+
+type WebDAVLocks []WebDAVLock
+
+// WebDAVLock represents a persistent WebDAV resource lock, the root is the absolute path of the locked file or directory.
+type WebDAVLock struct {
+	LockToken string     `gorm:"type:VARBINARY(64);primary_key;auto_increment:false;" json:"Token" yaml:"-"`
+	LockRoot  string     `gorm:"type:VARBINARY(1024);" json:"Root" yaml:"-"`
+	ZeroDepth bool       `json:"ZeroDepth" yaml:"-"`
+	OwnerXML  string     `gorm:"type:TEXT;" json:"OwnerXML" yaml:"-"`
+	ExpiresAt *time.Time `json:"ExpiresAt" yaml:"-"`
+	CreatedAt time.Time  `json:"CreatedAt" yaml:"-"`
+}
+
+// TableName returns the entity database table name.
+func (WebDAVLock) TableName() string {
+	return "webdav_locks"
+}
+
+// Create inserts a new row to the database.
+func (m *WebDAVLock) Create() error {
+	return Db().Create(m).Error
+}
+
+// Delete removes the lock from the database.
+func (m *WebDAVLock) Delete() error {
+	return Db().Delete(m, "lock_token = ?", m.LockToken).Error
+}
+
+// SetExpiresAt updates the lock expiration time, nil means the lock does not expire.
+func (m *WebDAVLock) SetExpiresAt(t *time.Time) error {
+	m.ExpiresAt = t
+
+	return Db().Model(m).UpdateColumn("expires_at", t).Error
+}
+
+// Expired returns true if the lock has expired.
+func (m *WebDAVLock) Expired(now time.Time) bool {
+	return m.ExpiresAt != nil && !now.Before(*m.ExpiresAt)
+}
+
+// Overlaps returns true if the lock applies to the path or one of its descendants, or if the path is within a locked directory.
+func (m *WebDAVLock) Overlaps(path string) bool {
+	return pathContains(m.LockRoot, path) || pathContains(path, m.LockRoot)
+}
+
+// pathContains returns true if the name is the directory or within it.
+func pathContains(dir, name string) bool {
+	return name == dir || dir == "/" || strings.HasPrefix(name, dir+"/")
+}
+
+// FindWebDAVLocks returns the locks that have not expired and overlap with the absolute path,
+// so that users with nested root directories see each other's locks.
+func FindWebDAVLocks(path string, now time.Time) (result WebDAVLocks, err error) {
+	var locks WebDAVLocks
+
+	if err := Db().Where("expires_at IS NOT NULL AND expires_at <= ?", now).Delete(&WebDAVLock{}).Error; err != nil {
+		return result, err
+	}
+
+	if err = Db().Order("lock_root").Find(&locks).Error; err != nil {
+		return result, err
+	}
+
+	for _, l := range locks {
+		if l.Overlaps(path) {
+			result = append(result, l)
+		}
+	}
+
+	return result, nil
+}
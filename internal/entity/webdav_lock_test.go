@@ -0,0 +1,99 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebDAVLock_TableName(t *testing.T) {
+	assert.Equal(t, "webdav_locks", WebDAVLock{}.TableName())
+}
+
+func TestWebDAVLock_Expired(t *testing.T) {
+	now := time.Now()
+	past := now.Add(-time.Minute)
+	future := now.Add(time.Minute)
+
+	assert.False(t, (&WebDAVLock{}).Expired(now))
+	assert.True(t, (&WebDAVLock{ExpiresAt: &past}).Expired(now))
+	assert.False(t, (&WebDAVLock{ExpiresAt: &future}).Expired(now))
+}
+
+func TestWebDAVLock_Overlaps(t *testing.T) {
+	l := WebDAVLock{LockRoot: "/photoprism/originals/user"}
+
+	assert.True(t, l.Overlaps("/photoprism/originals/user"))
+	assert.True(t, l.Overlaps("/photoprism/originals"))
+	assert.True(t, l.Overlaps("/photoprism/originals/user/2021"))
+	assert.False(t, l.Overlaps("/photoprism/originals/username"))
+	assert.False(t, l.Overlaps("/photoprism/import/user"))
+	assert.True(t, (&WebDAVLock{LockRoot: "/"}).Overlaps("/photoprism/import"))
+}
+
+func TestFindWebDAVLocks(t *testing.T) {
+	now := time.Now().UTC()
+	past := now.Add(-time.Minute)
+	future := now.Add(time.Hour)
+
+	if err := Db().Where("lock_token LIKE ?", "opaquelocktoken:find-test-%").Delete(&WebDAVLock{}).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	locks := []WebDAVLock{
+		{LockToken: "opaquelocktoken:find-test-1", LockRoot: "/photoprism/originals/test/a", ExpiresAt: &future},
+		{LockToken: "opaquelocktoken:find-test-2", LockRoot: "/photoprism/originals/test/b"},
+		{LockToken: "opaquelocktoken:find-test-3", LockRoot: "/photoprism/originals/test/c", ExpiresAt: &past},
+		{LockToken: "opaquelocktoken:find-test-4", LockRoot: "/photoprism/import/test/a"},
+	}
+
+	for _, l := range locks {
+		if err := l.Create(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	result, err := FindWebDAVLocks("/photoprism/originals/test", now)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(t, result, 2)
+	assert.Equal(t, "opaquelocktoken:find-test-1", result[0].LockToken)
+	assert.Equal(t, "opaquelocktoken:find-test-2", result[1].LockToken)
+
+	// Locks of ancestor directories apply as well.
+	result, err = FindWebDAVLocks("/photoprism/originals/test/a/2021", now)
+
+	assert.NoError(t, err)
+	assert.Len(t, result, 1)
+
+	// Expired locks are removed.
+	count := RowCount{}
+	Db().Raw("SELECT COUNT(*) AS count FROM webdav_locks WHERE lock_token = ?", "opaquelocktoken:find-test-3").Scan(&count)
+	assert.Equal(t, 0, count.Count)
+
+	if err := result[0].SetExpiresAt(&past); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err = FindWebDAVLocks("/photoprism/originals/test", now)
+
+	assert.NoError(t, err)
+	assert.Len(t, result, 1)
+
+	assert.NoError(t, result[0].Delete())
+
+	result, err = FindWebDAVLocks("/photoprism/originals/test", now)
+
+	assert.NoError(t, err)
+	assert.Len(t, result, 0)
+
+	result, err = FindWebDAVLocks("/photoprism/import/test", now)
+
+	assert.NoError(t, err)
+	assert.Len(t, result, 1)
+	assert.NoError(t, result[0].Delete())
+}
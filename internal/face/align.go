@@ -0,0 +1,57 @@
+package face
+
+import (
+	"image"
+	"image/color"
+	"math"
+
+	"github.com/disintegration/imaging"
+)
+
+// MaxAlignAngle is the max eye angle in degrees that gets corrected before computing embeddings.
+const MaxAlignAngle = 45.0
+
+// EyesAngle returns the angle between the eyes and the horizontal axis in degrees.
+func (f *Face) EyesAngle() (angle float64, ok bool) {
+	var left, right *Point
+
+	for i := range f.Eyes {
+		switch f.Eyes[i].Name {
+		case "eye_l":
+			left = &f.Eyes[i]
+		case "eye_r":
+			right = &f.Eyes[i]
+		}
+	}
+
+	if left == nil || right == nil || right.Col <= left.Col {
+		return 0, false
+	}
+
+	return math.Atan2(float64(right.Row-left.Row), float64(right.Col-left.Col)) * 180 / math.Pi, true
+}
+
+// Crop returns a square image of the face, rotated so that the eyes are level if possible.
+func (f *Face) Crop(img image.Image) image.Image {
+	size := f.Face.Scale
+	row, col := f.Face.TopLeft()
+
+	angle, ok := f.EyesAngle()
+
+	// Use an unaligned crop if the eye positions are missing or implausible.
+	if !ok || math.Abs(angle) < 1 || math.Abs(angle) > MaxAlignAngle {
+		return imaging.Crop(img, image.Rect(col, row, col+size, row+size))
+	}
+
+	// Crop a larger area first so that the corners of the rotated face are not empty.
+	half := int(math.Ceil(float64(size) * math.Sqrt2 / 2))
+	area := image.Rect(f.Face.Col-half, f.Face.Row-half, f.Face.Col+half, f.Face.Row+half)
+
+	if !area.In(img.Bounds()) {
+		return imaging.Crop(img, image.Rect(col, row, col+size, row+size))
+	}
+
+	result := imaging.Rotate(imaging.Crop(img, area), angle, color.Black)
+
+	return imaging.CropCenter(result, size, size)
+}
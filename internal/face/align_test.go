@@ -0,0 +1,64 @@
+package face
+
+import (
+	"image"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFace_EyesAngle(t *testing.T) {
+	t.Run("Level", func(t *testing.T) {
+		f := Face{Eyes: Points{NewPoint("eye_l", 100, 80, 10), NewPoint("eye_r", 100, 120, 10)}}
+		angle, ok := f.EyesAngle()
+
+		assert.True(t, ok)
+		assert.Equal(t, 0.0, angle)
+	})
+	t.Run("Tilted", func(t *testing.T) {
+		f := Face{Eyes: Points{NewPoint("eye_l", 100, 80, 10), NewPoint("eye_r", 140, 120, 10)}}
+		angle, ok := f.EyesAngle()
+
+		assert.True(t, ok)
+		assert.InDelta(t, 45.0, angle, 0.001)
+	})
+	t.Run("OneEye", func(t *testing.T) {
+		f := Face{Eyes: Points{NewPoint("eye_l", 100, 80, 10)}}
+		angle, ok := f.EyesAngle()
+
+		assert.False(t, ok)
+		assert.Equal(t, 0.0, angle)
+	})
+}
+
+func TestFace_Crop(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 400, 300))
+
+	t.Run("Unaligned", func(t *testing.T) {
+		f := Face{Face: NewPoint("face", 150, 200, 100)}
+		result := f.Crop(img)
+
+		assert.Equal(t, 100, result.Bounds().Dx())
+		assert.Equal(t, 100, result.Bounds().Dy())
+	})
+	t.Run("Aligned", func(t *testing.T) {
+		f := Face{
+			Face: NewPoint("face", 150, 200, 100),
+			Eyes: Points{NewPoint("eye_l", 140, 170, 10), NewPoint("eye_r", 150, 230, 10)},
+		}
+		result := f.Crop(img)
+
+		assert.Equal(t, 100, result.Bounds().Dx())
+		assert.Equal(t, 100, result.Bounds().Dy())
+	})
+	t.Run("Edge", func(t *testing.T) {
+		f := Face{
+			Face: NewPoint("face", 50, 50, 100),
+			Eyes: Points{NewPoint("eye_l", 40, 20, 10), NewPoint("eye_r", 50, 80, 10)},
+		}
+		result := f.Crop(img)
+
+		assert.Equal(t, 100, result.Bounds().Dx())
+		assert.Equal(t, 100, result.Bounds().Dy())
+	})
+}
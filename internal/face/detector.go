@@ -67,6 +67,7 @@ type Detector struct {
 	scaleFactor    float64
 	iouThreshold   float64
 	scoreThreshold float32
+	maxFaces       int
 	perturb        int
 }
 
@@ -79,13 +80,14 @@ func Detect(fileName string) (faces Faces, err error) {
 	}()
 
 	fd := &Detector{
-		minSize:        20,
+		minSize:        MinSize,
 		maxSize:        1000,
 		angle:          0.0,
 		shiftFactor:    0.1,
 		scaleFactor:    1.1,
 		iouThreshold:   0.2,
-		scoreThreshold: 9.0,
+		scoreThreshold: float32(ScoreThreshold),
+		maxFaces:       MaxFaces,
 		perturb:        63,
 	}
 
@@ -179,7 +181,11 @@ func (fd *Detector) Faces(det []pigo.Detection, params pigo.CascadeParams) (resu
 		var landmarkCoords []Point
 		var puploc *pigo.Puploc
 
-		if face.Q < fd.scoreThreshold {
+		if fd.maxFaces > 0 && len(results) >= fd.maxFaces {
+			break
+		}
+
+		if face.Q < fd.scoreThreshold || face.Scale < fd.minSize {
 			continue
 		}
 
@@ -299,14 +305,18 @@ func (fd *Detector) Faces(det []pigo.Detection, params pigo.CascadeParams) (resu
 			}
 		}
 
-		results = append(results, Face{
+		f := Face{
 			Rows:      params.ImageParams.Rows,
 			Cols:      params.ImageParams.Cols,
 			Score:     int(face.Q),
 			Face:      faceCoord,
 			Eyes:      eyesCoords,
 			Landmarks: landmarkCoords,
-		})
+		}
+
+		f.Quality = f.QualityScore()
+
+		results = append(results, f)
 	}
 
 	return results, nil
@@ -34,12 +34,19 @@ package face
 
 import (
 	"encoding/json"
+	"math"
 
 	"github.com/photoprism/photoprism/internal/event"
 )
 
 var log = event.Log
 
+var (
+	MinSize        = 20  // Min face size in pixels.
+	ScoreThreshold = 9.0 // Min face detection score.
+	MaxFaces       = 20  // Max number of faces per image, 0 for no limit.
+)
+
 // Faces is a list of face detection results.
 type Faces []Face
 
@@ -54,38 +61,19 @@ func (faces Faces) Uncertainty() int {
 		return 100
 	}
 
-	maxScore := 0
+	maxQuality := 0
 
 	for _, f := range faces {
-		if f.Score > maxScore {
-			maxScore = f.Score
+		if q := f.QualityScore(); q > maxQuality {
+			maxQuality = q
 		}
 	}
 
-	switch {
-	case maxScore > 300:
-		return 1
-	case maxScore > 200:
-		return 5
-	case maxScore > 100:
-		return 10
-	case maxScore > 80:
-		return 15
-	case maxScore > 65:
-		return 20
-	case maxScore > 50:
-		return 25
-	case maxScore > 40:
-		return 30
-	case maxScore > 30:
-		return 35
-	case maxScore > 20:
-		return 40
-	case maxScore > 10:
-		return 45
+	if result := 50 - maxQuality/2; result > 1 {
+		return result
 	}
 
-	return 50
+	return 1
 }
 
 // Face represents a face detection result.
@@ -93,12 +81,30 @@ type Face struct {
 	Rows      int       `json:"rows,omitempty"`
 	Cols      int       `json:"cols,omitempty"`
 	Score     int       `json:"score,omitempty"`
+	Quality   int       `json:"quality,omitempty"`
 	Face      Point     `json:"face,omitempty"`
 	Eyes      Points    `json:"eyes,omitempty"`
 	Landmarks Points    `json:"landmarks,omitempty"`
 	Embedding []float32 `json:"embedding,omitempty"`
 }
 
+// QualityScore returns the face quality in percent based on detection score, size and landmarks.
+func (f *Face) QualityScore() int {
+	if f.Quality > 0 {
+		return f.Quality
+	}
+
+	score := math.Min(float64(f.Score), 100) / 100
+	size := math.Min(float64(f.Face.Scale), 100) / 100
+	eyes := 0.5
+
+	if len(f.Eyes) == 2 {
+		eyes = 1
+	}
+
+	return int(math.Round(100 * (0.5*score + 0.3*size + 0.2*eyes)))
+}
+
 // Dim returns the max number of rows and cols as float32 to calculate relative coordinates.
 func (f *Face) Dim() float32 {
 	if f.Cols > 0 {
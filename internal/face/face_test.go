@@ -83,7 +83,7 @@ func TestDetect(t *testing.T) {
 					t.Logf("marker[%d]: %#v %#v", i, f.Marker(), f.Face)
 					t.Logf("landmarks[%d]: %s", i, f.RelativeLandmarksJSON())
 
-					embedding := tfInstance.getFaceEmbedding(fileName, f.Face)
+					embedding := tfInstance.getFaceEmbedding(fileName, f)
 
 					if b, err := json.Marshal(embedding[0]); err != nil {
 						t.Fatal(err)
@@ -145,3 +145,64 @@ func TestDetect(t *testing.T) {
 	// 4 out of 55 with the 1.21 threshold
 	assert.True(t, correct == 51)
 }
+
+func TestDetect_Settings(t *testing.T) {
+	defer func(minSize, maxFaces int) {
+		MinSize = minSize
+		MaxFaces = maxFaces
+	}(MinSize, MaxFaces)
+
+	t.Run("MaxFaces", func(t *testing.T) {
+		MaxFaces = 1
+
+		faces, err := Detect("testdata/18.jpg")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 1, faces.Count())
+	})
+	t.Run("MinSize", func(t *testing.T) {
+		MinSize = 1000
+
+		faces, err := Detect("testdata/18.jpg")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 0, faces.Count())
+	})
+}
+
+func TestFace_QualityScore(t *testing.T) {
+	t.Run("Stored", func(t *testing.T) {
+		f := Face{Score: 10, Quality: 77}
+		assert.Equal(t, 77, f.QualityScore())
+	})
+	t.Run("Large", func(t *testing.T) {
+		f := Face{
+			Score: 150,
+			Face:  NewPoint("face", 100, 100, 200),
+			Eyes:  Points{NewPoint("eye_l", 90, 80, 10), NewPoint("eye_r", 90, 120, 10)},
+		}
+		assert.Equal(t, 100, f.QualityScore())
+	})
+	t.Run("Small", func(t *testing.T) {
+		f := Face{Score: 10, Face: NewPoint("face", 100, 100, 20)}
+		assert.Equal(t, 21, f.QualityScore())
+	})
+}
+
+func TestFaces_Uncertainty(t *testing.T) {
+	t.Run("None", func(t *testing.T) {
+		assert.Equal(t, 100, Faces{}.Uncertainty())
+	})
+	t.Run("High", func(t *testing.T) {
+		assert.Equal(t, 1, Faces{{Quality: 100}}.Uncertainty())
+	})
+	t.Run("Low", func(t *testing.T) {
+		assert.Equal(t, 40, Faces{{Quality: 20}, {Quality: 10}}.Uncertainty())
+	})
+}
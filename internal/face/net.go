@@ -53,7 +53,7 @@ func (t *Net) Detect(fileName string) (faces Faces, err error) {
 			continue
 		}
 
-		embedding := t.getFaceEmbedding(fileName, f.Face)
+		embedding := t.getFaceEmbedding(fileName, f)
 
 		if len(embedding) > 0 {
 			faces[i].Embedding = embedding[0]
@@ -63,6 +63,27 @@ func (t *Net) Detect(fileName string) (faces Faces, err error) {
 	return faces, nil
 }
 
+// Embedding returns the embedding of a face found at a known position, e.g. drawn manually.
+func (t *Net) Embedding(fileName string, f Face) ([]float32, error) {
+	if t.disabled {
+		return nil, fmt.Errorf("face: facenet is disabled")
+	}
+
+	if f.Face.Scale < 1 {
+		return nil, fmt.Errorf("face: invalid size")
+	}
+
+	if err := t.loadModel(); err != nil {
+		return nil, err
+	}
+
+	if embedding := t.getFaceEmbedding(fileName, f); len(embedding) > 0 {
+		return embedding[0], nil
+	}
+
+	return nil, fmt.Errorf("face: no embedding")
+}
+
 // ModelLoaded tests if the TensorFlow model is loaded.
 func (t *Net) ModelLoaded() bool {
 	return t.model != nil
@@ -92,16 +113,23 @@ func (t *Net) loadModel() error {
 	return nil
 }
 
-func (t *Net) getFaceEmbedding(fileName string, f Point) [][]float32 {
-	x, y := f.TopLeft()
-
+// getFaceEmbedding returns the embedding of a face, aligned based on the eye positions.
+func (t *Net) getFaceEmbedding(fileName string, f Face) [][]float32 {
 	imageBuffer, err := ioutil.ReadFile(fileName)
+
+	if err != nil {
+		log.Errorf("face: failed to read image: %v", err)
+		return nil
+	}
+
 	img, err := imaging.Decode(bytes.NewReader(imageBuffer), imaging.AutoOrientation(true))
+
 	if err != nil {
 		log.Errorf("face: failed to decode image: %v", err)
+		return nil
 	}
 
-	img = imaging.Crop(img, image.Rect(y, x, y+f.Scale, x+f.Scale))
+	img = f.Crop(img)
 	img = imaging.Fill(img, 160, 160, imaging.Center, imaging.Lanczos)
 	// err = imaging.Save(img, "testdata_out/face" + strconv.Itoa(t.count) + ".jpg")
 	// if err != nil {
@@ -125,3 +125,22 @@ func TestNet(t *testing.T) {
 	// 4 out of 55 with the 1.21 threshold
 	assert.True(t, correct == 51)
 }
+
+func TestNet_Embedding(t *testing.T) {
+	t.Run("Disabled", func(t *testing.T) {
+		faceNet := NewNet(modelPath, true)
+
+		embedding, err := faceNet.Embedding("testdata/1.jpg", Face{Face: NewPoint("face", 100, 100, 50)})
+
+		assert.Error(t, err)
+		assert.Nil(t, embedding)
+	})
+	t.Run("InvalidSize", func(t *testing.T) {
+		faceNet := NewNet(modelPath, false)
+
+		embedding, err := faceNet.Embedding("testdata/1.jpg", Face{Face: NewPoint("face", 100, 100, 0)})
+
+		assert.Error(t, err)
+		assert.Nil(t, embedding)
+	})
+}
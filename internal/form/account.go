@@ -27,6 +27,8 @@ type Account struct {
 	SyncDownload  bool   `json:"SyncDownload"`
 	SyncFilenames bool   `json:"SyncFilenames"`
 	SyncRaw       bool   `json:"SyncRaw"`
+	AccLibrary    bool   `json:"AccLibrary"`
+	LibraryPath   string `json:"LibraryPath"`
 }
 
 func NewAccount(m interface{}) (f Account, err error) {
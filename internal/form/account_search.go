@@ -2,13 +2,14 @@ package form
 
 // AccountSearch represents search form fields for "/api/v1/accounts".
 type AccountSearch struct {
-	Query  string `form:"q"`
-	Share  bool   `form:"share"`
-	Sync   bool   `form:"sync"`
-	Status string `form:"status"`
-	Count  int    `form:"count" binding:"required" serialize:"-"`
-	Offset int    `form:"offset" serialize:"-"`
-	Order  string `form:"order" serialize:"-"`
+	Query   string `form:"q"`
+	Share   bool   `form:"share"`
+	Sync    bool   `form:"sync"`
+	Library bool   `form:"library"`
+	Status  string `form:"status"`
+	Count   int    `form:"count" binding:"required" serialize:"-"`
+	Offset  int    `form:"offset" serialize:"-"`
+	Order   string `form:"order" serialize:"-"`
 }
 
 func (f *AccountSearch) GetQuery() string {
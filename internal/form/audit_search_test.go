@@ -0,0 +1,38 @@
+package form
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAuditSearch(t *testing.T) {
+	r := NewAuditSearch("action:photos.delete")
+	assert.IsType(t, AuditSearch{}, r)
+	assert.Equal(t, "action:photos.delete", r.GetQuery())
+}
+
+func TestAuditSearch_ParseQueryString(t *testing.T) {
+	t.Run("valid query", func(t *testing.T) {
+		form := &AuditSearch{Query: "actor:admin action:photos.delete resource:pt9jtdre2lvl0yh7 after:2021-01-02"}
+
+		if err := form.ParseQueryString(); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "admin", form.Actor)
+		assert.Equal(t, "photos.delete", form.Action)
+		assert.Equal(t, "pt9jtdre2lvl0yh7", form.Resource)
+		assert.Equal(t, 2021, form.After.Year())
+		assert.Equal(t, "", form.Query)
+	})
+	t.Run("text only", func(t *testing.T) {
+		form := &AuditSearch{Query: "albums"}
+
+		if err := form.ParseQueryString(); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "albums", form.Query)
+	})
+}
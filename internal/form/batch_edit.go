@@ -0,0 +1,219 @@
+package form
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// srcManual is the source of values changed with a batch edit.
+const srcManual = "manual"
+
+// BatchEdit represents the batch edit form for "/api/v1/batch/photos/edit",
+// empty values are ignored so that only the given fields are changed.
+type BatchEdit struct {
+	Selection
+	TakenAt        time.Time `json:"TakenAt"`     // Local time.
+	TakenOffset    string    `json:"TakenOffset"` // Duration to shift the time by, e.g. "-1h30m".
+	TimeZone       string    `json:"TimeZone"`
+	Lat            *float32  `json:"Lat"`
+	Lng            *float32  `json:"Lng"`
+	Altitude       *int      `json:"Altitude"`
+	Country        string    `json:"Country"`
+	PlaceID        string    `json:"PlaceID"`
+	Title          string    `json:"Title"`       // Template, e.g. "{title} ({n})".
+	Description    string    `json:"Description"` // Template, e.g. "{description} {year}".
+	AddLabels      []string  `json:"AddLabels"`
+	RemoveLabels   []string  `json:"RemoveLabels"`
+	AddKeywords    []string  `json:"AddKeywords"`
+	RemoveKeywords []string  `json:"RemoveKeywords"`
+	CameraID       uint      `json:"CameraID"`
+	LensID         uint      `json:"LensID"`
+}
+
+// Offset returns the duration to shift the time by.
+func (f BatchEdit) Offset() (time.Duration, error) {
+	if s := strings.TrimSpace(f.TakenOffset); s == "" {
+		return 0, nil
+	} else {
+		return time.ParseDuration(s)
+	}
+}
+
+// HasLocation returns true if the coordinates should be changed.
+func (f BatchEdit) HasLocation() bool {
+	return f.Lat != nil && f.Lng != nil
+}
+
+// HasLabels returns true if labels should be added or removed.
+func (f BatchEdit) HasLabels() bool {
+	return len(f.AddLabels) > 0 || len(f.RemoveLabels) > 0
+}
+
+// NoChanges returns true if no changes were requested.
+func (f BatchEdit) NoChanges() bool {
+	switch {
+	case !f.TakenAt.IsZero(), f.TakenOffset != "", f.TimeZone != "":
+		return false
+	case f.HasLocation(), f.Altitude != nil, f.Country != "", f.PlaceID != "":
+		return false
+	case f.Title != "", f.Description != "":
+		return false
+	case f.HasLabels(), len(f.AddKeywords) > 0, len(f.RemoveKeywords) > 0:
+		return false
+	case f.CameraID > 0, f.LensID > 0:
+		return false
+	}
+
+	return true
+}
+
+// HasTime returns true if the date or time should be changed.
+func (f BatchEdit) HasTime() bool {
+	return !f.TakenAt.IsZero() || f.TakenOffset != "" || f.TimeZone != ""
+}
+
+// Apply returns the photo form updated with the batch changes, n is the position in the selection
+// starting at 1, which can be used as "{n}" in title and description templates.
+func (f BatchEdit) Apply(p Photo, n int) (Photo, error) {
+	if f.HasTime() {
+		offset, err := f.Offset()
+
+		if err != nil {
+			return p, err
+		}
+
+		local := p.TakenAtLocal
+
+		if !f.TakenAt.IsZero() {
+			local = time.Date(f.TakenAt.Year(), f.TakenAt.Month(), f.TakenAt.Day(),
+				f.TakenAt.Hour(), f.TakenAt.Minute(), f.TakenAt.Second(), 0, time.UTC)
+		}
+
+		local = local.Add(offset).Round(time.Second)
+
+		if f.TimeZone != "" {
+			p.TimeZone = f.TimeZone
+		}
+
+		if loc, err := time.LoadLocation(p.TimeZone); p.TimeZone != "" && err == nil {
+			p.TakenAt = time.Date(local.Year(), local.Month(), local.Day(),
+				local.Hour(), local.Minute(), local.Second(), 0, loc).UTC()
+		} else if f.TakenAt.IsZero() {
+			p.TakenAt = p.TakenAt.Add(offset).Round(time.Second)
+		} else {
+			p.TakenAt = local
+		}
+
+		p.TakenAtLocal = local
+		p.PhotoYear = local.Year()
+		p.PhotoMonth = int(local.Month())
+		p.PhotoDay = local.Day()
+		p.TakenSrc = srcManual
+	}
+
+	if f.HasLocation() {
+		p.PhotoLat = *f.Lat
+		p.PhotoLng = *f.Lng
+		p.PlaceSrc = srcManual
+	}
+
+	if f.Altitude != nil {
+		p.PhotoAltitude = *f.Altitude
+		p.PlaceSrc = srcManual
+	}
+
+	if f.Country != "" {
+		p.PhotoCountry = strings.ToLower(f.Country)
+		p.PlaceSrc = srcManual
+	}
+
+	if f.PlaceID != "" {
+		p.PlaceID = f.PlaceID
+		p.PlaceSrc = srcManual
+	}
+
+	if f.CameraID > 0 {
+		p.CameraID = f.CameraID
+		p.CameraSrc = srcManual
+	}
+
+	if f.LensID > 0 {
+		p.LensID = f.LensID
+		p.CameraSrc = srcManual
+	}
+
+	// Keywords of removed labels are removed as well, so that the labels are not added again.
+	if len(f.AddKeywords) > 0 || len(f.RemoveKeywords) > 0 || len(f.RemoveLabels) > 0 {
+		remove := make(map[string]bool)
+
+		for _, w := range txt.UniqueWords(f.RemoveKeywords) {
+			remove[w] = true
+		}
+
+		for _, w := range txt.UniqueWords(txt.Words(strings.Join(f.RemoveLabels, " "))) {
+			remove[w] = true
+		}
+
+		var keywords []string
+
+		for _, w := range txt.UniqueWords(append(txt.Words(p.Details.Keywords), f.AddKeywords...)) {
+			if !remove[w] {
+				keywords = append(keywords, w)
+			}
+		}
+
+		if s := strings.Join(keywords, ", "); s != p.Details.Keywords {
+			p.Details.Keywords = s
+			p.Details.KeywordsSrc = srcManual
+		}
+	}
+
+	// Templates are expanded last so that they include the changed values.
+	if f.Title != "" {
+		p.PhotoTitle = strings.TrimSpace(expandTemplate(f.Title, p, n))
+		p.TitleSrc = srcManual
+	}
+
+	if f.Description != "" {
+		p.PhotoDescription = strings.TrimSpace(expandTemplate(f.Description, p, n))
+		p.DescriptionSrc = srcManual
+	}
+
+	return p, nil
+}
+
+// expandTemplate replaces the placeholders in a title or description template.
+func expandTemplate(tpl string, p Photo, n int) string {
+	var year, month, day, date string
+
+	if p.PhotoYear > 0 {
+		year = strconv.Itoa(p.PhotoYear)
+	}
+
+	if p.PhotoMonth > 0 {
+		month = strconv.Itoa(p.PhotoMonth)
+	}
+
+	if p.PhotoDay > 0 {
+		day = strconv.Itoa(p.PhotoDay)
+	}
+
+	if !p.TakenAtLocal.IsZero() {
+		date = p.TakenAtLocal.Format("2006-01-02")
+	}
+
+	return strings.NewReplacer(
+		"{title}", p.PhotoTitle,
+		"{description}", p.PhotoDescription,
+		"{name}", p.OriginalName,
+		"{year}", year,
+		"{month}", month,
+		"{day}", day,
+		"{date}", date,
+		"{country}", p.PhotoCountry,
+		"{n}", strconv.Itoa(n),
+	).Replace(tpl)
+}
@@ -0,0 +1,140 @@
+package form
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBatchEdit_Offset(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		d, err := BatchEdit{}.Offset()
+		assert.NoError(t, err)
+		assert.Equal(t, time.Duration(0), d)
+	})
+	t.Run("negative", func(t *testing.T) {
+		d, err := BatchEdit{TakenOffset: "-1h30m"}.Offset()
+		assert.NoError(t, err)
+		assert.Equal(t, -90*time.Minute, d)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		_, err := BatchEdit{TakenOffset: "1 day"}.Offset()
+		assert.Error(t, err)
+	})
+}
+
+func TestBatchEdit_NoChanges(t *testing.T) {
+	lat := float32(0)
+
+	assert.True(t, BatchEdit{Selection: Selection{Photos: []string{"pt9jtdre2lvl0yh7"}}}.NoChanges())
+	assert.True(t, BatchEdit{Lat: &lat}.NoChanges())
+	assert.False(t, BatchEdit{Lat: &lat, Lng: &lat}.NoChanges())
+	assert.False(t, BatchEdit{Title: "{title}"}.NoChanges())
+	assert.False(t, BatchEdit{RemoveKeywords: []string{"cat"}}.NoChanges())
+	assert.False(t, BatchEdit{PlaceID: "s2:85d1ea7d3278"}.NoChanges())
+}
+
+func TestBatchEdit_Apply(t *testing.T) {
+	p := Photo{
+		PhotoTitle:   "Beach",
+		TakenAt:      time.Date(2020, 6, 1, 10, 0, 0, 0, time.UTC),
+		TakenAtLocal: time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC),
+		TimeZone:     "Europe/Berlin",
+		PhotoYear:    2020,
+		PhotoMonth:   6,
+		PhotoDay:     1,
+		Details:      Details{Keywords: "sand, sea"},
+	}
+
+	t.Run("offset", func(t *testing.T) {
+		r, err := BatchEdit{TakenOffset: "-13h"}.Apply(p, 1)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, time.Date(2020, 5, 31, 23, 0, 0, 0, time.UTC), r.TakenAtLocal)
+		assert.Equal(t, time.Date(2020, 5, 31, 21, 0, 0, 0, time.UTC), r.TakenAt)
+		assert.Equal(t, 31, r.PhotoDay)
+		assert.Equal(t, "manual", r.TakenSrc)
+	})
+	t.Run("time zone", func(t *testing.T) {
+		r, err := BatchEdit{TimeZone: "UTC"}.Apply(p, 1)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, p.TakenAtLocal, r.TakenAtLocal)
+		assert.Equal(t, p.TakenAtLocal, r.TakenAt)
+	})
+	t.Run("invalid offset", func(t *testing.T) {
+		_, err := BatchEdit{TakenOffset: "xxx"}.Apply(p, 1)
+		assert.Error(t, err)
+	})
+	t.Run("location", func(t *testing.T) {
+		lat, lng := float32(52.5), float32(13.4)
+
+		r, err := BatchEdit{Lat: &lat, Lng: &lng, Country: "DE"}.Apply(p, 1)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, lat, r.PhotoLat)
+		assert.Equal(t, lng, r.PhotoLng)
+		assert.Equal(t, "de", r.PhotoCountry)
+		assert.Equal(t, "manual", r.PlaceSrc)
+	})
+	t.Run("place", func(t *testing.T) {
+		r, err := BatchEdit{PlaceID: "s2:85d1ea7d3278"}.Apply(p, 1)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "s2:85d1ea7d3278", r.PlaceID)
+		assert.Equal(t, "manual", r.PlaceSrc)
+	})
+	t.Run("remove labels", func(t *testing.T) {
+		r, err := BatchEdit{RemoveLabels: []string{"Sea"}}.Apply(p, 1)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "sand", r.Details.Keywords)
+		assert.Equal(t, "manual", r.Details.KeywordsSrc)
+	})
+	t.Run("keywords unchanged", func(t *testing.T) {
+		r, err := BatchEdit{RemoveLabels: []string{"Cat"}}.Apply(p, 1)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "sand, sea", r.Details.Keywords)
+		assert.Equal(t, "", r.Details.KeywordsSrc)
+	})
+	t.Run("templates and keywords", func(t *testing.T) {
+		f := BatchEdit{
+			Title:          "{title} {year} #{n}",
+			Description:    "Taken on {date}",
+			AddKeywords:    []string{"Holiday"},
+			RemoveKeywords: []string{"sand"},
+		}
+
+		r, err := f.Apply(p, 3)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "Beach 2020 #3", r.PhotoTitle)
+		assert.Equal(t, "Taken on 2020-06-01", r.PhotoDescription)
+		assert.Equal(t, "holiday, sea", r.Details.Keywords)
+		assert.Equal(t, "manual", r.TitleSrc)
+		assert.Equal(t, "manual", r.Details.KeywordsSrc)
+	})
+}
@@ -25,6 +25,9 @@ type GeoSearch struct {
 	S2       string    `form:"s2"`
 	Olc      string    `form:"olc"`
 	Dist     uint      `form:"dist"`
+	Bbox     string    `form:"bbox"`    // West, south, east, north in degrees.
+	Polygon  string    `form:"polygon"` // GeoJSON geometry.
+	Near     string    `form:"near"`    // Place name.
 	Album    string    `form:"album"`
 	Country  string    `form:"country"`
 	Year     int       `form:"year"`  // Moments
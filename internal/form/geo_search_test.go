@@ -24,6 +24,19 @@ func TestGeoSearch(t *testing.T) {
 		assert.Equal(t, uint(0x61a8), form.Dist)
 		assert.Equal(t, float32(33.45343), form.Lat)
 	})
+	t.Run("valid query near", func(t *testing.T) {
+		form := &GeoSearch{Query: "near:\"Yosemite National Park\" bbox:-120,37,-119,38"}
+
+		err := form.ParseQueryString()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "", form.Query)
+		assert.Equal(t, "Yosemite National Park", form.Near)
+		assert.Equal(t, "-120,37,-119,38", form.Bbox)
+	})
 	t.Run("valid query path empty folder not empty", func(t *testing.T) {
 		form := &GeoSearch{Query: "query:\"fooBar baz\" before:2019-01-15 dist:25000 lat:33.45343166666667 folder:test"}
 
@@ -1,6 +1,7 @@
 package form
 
 type IndexOptions struct {
+	Root   string `json:"root"`
 	Path   string `json:"path"`
 	Rescan bool   `json:"rescan"`
 }
@@ -6,3 +6,9 @@ type Label struct {
 	Uncertainty   int    `json:"Uncertainty"`
 	LabelPriority int    `json:"Priority"`
 }
+
+// LabelSynonym represents a label synonym form, the locale is optional.
+type LabelSynonym struct {
+	SynonymName   string `json:"Name"`
+	SynonymLocale string `json:"Locale"`
+}
@@ -1,10 +1,12 @@
 package form
 
 type Login struct {
-	Email    string `json:"email"`
-	UserName string `json:"username"`
-	Password string `json:"password"`
-	Token    string `json:"token"`
+	Email     string `json:"email"`
+	UserName  string `json:"username"`
+	Password  string `json:"password"`
+	Token     string `json:"token"`
+	Challenge string `json:"challenge"`
+	Passcode  string `json:"passcode"`
 }
 
 func (f Login) HasToken() bool {
@@ -22,3 +24,11 @@ func (f Login) HasPassword() bool {
 func (f Login) HasCredentials() bool {
 	return f.HasUserName() && f.HasPassword()
 }
+
+func (f Login) HasChallenge() bool {
+	return f.Challenge != "" && len(f.Challenge) <= 255
+}
+
+func (f Login) HasPasscode() bool {
+	return f.Passcode != "" && len(f.Passcode) <= 255
+}
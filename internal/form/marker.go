@@ -4,13 +4,18 @@ import "github.com/ulule/deepcopier"
 
 // Marker represents an image marker edit form.
 type Marker struct {
-	RefUID        string `json:"RefUID"`
-	RefSrc        string `json:"RefSrc"`
-	MarkerSrc     string `json:"Src"`
-	MarkerType    string `json:"Type"`
-	MarkerScore   int    `json:"Score"`
-	MarkerInvalid bool   `json:"Invalid"`
-	MarkerLabel   string `json:"Label"`
+	RefUID        string  `json:"RefUID"`
+	RefSrc        string  `json:"RefSrc"`
+	MarkerSrc     string  `json:"Src"`
+	MarkerType    string  `json:"Type"`
+	MarkerScore   int     `json:"Score"`
+	MarkerInvalid bool    `json:"Invalid"`
+	MarkerLabel   string  `json:"Label"`
+	X             float32 `json:"X"`
+	Y             float32 `json:"Y"`
+	W             float32 `json:"W"`
+	H             float32 `json:"H"`
+	NoFace        bool    `json:"NoFace"`
 }
 
 func NewMarker(m interface{}) (f Marker, err error) {
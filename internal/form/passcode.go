@@ -0,0 +1,7 @@
+package form
+
+// Passcode represents a form for enabling or disabling two-factor authentication.
+type Passcode struct {
+	Passcode string `json:"passcode"`
+	Password string `json:"password"`
+}
@@ -68,3 +68,10 @@ func NewPhoto(m interface{}) (f Photo, err error) {
 
 	return f, err
 }
+
+// NewDetails creates Details struct from interface
+func NewDetails(m interface{}) (f Details, err error) {
+	err = deepcopier.Copy(m).To(&f)
+
+	return f, err
+}
@@ -10,6 +10,7 @@ type PhotoSearch struct {
 	Filter    string    `form:"filter"`
 	ID        string    `form:"id"`
 	Type      string    `form:"type"`
+	Root      string    `form:"root"`
 	Path      string    `form:"path"`
 	Folder    string    `form:"folder"` // Alias for Path
 	Name      string    `form:"name"`
@@ -35,6 +36,9 @@ type PhotoSearch struct {
 	Lat       float32   `form:"lat"`
 	Lng       float32   `form:"lng"`
 	Dist      uint      `form:"dist"`
+	Bbox      string    `form:"bbox"`    // West, south, east, north in degrees.
+	Polygon   string    `form:"polygon"` // GeoJSON geometry.
+	Near      string    `form:"near"`    // Place name.
 	Fmin      float32   `form:"fmin"`
 	Fmax      float32   `form:"fmax"`
 	Chroma    uint8     `form:"chroma"`
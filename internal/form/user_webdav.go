@@ -0,0 +1,8 @@
+package form
+
+// UserWebDAV represents a WebDAV access settings form.
+type UserWebDAV struct {
+	WebDAV      bool   `json:"WebDAV"`
+	ReadOnly    bool   `json:"ReadOnly"`
+	StoragePath string `json:"StoragePath"`
+}
@@ -31,18 +31,21 @@ func SetDir(dir string) {
 }
 
 func SetLocale(loc string) {
+	locale = ParseLocale(loc)
+
+	gotext.Configure(localeDir, string(locale), "default")
+}
+
+// ParseLocale returns the normalized locale, e.g. "pt_BR" for "pt-br", or the default locale if invalid.
+func ParseLocale(loc string) Locale {
 	switch len(loc) {
 	case 2:
-		loc = strings.ToLower(loc[:2])
-		locale = Locale(loc)
+		return Locale(strings.ToLower(loc[:2]))
 	case 5:
-		loc = strings.ToLower(loc[:2]) + "_" + strings.ToUpper(loc[3:5])
-		locale = Locale(loc)
+		return Locale(strings.ToLower(loc[:2]) + "_" + strings.ToUpper(loc[3:5]))
 	default:
-		locale = Default
+		return Default
 	}
-
-	gotext.Configure(localeDir, string(locale), "default")
 }
 
 func (l Locale) Locale() string {
@@ -36,3 +36,10 @@ func TestSetLocale(t *testing.T) {
 	assert.Equal(t, English, locale)
 	assert.Equal(t, Default, locale)
 }
+
+func TestParseLocale(t *testing.T) {
+	assert.Equal(t, German, ParseLocale("DE"))
+	assert.Equal(t, BrazilianPortuguese, ParseLocale("pt-br"))
+	assert.Equal(t, Default, ParseLocale("x"))
+	assert.Equal(t, Default, ParseLocale(""))
+}
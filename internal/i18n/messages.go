@@ -30,6 +30,11 @@ const (
 	ErrZipFailed
 	ErrInvalidCredentials
 	ErrInvalidLink
+	ErrPasscodeRequired
+	ErrInvalidPasscode
+	ErrTooManyRequests
+	ErrModifiedSince
+	ErrBusy
 
 	MsgChangesSaved
 	MsgAlbumCreated
@@ -67,6 +72,9 @@ const (
 	MsgAlbumsDeleted
 	MsgZipCreatedIn
 	MsgPermanentlyDeleted
+	MsgTwoFactorEnabled
+	MsgTwoFactorDisabled
+	MsgChangesReverted
 )
 
 var Messages = MessageMap{
@@ -100,6 +108,11 @@ var Messages = MessageMap{
 	ErrZipFailed:          gettext("Failed to create zip file"),
 	ErrInvalidCredentials: gettext("Invalid credentials"),
 	ErrInvalidLink:        gettext("Invalid link"),
+	ErrPasscodeRequired:   gettext("Please enter your verification code"),
+	ErrInvalidPasscode:    gettext("Invalid verification code, please try again"),
+	ErrTooManyRequests:    gettext("Too many failed attempts, please try again later"),
+	ErrModifiedSince:      gettext("Modified since, can't be reverted"),
+	ErrBusy:               gettext("Busy, please try again later"),
 
 	// Info and confirmation messages:
 	MsgChangesSaved:          gettext("Changes successfully saved"),
@@ -138,4 +151,7 @@ var Messages = MessageMap{
 	MsgAlbumsDeleted:         gettext("Albums deleted"),
 	MsgZipCreatedIn:          gettext("Zip created in %d s"),
 	MsgPermanentlyDeleted:    gettext("Permanently deleted"),
+	MsgTwoFactorEnabled:      gettext("Two-factor authentication enabled"),
+	MsgTwoFactorDisabled:     gettext("Two-factor authentication disabled"),
+	MsgChangesReverted:       gettext("%d changes reverted"),
 }
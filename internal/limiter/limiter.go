@@ -0,0 +1,200 @@
+/*
+
+Package limiter provides rate limiting of failed authentication attempts.
+
+Copyright (c) 2018 - 2021 Michael Mayer <hello@photoprism.org>
+
+    This program is free software: you can redistribute it and/or modify
+    it under the terms of the GNU Affero General Public License as published
+    by the Free Software Foundation, either version 3 of the License, or
+    (at your option) any later version.
+
+    This program is distributed in the hope that it will be useful,
+    but WITHOUT ANY WARRANTY; without even the implied warranty of
+    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+    GNU Affero General Public License for more details.
+
+    You should have received a copy of the GNU Affero General Public License
+    along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+    PhotoPrism® is a registered trademark of Michael Mayer.  You may use it as required
+    to describe our software, run your own server, for educational purposes, but not for
+    offering commercial goods, products, or services without prior written permission.
+    In other words, please ask.
+
+Feel free to send an e-mail to hello@photoprism.org if you have questions,
+want to support our work, or just want to say hello.
+
+Additional information can be found in our Developer Guide:
+https://docs.photoprism.org/developer-guide/
+
+*/
+package limiter
+
+import (
+	"sync"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/event"
+)
+
+var log = event.Log
+
+// FreeAttempts is the number of failed attempts that are not delayed, e.g. to allow for typos.
+const FreeAttempts = 3
+
+// maxEntries is the number of tracked keys after which expired entries are purged.
+const maxEntries = 10000
+
+// Limiter tracks failed attempts per key, e.g. a client IP or user name, and blocks further
+// attempts with exponential backoff until the key is temporarily locked out.
+type Limiter struct {
+	mutex    sync.Mutex
+	entries  map[string]*entry
+	attempts int
+	delay    time.Duration
+	lockout  time.Duration
+}
+
+// entry represents the failed attempts of a single key.
+type entry struct {
+	failures     int
+	blockedUntil time.Time
+	lastFailure  time.Time
+}
+
+// New returns a limiter that locks a key out after the given number of failed attempts.
+// After FreeAttempts, the initial delay is doubled with every failed attempt, up to the lockout duration.
+func New(attempts int, delay, lockout time.Duration) *Limiter {
+	return &Limiter{
+		entries:  make(map[string]*entry),
+		attempts: attempts,
+		delay:    delay,
+		lockout:  lockout,
+	}
+}
+
+// Disabled tests if rate limiting is disabled.
+func (l *Limiter) Disabled() bool {
+	return l == nil || l.attempts <= 0
+}
+
+// expired tests if the failed attempts of an entry may be forgotten.
+func (l *Limiter) expired(e *entry, now time.Time) bool {
+	return now.After(e.blockedUntil) && now.Sub(e.lastFailure) > l.lockout
+}
+
+// Blocked returns how long further attempts for the key are blocked, or zero if not blocked.
+func (l *Limiter) Blocked(key string) time.Duration {
+	if l.Disabled() {
+		return 0
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	e, ok := l.entries[key]
+
+	if !ok {
+		return 0
+	}
+
+	now := time.Now()
+
+	if l.expired(e, now) {
+		delete(l.entries, key)
+		return 0
+	}
+
+	if wait := e.blockedUntil.Sub(now); wait > 0 {
+		return wait
+	}
+
+	return 0
+}
+
+// Fail records a failed attempt and returns how long the key is blocked,
+// and whether it has been locked out because the max number of attempts was reached.
+func (l *Limiter) Fail(key string) (wait time.Duration, locked bool) {
+	if l.Disabled() {
+		return 0, false
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	now := time.Now()
+
+	if len(l.entries) >= maxEntries {
+		l.purge(now)
+	}
+
+	e, ok := l.entries[key]
+
+	if !ok || l.expired(e, now) {
+		e = &entry{}
+		l.entries[key] = e
+	}
+
+	e.failures++
+	e.lastFailure = now
+
+	if e.failures >= l.attempts {
+		wait = l.lockout
+		locked = true
+
+		// Start over after the lockout has expired.
+		e.failures = 0
+	} else if e.failures > FreeAttempts {
+		wait = l.delay << uint(e.failures-FreeAttempts-1)
+
+		if wait > l.lockout || wait <= 0 {
+			wait = l.lockout
+		}
+	}
+
+	e.blockedUntil = now.Add(wait)
+
+	if locked {
+		log.Warnf("limiter: too many failed attempts, locked out for %s", wait.String())
+	}
+
+	return wait, locked
+}
+
+// Failures returns the number of failed attempts for the key since the last lockout.
+func (l *Limiter) Failures(key string) int {
+	if l.Disabled() {
+		return 0
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if e, ok := l.entries[key]; ok && !l.expired(e, time.Now()) {
+		return e.failures
+	}
+
+	return 0
+}
+
+// Reset forgets all failed attempts for the key, e.g. after a successful login.
+func (l *Limiter) Reset(key string) {
+	if l.Disabled() {
+		return
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	delete(l.entries, key)
+}
+
+// purge removes expired entries, the caller must hold the mutex.
+func (l *Limiter) purge(now time.Time) {
+	for key, e := range l.entries {
+		if l.expired(e, now) {
+			delete(l.entries, key)
+		}
+	}
+}
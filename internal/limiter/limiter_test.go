@@ -0,0 +1,85 @@
+package limiter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLimiter_Fail(t *testing.T) {
+	t.Run("backoff", func(t *testing.T) {
+		l := New(FreeAttempts+4, time.Second, time.Minute)
+
+		assert.Equal(t, time.Duration(0), l.Blocked("ip:127.0.0.1"))
+
+		for i := 0; i < FreeAttempts; i++ {
+			wait, locked := l.Fail("ip:127.0.0.1")
+			assert.Equal(t, time.Duration(0), wait)
+			assert.False(t, locked)
+		}
+
+		assert.Equal(t, time.Duration(0), l.Blocked("ip:127.0.0.1"))
+
+		wait, locked := l.Fail("ip:127.0.0.1")
+		assert.Equal(t, time.Second, wait)
+		assert.False(t, locked)
+		assert.True(t, l.Blocked("ip:127.0.0.1") > 0)
+
+		wait, locked = l.Fail("ip:127.0.0.1")
+		assert.Equal(t, 2*time.Second, wait)
+		assert.False(t, locked)
+
+		wait, locked = l.Fail("ip:127.0.0.1")
+		assert.Equal(t, 4*time.Second, wait)
+		assert.False(t, locked)
+		assert.Equal(t, FreeAttempts+3, l.Failures("ip:127.0.0.1"))
+
+		wait, locked = l.Fail("ip:127.0.0.1")
+		assert.Equal(t, time.Minute, wait)
+		assert.True(t, locked)
+		assert.True(t, l.Blocked("ip:127.0.0.1") > 30*time.Second)
+
+		assert.Equal(t, time.Duration(0), l.Blocked("ip:127.0.0.2"))
+	})
+	t.Run("max delay", func(t *testing.T) {
+		l := New(FreeAttempts+10, time.Second, 3*time.Second)
+
+		for i := 0; i < FreeAttempts+2; i++ {
+			l.Fail("user:admin")
+		}
+
+		wait, locked := l.Fail("user:admin")
+		assert.Equal(t, 3*time.Second, wait)
+		assert.False(t, locked)
+	})
+}
+
+func TestLimiter_Reset(t *testing.T) {
+	l := New(FreeAttempts+3, time.Second, time.Minute)
+
+	for i := 0; i <= FreeAttempts; i++ {
+		l.Fail("user:admin")
+	}
+
+	assert.True(t, l.Blocked("user:admin") > 0)
+
+	l.Reset("user:admin")
+	assert.Equal(t, time.Duration(0), l.Blocked("user:admin"))
+	assert.Equal(t, 0, l.Failures("user:admin"))
+}
+
+func TestLimiter_Disabled(t *testing.T) {
+	l := New(0, time.Second, time.Minute)
+
+	assert.True(t, l.Disabled())
+
+	wait, locked := l.Fail("user:admin")
+	assert.Equal(t, time.Duration(0), wait)
+	assert.False(t, locked)
+	assert.Equal(t, time.Duration(0), l.Blocked("user:admin"))
+
+	var empty *Limiter
+
+	assert.True(t, empty.Disabled())
+}
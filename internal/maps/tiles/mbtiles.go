@@ -0,0 +1,128 @@
+package tiles
+
+import (
+	"bytes"
+	"database/sql"
+	"fmt"
+	"strconv"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// MBTiles represents a SQLite tile archive.
+type MBTiles struct {
+	db       *sql.DB
+	format   string
+	gzip     bool
+	minZoom  int
+	maxZoom  int
+	metadata map[string]string
+}
+
+// OpenMBTiles opens a MBTiles archive in read-only mode.
+func OpenMBTiles(fileName string) (*MBTiles, error) {
+	db, err := sql.Open("sqlite3", fmt.Sprintf("file:%s?mode=ro", fileName))
+
+	if err != nil {
+		return nil, err
+	}
+
+	m := &MBTiles{db: db, metadata: make(map[string]string), maxZoom: 22}
+
+	if err := m.readMetadata(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("tiles: %s (%s)", err, fileName)
+	}
+
+	return m, nil
+}
+
+// readMetadata reads the tile format and zoom levels from the metadata table.
+func (m *MBTiles) readMetadata() error {
+	rows, err := m.db.Query("SELECT name, value FROM metadata")
+
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var name, value string
+
+		if err := rows.Scan(&name, &value); err != nil {
+			return err
+		}
+
+		m.metadata[name] = value
+	}
+
+	switch m.metadata["format"] {
+	case "pbf", "mvt":
+		m.format = FormatPbf
+	case "jpg", "jpeg":
+		m.format = FormatJpeg
+	case "webp":
+		m.format = FormatWebp
+	default:
+		m.format = FormatPng
+	}
+
+	if v, err := strconv.Atoi(m.metadata["minzoom"]); err == nil {
+		m.minZoom = v
+	}
+
+	if v, err := strconv.Atoi(m.metadata["maxzoom"]); err == nil {
+		m.maxZoom = v
+	}
+
+	// Vector tiles are usually stored gzip compressed, check the first tile to make sure.
+	if m.format == FormatPbf {
+		var data []byte
+
+		if err := m.db.QueryRow("SELECT tile_data FROM tiles LIMIT 1").Scan(&data); err == nil {
+			m.gzip = bytes.HasPrefix(data, []byte{0x1f, 0x8b})
+		}
+	}
+
+	return rows.Err()
+}
+
+// Tile returns the tile data for the given XYZ coordinates, or ErrNotFound.
+func (m *MBTiles) Tile(z, x, y int) (data []byte, err error) {
+	// MBTiles uses the TMS scheme with the origin in the bottom left corner.
+	row := (1 << uint(z)) - 1 - y
+
+	err = m.db.QueryRow("SELECT tile_data FROM tiles WHERE zoom_level = ? AND tile_column = ? AND tile_row = ?", z, x, row).Scan(&data)
+
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+
+	return data, err
+}
+
+// Format returns the tile format, e.g. "pbf" or "png".
+func (m *MBTiles) Format() string {
+	return m.format
+}
+
+// Gzip returns true if tiles are stored gzip compressed.
+func (m *MBTiles) Gzip() bool {
+	return m.gzip
+}
+
+// MinZoom returns the minimum zoom level.
+func (m *MBTiles) MinZoom() int {
+	return m.minZoom
+}
+
+// MaxZoom returns the maximum zoom level.
+func (m *MBTiles) MaxZoom() int {
+	return m.maxZoom
+}
+
+// Close closes the archive.
+func (m *MBTiles) Close() error {
+	return m.db.Close()
+}
@@ -0,0 +1,58 @@
+package tiles
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOpenMBTiles(t *testing.T) {
+	t.Run("png", func(t *testing.T) {
+		m, err := OpenMBTiles(createMBTiles(t, "png", nil))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer m.Close()
+
+		assert.Equal(t, FormatPng, m.Format())
+		assert.False(t, m.Gzip())
+		assert.Equal(t, 0, m.MinZoom())
+		assert.Equal(t, 14, m.MaxZoom())
+	})
+	t.Run("pbf", func(t *testing.T) {
+		m, err := OpenMBTiles(createMBTiles(t, "pbf", []byte{0x1f, 0x8b, 0x08}))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer m.Close()
+
+		assert.Equal(t, FormatPbf, m.Format())
+		assert.True(t, m.Gzip())
+	})
+}
+
+func TestMBTiles_Tile(t *testing.T) {
+	m, err := OpenMBTiles(createMBTiles(t, "png", nil))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer m.Close()
+
+	t.Run("found", func(t *testing.T) {
+		data, err := m.Tile(1, 1, 0)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("tile"), data)
+	})
+	t.Run("not found", func(t *testing.T) {
+		_, err := m.Tile(1, 1, 1)
+
+		assert.Equal(t, ErrNotFound, err)
+	})
+}
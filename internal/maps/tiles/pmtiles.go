@@ -0,0 +1,323 @@
+package tiles
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+)
+
+// PMTiles header size and compression types, see https://github.com/protomaps/PMTiles/blob/main/spec/v3/spec.md
+const (
+	pmHeaderSize   = 127
+	pmCompressNone = 1
+	pmCompressGzip = 2
+	pmMaxDirDepth  = 4
+	pmTileTypeMvt  = 1
+	pmTileTypePng  = 2
+	pmTileTypeJpeg = 3
+	pmTileTypeWebp = 4
+)
+
+// pmEntry represents a PMTiles directory entry.
+type pmEntry struct {
+	TileID    uint64
+	Offset    uint64
+	Length    uint32
+	RunLength uint32
+}
+
+// PMTiles represents a single file tile archive.
+type PMTiles struct {
+	file            *os.File
+	rootDir         []pmEntry
+	leafDirsOffset  uint64
+	tileDataOffset  uint64
+	dirCompression  uint8
+	tileCompression uint8
+	format          string
+	minZoom         int
+	maxZoom         int
+}
+
+// OpenPMTiles opens a PMTiles version 3 archive.
+func OpenPMTiles(fileName string) (*PMTiles, error) {
+	f, err := os.Open(fileName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	p := &PMTiles{file: f}
+
+	if err := p.readHeader(); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("tiles: %s (%s)", err, fileName)
+	}
+
+	return p, nil
+}
+
+// readHeader reads the archive header and root directory.
+func (p *PMTiles) readHeader() error {
+	h := make([]byte, pmHeaderSize)
+
+	if _, err := p.file.ReadAt(h, 0); err != nil {
+		return err
+	}
+
+	if string(h[0:7]) != "PMTiles" {
+		return errors.New("invalid pmtiles header")
+	} else if h[7] != 3 {
+		return fmt.Errorf("unsupported pmtiles version %d", h[7])
+	}
+
+	rootOffset := binary.LittleEndian.Uint64(h[8:16])
+	rootLength := binary.LittleEndian.Uint64(h[16:24])
+	p.leafDirsOffset = binary.LittleEndian.Uint64(h[40:48])
+	p.tileDataOffset = binary.LittleEndian.Uint64(h[56:64])
+	p.dirCompression = h[97]
+	p.tileCompression = h[98]
+	p.minZoom = int(h[100])
+	p.maxZoom = int(h[101])
+
+	switch h[99] {
+	case pmTileTypeMvt:
+		p.format = FormatPbf
+	case pmTileTypeJpeg:
+		p.format = FormatJpeg
+	case pmTileTypeWebp:
+		p.format = FormatWebp
+	default:
+		p.format = FormatPng
+	}
+
+	if p.tileCompression != pmCompressNone && p.tileCompression != pmCompressGzip {
+		return fmt.Errorf("unsupported tile compression %d", p.tileCompression)
+	}
+
+	dir, err := p.readDir(rootOffset, rootLength)
+
+	if err != nil {
+		return err
+	}
+
+	p.rootDir = dir
+
+	return nil
+}
+
+// readDir reads and decodes a directory.
+func (p *PMTiles) readDir(offset, length uint64) ([]pmEntry, error) {
+	b := make([]byte, length)
+
+	if _, err := p.file.ReadAt(b, int64(offset)); err != nil {
+		return nil, err
+	}
+
+	switch p.dirCompression {
+	case pmCompressNone:
+	case pmCompressGzip:
+		r, err := gzip.NewReader(bytes.NewReader(b))
+
+		if err != nil {
+			return nil, err
+		}
+
+		if b, err = ioutil.ReadAll(r); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported directory compression %d", p.dirCompression)
+	}
+
+	return decodePmDir(b)
+}
+
+// decodePmDir decodes a serialized directory with delta encoded tile ids and offsets.
+func decodePmDir(b []byte) (entries []pmEntry, err error) {
+	r := bytes.NewReader(b)
+
+	n, err := binary.ReadUvarint(r)
+
+	if err != nil {
+		return nil, err
+	} else if n > uint64(len(b)) {
+		return nil, errors.New("invalid pmtiles directory")
+	}
+
+	entries = make([]pmEntry, n)
+
+	var lastID uint64
+
+	for i := range entries {
+		v, err := binary.ReadUvarint(r)
+
+		if err != nil {
+			return nil, err
+		}
+
+		lastID += v
+		entries[i].TileID = lastID
+	}
+
+	for i := range entries {
+		v, err := binary.ReadUvarint(r)
+
+		if err != nil {
+			return nil, err
+		}
+
+		entries[i].RunLength = uint32(v)
+	}
+
+	for i := range entries {
+		v, err := binary.ReadUvarint(r)
+
+		if err != nil {
+			return nil, err
+		}
+
+		entries[i].Length = uint32(v)
+	}
+
+	for i := range entries {
+		v, err := binary.ReadUvarint(r)
+
+		if err != nil {
+			return nil, err
+		}
+
+		// Zero means the data directly follows the previous entry.
+		if v == 0 && i > 0 {
+			entries[i].Offset = entries[i-1].Offset + uint64(entries[i-1].Length)
+		} else {
+			entries[i].Offset = v - 1
+		}
+	}
+
+	return entries, nil
+}
+
+// findPmEntry returns the directory entry containing the tile id.
+func findPmEntry(entries []pmEntry, tileID uint64) (pmEntry, bool) {
+	i := sort.Search(len(entries), func(i int) bool { return entries[i].TileID > tileID }) - 1
+
+	if i < 0 {
+		return pmEntry{}, false
+	}
+
+	e := entries[i]
+
+	// Leaf directory entries have a run length of zero.
+	if e.RunLength == 0 || tileID < e.TileID+uint64(e.RunLength) {
+		return e, true
+	}
+
+	return pmEntry{}, false
+}
+
+// Tile returns the tile data for the given XYZ coordinates, or ErrNotFound.
+func (p *PMTiles) Tile(z, x, y int) ([]byte, error) {
+	tileID := ZxyToID(uint8(z), uint32(x), uint32(y))
+	dir := p.rootDir
+
+	for depth := 0; depth < pmMaxDirDepth; depth++ {
+		e, ok := findPmEntry(dir, tileID)
+
+		if !ok {
+			return nil, ErrNotFound
+		}
+
+		if e.RunLength > 0 {
+			data := make([]byte, e.Length)
+
+			if _, err := p.file.ReadAt(data, int64(p.tileDataOffset+e.Offset)); err != nil {
+				return nil, err
+			}
+
+			return data, nil
+		}
+
+		leaf, err := p.readDir(p.leafDirsOffset+e.Offset, uint64(e.Length))
+
+		if err != nil {
+			return nil, err
+		}
+
+		dir = leaf
+	}
+
+	log.Warnf("tiles: max directory depth exceeded for tile %d/%d/%d", z, x, y)
+
+	return nil, ErrNotFound
+}
+
+// Format returns the tile format, e.g. "pbf" or "png".
+func (p *PMTiles) Format() string {
+	return p.format
+}
+
+// Gzip returns true if tiles are stored gzip compressed.
+func (p *PMTiles) Gzip() bool {
+	return p.tileCompression == pmCompressGzip
+}
+
+// MinZoom returns the minimum zoom level.
+func (p *PMTiles) MinZoom() int {
+	return p.minZoom
+}
+
+// MaxZoom returns the maximum zoom level.
+func (p *PMTiles) MaxZoom() int {
+	return p.maxZoom
+}
+
+// Close closes the archive.
+func (p *PMTiles) Close() error {
+	return p.file.Close()
+}
+
+// ZxyToID returns the PMTiles tile id, which is based on a Hilbert curve for each zoom level.
+func ZxyToID(z uint8, x, y uint32) uint64 {
+	var acc uint64
+
+	for t := uint8(0); t < z; t++ {
+		acc += uint64(1) << (2 * t)
+	}
+
+	n := uint64(1) << z
+	tx, ty := uint64(x), uint64(y)
+
+	var d uint64
+
+	for s := n / 2; s > 0; s /= 2 {
+		var rx, ry uint64
+
+		if tx&s > 0 {
+			rx = 1
+		}
+
+		if ty&s > 0 {
+			ry = 1
+		}
+
+		d += s * s * ((3 * rx) ^ ry)
+
+		// Rotate the quadrant.
+		if ry == 0 {
+			if rx == 1 {
+				tx = n - 1 - tx
+				ty = n - 1 - ty
+			}
+
+			tx, ty = ty, tx
+		}
+	}
+
+	return acc + d
+}
@@ -0,0 +1,180 @@
+package tiles
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type pmTestTile struct {
+	Z, X, Y int
+	Data    []byte
+}
+
+// createPMTiles creates a PMTiles archive with a single root directory and returns the file name.
+func createPMTiles(t *testing.T, tiles []pmTestTile, compression uint8) string {
+	var entries []pmEntry
+	var data []byte
+
+	sort.Slice(tiles, func(i, j int) bool {
+		return ZxyToID(uint8(tiles[i].Z), uint32(tiles[i].X), uint32(tiles[i].Y)) < ZxyToID(uint8(tiles[j].Z), uint32(tiles[j].X), uint32(tiles[j].Y))
+	})
+
+	for _, tile := range tiles {
+		entries = append(entries, pmEntry{
+			TileID:    ZxyToID(uint8(tile.Z), uint32(tile.X), uint32(tile.Y)),
+			Offset:    uint64(len(data)),
+			Length:    uint32(len(tile.Data)),
+			RunLength: 1,
+		})
+
+		data = append(data, tile.Data...)
+	}
+
+	dir := encodePmDir(entries)
+
+	if compression == pmCompressGzip {
+		var buf bytes.Buffer
+		w := gzip.NewWriter(&buf)
+		w.Write(dir)
+		w.Close()
+		dir = buf.Bytes()
+	}
+
+	h := make([]byte, pmHeaderSize)
+	copy(h, "PMTiles")
+	h[7] = 3
+	binary.LittleEndian.PutUint64(h[8:16], pmHeaderSize)
+	binary.LittleEndian.PutUint64(h[16:24], uint64(len(dir)))
+	binary.LittleEndian.PutUint64(h[40:48], uint64(pmHeaderSize+len(dir)))
+	binary.LittleEndian.PutUint64(h[56:64], uint64(pmHeaderSize+len(dir)))
+	binary.LittleEndian.PutUint64(h[64:72], uint64(len(data)))
+	h[97] = compression
+	h[98] = pmCompressNone
+	h[99] = pmTileTypeMvt
+	h[100] = 0
+	h[101] = 14
+
+	fileName := filepath.Join(t.TempDir(), "test.pmtiles")
+
+	if err := ioutil.WriteFile(fileName, append(append(h, dir...), data...), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	return fileName
+}
+
+// encodePmDir serializes directory entries.
+func encodePmDir(entries []pmEntry) []byte {
+	var b []byte
+
+	buf := make([]byte, binary.MaxVarintLen64)
+	put := func(v uint64) {
+		b = append(b, buf[:binary.PutUvarint(buf, v)]...)
+	}
+
+	put(uint64(len(entries)))
+
+	var lastID uint64
+
+	for _, e := range entries {
+		put(e.TileID - lastID)
+		lastID = e.TileID
+	}
+
+	for _, e := range entries {
+		put(uint64(e.RunLength))
+	}
+
+	for _, e := range entries {
+		put(uint64(e.Length))
+	}
+
+	for i, e := range entries {
+		if i > 0 && e.Offset == entries[i-1].Offset+uint64(entries[i-1].Length) {
+			put(0)
+		} else {
+			put(e.Offset + 1)
+		}
+	}
+
+	return b
+}
+
+func TestZxyToID(t *testing.T) {
+	assert.Equal(t, uint64(0), ZxyToID(0, 0, 0))
+	assert.Equal(t, uint64(1), ZxyToID(1, 0, 0))
+	assert.Equal(t, uint64(2), ZxyToID(1, 0, 1))
+	assert.Equal(t, uint64(3), ZxyToID(1, 1, 1))
+	assert.Equal(t, uint64(4), ZxyToID(1, 1, 0))
+	assert.Equal(t, uint64(5), ZxyToID(2, 0, 0))
+	assert.Equal(t, uint64(20), ZxyToID(2, 3, 0))
+}
+
+func TestDecodePmDir(t *testing.T) {
+	entries := []pmEntry{
+		{TileID: 1, Offset: 0, Length: 10, RunLength: 1},
+		{TileID: 2, Offset: 10, Length: 5, RunLength: 2},
+		{TileID: 7, Offset: 100, Length: 20, RunLength: 0},
+	}
+
+	result, err := decodePmDir(encodePmDir(entries))
+
+	assert.NoError(t, err)
+	assert.Equal(t, entries, result)
+}
+
+func TestPMTiles_Tile(t *testing.T) {
+	tiles := []pmTestTile{
+		{0, 0, 0, []byte("world")},
+		{1, 1, 0, []byte("north-east")},
+		{1, 0, 1, []byte("south-west")},
+	}
+
+	for _, compression := range []uint8{pmCompressNone, pmCompressGzip} {
+		p, err := OpenPMTiles(createPMTiles(t, tiles, compression))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, FormatPbf, p.Format())
+		assert.False(t, p.Gzip())
+		assert.Equal(t, 14, p.MaxZoom())
+
+		for _, tile := range tiles {
+			data, err := p.Tile(tile.Z, tile.X, tile.Y)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tile.Data, data)
+		}
+
+		_, err = p.Tile(1, 1, 1)
+		assert.Equal(t, ErrNotFound, err)
+
+		_, err = p.Tile(5, 0, 0)
+		assert.Equal(t, ErrNotFound, err)
+
+		p.Close()
+	}
+}
+
+func TestOpenPMTiles(t *testing.T) {
+	t.Run("invalid header", func(t *testing.T) {
+		fileName := filepath.Join(t.TempDir(), "invalid.pmtiles")
+
+		if err := ioutil.WriteFile(fileName, make([]byte, pmHeaderSize), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		_, err := OpenPMTiles(fileName)
+
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,101 @@
+/*
+
+Package tiles provides read access to local map tile archives in MBTiles and PMTiles format.
+
+See https://github.com/mapbox/mbtiles-spec and https://github.com/protomaps/PMTiles
+
+Copyright (c) 2018 - 2021 Michael Mayer <hello@photoprism.org>
+
+    This program is free software: you can redistribute it and/or modify
+    it under the terms of the GNU Affero General Public License as published
+    by the Free Software Foundation, either version 3 of the License, or
+    (at your option) any later version.
+
+    This program is distributed in the hope that it will be useful,
+    but WITHOUT ANY WARRANTY; without even the implied warranty of
+    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+    GNU Affero General Public License for more details.
+
+    You should have received a copy of the GNU Affero General Public License
+    along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+    PhotoPrism® is a registered trademark of Michael Mayer.  You may use it as required
+    to describe our software, run your own server, for educational purposes, but not for
+    offering commercial goods, products, or services without prior written permission.
+    In other words, please ask.
+
+Feel free to send an e-mail to hello@photoprism.org if you have questions,
+want to support our work, or just want to say hello.
+
+Additional information can be found in our Developer Guide:
+https://docs.photoprism.org/developer-guide/
+
+*/
+package tiles
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/event"
+)
+
+var log = event.Log
+
+// ErrNotFound is returned if an archive doesn't contain the requested tile.
+var ErrNotFound = errors.New("tile not found")
+
+// Tile formats.
+const (
+	FormatPbf  = "pbf"
+	FormatPng  = "png"
+	FormatJpeg = "jpg"
+	FormatWebp = "webp"
+)
+
+// ContentTypes maps tile formats to their MIME type.
+var ContentTypes = map[string]string{
+	FormatPbf:  "application/vnd.mapbox-vector-tile",
+	FormatPng:  "image/png",
+	FormatJpeg: "image/jpeg",
+	FormatWebp: "image/webp",
+}
+
+// Source represents a local tile archive.
+type Source interface {
+	// Tile returns the tile data for the given XYZ coordinates, or ErrNotFound.
+	Tile(z, x, y int) ([]byte, error)
+	// Format returns the tile format, e.g. "pbf" or "png".
+	Format() string
+	// Gzip returns true if tiles are stored gzip compressed.
+	Gzip() bool
+	// MinZoom returns the minimum zoom level.
+	MinZoom() int
+	// MaxZoom returns the maximum zoom level.
+	MaxZoom() int
+	// Close closes the archive.
+	Close() error
+}
+
+// Open opens a MBTiles or PMTiles archive depending on the file extension.
+func Open(fileName string) (Source, error) {
+	switch strings.ToLower(filepath.Ext(fileName)) {
+	case ".mbtiles":
+		return OpenMBTiles(fileName)
+	case ".pmtiles":
+		return OpenPMTiles(fileName)
+	default:
+		return nil, fmt.Errorf("tiles: unsupported file type %s", filepath.Ext(fileName))
+	}
+}
+
+// ContentType returns the MIME type of the tiles provided by source.
+func ContentType(s Source) string {
+	if t, ok := ContentTypes[s.Format()]; ok {
+		return t
+	}
+
+	return "application/octet-stream"
+}
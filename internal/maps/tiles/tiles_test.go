@@ -0,0 +1,84 @@
+package tiles
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOpen(t *testing.T) {
+	t.Run("mbtiles", func(t *testing.T) {
+		fileName := createMBTiles(t, "png", nil)
+
+		s, err := Open(fileName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer s.Close()
+
+		assert.IsType(t, &MBTiles{}, s)
+		assert.Equal(t, "image/png", ContentType(s))
+	})
+	t.Run("pmtiles", func(t *testing.T) {
+		fileName := createPMTiles(t, []pmTestTile{{0, 0, 0, []byte("world")}}, pmCompressGzip)
+
+		s, err := Open(fileName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer s.Close()
+
+		assert.IsType(t, &PMTiles{}, s)
+		assert.Equal(t, "application/vnd.mapbox-vector-tile", ContentType(s))
+	})
+	t.Run("unsupported", func(t *testing.T) {
+		_, err := Open("world.zip")
+
+		assert.Error(t, err)
+	})
+	t.Run("not found", func(t *testing.T) {
+		_, err := Open(filepath.Join(t.TempDir(), "missing.pmtiles"))
+
+		assert.Error(t, err)
+	})
+}
+
+// createMBTiles creates a MBTiles archive containing a tile for 1/1/0 and returns the file name.
+func createMBTiles(t *testing.T, format string, data []byte) string {
+	fileName := filepath.Join(t.TempDir(), "test.mbtiles")
+
+	db, err := sql.Open("sqlite3", fileName)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer db.Close()
+
+	if data == nil {
+		data = []byte("tile")
+	}
+
+	for _, stmt := range []string{
+		"CREATE TABLE metadata (name TEXT, value TEXT)",
+		"CREATE TABLE tiles (zoom_level INTEGER, tile_column INTEGER, tile_row INTEGER, tile_data BLOB)",
+		"INSERT INTO metadata (name, value) VALUES ('format', '" + format + "'), ('minzoom', '0'), ('maxzoom', '14')",
+	} {
+		if _, err := db.Exec(stmt); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Rows are stored in TMS order, so 1/1/0 is stored as row 1.
+	if _, err := db.Exec("INSERT INTO tiles (zoom_level, tile_column, tile_row, tile_data) VALUES (1, 1, 1, ?)", data); err != nil {
+		t.Fatal(err)
+	}
+
+	return fileName
+}
@@ -0,0 +1,56 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler(t *testing.T) {
+	IndexFiles.WithLabelValues("added").Inc()
+	IndexFiles.WithLabelValues("skipped").Add(2)
+
+	req, _ := http.NewRequest("GET", "/metrics", nil)
+	w := httptest.NewRecorder()
+
+	Handler().ServeHTTP(w, req)
+
+	out := w.Body.String()
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, out, "# HELP photoprism_index_files_total Number of indexed files by result.\n")
+	assert.Contains(t, out, "# TYPE photoprism_index_files_total counter\n")
+	assert.Contains(t, out, "photoprism_index_files_total{result=\"added\"} 1\n")
+	assert.Contains(t, out, "photoprism_index_files_total{result=\"skipped\"} 2\n")
+	assert.Contains(t, out, "photoprism_thumb_cache_hit_ratio ")
+}
+
+func TestStageDuration(t *testing.T) {
+	StageDuration.WithLabelValues(StageFaces).Observe(0.05)
+	StageDuration.WithLabelValues(StageFaces).Observe(5)
+
+	assert.Equal(t, 1, testutil.CollectAndCount(StageDuration, "photoprism_index_stage_duration_seconds"))
+}
+
+func TestThumbCacheRatio(t *testing.T) {
+	ThumbCache.Reset()
+
+	assert.Equal(t, float64(0), testutil.ToFloat64(ThumbCacheRatio))
+
+	ThumbCache.WithLabelValues(CacheHit).Inc()
+	ThumbCache.WithLabelValues(CacheHit).Inc()
+	ThumbCache.WithLabelValues(CacheHit).Inc()
+	ThumbCache.WithLabelValues(CacheMiss).Inc()
+
+	assert.Equal(t, 0.75, testutil.ToFloat64(ThumbCacheRatio))
+}
+
+func TestRegistry(t *testing.T) {
+	problems, err := testutil.GatherAndLint(Registry)
+
+	assert.NoError(t, err)
+	assert.Empty(t, problems)
+}
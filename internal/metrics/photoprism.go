@@ -0,0 +1,132 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// Index and import stages with separate duration metrics.
+const (
+	StageMetadata   = "metadata"
+	StageThumbnails = "thumbnails"
+	StageTensorFlow = "tensorflow"
+	StageFaces      = "faces"
+	StageObjects    = "objects"
+	StageOcr        = "ocr"
+)
+
+// Work queues with depth metrics.
+const (
+	QueueIndex    = "index"
+	QueueImport   = "import"
+	QueueThumbs   = "thumbs"
+	QueueClassify = "classify"
+)
+
+// Thumbnail cache results.
+const (
+	CacheHit  = "hit"
+	CacheMiss = "miss"
+)
+
+// DefaultBuckets are the histogram upper bounds in seconds.
+var DefaultBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10, 30, 60}
+
+var (
+	// LibraryItems is the number of library items by type, updated before metrics are written.
+	LibraryItems = factory.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "photoprism_library_items",
+		Help: "Number of library items by type.",
+	}, []string{"type"})
+
+	// IndexFiles counts indexed files by result, e.g. added or skipped.
+	IndexFiles = factory.NewCounterVec(prometheus.CounterOpts{
+		Name: "photoprism_index_files_total",
+		Help: "Number of indexed files by result.",
+	}, []string{"result"})
+
+	// ImportFiles counts imported files by result.
+	ImportFiles = factory.NewCounterVec(prometheus.CounterOpts{
+		Name: "photoprism_import_files_total",
+		Help: "Number of imported files by result.",
+	}, []string{"result"})
+
+	// StageDuration measures the duration of index stages.
+	StageDuration = factory.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "photoprism_index_stage_duration_seconds",
+		Help:    "Duration of index stages in seconds.",
+		Buckets: DefaultBuckets,
+	}, []string{"stage"})
+
+	// QueueDepth is the number of jobs waiting in work queues.
+	QueueDepth = factory.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "photoprism_queue_depth",
+		Help: "Number of jobs waiting in work queues.",
+	}, []string{"queue"})
+
+	// ThumbCache counts thumbnail cache requests by result.
+	ThumbCache = factory.NewCounterVec(prometheus.CounterOpts{
+		Name: "photoprism_thumb_cache_requests_total",
+		Help: "Number of thumbnail cache requests by result.",
+	}, []string{"result"})
+
+	// ThumbCacheRatio is the share of thumbnail cache hits.
+	ThumbCacheRatio = factory.NewGaugeFunc(prometheus.GaugeOpts{
+		Name: "photoprism_thumb_cache_hit_ratio",
+		Help: "Share of thumbnail cache requests that were hits.",
+	}, thumbCacheRatio)
+
+	// HttpDuration measures the latency of HTTP requests by route.
+	HttpDuration = factory.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "photoprism_http_request_duration_seconds",
+		Help:    "Latency of HTTP requests in seconds.",
+		Buckets: DefaultBuckets,
+	}, []string{"method", "route", "status"})
+
+	// DbConnections is the number of database connections by state, updated before metrics are written.
+	DbConnections = factory.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "photoprism_db_connections",
+		Help: "Number of database connections by state.",
+	}, []string{"state"})
+
+	// DbWaits is the total number of connections waited for, updated before metrics are written.
+	DbWaits = factory.NewGauge(prometheus.GaugeOpts{
+		Name: "photoprism_db_waits",
+		Help: "Total number of database connections waited for.",
+	})
+
+	// DbWaitDuration is the total time blocked waiting for connections, updated before metrics are written.
+	DbWaitDuration = factory.NewGauge(prometheus.GaugeOpts{
+		Name: "photoprism_db_wait_duration_seconds",
+		Help: "Total time blocked waiting for database connections in seconds.",
+	})
+
+	// WorkerErrors counts errors of background workers, e.g. sync.
+	WorkerErrors = factory.NewCounterVec(prometheus.CounterOpts{
+		Name: "photoprism_worker_errors_total",
+		Help: "Number of background worker errors.",
+	}, []string{"worker"})
+)
+
+// counterValue returns the current value of a counter.
+func counterValue(c prometheus.Counter) float64 {
+	m := &dto.Metric{}
+
+	if err := c.Write(m); err != nil {
+		return 0
+	}
+
+	return m.GetCounter().GetValue()
+}
+
+// thumbCacheRatio returns the share of thumbnail cache hits based on the request counters.
+func thumbCacheRatio() float64 {
+	hits := counterValue(ThumbCache.WithLabelValues(CacheHit))
+	total := hits + counterValue(ThumbCache.WithLabelValues(CacheMiss))
+
+	if total == 0 {
+		return 0
+	}
+
+	return hits / total
+}
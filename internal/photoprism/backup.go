@@ -0,0 +1,67 @@
+package photoprism
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+
+	"github.com/photoprism/photoprism/internal/config"
+)
+
+// DumpIndex returns an SQL dump of the index database.
+func DumpIndex() ([]byte, error) {
+	c := Config()
+
+	var cmd *exec.Cmd
+
+	switch c.DatabaseDriver() {
+	case config.MySQL, config.MariaDB:
+		cmd = exec.Command(
+			c.MysqldumpBin(),
+			"--protocol", "tcp",
+			"-h", c.DatabaseHost(),
+			"-P", c.DatabasePortString(),
+			"-u", c.DatabaseUser(),
+			"-p"+c.DatabasePassword(),
+			c.DatabaseName(),
+		)
+	case config.Postgres:
+		cmd = exec.Command(
+			c.PgDumpBin(),
+			"-h", c.DatabaseHost(),
+			"-p", c.DatabasePortString(),
+			"-U", c.DatabaseUser(),
+			"--clean",
+			"--if-exists",
+			c.DatabaseName(),
+		)
+		cmd.Env = append(os.Environ(), "PGPASSWORD="+c.DatabasePassword())
+	case config.SQLite:
+		cmd = exec.Command(
+			c.SqliteBin(),
+			c.DatabaseDsn(),
+			".dump",
+		)
+	default:
+		return nil, fmt.Errorf("unsupported database type: %s", c.DatabaseDriver())
+	}
+
+	// Fetch command output.
+	var out bytes.Buffer
+	var stderr bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &stderr
+
+	// Run backup command.
+	if err := cmd.Run(); err != nil {
+		if stderr.String() != "" {
+			return nil, errors.New(stderr.String())
+		}
+
+		return nil, err
+	}
+
+	return out.Bytes(), nil
+}
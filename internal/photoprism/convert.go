@@ -226,6 +226,15 @@ func (c *Convert) JpegConvertCommand(f *MediaFile, jpegName string, xmpName stri
 	return result, useMutex, nil
 }
 
+// sidecarWritable checks if sidecar files can be created for the media file without writing to read-only libraries.
+func (c *Convert) sidecarWritable(f *MediaFile) bool {
+	if !c.conf.SidecarWritable() {
+		return false
+	}
+
+	return c.conf.SidecarPathIsAbs() || !c.conf.RootReadOnly(f.Root())
+}
+
 // ToJpeg converts a single image file to JPEG if possible.
 func (c *Convert) ToJpeg(f *MediaFile) (*MediaFile, error) {
 	if f == nil {
@@ -248,7 +257,7 @@ func (c *Convert) ToJpeg(f *MediaFile) (*MediaFile, error) {
 		return mediaFile, nil
 	}
 
-	if !c.conf.SidecarWritable() {
+	if !c.sidecarWritable(f) {
 		return nil, fmt.Errorf("convert: disabled in read only mode (%s)", f.RelName(c.conf.OriginalsPath()))
 	}
 
@@ -386,7 +395,7 @@ func (c *Convert) ToAvc(f *MediaFile, encoderName string) (file *MediaFile, err
 		return mediaFile, nil
 	}
 
-	if !c.conf.SidecarWritable() {
+	if !c.sidecarWritable(f) {
 		return nil, fmt.Errorf("convert: transcoding disabled in read only mode (%s)", f.RelName(c.conf.OriginalsPath()))
 	}
 
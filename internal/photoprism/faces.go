@@ -0,0 +1,57 @@
+package photoprism
+
+import (
+	"fmt"
+	"image"
+	"math"
+	"os"
+
+	"github.com/photoprism/photoprism/internal/face"
+)
+
+// faceThumbSize returns the best thumbnail type for face detection depending on the configured size.
+func faceThumbSize() string {
+	if Config().ThumbSize() < 1280 {
+		return "fit_720"
+	}
+
+	return "fit_1280"
+}
+
+// FaceEmbedding returns the embedding of a face at a relative marker position, e.g. drawn manually.
+func (ind *Index) FaceEmbedding(jpeg *MediaFile, x, y, w, h float32) ([]float32, error) {
+	if jpeg == nil {
+		return nil, fmt.Errorf("faces: file is nil - you might have found a bug")
+	}
+
+	thumbName, err := jpeg.Thumbnail(Config().ThumbPath(), faceThumbSize())
+
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(thumbName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer file.Close()
+
+	size, _, err := image.DecodeConfig(file)
+
+	if err != nil {
+		return nil, err
+	}
+
+	cols, rows := float64(size.Width), float64(size.Height)
+	scale := math.Max(float64(w)*cols, float64(h)*rows)
+
+	f := face.Face{
+		Rows: size.Height,
+		Cols: size.Width,
+		Face: face.NewPoint("face", int(math.Round(float64(y)*rows)), int(math.Round(float64(x)*cols)), int(math.Round(scale))),
+	}
+
+	return ind.faceNet.Embedding(thumbName, f)
+}
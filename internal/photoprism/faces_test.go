@@ -0,0 +1,42 @@
+package photoprism
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/classify"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/detect"
+	"github.com/photoprism/photoprism/internal/face"
+	"github.com/photoprism/photoprism/internal/nsfw"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIndex_FaceEmbedding(t *testing.T) {
+	conf := config.TestConfig()
+
+	tf := classify.New(conf.AssetsPath(), conf.DisableTensorFlow())
+	nd := nsfw.New(conf.NSFWModelPath())
+	fn := face.NewNet(conf.FaceNetModelPath(), true)
+	convert := NewConvert(conf)
+
+	ind := NewIndex(conf, tf, nd, fn, detect.New(conf.DetectModelPath(), true), convert, NewFiles(), NewPhotos())
+
+	t.Run("Nil", func(t *testing.T) {
+		embedding, err := ind.FaceEmbedding(nil, 0.5, 0.5, 0.1, 0.1)
+
+		assert.Error(t, err)
+		assert.Nil(t, embedding)
+	})
+	t.Run("Disabled", func(t *testing.T) {
+		mf, err := NewMediaFile(conf.ExamplesPath() + "/elephants.jpg")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		embedding, err := ind.FaceEmbedding(mf, 0.5, 0.5, 0.1, 0.1)
+
+		assert.EqualError(t, err, "face: facenet is disabled")
+		assert.Nil(t, embedding)
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"path"
 	"path/filepath"
+	"strconv"
 
 	"github.com/photoprism/photoprism/pkg/fs"
 
@@ -11,18 +12,36 @@ import (
 )
 
 func FileName(fileRoot, fileName string) string {
+	return path.Join(RootPath(fileRoot), fileName)
+}
+
+// RootPath returns the absolute path of a file root, e.g. of an additional library.
+func RootPath(fileRoot string) string {
 	switch fileRoot {
 	case entity.RootSidecar:
-		return path.Join(Config().SidecarPath(), fileName)
+		return Config().SidecarPath()
 	case entity.RootImport:
-		return path.Join(Config().ImportPath(), fileName)
+		return Config().ImportPath()
 	case entity.RootExamples:
-		return path.Join(Config().ExamplesPath(), fileName)
+		return Config().ExamplesPath()
+	case entity.RootOriginals:
+		return Config().OriginalsPath()
 	default:
-		return path.Join(Config().OriginalsPath(), fileName)
+		if entity.IsRemoteRoot(fileRoot) {
+			return RemoteRootPath(fileRoot)
+		} else if lib, ok := Config().Library(fileRoot); ok {
+			return lib.Path
+		}
+
+		return Config().OriginalsPath()
 	}
 }
 
+// RemoteRootPath returns the local directory for temporary copies of files in a remote library root.
+func RemoteRootPath(fileRoot string) string {
+	return filepath.Join(Config().RemotePath(), strconv.Itoa(int(entity.RemoteRootID(fileRoot))))
+}
+
 // CachePath returns a cache directory name based on the base path, file hash and cache namespace.
 func CachePath(fileHash, namespace string) (cachePath string, err error) {
 	return fs.CachePath(Config().CachePath(), fileHash, namespace, true)
@@ -18,7 +18,22 @@ func TestFileName(t *testing.T) {
 	t.Run("examples", func(t *testing.T) {
 		assert.Equal(t, conf.ExamplesPath()+"/test.jpg", FileName("examples", "test.jpg"))
 	})
+	t.Run("remote", func(t *testing.T) {
+		assert.Equal(t, conf.RemotePath()+"/5/foo/test.jpg", FileName("remote:5", "foo/test.jpg"))
+	})
+}
+
+func TestRootPath(t *testing.T) {
+	conf := config.TestConfig()
+	assert.Equal(t, conf.OriginalsPath(), RootPath("/"))
+	assert.Equal(t, conf.SidecarPath(), RootPath("sidecar"))
+	assert.Equal(t, conf.RemotePath()+"/3", RootPath("remote:3"))
+	assert.Equal(t, conf.OriginalsPath(), RootPath("unknown"))
+}
 
+func TestRemoteRootPath(t *testing.T) {
+	conf := config.TestConfig()
+	assert.Equal(t, conf.RemotePath()+"/12", RemoteRootPath("remote:12"))
 }
 
 func TestCacheName(t *testing.T) {
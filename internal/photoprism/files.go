@@ -72,6 +72,18 @@ func (m *Files) Remove(fileName, fileRoot string) {
 	delete(m.files, key)
 }
 
+// Exists tests if a file is known, file name must be relative to the originals path.
+func (m *Files) Exists(fileName, fileRoot string) bool {
+	key := path.Join(fileRoot, fileName)
+
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	_, ok := m.files[key]
+
+	return ok
+}
+
 // Ignore tests of a file requires indexing, file name must be relative to the originals path.
 func (m *Files) Ignore(fileName, fileRoot string, modTime time.Time, rescan bool) bool {
 	timestamp := modTime.Unix()
@@ -10,11 +10,13 @@ import (
 	"sort"
 	"strings"
 	"sync"
+	"sync/atomic"
 
 	"github.com/karrick/godirwalk"
 	"github.com/photoprism/photoprism/internal/config"
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/metrics"
 	"github.com/photoprism/photoprism/internal/mutex"
 	"github.com/photoprism/photoprism/pkg/fs"
 	"github.com/photoprism/photoprism/pkg/txt"
@@ -78,6 +80,9 @@ func (imp *Import) Start(opt ImportOptions) fs.Done {
 		return done
 	}
 
+	report := entity.NewIndexReport(entity.ReportImport, entity.RootImport, imp.reportPath(importPath))
+	report.ReportMove = opt.Move
+
 	jobs := make(chan ImportJob)
 
 	// Start a fixed number of goroutines to import files.
@@ -98,8 +103,20 @@ func (imp *Import) Start(opt ImportOptions) fs.Done {
 		Rescan:  true,
 		Stack:   true,
 		Convert: imp.conf.Settings().Index.Convert && imp.conf.SidecarWritable(),
+		Report:  NewReport(report),
+
+		// New imports take precedence over rescans in the work queues.
+		Priority:      entity.PriorityImport,
+		DeferThumbs:   imp.conf.ThumbWorkers() > 0,
+		DeferClassify: imp.conf.ClassifyWorkers() > 0,
 	}
 
+	var importing int32 = 1
+
+	stages := imp.index.runStages(indexOpt.Report, func() bool {
+		return atomic.LoadInt32(&importing) == 1
+	})
+
 	ignore := fs.NewIgnoreList(fs.IgnoreFile, true, false)
 
 	if err := ignore.Dir(importPath); err != nil {
@@ -186,6 +203,8 @@ func (imp *Import) Start(opt ImportOptions) fs.Done {
 
 			related.Files = files
 
+			metrics.QueueDepth.WithLabelValues(metrics.QueueImport).Inc()
+
 			jobs <- ImportJob{
 				FileName:  fileName,
 				Related:   related,
@@ -203,6 +222,10 @@ func (imp *Import) Start(opt ImportOptions) fs.Done {
 	close(jobs)
 	wg.Wait()
 
+	atomic.StoreInt32(&importing, 0)
+
+	<-stages
+
 	sort.Slice(directories, func(i, j int) bool {
 		return len(directories[i]) > len(directories[j])
 	})
@@ -237,6 +260,8 @@ func (imp *Import) Start(opt ImportOptions) fs.Done {
 		log.Error(err.Error())
 	}
 
+	indexOpt.Report.Finish(err)
+
 	if filesImported > 0 {
 		if err := entity.UpdatePhotoCounts(); err != nil {
 			log.Errorf("import: %s", err)
@@ -248,6 +273,26 @@ func (imp *Import) Start(opt ImportOptions) fs.Done {
 	return done
 }
 
+// reportPath returns the import folder relative to the default import path, or the absolute path if it is outside.
+func (imp *Import) reportPath(importPath string) string {
+	if defaultPath := imp.conf.ImportPath(); importPath == defaultPath {
+		return ""
+	} else if strings.HasPrefix(importPath, defaultPath+string(os.PathSeparator)) {
+		return strings.TrimPrefix(importPath, defaultPath+string(os.PathSeparator))
+	}
+
+	return importPath
+}
+
+// importPath returns the absolute import folder of a report.
+func (imp *Import) importPath(report *entity.IndexReport) string {
+	if filepath.IsAbs(report.ReportPath) {
+		return report.ReportPath
+	}
+
+	return filepath.Join(imp.conf.ImportPath(), report.ReportPath)
+}
+
 // Cancel stops the current import operation.
 func (imp *Import) Cancel() {
 	mutex.MainWorker.Cancel()
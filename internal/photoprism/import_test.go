@@ -5,6 +5,7 @@ import (
 
 	"github.com/photoprism/photoprism/internal/classify"
 	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/detect"
 	"github.com/photoprism/photoprism/internal/face"
 	"github.com/photoprism/photoprism/internal/nsfw"
 	"github.com/stretchr/testify/assert"
@@ -18,7 +19,7 @@ func TestNewImport(t *testing.T) {
 	fn := face.NewNet(conf.FaceNetModelPath(), conf.DisableTensorFlow())
 	convert := NewConvert(conf)
 
-	ind := NewIndex(conf, tf, nd, fn, convert, NewFiles(), NewPhotos())
+	ind := NewIndex(conf, tf, nd, fn, detect.New(conf.DetectModelPath(), true), convert, NewFiles(), NewPhotos())
 	imp := NewImport(conf, ind, convert)
 
 	assert.IsType(t, &Import{}, imp)
@@ -34,7 +35,7 @@ func TestImport_DestinationFilename(t *testing.T) {
 	fn := face.NewNet(conf.FaceNetModelPath(), conf.DisableTensorFlow())
 	convert := NewConvert(conf)
 
-	ind := NewIndex(conf, tf, nd, fn, convert, NewFiles(), NewPhotos())
+	ind := NewIndex(conf, tf, nd, fn, detect.New(conf.DetectModelPath(), true), convert, NewFiles(), NewPhotos())
 
 	imp := NewImport(conf, ind, convert)
 
@@ -67,7 +68,7 @@ func TestImport_Start(t *testing.T) {
 	fn := face.NewNet(conf.FaceNetModelPath(), conf.DisableTensorFlow())
 	convert := NewConvert(conf)
 
-	ind := NewIndex(conf, tf, nd, fn, convert, NewFiles(), NewPhotos())
+	ind := NewIndex(conf, tf, nd, fn, detect.New(conf.DetectModelPath(), true), convert, NewFiles(), NewPhotos())
 
 	imp := NewImport(conf, ind, convert)
 
@@ -1,6 +1,7 @@
 package photoprism
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 
@@ -8,6 +9,7 @@ import (
 
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/metrics"
 	"github.com/photoprism/photoprism/pkg/fs"
 	"github.com/photoprism/photoprism/pkg/txt"
 )
@@ -22,6 +24,8 @@ type ImportJob struct {
 
 func ImportWorker(jobs <-chan ImportJob) {
 	for job := range jobs {
+		metrics.QueueDepth.WithLabelValues(metrics.QueueImport).Dec()
+
 		var destMainFileName string
 		related := job.Related
 		imp := job.Imp
@@ -31,6 +35,7 @@ func ImportWorker(jobs <-chan ImportJob) {
 
 		if related.Main == nil {
 			log.Warnf("import: %s belongs to no supported media file", txt.Quote(fs.RelName(job.FileName, importPath)))
+			indexOpt.Report.Fail(entity.RootImport, fs.RelName(job.FileName, importPath), StageFile, fmt.Errorf("no supported media file"))
 			continue
 		}
 
@@ -78,15 +83,26 @@ func ImportWorker(jobs <-chan ImportJob) {
 					log.Infof("import: moving related %s file %s to %s", f.FileType(), txt.Quote(relFileName), txt.Quote(fs.RelName(destFileName, imp.originalsPath())))
 				}
 
+				var err error
+
 				if opt.Move {
-					if err := f.Move(destFileName); err != nil {
+					if err = f.Move(destFileName); err != nil {
 						log.Errorf("import: failed moving file to %s (%s)", txt.Quote(fs.RelName(destMainFileName, imp.originalsPath())), err.Error())
 					}
 				} else {
-					if err := f.Copy(destFileName); err != nil {
+					if err = f.Copy(destFileName); err != nil {
 						log.Errorf("import: failed copying file to %s (%s)", txt.Quote(fs.RelName(destMainFileName, imp.originalsPath())), err.Error())
 					}
 				}
+
+				if err != nil {
+					indexOpt.Report.Fail(entity.RootImport, relFileName, StageImport, err)
+
+					// Don't index the main file if it could not be moved or copied.
+					if related.Main.HasSameName(f) {
+						destMainFileName = ""
+					}
+				}
 			} else {
 				log.Infof("import: %s", err)
 
@@ -115,6 +131,7 @@ func ImportWorker(jobs <-chan ImportJob) {
 
 			if err != nil {
 				log.Errorf("import: %s in %s", err.Error(), txt.Quote(fs.RelName(destMainFileName, imp.originalsPath())))
+				indexOpt.Report.Fail(entity.RootOriginals, fs.RelName(destMainFileName, imp.originalsPath()), StageFile, err)
 				continue
 			}
 
@@ -129,6 +146,7 @@ func ImportWorker(jobs <-chan ImportJob) {
 			if indexOpt.Convert && f.IsMedia() && !f.HasJpeg() {
 				if jpegFile, err := imp.convert.ToJpeg(f); err != nil {
 					log.Errorf("import: %s in %s (convert to jpeg)", err.Error(), txt.Quote(fs.RelName(destMainFileName, imp.originalsPath())))
+					indexOpt.Report.Fail(f.Root(), f.RootRelName(), StageConvert, err)
 					continue
 				} else {
 					log.Debugf("import: %s created", txt.Quote(jpegFile.BaseName()))
@@ -138,8 +156,9 @@ func ImportWorker(jobs <-chan ImportJob) {
 			if jpg, err := f.Jpeg(); err != nil {
 				log.Error(err)
 			} else {
-				if err := jpg.ResampleDefault(imp.thumbPath(), false); err != nil {
+				if err := imp.index.thumbs(jpg, indexOpt); err != nil {
 					log.Errorf("import: %s in %s (resample)", err.Error(), txt.Quote(jpg.BaseName()))
+					indexOpt.Report.Fail(f.Root(), f.RootRelName(), StageThumbnails, err)
 					continue
 				}
 			}
@@ -148,6 +167,7 @@ func ImportWorker(jobs <-chan ImportJob) {
 
 			if err != nil {
 				log.Errorf("import: %s in %s (find related files)", err.Error(), txt.Quote(fs.RelName(destMainFileName, imp.originalsPath())))
+				indexOpt.Report.Fail(f.Root(), f.RootRelName(), StageFile, err)
 
 				continue
 			}
@@ -161,13 +181,17 @@ func ImportWorker(jobs <-chan ImportJob) {
 
 				// Enforce file size limit for originals.
 				if sizeLimit > 0 && f.FileSize() > sizeLimit {
-					log.Warnf("import: %s exceeds file size limit (%d / %d MB)", txt.Quote(f.BaseName()), f.FileSize()/(1024*1024), sizeLimit/(1024*1024))
+					err := fmt.Errorf("import: %s exceeds file size limit (%d / %d MB)", txt.Quote(f.BaseName()), f.FileSize()/(1024*1024), sizeLimit/(1024*1024))
+					log.Warn(err)
+					indexOpt.Report.Fail(f.Root(), f.RootRelName(), StageFile, err)
 					continue
 				}
 
 				res := ind.MediaFile(f, indexOpt, originalName)
 
 				log.Infof("import: %s main %s file %s", res, f.FileType(), txt.Quote(f.RelName(ind.originalsPath())))
+				metrics.ImportFiles.WithLabelValues(res.String()).Inc()
+				indexOpt.Report.Result(f, res)
 				done[f.FileName()] = true
 
 				if res.Success() {
@@ -194,7 +218,9 @@ func ImportWorker(jobs <-chan ImportJob) {
 
 				// Enforce file size limit for originals.
 				if sizeLimit > 0 && f.FileSize() > sizeLimit {
-					log.Warnf("import: %s exceeds file size limit (%d / %d MB)", txt.Quote(f.BaseName()), f.FileSize()/(1024*1024), sizeLimit/(1024*1024))
+					err := fmt.Errorf("import: %s exceeds file size limit (%d / %d MB)", txt.Quote(f.BaseName()), f.FileSize()/(1024*1024), sizeLimit/(1024*1024))
+					log.Warn(err)
+					indexOpt.Report.Fail(f.Root(), f.RootRelName(), StageFile, err)
 					continue
 				}
 
@@ -209,13 +235,15 @@ func ImportWorker(jobs <-chan ImportJob) {
 				res := ind.MediaFile(f, indexOpt, "")
 
 				if res.Indexed() && f.IsJpeg() {
-					if err := f.ResampleDefault(ind.thumbPath(), false); err != nil {
+					if err := ind.thumbs(f, indexOpt); err != nil {
 						log.Errorf("import: failed creating thumbnails for %s (%s)", txt.Quote(f.BaseName()), err.Error())
 						query.SetFileError(res.FileUID, err.Error())
 					}
 				}
 
 				log.Infof("import: %s related %s file %s", res, f.FileType(), txt.Quote(f.RelName(ind.originalsPath())))
+				metrics.ImportFiles.WithLabelValues(res.String()).Inc()
+				indexOpt.Report.Result(f, res)
 			}
 
 		}
@@ -7,8 +7,9 @@ import (
 	"runtime"
 	"runtime/debug"
 	"strings"
-	"sync"
+	"sync/atomic"
 
+	"github.com/photoprism/photoprism/internal/detect"
 	"github.com/photoprism/photoprism/internal/face"
 
 	"github.com/karrick/godirwalk"
@@ -28,18 +29,20 @@ type Index struct {
 	tensorFlow   *classify.TensorFlow
 	nsfwDetector *nsfw.Detector
 	faceNet      *face.Net
+	detector     *detect.Detector
 	convert      *Convert
 	files        *Files
 	photos       *Photos
 }
 
 // NewIndex returns a new indexer and expects its dependencies as arguments.
-func NewIndex(conf *config.Config, tensorFlow *classify.TensorFlow, nsfwDetector *nsfw.Detector, faceNet *face.Net, convert *Convert, files *Files, photos *Photos) *Index {
+func NewIndex(conf *config.Config, tensorFlow *classify.TensorFlow, nsfwDetector *nsfw.Detector, faceNet *face.Net, detector *detect.Detector, convert *Convert, files *Files, photos *Photos) *Index {
 	i := &Index{
 		conf:         conf,
 		tensorFlow:   tensorFlow,
 		nsfwDetector: nsfwDetector,
 		faceNet:      faceNet,
+		detector:     detector,
 		convert:      convert,
 		files:        files,
 		photos:       photos,
@@ -70,8 +73,22 @@ func (ind *Index) Start(opt IndexOptions) fs.Done {
 	}()
 
 	done := make(fs.Done)
-	originalsPath := ind.originalsPath()
-	optionsPath := filepath.Join(originalsPath, opt.Path)
+	rootName := opt.RootName()
+	rootPath := ind.originalsPath()
+
+	var ignoreItems []string
+
+	if rootName != entity.RootOriginals {
+		if lib, ok := ind.conf.Library(rootName); !ok {
+			event.Error(fmt.Sprintf("index: library %s not found", txt.Quote(rootName)))
+			return done
+		} else {
+			rootPath = lib.Path
+			ignoreItems = lib.Ignore
+		}
+	}
+
+	optionsPath := filepath.Join(rootPath, opt.Path)
 
 	if !fs.PathExists(optionsPath) {
 		event.Error(fmt.Sprintf("index: %s does not exist", txt.Quote(optionsPath)))
@@ -91,18 +108,7 @@ func (ind *Index) Start(opt IndexOptions) fs.Done {
 		return done
 	}
 
-	jobs := make(chan IndexJob)
-
-	// Start a fixed number of goroutines to index files.
-	var wg sync.WaitGroup
-	var numWorkers = ind.conf.Workers()
-	wg.Add(numWorkers)
-	for i := 0; i < numWorkers; i++ {
-		go func() {
-			IndexWorker(jobs) // HLc
-			wg.Done()
-		}()
-	}
+	opt.Report = NewReport(entity.NewIndexReport(entity.ReportIndex, rootName, opt.Path))
 
 	if err := ind.files.Init(); err != nil {
 		log.Errorf("index: %s", err)
@@ -113,17 +119,36 @@ func (ind *Index) Start(opt IndexOptions) fs.Done {
 	filesIndexed := 0
 	ignore := fs.NewIgnoreList(fs.IgnoreFile, true, false)
 
-	if err := ignore.Dir(originalsPath); err != nil {
+	if err := ignore.Dir(rootPath); err != nil {
 		log.Infof("index: %s", err)
 	}
 
+	if err := ignore.AppendItems(rootPath, ignoreItems); err != nil {
+		log.Errorf("index: %s", err)
+	}
+
+	// Apply ignore files in parent folders when only a subfolder is indexed.
+	for dir := filepath.Dir(optionsPath); len(dir) > len(rootPath); dir = filepath.Dir(dir) {
+		_ = ignore.Dir(dir)
+	}
+
 	ignore.Log = func(fileName string) {
-		log.Infof(`index: ignored "%s"`, fs.RelName(fileName, originalsPath))
+		log.Infof(`index: ignored "%s"`, fs.RelName(fileName, rootPath))
 	}
 
+	// Index queued files while walking, so that the work can be resumed if it is interrupted.
+	opt.DeferThumbs = ind.conf.ThumbWorkers() > 0
+	opt.DeferClassify = ind.conf.ClassifyWorkers() > 0
+
+	var walking int32 = 1
+
+	processed := ind.process(opt, func() bool {
+		return atomic.LoadInt32(&walking) == 1
+	})
+
 	err := godirwalk.Walk(optionsPath, &godirwalk.Options{
 		ErrorCallback: func(fileName string, err error) godirwalk.ErrorAction {
-			log.Errorf("index: %s", strings.Replace(err.Error(), originalsPath, "", 1))
+			log.Errorf("index: %s", strings.Replace(err.Error(), rootPath, "", 1))
 			return godirwalk.SkipNode
 		},
 		Callback: func(fileName string, info *godirwalk.Dirent) error {
@@ -133,11 +158,11 @@ func (ind *Index) Start(opt IndexOptions) fs.Done {
 
 			isDir := info.IsDir()
 			isSymlink := info.IsSymlink()
-			relName := fs.RelName(fileName, originalsPath)
+			relName := fs.RelName(fileName, rootPath)
 
 			if skip, result := fs.SkipWalk(fileName, isDir, isSymlink, done, ignore); skip {
 				if (isSymlink || isDir) && result != filepath.SkipDir {
-					folder := entity.NewFolder(entity.RootOriginals, relName, fs.BirthTime(fileName))
+					folder := entity.NewFolder(rootName, relName, fs.BirthTime(fileName))
 
 					if err := folder.Create(); err == nil {
 						log.Infof("index: added folder /%s", folder.Path)
@@ -171,7 +196,7 @@ func (ind *Index) Start(opt IndexOptions) fs.Done {
 				return nil
 			}
 
-			if ind.files.Indexed(relName, entity.RootOriginals, mf.modTime, opt.Rescan) {
+			if ind.files.Indexed(relName, rootName, mf.modTime, opt.Rescan) {
 				return nil
 			}
 
@@ -207,28 +232,33 @@ func (ind *Index) Start(opt IndexOptions) fs.Done {
 				return nil
 			}
 
-			related.Files = files
+			// Files that have not been indexed before take precedence over rescans.
+			isNew := !ind.files.Exists(relName, rootName)
 
-			jobs <- IndexJob{
-				FileName: mf.FileName(),
-				Related:  related,
-				IndexOpt: opt,
-				Ind:      ind,
+			if isNew {
+				opt.Priority = entity.PriorityNew
+			} else {
+				opt.Priority = entity.PriorityRescan
 			}
 
+			opt.enqueue(entity.QueueIndex, mf, "", isNew)
+
 			return nil
 		},
 		Unsorted:            false,
 		FollowSymbolicLinks: true,
 	})
 
-	close(jobs)
-	wg.Wait()
+	atomic.StoreInt32(&walking, 0)
+
+	<-processed
 
 	if err != nil {
 		log.Error(err.Error())
 	}
 
+	opt.Report.Finish(err)
+
 	if filesIndexed > 0 {
 		event.Publish("index.updating", event.Data{
 			"step": "counts",
@@ -253,6 +283,8 @@ func (ind *Index) FileName(fileName string, o IndexOptions) (result IndexResult)
 	if err != nil {
 		result.Err = err
 		result.Status = IndexFailed
+		result.Stage = StageFile
+		o.Report.Fail(o.RootName(), fs.RelName(fileName, RootPath(o.RootName())), StageFile, err)
 
 		return result
 	}
@@ -262,6 +294,8 @@ func (ind *Index) FileName(fileName string, o IndexOptions) (result IndexResult)
 	if err != nil {
 		result.Err = err
 		result.Status = IndexFailed
+		result.Stage = StageFile
+		o.Report.Result(file, result)
 
 		return result
 	}
@@ -11,10 +11,12 @@ import (
 	"github.com/jinzhu/gorm"
 
 	"github.com/photoprism/photoprism/internal/classify"
+	"github.com/photoprism/photoprism/internal/detect"
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/event"
 	"github.com/photoprism/photoprism/internal/face"
 	"github.com/photoprism/photoprism/internal/meta"
+	"github.com/photoprism/photoprism/internal/metrics"
 	"github.com/photoprism/photoprism/internal/nsfw"
 	"github.com/photoprism/photoprism/internal/query"
 	"github.com/photoprism/photoprism/pkg/fs"
@@ -40,6 +42,7 @@ type IndexResult struct {
 	FileUID  string
 	PhotoID  uint
 	PhotoUID string
+	Stage    string
 }
 
 func (r IndexResult) String() string {
@@ -76,6 +79,7 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName string) (
 		log.Error(err)
 		result.Err = err
 		result.Status = IndexFailed
+		result.Stage = StageFile
 		return result
 	}
 
@@ -94,9 +98,18 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName string) (
 	photo := entity.NewPhoto(o.Stack)
 	metaData := meta.NewData()
 	labels := classify.Labels{}
+	var predictions entity.ImagePredictions
+	var fileText *entity.FileText
 	stripSequence := Config().Settings().StackSequences() && o.Stack
 
 	fileRoot, fileBase, filePath, fileName := m.PathNameInfo(stripSequence)
+
+	// Photos are identified by root, path, and name. Sidecar files belong to photos in originals.
+	photoRoot := fileRoot
+
+	if photoRoot == entity.RootSidecar {
+		photoRoot = entity.RootOriginals
+	}
 	fullBase := m.BasePrefix(false)
 	logName := txt.Quote(fileName)
 	fileSize, modTime, err := m.Stat()
@@ -106,6 +119,7 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName string) (
 		log.Error(err)
 		result.Err = err
 		result.Status = IndexFailed
+		result.Stage = StageFile
 		return result
 	}
 
@@ -155,6 +169,7 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName string) (
 			log.Errorf("index: %s in %s (rename)", err.Error(), logName)
 
 			result.Status = IndexFailed
+			result.Stage = StageDatabase
 			result.Err = err
 
 			return result
@@ -175,9 +190,9 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName string) (
 
 	// Look for existing photo if file wasn't indexed yet...
 	if !fileExists {
-		if photoQuery = entity.UnscopedDb().First(&photo, "photo_path = ? AND photo_name = ?", filePath, fullBase); photoQuery.Error == nil || fileBase == fullBase || !o.Stack {
+		if photoQuery = entity.UnscopedDb().First(&photo, "photo_root = ? AND photo_path = ? AND photo_name = ?", photoRoot, filePath, fullBase); photoQuery.Error == nil || fileBase == fullBase || !o.Stack {
 			// Skip next query.
-		} else if photoQuery = entity.UnscopedDb().First(&photo, "photo_path = ? AND photo_name = ? AND photo_stack > -1", filePath, fileBase); photoQuery.Error == nil {
+		} else if photoQuery = entity.UnscopedDb().First(&photo, "photo_root = ? AND photo_path = ? AND photo_name = ? AND photo_stack > -1", photoRoot, filePath, fileBase); photoQuery.Error == nil {
 			fileStacked = true
 		}
 
@@ -253,6 +268,7 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName string) (
 		fileHash = m.Hash()
 	}
 
+	photo.PhotoRoot = photoRoot
 	photo.PhotoPath = filePath
 
 	if !o.Stack || !stripSequence || photo.PhotoStack == entity.IsUnstacked {
@@ -487,9 +503,9 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName string) (
 	if file.FilePrimary {
 		primaryFile = file
 
-		if !Config().DisableTensorFlow() {
+		if !Config().DisableTensorFlow() && !o.DeferClassify {
 			// Image classification via TensorFlow.
-			labels = ind.classifyImage(m)
+			labels, predictions = ind.classifyImage(m)
 
 			if !photoExists && Config().Settings().Features.Private && Config().DetectNSFW() {
 				photo.PhotoPrivate = ind.NSFW(m)
@@ -560,6 +576,7 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName string) (
 		if err := photo.Save(); err != nil {
 			log.Errorf("index: %s in %s (update existing photo)", err, logName)
 			result.Status = IndexFailed
+			result.Stage = StageDatabase
 			result.Err = err
 			return result
 		}
@@ -567,6 +584,7 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName string) (
 		if err := photo.FirstOrCreate(); err != nil {
 			log.Errorf("index: %s in %s (find or create photo)", err, logName)
 			result.Status = IndexFailed
+			result.Stage = StageDatabase
 			result.Err = err
 			return result
 		}
@@ -600,7 +618,7 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName string) (
 
 	// Main JPEG file.
 	if file.FilePrimary {
-		if Config().Experimental() && Config().Settings().Features.People {
+		if Config().Experimental() && Config().Settings().Features.People && !o.DeferClassify {
 			faces := ind.detectFaces(m)
 
 			photo.AddLabels(classify.FaceLabels(faces, entity.SrcImage))
@@ -614,6 +632,14 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName string) (
 			photo.PhotoFaces = file.Markers.FaceCount()
 		}
 
+		if Config().DetectObjects() && !o.DeferClassify {
+			ind.addObjects(&photo, &file, m)
+		}
+
+		if ind.ocrEnabled(&photo) && !o.DeferClassify {
+			fileText = ind.recognizeText(m)
+		}
+
 		labels := photo.ClassifyLabels()
 
 		if err := photo.UpdateTitle(labels); err != nil {
@@ -639,6 +665,10 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName string) (
 		w = append(w, file.FileMainColor)
 		w = append(w, labels.Keywords()...)
 
+		if fileText != nil {
+			w = append(w, fileText.Keywords()...)
+		}
+
 		details.Keywords = strings.Join(txt.UniqueWords(w), ", ")
 
 		if details.Keywords != "" {
@@ -652,6 +682,7 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName string) (
 		if err := photo.Save(); err != nil {
 			log.Errorf("index: %s in %s (update metadata)", err, logName)
 			result.Status = IndexFailed
+			result.Stage = StageDatabase
 			result.Err = err
 			return result
 		}
@@ -670,6 +701,7 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName string) (
 	} else if err := photo.UpdateQuality(); err != nil {
 		log.Errorf("index: %s in %s (update quality)", err, logName)
 		result.Status = IndexFailed
+		result.Stage = StageDatabase
 		result.Err = err
 		return result
 	}
@@ -682,6 +714,7 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName string) (
 		if err := file.Save(); err != nil {
 			log.Errorf("index: %s in %s (update existing file)", err, logName)
 			result.Status = IndexFailed
+			result.Stage = StageDatabase
 			result.Err = err
 			return result
 		}
@@ -691,6 +724,7 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName string) (
 		if err := file.Create(); err != nil {
 			log.Errorf("index: %s in %s (add new file)", err, logName)
 			result.Status = IndexFailed
+			result.Stage = StageDatabase
 			result.Err = err
 			return result
 		}
@@ -715,6 +749,22 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName string) (
 	result.FileID = file.ID
 	result.FileUID = file.FileUID
 
+	// Keep raw classification results, so that labels can be rebuilt without running TensorFlow again.
+	if len(predictions) > 0 {
+		if err := entity.NewFilePredictions(file.ID, ind.tensorFlow.ModelName(), predictions).Save(); err != nil {
+			log.Errorf("index: %s in %s (save predictions)", err, logName)
+		}
+	}
+
+	// Keep recognized text, e.g. of document scans.
+	if fileText != nil {
+		fileText.FileID = file.ID
+
+		if err := fileText.Save(); err != nil {
+			log.Errorf("index: %s in %s (save text)", err, logName)
+		}
+	}
+
 	downloadedAs := fileName
 
 	if originalName != "" {
@@ -754,6 +804,11 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName string) (
 		}
 	}
 
+	// Image classification and face detection run in a separate queue.
+	if file.FilePrimary && o.DeferClassify {
+		o.enqueue(entity.QueueClassify, m, file.FileUID, !photoExists)
+	}
+
 	return result
 }
 
@@ -779,8 +834,8 @@ func (ind *Index) NSFW(jpeg *MediaFile) bool {
 	return false
 }
 
-// classifyImage classifies a JPEG image and returns matching labels.
-func (ind *Index) classifyImage(jpeg *MediaFile) (results classify.Labels) {
+// classifyImage classifies a JPEG image and returns matching labels along with the raw predictions.
+func (ind *Index) classifyImage(jpeg *MediaFile) (results classify.Labels, predictions entity.ImagePredictions) {
 	start := time.Now()
 
 	var thumbs []string
@@ -791,8 +846,6 @@ func (ind *Index) classifyImage(jpeg *MediaFile) (results classify.Labels) {
 		thumbs = []string{"tile_224", "left_224", "right_224"}
 	}
 
-	var labels classify.Labels
-
 	for _, thumb := range thumbs {
 		filename, err := jpeg.Thumbnail(Config().ThumbPath(), thumb)
 
@@ -801,16 +854,33 @@ func (ind *Index) classifyImage(jpeg *MediaFile) (results classify.Labels) {
 			continue
 		}
 
-		imageLabels, err := ind.tensorFlow.File(filename)
+		imagePredictions, err := ind.tensorFlow.FilePredictions(filename)
 
 		if err != nil {
 			log.Debugf("%s in %s", err, txt.Quote(jpeg.BaseName()))
 			continue
+		} else if len(imagePredictions) == 0 {
+			continue
 		}
 
-		labels = append(labels, imageLabels...)
+		predictions = append(predictions, imagePredictions)
 	}
 
+	results = classifyLabels(predictions)
+
+	elapsed := time.Since(start)
+
+	metrics.StageDuration.WithLabelValues(metrics.StageTensorFlow).Observe(elapsed.Seconds())
+
+	log.Debugf("index: image classification took %s", elapsed)
+
+	return results, predictions
+}
+
+// classifyLabels returns the best labels for raw image predictions based on the current label rules.
+func classifyLabels(predictions entity.ImagePredictions) (results classify.Labels) {
+	labels := predictions.Labels()
+
 	// Sort by priority and uncertainty
 	sort.Sort(labels)
 
@@ -826,10 +896,6 @@ func (ind *Index) classifyImage(jpeg *MediaFile) (results classify.Labels) {
 		}
 	}
 
-	elapsed := time.Since(start)
-
-	log.Debugf("index: image classification took %s", elapsed)
-
 	return results
 }
 
@@ -839,14 +905,7 @@ func (ind *Index) detectFaces(jpeg *MediaFile) face.Faces {
 		return face.Faces{}
 	}
 
-	var thumbSize string
-
-	// Select best thumbnail depending on configured size.
-	if Config().ThumbSize() < 1280 {
-		thumbSize = "fit_720"
-	} else {
-		thumbSize = "fit_1280"
-	}
+	thumbSize := faceThumbSize()
 
 	thumbName, err := jpeg.Thumbnail(Config().ThumbPath(), thumbSize)
 
@@ -870,7 +929,57 @@ func (ind *Index) detectFaces(jpeg *MediaFile) face.Faces {
 
 	elapsed := time.Since(start)
 
+	metrics.StageDuration.WithLabelValues(metrics.StageFaces).Observe(elapsed.Seconds())
+
 	log.Debugf("index: face detection took %s", elapsed)
 
 	return faces
 }
+
+// detectObjects returns the objects found in a JPEG image.
+func (ind *Index) detectObjects(jpeg *MediaFile) detect.Objects {
+	if jpeg == nil {
+		return detect.Objects{}
+	}
+
+	thumbName, err := jpeg.Thumbnail(Config().ThumbPath(), "fit_720")
+
+	if err != nil {
+		log.Debugf("index: %s in %s (objects)", err, txt.Quote(jpeg.BaseName()))
+		return detect.Objects{}
+	}
+
+	start := time.Now()
+
+	objects, err := ind.detector.File(thumbName)
+
+	if err != nil {
+		log.Debugf("%s in %s", err, txt.Quote(jpeg.BaseName()))
+	}
+
+	elapsed := time.Since(start)
+
+	metrics.StageDuration.WithLabelValues(metrics.StageObjects).Observe(elapsed.Seconds())
+
+	log.Debugf("index: object detection took %s", elapsed)
+
+	return objects
+}
+
+// addObjects adds labels and label markers for objects found in a JPEG image.
+func (ind *Index) addObjects(photo *entity.Photo, file *entity.File, jpeg *MediaFile) {
+	objects := ind.detectObjects(jpeg)
+
+	if len(objects) == 0 {
+		return
+	}
+
+	// Labels must exist before markers can refer to them.
+	photo.AddLabels(classify.ObjectLabels(objects, entity.SrcImage))
+
+	if len(file.Markers) == 0 {
+		file.PreloadMarkers()
+	}
+
+	file.AddObjects(objects)
+}
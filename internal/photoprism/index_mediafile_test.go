@@ -3,6 +3,7 @@ package photoprism
 import (
 	"testing"
 
+	"github.com/photoprism/photoprism/internal/detect"
 	"github.com/photoprism/photoprism/internal/face"
 
 	"github.com/photoprism/photoprism/internal/classify"
@@ -26,7 +27,7 @@ func TestIndex_MediaFile(t *testing.T) {
 		fn := face.NewNet(conf.FaceNetModelPath(), conf.DisableTensorFlow())
 		convert := NewConvert(conf)
 
-		ind := NewIndex(conf, tf, nd, fn, convert, NewFiles(), NewPhotos())
+		ind := NewIndex(conf, tf, nd, fn, detect.New(conf.DetectModelPath(), true), convert, NewFiles(), NewPhotos())
 		indexOpt := IndexOptionsAll()
 		mediaFile, err := NewMediaFile("testdata/flash.jpg")
 
@@ -58,7 +59,7 @@ func TestIndex_MediaFile(t *testing.T) {
 		fn := face.NewNet(conf.FaceNetModelPath(), conf.DisableTensorFlow())
 		convert := NewConvert(conf)
 
-		ind := NewIndex(conf, tf, nd, fn, convert, NewFiles(), NewPhotos())
+		ind := NewIndex(conf, tf, nd, fn, detect.New(conf.DetectModelPath(), true), convert, NewFiles(), NewPhotos())
 		indexOpt := IndexOptionsAll()
 		mediaFile, err := NewMediaFile(conf.ExamplesPath() + "/blue-go-video.mp4")
 		if err != nil {
@@ -80,7 +81,7 @@ func TestIndex_MediaFile(t *testing.T) {
 		fn := face.NewNet(conf.FaceNetModelPath(), conf.DisableTensorFlow())
 		convert := NewConvert(conf)
 
-		ind := NewIndex(conf, tf, nd, fn, convert, NewFiles(), NewPhotos())
+		ind := NewIndex(conf, tf, nd, fn, detect.New(conf.DetectModelPath(), true), convert, NewFiles(), NewPhotos())
 		indexOpt := IndexOptionsAll()
 
 		result := ind.MediaFile(nil, indexOpt, "blue-go-video.mp4")
@@ -90,24 +91,24 @@ func TestIndex_MediaFile(t *testing.T) {
 
 func TestIndexResult_Archived(t *testing.T) {
 	t.Run("true", func(t *testing.T) {
-		r := &IndexResult{IndexArchived, nil, 5, "", 5, ""}
+		r := &IndexResult{IndexArchived, nil, 5, "", 5, "", ""}
 		assert.True(t, r.Archived())
 	})
 
 	t.Run("false", func(t *testing.T) {
-		r := &IndexResult{IndexAdded, nil, 5, "", 5, ""}
+		r := &IndexResult{IndexAdded, nil, 5, "", 5, "", ""}
 		assert.False(t, r.Archived())
 	})
 }
 
 func TestIndexResult_Skipped(t *testing.T) {
 	t.Run("true", func(t *testing.T) {
-		r := &IndexResult{IndexSkipped, nil, 5, "", 5, ""}
+		r := &IndexResult{IndexSkipped, nil, 5, "", 5, "", ""}
 		assert.True(t, r.Skipped())
 	})
 
 	t.Run("false", func(t *testing.T) {
-		r := &IndexResult{IndexAdded, nil, 5, "", 5, ""}
+		r := &IndexResult{IndexAdded, nil, 5, "", 5, "", ""}
 		assert.False(t, r.Skipped())
 	})
 }
@@ -1,16 +1,32 @@
 package photoprism
 
+import "github.com/photoprism/photoprism/internal/entity"
+
 type IndexOptions struct {
-	Path    string
-	Rescan  bool
-	Convert bool
-	Stack   bool
+	Root          string
+	Path          string
+	Rescan        bool
+	Convert       bool
+	Stack         bool
+	Report        *Report
+	Priority      int
+	DeferThumbs   bool
+	DeferClassify bool
 }
 
 func (o *IndexOptions) SkipUnchanged() bool {
 	return !o.Rescan
 }
 
+// RootName returns the file root to be indexed, originals by default.
+func (o *IndexOptions) RootName() string {
+	if o.Root == "" {
+		return entity.RootOriginals
+	}
+
+	return o.Root
+}
+
 // IndexOptionsAll returns new index options with all options set to true.
 func IndexOptionsAll() IndexOptions {
 	result := IndexOptions{
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"path/filepath"
 
+	"github.com/photoprism/photoprism/internal/metrics"
 	"github.com/photoprism/photoprism/internal/query"
 	"github.com/photoprism/photoprism/pkg/txt"
 )
@@ -14,6 +15,7 @@ func IndexMain(related *RelatedFiles, ind *Index, opt IndexOptions) (result Inde
 	if related.Main == nil {
 		result.Err = fmt.Errorf("index: no main file found for %s", txt.Quote(related.String()))
 		result.Status = IndexFailed
+		result.Stage = StageFile
 		return result
 	}
 
@@ -24,6 +26,7 @@ func IndexMain(related *RelatedFiles, ind *Index, opt IndexOptions) (result Inde
 	if sizeLimit > 0 && f.FileSize() > sizeLimit {
 		result.Err = fmt.Errorf("index: %s exceeds file size limit (%d / %d MB)", txt.Quote(f.BaseName()), f.FileSize()/(1024*1024), sizeLimit/(1024*1024))
 		result.Status = IndexFailed
+		result.Stage = StageFile
 		return result
 	}
 
@@ -39,14 +42,16 @@ func IndexMain(related *RelatedFiles, ind *Index, opt IndexOptions) (result Inde
 		if jpegFile, err := ind.convert.ToJpeg(f); err != nil {
 			result.Err = fmt.Errorf("index: failed converting %s to jpeg (%s)", txt.Quote(f.BaseName()), err.Error())
 			result.Status = IndexFailed
+			result.Stage = StageConvert
 
 			return result
 		} else {
 			log.Debugf("index: %s created", txt.Quote(jpegFile.BaseName()))
 
-			if err := jpegFile.ResampleDefault(ind.thumbPath(), false); err != nil {
+			if err := ind.thumbs(jpegFile, opt); err != nil {
 				result.Err = fmt.Errorf("index: failed creating thumbnails for %s (%s)", txt.Quote(f.BaseName()), err.Error())
 				result.Status = IndexFailed
+				result.Stage = StageThumbnails
 
 				return result
 			}
@@ -58,13 +63,13 @@ func IndexMain(related *RelatedFiles, ind *Index, opt IndexOptions) (result Inde
 	result = ind.MediaFile(f, opt, "")
 
 	if result.Indexed() && f.IsJpeg() {
-		if err := f.ResampleDefault(ind.thumbPath(), false); err != nil {
+		if err := ind.thumbs(f, opt); err != nil {
 			log.Errorf("index: failed creating thumbnails for %s (%s)", txt.Quote(f.BaseName()), err.Error())
 			query.SetFileError(result.FileUID, err.Error())
 		}
 	}
 
-	log.Infof("index: %s main %s file %s", result, f.FileType(), txt.Quote(f.RelName(ind.originalsPath())))
+	log.Infof("index: %s main %s file %s", result, f.FileType(), txt.Quote(f.RootRelName()))
 
 	return result
 }
@@ -76,6 +81,9 @@ func IndexRelated(related RelatedFiles, ind *Index, opt IndexOptions) (result In
 
 	result = IndexMain(&related, ind, opt)
 
+	metrics.IndexFiles.WithLabelValues(result.String()).Inc()
+	opt.Report.Result(related.Main, result)
+
 	if result.Failed() {
 		log.Warn(result.Err)
 		return result
@@ -104,7 +112,9 @@ func IndexRelated(related RelatedFiles, ind *Index, opt IndexOptions) (result In
 
 		// Enforce file size limit for originals.
 		if sizeLimit > 0 && f.FileSize() > sizeLimit {
-			log.Warnf("index: %s exceeds file size limit (%d / %d MB)", txt.Quote(f.BaseName()), f.FileSize()/(1024*1024), sizeLimit/(1024*1024))
+			err := fmt.Errorf("index: %s exceeds file size limit (%d / %d MB)", txt.Quote(f.BaseName()), f.FileSize()/(1024*1024), sizeLimit/(1024*1024))
+			log.Warn(err)
+			opt.Report.Fail(f.Root(), f.RootRelName(), StageFile, err)
 			continue
 		}
 
@@ -120,14 +130,18 @@ func IndexRelated(related RelatedFiles, ind *Index, opt IndexOptions) (result In
 			if jpegFile, err := ind.convert.ToJpeg(f); err != nil {
 				result.Err = fmt.Errorf("index: failed converting %s to jpeg (%s)", txt.Quote(f.BaseName()), err.Error())
 				result.Status = IndexFailed
+				result.Stage = StageConvert
+				opt.Report.Result(f, result)
 
 				return result
 			} else {
 				log.Debugf("index: %s created", txt.Quote(jpegFile.BaseName()))
 
-				if err := jpegFile.ResampleDefault(ind.thumbPath(), false); err != nil {
+				if err := ind.thumbs(jpegFile, opt); err != nil {
 					result.Err = fmt.Errorf("index: failed creating thumbnails for %s (%s)", txt.Quote(f.BaseName()), err.Error())
 					result.Status = IndexFailed
+					result.Stage = StageThumbnails
+					opt.Report.Result(f, result)
 
 					return result
 				}
@@ -139,13 +153,16 @@ func IndexRelated(related RelatedFiles, ind *Index, opt IndexOptions) (result In
 		res := ind.MediaFile(f, opt, "")
 
 		if res.Indexed() && f.IsJpeg() {
-			if err := f.ResampleDefault(ind.thumbPath(), false); err != nil {
+			if err := ind.thumbs(f, opt); err != nil {
 				log.Errorf("index: failed creating thumbnails for %s (%s)", txt.Quote(f.BaseName()), err.Error())
 				query.SetFileError(res.FileUID, err.Error())
 			}
 		}
 
 		log.Infof("index: %s related %s file %s", res, f.FileType(), txt.Quote(f.BaseName()))
+
+		metrics.IndexFiles.WithLabelValues(res.String()).Inc()
+		opt.Report.Result(f, res)
 	}
 
 	return result
@@ -4,6 +4,7 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/photoprism/photoprism/internal/detect"
 	"github.com/photoprism/photoprism/internal/face"
 
 	"github.com/photoprism/photoprism/internal/classify"
@@ -58,7 +59,7 @@ func TestIndexRelated(t *testing.T) {
 		fn := face.NewNet(conf.FaceNetModelPath(), conf.DisableTensorFlow())
 		convert := NewConvert(conf)
 
-		ind := NewIndex(conf, tf, nd, fn, convert, NewFiles(), NewPhotos())
+		ind := NewIndex(conf, tf, nd, fn, detect.New(conf.DetectModelPath(), true), convert, NewFiles(), NewPhotos())
 		opt := IndexOptionsAll()
 
 		result := IndexRelated(related, ind, opt)
@@ -119,7 +120,7 @@ func TestIndexRelated(t *testing.T) {
 		fn := face.NewNet(conf.FaceNetModelPath(), conf.DisableTensorFlow())
 		convert := NewConvert(conf)
 
-		ind := NewIndex(conf, tf, nd, fn, convert, NewFiles(), NewPhotos())
+		ind := NewIndex(conf, tf, nd, fn, detect.New(conf.DetectModelPath(), true), convert, NewFiles(), NewPhotos())
 		opt := IndexOptionsAll()
 
 		result := IndexRelated(related, ind, opt)
@@ -3,6 +3,7 @@ package photoprism
 import (
 	"testing"
 
+	"github.com/photoprism/photoprism/internal/detect"
 	"github.com/photoprism/photoprism/internal/face"
 
 	"github.com/stretchr/testify/assert"
@@ -26,7 +27,7 @@ func TestIndex_Start(t *testing.T) {
 	fn := face.NewNet(conf.FaceNetModelPath(), conf.DisableTensorFlow())
 	convert := NewConvert(conf)
 
-	ind := NewIndex(conf, tf, nd, fn, convert, NewFiles(), NewPhotos())
+	ind := NewIndex(conf, tf, nd, fn, detect.New(conf.DetectModelPath(), true), convert, NewFiles(), NewPhotos())
 	imp := NewImport(conf, ind, convert)
 	opt := ImportOptionsMove(conf.ImportPath())
 
@@ -52,7 +53,7 @@ func TestIndex_File(t *testing.T) {
 	fn := face.NewNet(conf.FaceNetModelPath(), conf.DisableTensorFlow())
 	convert := NewConvert(conf)
 
-	ind := NewIndex(conf, tf, nd, fn, convert, NewFiles(), NewPhotos())
+	ind := NewIndex(conf, tf, nd, fn, detect.New(conf.DetectModelPath(), true), convert, NewFiles(), NewPhotos())
 
 	err := ind.FileName("xxx", IndexOptionsAll())
 
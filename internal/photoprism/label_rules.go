@@ -0,0 +1,158 @@
+package photoprism
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/gosimple/slug"
+	"github.com/photoprism/photoprism/internal/classify"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// LabelRuleChanges counts the changes made by applying label rules to existing labels.
+type LabelRuleChanges struct {
+	Labels   int `json:"labels"`   // Labels matching a rule
+	Moved    int `json:"moved"`    // Photo labels moved to the label of their rule
+	Removed  int `json:"removed"`  // Photo labels below the rule threshold
+	Synonyms int `json:"synonyms"` // Synonyms added for labels that rules map to another label
+}
+
+// ApplyLabelRules applies the active label rules to photo labels found by image classification,
+// so that changed thresholds, priorities, categories and aliases don't require running TensorFlow again.
+func ApplyLabelRules() (result LabelRuleChanges, err error) {
+	if err := mutex.MainWorker.Start(); err != nil {
+		return result, ErrBusy
+	}
+
+	defer mutex.MainWorker.Stop()
+
+	labels, err := query.ClassifiedLabels()
+
+	if err != nil {
+		return result, err
+	}
+
+	// Labels are matched by their original slug, so that renamed labels still match.
+	rules := make(map[string]classify.LabelRule)
+
+	for name, rule := range classify.Rules() {
+		rules[slug.Make(name)] = rule
+	}
+
+	for _, label := range labels {
+		rule, ok := rules[label.LabelSlug]
+
+		if !ok {
+			continue
+		}
+
+		result.Labels++
+
+		target := &label
+
+		if rule.Label != "" && slug.Make(rule.Label) != label.LabelSlug {
+			if target = entity.FirstOrCreateLabel(entity.NewLabel(txt.Title(rule.Label), rule.Priority)); target == nil {
+				log.Errorf("labels: failed adding %s", txt.Quote(rule.Label))
+				continue
+			} else if target.Deleted() {
+				log.Debugf("labels: skipping deleted label %s", txt.Quote(target.LabelName))
+				continue
+			}
+		}
+
+		photoLabels, err := query.ClassifiedPhotoLabels(label.ID)
+
+		if err != nil {
+			return result, err
+		}
+
+		maxUncertainty := 100 - int(math.Round(float64(rule.Threshold*100)))
+
+		for _, photoLabel := range photoLabels {
+			if photoLabel.Uncertainty > maxUncertainty {
+				if err := photoLabel.Delete(); err != nil {
+					return result, err
+				}
+
+				result.Removed++
+			} else if target.ID != label.ID {
+				if err := movePhotoLabel(photoLabel, target.ID); err != nil {
+					return result, err
+				}
+
+				result.Moved++
+			}
+		}
+
+		// Priorities changed manually are kept.
+		if err := target.SetRulePriority(rule.Priority); err != nil {
+			return result, err
+		}
+
+		// Parent labels added with the API are kept.
+		if err := target.ReplaceCategories(rule.Categories); err != nil {
+			return result, err
+		}
+	}
+
+	result.Synonyms = seedLabelSynonyms(rules)
+
+	if result.Moved > 0 || result.Removed > 0 {
+		if err := entity.UpdatePhotoCounts(); err != nil {
+			log.Errorf("labels: %s", err)
+		}
+	}
+
+	return result, nil
+}
+
+// seedLabelSynonyms adds the names of labels that rules map to another label as synonyms of that label,
+// so that search finds them, and returns the number of synonyms added.
+func seedLabelSynonyms(rules map[string]classify.LabelRule) (count int) {
+	for name, rule := range rules {
+		if rule.Label == "" || slug.Make(rule.Label) == name {
+			continue
+		}
+
+		target := entity.FindLabel(rule.Label)
+
+		if target == nil || entity.FindLabelSynonymSlug(name, "") != nil {
+			continue
+		}
+
+		synonym := entity.NewLabelSynonym(target.ID, txt.Title(strings.ReplaceAll(name, "-", " ")), "")
+
+		if err := synonym.Create(); err != nil {
+			log.Warnf("labels: %s (add synonym %s)", err, txt.Quote(synonym.SynonymName))
+			continue
+		}
+
+		count++
+	}
+
+	return count
+}
+
+// movePhotoLabel assigns a photo label to another label, keeping the lower uncertainty if the photo already has it.
+func movePhotoLabel(photoLabel entity.PhotoLabel, labelID uint) error {
+	moved := entity.FirstOrCreatePhotoLabel(entity.NewPhotoLabel(photoLabel.PhotoID, labelID, photoLabel.Uncertainty, photoLabel.LabelSrc))
+
+	if moved == nil {
+		return fmt.Errorf("failed moving label %d of photo %d", photoLabel.LabelID, photoLabel.PhotoID)
+	}
+
+	if moved.Uncertainty > photoLabel.Uncertainty && moved.Uncertainty < 100 {
+		if err := moved.Updates(map[string]interface{}{
+			"Uncertainty": photoLabel.Uncertainty,
+			"LabelSrc":    photoLabel.LabelSrc,
+		}); err != nil {
+			return err
+		}
+	}
+
+	return photoLabel.Delete()
+}
@@ -0,0 +1,126 @@
+package photoprism
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/classify"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyLabelRules(t *testing.T) {
+	photo := entity.PhotoFixtures.Get("Photo04")
+
+	defer func() {
+		_ = classify.SetCustomRules(classify.LabelRules{})
+	}()
+
+	if err := classify.SetCustomRules(classify.LabelRules{
+		"rules kitten": {Label: "rules cat", Threshold: 0.3, Priority: 2, Categories: []string{"animal"}},
+		"rules abacus": {Threshold: 0.9},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	kitten := entity.FirstOrCreateLabel(entity.NewLabel("Rules Kitten", 0))
+	abacus := entity.FirstOrCreateLabel(entity.NewLabel("Rules Abacus", 0))
+
+	if err := entity.NewPhotoLabel(photo.ID, kitten.ID, 20, entity.SrcImage).Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := entity.NewPhotoLabel(photo.ID, abacus.ID, 30, entity.SrcImage).Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := ApplyLabelRules()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.GreaterOrEqual(t, result.Labels, 2)
+	assert.GreaterOrEqual(t, result.Moved, 1)
+	assert.GreaterOrEqual(t, result.Removed, 1)
+
+	cat := entity.FindLabel("rules cat")
+
+	if cat == nil {
+		t.Fatal("label should exist")
+	}
+
+	assert.Equal(t, 2, cat.LabelPriority)
+
+	if m, err := query.PhotoLabel(photo.ID, cat.ID); err != nil {
+		t.Fatal(err)
+	} else {
+		assert.Equal(t, 20, m.Uncertainty)
+	}
+
+	_, err = query.PhotoLabel(photo.ID, kitten.ID)
+	assert.Error(t, err)
+
+	_, err = query.PhotoLabel(photo.ID, abacus.ID)
+	assert.Error(t, err)
+
+	if parents := cat.Parents(); assert.Len(t, parents, 1) {
+		assert.Equal(t, "Animal", parents[0].LabelName)
+	}
+
+	if synonyms := cat.Synonyms(); assert.Len(t, synonyms, 1) {
+		assert.Equal(t, "Rules Kitten", synonyms[0].SynonymName)
+	}
+
+	assert.GreaterOrEqual(t, result.Synonyms, 1)
+
+	// Priorities changed manually are kept.
+	if err := cat.Update("LabelPriority", 7); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := entity.NewPhotoLabel(photo.ID, kitten.ID, 20, entity.SrcImage).Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := ApplyLabelRules(); err != nil {
+		t.Fatal(err)
+	}
+
+	if cat = entity.FindLabel("rules cat"); cat == nil {
+		t.Fatal("label should exist")
+	}
+
+	assert.Equal(t, 7, cat.LabelPriority)
+
+	// Categories defined by rules are replaced.
+	if err := classify.SetCustomRules(classify.LabelRules{
+		"rules kitten": {Label: "rules cat", Threshold: 0.3, Priority: 2, Categories: []string{"pet"}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := entity.NewPhotoLabel(photo.ID, kitten.ID, 20, entity.SrcImage).Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := ApplyLabelRules(); err != nil {
+		t.Fatal(err)
+	}
+
+	if parents := cat.Parents(); assert.Len(t, parents, 1) {
+		assert.Equal(t, "Pet", parents[0].LabelName)
+	}
+}
+
+func TestApplyLabelRules_Busy(t *testing.T) {
+	if err := mutex.MainWorker.Start(); err != nil {
+		t.Fatal(err)
+	}
+
+	defer mutex.MainWorker.Stop()
+
+	_, err := ApplyLabelRules()
+	assert.Equal(t, ErrBusy, err)
+}
@@ -0,0 +1,91 @@
+package photoprism
+
+import (
+	"errors"
+
+	"github.com/photoprism/photoprism/internal/classify"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/query"
+)
+
+// RebuildLabels recomputes photo labels found by image classification from stored raw predictions
+// with the current label rules, without running TensorFlow again. Returns the number of updated photos.
+func RebuildLabels() (count int, err error) {
+	if err := mutex.MainWorker.Start(); err != nil {
+		return count, ErrBusy
+	}
+
+	defer mutex.MainWorker.Stop()
+
+	limit := 500
+	offset := 0
+
+	for {
+		results, err := query.PrimaryFilePredictions(limit, offset)
+
+		if err != nil {
+			return count, err
+		} else if len(results) == 0 {
+			break
+		}
+
+		for _, result := range results {
+			if mutex.MainWorker.Canceled() {
+				return count, errors.New("labels: rebuild canceled")
+			}
+
+			photo, err := query.PhotoByID(uint64(result.PhotoID))
+
+			if err != nil {
+				log.Warnf("labels: %s (find photo %d)", err, result.PhotoID)
+				continue
+			} else if photo.DeletedAt != nil {
+				continue
+			}
+
+			if err := photo.RemoveClassifiedLabels(faceLabelIDs(photo)); err != nil {
+				return count, err
+			}
+
+			photo.AddLabels(classifyLabels(result.Predictions))
+
+			if _, _, err := photo.Optimize(false, false, false, false); err != nil {
+				log.Errorf("labels: %s in photo %s", err, photo.PhotoUID)
+			}
+
+			count++
+		}
+
+		offset += limit
+	}
+
+	if count > 0 {
+		if err := entity.UpdatePhotoCounts(); err != nil {
+			log.Errorf("labels: %s", err)
+		}
+	}
+
+	return count, nil
+}
+
+// faceLabelIDs returns the ids of labels that were added by face detection, so that they are kept.
+func faceLabelIDs(photo entity.Photo) (ids []uint) {
+	if photo.PhotoFaces == 0 {
+		return ids
+	}
+
+	for _, name := range []string{"portrait", "people"} {
+		rule, ok := classify.FindRule(name)
+
+		if !ok || rule.Label == "" {
+			continue
+		}
+
+		if label := entity.FindLabel(rule.Label); label != nil {
+			ids = append(ids, label.ID)
+		}
+	}
+
+	return ids
+}
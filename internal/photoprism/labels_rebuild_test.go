@@ -0,0 +1,60 @@
+package photoprism
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRebuildLabels(t *testing.T) {
+	file := entity.FileFixtures["exampleFileName.jpg"]
+	predictions := entity.ImagePredictions{{{Name: "tabby cat", Probability: 0.82}}}
+
+	stale := entity.FirstOrCreateLabel(entity.NewLabel("Rebuild Stale", 0))
+
+	if err := entity.NewPhotoLabel(file.PhotoID, stale.ID, 10, entity.SrcImage).Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := entity.NewFilePredictions(file.ID, "nasnet", predictions).Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	count, err := RebuildLabels()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.GreaterOrEqual(t, count, 1)
+
+	_, err = query.PhotoLabel(file.PhotoID, stale.ID)
+	assert.Error(t, err)
+
+	cat := entity.FindLabel("cat")
+
+	if cat == nil {
+		t.Fatal("label should exist")
+	}
+
+	if m, err := query.PhotoLabel(file.PhotoID, cat.ID); err != nil {
+		t.Fatal(err)
+	} else {
+		assert.Equal(t, 18, m.Uncertainty)
+		assert.Equal(t, entity.SrcImage, m.LabelSrc)
+	}
+}
+
+func TestRebuildLabels_Busy(t *testing.T) {
+	if err := mutex.MainWorker.Start(); err != nil {
+		t.Fatal(err)
+	}
+
+	defer mutex.MainWorker.Stop()
+
+	_, err := RebuildLabels()
+	assert.Equal(t, ErrBusy, err)
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/djherbis/times"
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/meta"
+	"github.com/photoprism/photoprism/internal/metrics"
 	"github.com/photoprism/photoprism/internal/thumb"
 	"github.com/photoprism/photoprism/pkg/capture"
 	"github.com/photoprism/photoprism/pkg/fs"
@@ -352,21 +353,7 @@ func (m *MediaFile) RelatedFiles(stripSequence bool) (result RelatedFiles, err e
 // PathNameInfo returns file name infos for indexing.
 func (m *MediaFile) PathNameInfo(stripSequence bool) (fileRoot, fileBase, relativePath, relativeName string) {
 	fileRoot = m.Root()
-
-	var rootPath string
-
-	switch fileRoot {
-	case entity.RootSidecar:
-		rootPath = Config().SidecarPath()
-	case entity.RootImport:
-		rootPath = Config().ImportPath()
-	case entity.RootExamples:
-		rootPath = Config().ExamplesPath()
-	case entity.RootOriginals:
-		rootPath = Config().OriginalsPath()
-	default:
-		rootPath = Config().OriginalsPath()
-	}
+	rootPath := RootPath(fileRoot)
 
 	fileBase = m.BasePrefix(stripSequence)
 	relativePath = m.RelPath(rootPath)
@@ -434,16 +421,7 @@ func (m *MediaFile) RelPath(directory string) string {
 
 // RootPath returns the file root path based on the configuration.
 func (m *MediaFile) RootPath() string {
-	switch m.Root() {
-	case entity.RootSidecar:
-		return Config().SidecarPath()
-	case entity.RootImport:
-		return Config().ImportPath()
-	case entity.RootExamples:
-		return Config().ExamplesPath()
-	default:
-		return Config().OriginalsPath()
-	}
+	return RootPath(m.Root())
 }
 
 // RootRelPath returns the relative path and automatically detects the root path.
@@ -481,11 +459,26 @@ func (m *MediaFile) Root() string {
 		return m.fileRoot
 	}
 
+	// Temporary copies of remote library files.
+	if remotePath := Config().RemotePath() + string(os.PathSeparator); strings.HasPrefix(m.FileName(), remotePath) {
+		if id := strings.SplitN(strings.TrimPrefix(m.FileName(), remotePath), string(os.PathSeparator), 2)[0]; id != "" {
+			m.fileRoot = entity.RemoteRootPrefix + id
+			return m.fileRoot
+		}
+	}
+
 	if strings.HasPrefix(m.FileName(), Config().OriginalsPath()) {
 		m.fileRoot = entity.RootOriginals
 		return m.fileRoot
 	}
 
+	for _, lib := range Config().Libraries() {
+		if strings.HasPrefix(m.FileName(), lib.Path+string(os.PathSeparator)) {
+			m.fileRoot = lib.Name
+			return m.fileRoot
+		}
+	}
+
 	importPath := Config().ImportPath()
 
 	if importPath != "" && strings.HasPrefix(m.FileName(), importPath) {
@@ -931,6 +924,10 @@ func (m *MediaFile) ResampleDefault(thumbPath string, force bool) (err error) {
 		default:
 			log.Info(capture.Time(start, fmt.Sprintf("media: %d thumbnails created for %s", count, m.BasePrefix(false))))
 		}
+
+		if count > 0 {
+			metrics.StageDuration.WithLabelValues(metrics.StageThumbnails).Observe(time.Since(start).Seconds())
+		}
 	}()
 
 	hash := m.Hash()
@@ -5,6 +5,8 @@ import (
 	"path/filepath"
 
 	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/metrics"
+	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/photoprism/photoprism/internal/meta"
 	"github.com/photoprism/photoprism/pkg/fs"
@@ -69,6 +71,9 @@ func (m *MediaFile) ReadExifToolJson() error {
 // MetaData returns exif meta data of a media file.
 func (m *MediaFile) MetaData() (result meta.Data) {
 	m.metaDataOnce.Do(func() {
+		timer := prometheus.NewTimer(metrics.StageDuration.WithLabelValues(metrics.StageMetadata))
+		defer timer.ObserveDuration()
+
 		var err error
 
 		if m.ExifSupported() {
@@ -0,0 +1,125 @@
+package photoprism
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/metrics"
+	"github.com/photoprism/photoprism/internal/thumb"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// ocrSize is the max thumbnail size in pixels used for text recognition.
+const ocrSize = 2048
+
+// ocrEnabled tests if text in the photo should be recognized, e.g. because it's a document scan.
+func (ind *Index) ocrEnabled(photo *entity.Photo) bool {
+	if photo == nil || !ind.conf.TesseractEnabled() {
+		return false
+	}
+
+	return photo.PhotoScan || ind.conf.OcrAll()
+}
+
+// RecognizeText returns the text found in a JPEG image using Tesseract.
+func (ind *Index) RecognizeText(jpeg *MediaFile) (text string, err error) {
+	if jpeg == nil {
+		return "", fmt.Errorf("ocr: file is nil - you might have found a bug")
+	}
+
+	// Text is recognized in the largest pre-rendered thumbnail up to fit_2048,
+	// thumbnails are already rotated based on the Exif orientation.
+	size := ind.conf.ThumbSize()
+
+	if size > ocrSize {
+		size = ocrSize
+	}
+
+	typeName, _ := thumb.Find(size)
+
+	if typeName == "" {
+		return "", fmt.Errorf("ocr: no thumbnail type for size %d", size)
+	}
+
+	thumbName, err := jpeg.Thumbnail(ind.conf.ThumbPath(), typeName)
+
+	if err != nil {
+		return "", err
+	}
+
+	cmd := exec.Command(ind.conf.TesseractBin(), thumbName, "stdout", "-l", ind.conf.TesseractLang())
+
+	// Fetch command output.
+	var out bytes.Buffer
+	var stderr bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &stderr
+
+	start := time.Now()
+
+	// Run tesseract command.
+	if err := cmd.Run(); err != nil {
+		if stderr.String() != "" {
+			return "", errors.New(stderr.String())
+		} else {
+			return "", err
+		}
+	}
+
+	elapsed := time.Since(start)
+
+	metrics.StageDuration.WithLabelValues(metrics.StageOcr).Observe(elapsed.Seconds())
+
+	log.Debugf("index: text recognition took %s", elapsed)
+
+	return out.String(), nil
+}
+
+// recognizeText returns the text recognized in a JPEG image, or nil if there is none.
+func (ind *Index) recognizeText(jpeg *MediaFile) *entity.FileText {
+	text, err := ind.RecognizeText(jpeg)
+
+	if err != nil {
+		log.Warnf("index: %s in %s (ocr)", err, txt.Quote(jpeg.BaseName()))
+		return nil
+	}
+
+	result := entity.NewFileText(0, text, ind.conf.TesseractLang())
+
+	if result.Empty() {
+		return nil
+	}
+
+	return result
+}
+
+// addText recognizes text in a JPEG image, saves it and adds it to the photo keywords.
+func (ind *Index) addText(photo *entity.Photo, file *entity.File, jpeg *MediaFile) {
+	fileText := ind.recognizeText(jpeg)
+
+	if fileText == nil {
+		return
+	}
+
+	fileText.FileID = file.ID
+
+	if err := fileText.Save(); err != nil {
+		log.Errorf("index: %s in %s (save text)", err, txt.Quote(jpeg.BaseName()))
+		return
+	}
+
+	details := photo.GetDetails()
+	w := append(txt.Words(details.Keywords), fileText.Keywords()...)
+	details.Keywords = strings.Join(txt.UniqueWords(w), ", ")
+
+	if err := details.Save(); err != nil {
+		log.Errorf("index: %s in %s (save keywords)", err, txt.Quote(jpeg.BaseName()))
+	} else if err := photo.IndexKeywords(); err != nil {
+		log.Errorf("index: %s in %s (index keywords)", err, txt.Quote(jpeg.BaseName()))
+	}
+}
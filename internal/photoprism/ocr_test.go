@@ -0,0 +1,135 @@
+package photoprism
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/classify"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/detect"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/face"
+	"github.com/photoprism/photoprism/internal/nsfw"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIndex_ocrEnabled(t *testing.T) {
+	conf := config.TestConfig()
+
+	tf := classify.New(conf.AssetsPath(), conf.DisableTensorFlow())
+	nd := nsfw.New(conf.NSFWModelPath())
+	fn := face.NewNet(conf.FaceNetModelPath(), conf.DisableTensorFlow())
+	convert := NewConvert(conf)
+
+	ind := NewIndex(conf, tf, nd, fn, detect.New(conf.DetectModelPath(), true), convert, NewFiles(), NewPhotos())
+
+	t.Run("Nil", func(t *testing.T) {
+		assert.False(t, ind.ocrEnabled(nil))
+	})
+
+	t.Run("Scan", func(t *testing.T) {
+		photo := &entity.Photo{PhotoScan: true}
+		assert.Equal(t, conf.TesseractEnabled(), ind.ocrEnabled(photo))
+	})
+
+	t.Run("Photo", func(t *testing.T) {
+		photo := &entity.Photo{PhotoScan: false}
+		assert.Equal(t, conf.TesseractEnabled() && conf.OcrAll(), ind.ocrEnabled(photo))
+	})
+}
+
+func TestIndex_RecognizeText(t *testing.T) {
+	conf := config.TestConfig()
+
+	tf := classify.New(conf.AssetsPath(), conf.DisableTensorFlow())
+	nd := nsfw.New(conf.NSFWModelPath())
+	fn := face.NewNet(conf.FaceNetModelPath(), conf.DisableTensorFlow())
+	convert := NewConvert(conf)
+
+	ind := NewIndex(conf, tf, nd, fn, detect.New(conf.DetectModelPath(), true), convert, NewFiles(), NewPhotos())
+
+	t.Run("Nil", func(t *testing.T) {
+		text, err := ind.RecognizeText(nil)
+
+		assert.Error(t, err)
+		assert.Equal(t, "", text)
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		if conf.TesseractEnabled() {
+			t.Skip("tesseract is installed")
+		}
+
+		mf, err := NewMediaFile(conf.ExamplesPath() + "/elephants.jpg")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Nil(t, ind.recognizeText(mf))
+	})
+}
+
+func TestIndex_MediaFile_Text(t *testing.T) {
+	conf := config.TestConfig()
+
+	// A fake Tesseract binary, so that text recognition can be tested without installing it.
+	bin := filepath.Join(t.TempDir(), "tesseract-bin")
+	script := "#!/bin/sh\n[ \"$2\" = \"stdout\" ] || exit 1\necho \"Invoice 4711\"\necho \"  Gopher   Supplies  \"\n"
+
+	if err := ioutil.WriteFile(bin, []byte(script), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	opt := conf.Options()
+	tesseractBin, disableTesseract, ocrAll := opt.TesseractBin, opt.DisableTesseract, opt.OcrAll
+
+	defer func() {
+		opt.TesseractBin, opt.DisableTesseract, opt.OcrAll = tesseractBin, disableTesseract, ocrAll
+	}()
+
+	opt.TesseractBin, opt.DisableTesseract, opt.OcrAll = bin, false, true
+
+	tf := classify.New(conf.AssetsPath(), conf.DisableTensorFlow())
+	nd := nsfw.New(conf.NSFWModelPath())
+	fn := face.NewNet(conf.FaceNetModelPath(), conf.DisableTensorFlow())
+	convert := NewConvert(conf)
+
+	ind := NewIndex(conf, tf, nd, fn, detect.New(conf.DetectModelPath(), true), convert, NewFiles(), NewPhotos())
+
+	mf, err := NewMediaFile(conf.ExamplesPath() + "/elephants.jpg")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := ind.MediaFile(mf, IndexOptionsAll(), "")
+
+	if result.Failed() {
+		t.Fatal(result.Err)
+	}
+
+	fileText := entity.FindFileText(result.FileID)
+
+	if fileText == nil {
+		t.Fatal("text should be saved")
+	}
+
+	assert.Equal(t, "Invoice 4711\nGopher Supplies", fileText.TextContent)
+	assert.Equal(t, conf.TesseractLang(), fileText.TextLang)
+
+	photo, err := query.PhotoByUID(result.PhotoUID)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keywords := photo.GetDetails().Keywords
+
+	assert.Contains(t, keywords, "invoice")
+	assert.Contains(t, keywords, "gopher")
+	assert.Contains(t, keywords, "supplies")
+}
@@ -82,6 +82,16 @@ func (w *Purge) Start(opt PurgeOptions) (purgedFiles map[string]bool, purgedPhot
 				continue
 			}
 
+			// Files in remote libraries are only stored temporarily and checked by the sync worker.
+			if file.Remote() {
+				continue
+			}
+
+			// Skip libraries that are currently unavailable, e.g. because a drive is not mounted.
+			if lib, ok := Config().Library(file.FileRoot); ok && !fs.PathExists(lib.Path) {
+				continue
+			}
+
 			if file.FileMissing {
 				if fs.FileExists(fileName) {
 					if opt.Dry {
@@ -157,6 +167,10 @@ func (w *Purge) Start(opt PurgeOptions) (purgedFiles map[string]bool, purgedPhot
 				continue
 			}
 
+			if entity.IsRemoteRoot(file.FileRoot) {
+				continue
+			}
+
 			if !fs.FileExists(fileName) {
 				if opt.Dry {
 					purgedFiles[fileName] = true
@@ -0,0 +1,397 @@
+package photoprism
+
+import (
+	"errors"
+	"runtime/debug"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/classify"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// queueBatch is the number of pending items fetched from the database at once.
+const queueBatch = 100
+
+// queuePoll is the wait time before checking for new items while other queues are still being processed.
+var queuePoll = time.Second
+
+// enqueue adds a file to a persistent work queue with the priority of the current options.
+func (o IndexOptions) enqueue(queueName string, f *MediaFile, fileUID string, isNew bool) {
+	item := entity.NewQueueItem(queueName, f.Root(), f.RootRelName(), o.Priority)
+	item.FileUID = fileUID
+	item.ItemRescan = o.Rescan
+	item.ItemNew = isNew
+	item.ReportID = o.Report.ID()
+
+	if err := item.Enqueue(); err != nil {
+		log.Errorf("queue: %s in %s (add to %s queue)", err, txt.Quote(f.BaseName()), queueName)
+	}
+}
+
+// thumbs creates the default thumbnails for a JPEG, or adds it to the thumbnail queue if this is deferred.
+func (ind *Index) thumbs(f *MediaFile, opt IndexOptions) error {
+	if opt.DeferThumbs {
+		opt.enqueue(entity.QueueThumbs, f, "", false)
+		return nil
+	}
+
+	return f.ResampleDefault(ind.thumbPath(), false)
+}
+
+// runQueue processes the pending items of a work queue with the given number of workers until it is empty
+// and wait returns false. Completed items are removed, so that processing can be resumed after a restart.
+func runQueue(queueName string, workers int, wait func() bool, process func(item entity.QueueItem)) (count int) {
+	if workers < 1 {
+		workers = 1
+	}
+
+	items := make(chan entity.QueueItem)
+
+	var wg sync.WaitGroup
+
+	wg.Add(workers)
+
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+
+			for item := range items {
+				runQueueItem(item, process)
+			}
+		}()
+	}
+
+	for !mutex.MainWorker.Canceled() {
+		pending, err := query.PendingQueueItems(queueName, queueBatch)
+
+		if err != nil {
+			log.Errorf("queue: %s", err)
+			break
+		} else if len(pending) == 0 {
+			if wait != nil && wait() {
+				time.Sleep(queuePoll)
+				continue
+			}
+
+			break
+		}
+
+		for _, item := range pending {
+			if mutex.MainWorker.Canceled() {
+				break
+			}
+
+			if err := item.Start(); err != nil {
+				log.Errorf("queue: %s in %s", err, txt.Quote(item.String()))
+				continue
+			}
+
+			count++
+			items <- item
+		}
+	}
+
+	close(items)
+	wg.Wait()
+
+	return count
+}
+
+// runQueueItem processes a single item and removes it from the queue, unless it fails too often.
+func runQueueItem(item entity.QueueItem, process func(item entity.QueueItem)) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Errorf("queue: %s in %s (panic)\nstack: %s", r, txt.Quote(item.String()), debug.Stack())
+		}
+
+		if err := item.Done(); err != nil {
+			log.Errorf("queue: %s in %s", err, txt.Quote(item.String()))
+		}
+	}()
+
+	// Items that were started too often without completing, e.g. because they crash the process, are skipped.
+	if item.ItemAttempts > entity.QueueAttempts {
+		log.Warnf("queue: skipped %s after %d attempts", txt.Quote(item.String()), entity.QueueAttempts)
+		return
+	}
+
+	process(item)
+}
+
+// processedFiles remembers the modification times of files indexed in the current run,
+// so that files which changed in the meantime are indexed again when they are requeued.
+type processedFiles struct {
+	mutex    sync.Mutex
+	modTimes map[string]time.Time
+}
+
+// newProcessedFiles returns a new, empty set of processed files.
+func newProcessedFiles() *processedFiles {
+	return &processedFiles{modTimes: make(map[string]time.Time)}
+}
+
+// Claim returns true if the file has not been processed with the given modification time yet.
+func (p *processedFiles) Claim(fileName string, modTime time.Time) bool {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if t, ok := p.modTimes[fileName]; ok && t.Equal(modTime) {
+		return false
+	}
+
+	p.modTimes[fileName] = modTime
+
+	return true
+}
+
+// process indexes the files in the index queue in the background while wait returns true, and runs the
+// thumbnail and classification queues in parallel. The returned channel is closed when all queues are empty.
+func (ind *Index) process(opt IndexOptions, wait func() bool) chan struct{} {
+	done := make(chan struct{})
+
+	// Items claimed by a previous run that did not complete are processed again.
+	for _, queueName := range []string{entity.QueueIndex, entity.QueueThumbs, entity.QueueClassify} {
+		if err := entity.ResetQueue(queueName); err != nil {
+			log.Errorf("queue: %s", err)
+		}
+	}
+
+	go func() {
+		var indexing int32 = 1
+		processed := newProcessedFiles()
+
+		stages := ind.runStages(opt.Report, func() bool {
+			return atomic.LoadInt32(&indexing) == 1
+		})
+
+		runQueue(entity.QueueIndex, ind.conf.Workers(), wait, func(item entity.QueueItem) {
+			ind.indexItem(item, opt, processed)
+		})
+
+		atomic.StoreInt32(&indexing, 0)
+
+		<-stages
+
+		close(done)
+	}()
+
+	return done
+}
+
+// Resume processes work queue items left over from a previous run, e.g. after a crash or restart.
+func (ind *Index) Resume() {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Errorf("index: %s (panic)\nstack: %s", r, debug.Stack())
+		}
+	}()
+
+	if counts, err := query.QueueCounts(); err != nil {
+		log.Errorf("index: %s", err)
+		return
+	} else if counts[entity.QueueIndex]+counts[entity.QueueThumbs]+counts[entity.QueueClassify] == 0 {
+		return
+	}
+
+	if err := mutex.MainWorker.Start(); err != nil {
+		log.Warnf("index: %s (resume)", err)
+		return
+	}
+
+	defer mutex.MainWorker.Stop()
+
+	if err := ind.tensorFlow.Init(); err != nil {
+		log.Errorf("index: %s", err)
+		return
+	}
+
+	if err := ind.files.Init(); err != nil {
+		log.Errorf("index: %s", err)
+	}
+
+	defer ind.files.Done()
+
+	log.Infof("index: resuming queued work")
+
+	opt := IndexOptionsNone()
+	opt.Convert = ind.conf.Settings().Index.Convert && ind.conf.SidecarWritable()
+	opt.Stack = true
+	opt.DeferThumbs = ind.conf.ThumbWorkers() > 0
+	opt.DeferClassify = ind.conf.ClassifyWorkers() > 0
+
+	<-ind.process(opt, nil)
+
+	if err := entity.UpdatePhotoCounts(); err != nil {
+		log.Errorf("index: %s", err)
+	}
+}
+
+// runStages processes the thumbnail and classification queues in the background until they are empty and
+// indexing is done, the returned channel is closed when both have finished.
+func (ind *Index) runStages(report *Report, indexing func() bool) chan struct{} {
+	done := make(chan struct{})
+
+	go func() {
+		var wg sync.WaitGroup
+
+		wg.Add(2)
+
+		go func() {
+			defer wg.Done()
+			runQueue(entity.QueueThumbs, ind.conf.ThumbWorkers(), indexing, func(item entity.QueueItem) {
+				ind.thumbsItem(item, report)
+			})
+		}()
+
+		go func() {
+			defer wg.Done()
+			runQueue(entity.QueueClassify, ind.conf.ClassifyWorkers(), indexing, func(item entity.QueueItem) {
+				ind.classifyItem(item, report)
+			})
+		}()
+
+		wg.Wait()
+		close(done)
+	}()
+
+	return done
+}
+
+// indexItem indexes a file from the index queue together with related files that need indexing.
+func (ind *Index) indexItem(item entity.QueueItem, opt IndexOptions, processed *processedFiles) {
+	mf, err := NewMediaFile(FileName(item.FileRoot, item.FileName))
+
+	if err != nil {
+		log.Errorf("index: %s", err)
+		opt.Report.Fail(item.FileRoot, item.FileName, StageFile, err)
+		return
+	}
+
+	related, err := mf.RelatedFiles(ind.conf.Settings().StackSequences())
+
+	if err != nil {
+		log.Warnf("index: %s", err)
+		opt.Report.Fail(item.FileRoot, item.FileName, StageFile, err)
+		return
+	}
+
+	var files MediaFiles
+
+	for _, f := range related.Files {
+		if !processed.Claim(f.FileName(), f.ModTime()) {
+			continue
+		}
+
+		if f.FileSize() == 0 || ind.files.Indexed(f.RootRelName(), f.Root(), f.ModTime(), item.ItemRescan) {
+			continue
+		}
+
+		files = append(files, f)
+	}
+
+	if len(files) == 0 || related.Main == nil {
+		return
+	}
+
+	related.Files = files
+
+	opt.Rescan = item.ItemRescan
+	opt.Priority = item.ItemPriority
+
+	IndexRelated(related, ind, opt)
+}
+
+// thumbsItem creates the default thumbnails for a file from the thumbnail queue.
+func (ind *Index) thumbsItem(item entity.QueueItem, report *Report) {
+	mf, err := NewMediaFile(FileName(item.FileRoot, item.FileName))
+
+	if err != nil {
+		log.Errorf("thumbs: %s", err)
+		report.FailItem(item, StageThumbnails, err)
+		return
+	}
+
+	if err := mf.ResampleDefault(ind.thumbPath(), false); err != nil {
+		log.Errorf("thumbs: failed creating thumbnails for %s (%s)", txt.Quote(mf.BaseName()), err)
+		report.FailItem(item, StageThumbnails, err)
+
+		if item.FileUID != "" {
+			query.SetFileError(item.FileUID, err.Error())
+		}
+	}
+}
+
+// classifyItem adds labels and faces to the photo of a file from the classification queue.
+func (ind *Index) classifyItem(item entity.QueueItem, report *Report) {
+	file, err := query.FileByUID(item.FileUID)
+
+	if err != nil {
+		log.Errorf("classify: %s in %s", err, txt.Quote(item.String()))
+		report.FailItem(item, StageClassify, err)
+		return
+	} else if file.Photo == nil {
+		log.Errorf("classify: photo not found for %s", txt.Quote(item.String()))
+		report.FailItem(item, StageClassify, errors.New("photo not found"))
+		return
+	}
+
+	mf, err := NewMediaFile(FileName(file.FileRoot, file.FileName))
+
+	if err != nil {
+		log.Errorf("classify: %s", err)
+		report.FailItem(item, StageClassify, err)
+		return
+	}
+
+	photo := file.Photo
+	labels, predictions := ind.classifyImage(mf)
+
+	if len(predictions) > 0 {
+		if err := entity.NewFilePredictions(file.ID, ind.tensorFlow.ModelName(), predictions).Save(); err != nil {
+			log.Errorf("classify: %s in %s (save predictions)", err, txt.Quote(mf.BaseName()))
+		}
+	}
+
+	if item.ItemNew && ind.conf.Settings().Features.Private && ind.conf.DetectNSFW() && ind.NSFW(mf) {
+		photo.PhotoPrivate = true
+	}
+
+	if ind.conf.Experimental() && ind.conf.Settings().Features.People {
+		faces := ind.detectFaces(mf)
+
+		labels = append(labels, classify.FaceLabels(faces, entity.SrcImage)...)
+
+		file.PreloadMarkers()
+
+		if len(faces) > 0 {
+			file.AddFaces(faces)
+		}
+
+		photo.PhotoFaces = file.Markers.FaceCount()
+	}
+
+	if ind.conf.DetectObjects() {
+		ind.addObjects(photo, &file, mf)
+	}
+
+	if err := file.Markers.Save(file.ID); err != nil {
+		log.Errorf("classify: %s in %s (save markers)", err, txt.Quote(mf.BaseName()))
+	}
+
+	if ind.ocrEnabled(photo) {
+		ind.addText(photo, &file, mf)
+	}
+
+	photo.AddLabels(labels)
+
+	if _, _, err := photo.Optimize(false, false, false, false); err != nil {
+		log.Errorf("classify: %s in %s", err, txt.Quote(mf.BaseName()))
+	} else {
+		log.Debugf("classify: added %d labels to %s", len(labels), txt.Quote(mf.BaseName()))
+	}
+}
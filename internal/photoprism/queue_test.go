@@ -0,0 +1,124 @@
+package photoprism
+
+import (
+	"testing"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/classify"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/detect"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/face"
+	"github.com/photoprism/photoprism/internal/nsfw"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunQueue(t *testing.T) {
+	t.Run("priority", func(t *testing.T) {
+		for _, m := range []*entity.QueueItem{
+			entity.NewQueueItem(entity.QueueThumbs, entity.RootOriginals, "queue/rescan.jpg", entity.PriorityRescan),
+			entity.NewQueueItem(entity.QueueThumbs, entity.RootOriginals, "queue/new.jpg", entity.PriorityNew),
+			entity.NewQueueItem(entity.QueueThumbs, entity.RootOriginals, "queue/import.jpg", entity.PriorityImport),
+		} {
+			if err := m.Enqueue(); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		var names []string
+
+		count := runQueue(entity.QueueThumbs, 1, nil, func(item entity.QueueItem) {
+			names = append(names, item.FileName)
+		})
+
+		assert.Equal(t, 3, count)
+		assert.Equal(t, []string{"queue/import.jpg", "queue/new.jpg", "queue/rescan.jpg"}, names)
+
+		pending, err := query.PendingQueueItems(entity.QueueThumbs, 10)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, pending, 0)
+	})
+	t.Run("attempts", func(t *testing.T) {
+		m := entity.NewQueueItem(entity.QueueThumbs, entity.RootOriginals, "queue/crash.jpg", entity.PriorityNew)
+		m.ItemAttempts = entity.QueueAttempts
+
+		if err := m.Enqueue(); err != nil {
+			t.Fatal(err)
+		}
+
+		count := runQueue(entity.QueueThumbs, 1, nil, func(item entity.QueueItem) {
+			t.Errorf("%s should be skipped", item.String())
+		})
+
+		assert.Equal(t, 1, count)
+	})
+	t.Run("panic", func(t *testing.T) {
+		m := entity.NewQueueItem(entity.QueueThumbs, entity.RootOriginals, "queue/panic.jpg", entity.PriorityNew)
+
+		if err := m.Enqueue(); err != nil {
+			t.Fatal(err)
+		}
+
+		count := runQueue(entity.QueueThumbs, 2, nil, func(item entity.QueueItem) {
+			panic("failed")
+		})
+
+		assert.Equal(t, 1, count)
+	})
+}
+
+func TestIndex_Thumbs(t *testing.T) {
+	conf := config.TestConfig()
+
+	tf := classify.New(conf.AssetsPath(), true)
+	nd := nsfw.New(conf.NSFWModelPath())
+	fn := face.NewNet(conf.FaceNetModelPath(), true)
+	ind := NewIndex(conf, tf, nd, fn, detect.New(conf.DetectModelPath(), true), NewConvert(conf), NewFiles(), NewPhotos())
+
+	mf, err := NewMediaFile(conf.ExamplesPath() + "/elephants.jpg")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	opt := IndexOptionsNone()
+	opt.DeferThumbs = true
+	opt.Priority = entity.PriorityImport
+
+	if err := ind.thumbs(mf, opt); err != nil {
+		t.Fatal(err)
+	}
+
+	pending, err := query.PendingQueueItems(entity.QueueThumbs, 10)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if assert.Len(t, pending, 1) {
+		assert.Equal(t, mf.RootRelName(), pending[0].FileName)
+		assert.Equal(t, entity.PriorityImport, pending[0].ItemPriority)
+	}
+
+	assert.Equal(t, 1, runQueue(entity.QueueThumbs, 1, nil, func(item entity.QueueItem) {
+		ind.thumbsItem(item, opt.Report)
+	}))
+}
+
+func TestProcessedFiles_Claim(t *testing.T) {
+	p := newProcessedFiles()
+	modTime := time.Date(2021, 5, 14, 10, 30, 0, 0, time.UTC)
+
+	assert.True(t, p.Claim("/photos/a.jpg", modTime))
+	assert.False(t, p.Claim("/photos/a.jpg", modTime))
+	assert.True(t, p.Claim("/photos/b.jpg", modTime))
+
+	// Files that changed in the meantime are processed again.
+	assert.True(t, p.Claim("/photos/a.jpg", modTime.Add(time.Second)))
+	assert.False(t, p.Claim("/photos/a.jpg", modTime.Add(time.Second)))
+}
@@ -0,0 +1,31 @@
+package photoprism
+
+import (
+	"fmt"
+	"io"
+	"path"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/remote"
+	"github.com/photoprism/photoprism/internal/remote/webdav"
+)
+
+// OpenRemote returns a reader for the original of a file in a remote library, see RemoteRootPath.
+func OpenRemote(f entity.File) (io.ReadCloser, error) {
+	id := entity.RemoteRootID(f.FileRoot)
+
+	if id == 0 {
+		return nil, fmt.Errorf("%s is not a remote file", f.FileName)
+	}
+
+	a, err := query.AccountByID(id)
+
+	if err != nil {
+		return nil, err
+	} else if a.AccType != remote.ServiceWebDAV {
+		return nil, fmt.Errorf("account %s does not support streaming", a.AccName)
+	}
+
+	return webdav.New(a.AccURL, a.AccUser, a.AccPass).ReadStream(path.Join(a.LibraryPath, f.FileName))
+}
@@ -0,0 +1,113 @@
+package photoprism
+
+import (
+	"sync"
+
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+// Index stages reported for files that failed.
+const (
+	StageFile       = "file"
+	StageConvert    = "convert"
+	StageThumbnails = "thumbnails"
+	StageClassify   = "classify"
+	StageDatabase   = "database"
+	StageImport     = "import"
+)
+
+// Report collects the results of an index or import run, methods may be called on a nil Report.
+type Report struct {
+	mutex  sync.Mutex
+	report *entity.IndexReport
+}
+
+// NewReport saves a new index report and returns a collector for the results.
+func NewReport(m *entity.IndexReport) *Report {
+	if err := m.Create(); err != nil {
+		log.Errorf("report: %s", err)
+	}
+
+	return &Report{report: m}
+}
+
+// ID returns the report id.
+func (r *Report) ID() uint {
+	if r == nil {
+		return 0
+	}
+
+	return r.report.ID
+}
+
+// Result adds the index result of a file to the report.
+func (r *Report) Result(f *MediaFile, res IndexResult) {
+	if r == nil {
+		return
+	}
+
+	if res.Failed() {
+		if f == nil {
+			r.Fail("", "", res.Stage, res.Err)
+		} else {
+			r.Fail(f.Root(), f.RootRelName(), res.Stage, res.Err)
+		}
+
+		return
+	}
+
+	r.mutex.Lock()
+	r.report.ReportCounts[res.String()]++
+	r.mutex.Unlock()
+}
+
+// Fail adds a file that failed with the reason and stage to the report.
+func (r *Report) Fail(rootName, fileName, stage string, err error) {
+	if r == nil {
+		return
+	}
+
+	r.mutex.Lock()
+	r.report.ReportCounts[string(IndexFailed)]++
+	r.report.ReportFailed++
+	r.mutex.Unlock()
+
+	if err := entity.NewIndexFailure(r.report.ID, rootName, fileName, stage, err).Create(); err != nil {
+		log.Errorf("report: %s", err)
+	}
+}
+
+// FailItem adds a file from a deferred stage that failed to the report of the run that queued it.
+func (r *Report) FailItem(item entity.QueueItem, stage string, err error) {
+	if item.ReportID == 0 {
+		return
+	} else if item.ReportID == r.ID() {
+		r.Fail(item.FileRoot, item.FileName, stage, err)
+	} else if err := entity.AddIndexFailure(item.ReportID, item.FileRoot, item.FileName, stage, err); err != nil {
+		log.Errorf("report: %s", err)
+	}
+}
+
+// Finish saves the report and returns it.
+func (r *Report) Finish(err error) *entity.IndexReport {
+	if r == nil {
+		return nil
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if err := r.report.Finish(err); err != nil {
+		log.Errorf("report: %s", err)
+	}
+
+	if r.report.ReportFailed > 0 {
+		log.Warnf("%s: %d files failed, see report %d", r.report.ReportType, r.report.ReportFailed, r.report.ID)
+	}
+
+	if err := entity.PruneIndexReports(entity.IndexReportsKeep); err != nil {
+		log.Errorf("report: %s", err)
+	}
+
+	return r.report
+}
@@ -0,0 +1,128 @@
+package photoprism
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/classify"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/detect"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/face"
+	"github.com/photoprism/photoprism/internal/nsfw"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReport(t *testing.T) {
+	t.Run("nil", func(t *testing.T) {
+		var r *Report
+
+		r.Result(nil, IndexResult{Status: IndexAdded})
+		r.Fail(entity.RootOriginals, "test.jpg", StageFile, errors.New("failed"))
+
+		r.FailItem(entity.QueueItem{ReportID: 1}, StageThumbnails, errors.New("failed"))
+
+		assert.Equal(t, uint(0), r.ID())
+		assert.Nil(t, r.Finish(nil))
+	})
+	t.Run("results", func(t *testing.T) {
+		r := NewReport(entity.NewIndexReport(entity.ReportIndex, entity.RootOriginals, "/"))
+
+		assert.NotEmpty(t, r.ID())
+
+		r.Result(nil, IndexResult{Status: IndexAdded})
+		r.Result(nil, IndexResult{Status: IndexAdded})
+		r.Result(nil, IndexResult{Status: IndexSkipped})
+		r.Fail(entity.RootOriginals, "2021/broken.jpg", StageConvert, errors.New("failed"))
+
+		report := r.Finish(nil)
+
+		assert.False(t, report.Running())
+		assert.Equal(t, 1, report.ReportFailed)
+		assert.Equal(t, entity.JobCounts{"added": 2, "skipped": 1, "failed": 1}, report.ReportCounts)
+
+		failures, err := query.IndexFailures(report.ID, 0, 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, failures, 1)
+		assert.Equal(t, StageConvert, failures[0].FileStage)
+		assert.Equal(t, "2021/broken.jpg", failures[0].FileName)
+	})
+	t.Run("deferred", func(t *testing.T) {
+		queued := NewReport(entity.NewIndexReport(entity.ReportIndex, entity.RootOriginals, "/"))
+		queued.Finish(nil)
+
+		r := NewReport(entity.NewIndexReport(entity.ReportIndex, entity.RootOriginals, "/"))
+
+		// Items queued by the current run are added to its report, older items to the report that queued them.
+		r.FailItem(entity.QueueItem{FileRoot: entity.RootOriginals, FileName: "2021/new.jpg", ReportID: r.ID()}, StageThumbnails, errors.New("failed"))
+		r.FailItem(entity.QueueItem{FileRoot: entity.RootOriginals, FileName: "2021/old.jpg", ReportID: queued.ID()}, StageClassify, errors.New("failed"))
+		r.FailItem(entity.QueueItem{FileRoot: entity.RootOriginals, FileName: "2021/none.jpg"}, StageClassify, errors.New("failed"))
+
+		assert.Equal(t, 1, r.Finish(nil).ReportFailed)
+
+		if report := entity.FindIndexReport(queued.ID()); assert.NotNil(t, report) {
+			assert.Equal(t, 1, report.ReportFailed)
+		}
+
+		failures, err := query.IndexFailures(queued.ID(), 0, 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if assert.Len(t, failures, 1) {
+			assert.Equal(t, StageClassify, failures[0].FileStage)
+			assert.Equal(t, "2021/old.jpg", failures[0].FileName)
+		}
+	})
+}
+
+func TestIndex_Retry(t *testing.T) {
+	conf := config.TestConfig()
+
+	// Missing files are not indexed, so TensorFlow models don't need to be loaded.
+	tf := classify.New(conf.AssetsPath(), true)
+	nd := nsfw.New(conf.NSFWModelPath())
+	fn := face.NewNet(conf.FaceNetModelPath(), true)
+	ind := NewIndex(conf, tf, nd, fn, detect.New(conf.DetectModelPath(), true), NewConvert(conf), NewFiles(), NewPhotos())
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := ind.Retry(999999)
+		assert.Equal(t, ErrReportNotFound, err)
+	})
+	t.Run("no failed files", func(t *testing.T) {
+		r := NewReport(entity.NewIndexReport(entity.ReportIndex, entity.RootOriginals, "/"))
+		r.Finish(nil)
+
+		_, err := ind.Retry(r.ID())
+		assert.Equal(t, ErrNoFailedFiles, err)
+	})
+	t.Run("missing file", func(t *testing.T) {
+		r := NewReport(entity.NewIndexReport(entity.ReportIndex, entity.RootOriginals, "/"))
+		r.Fail(entity.RootOriginals, "retry/missing.jpg", StageDatabase, errors.New("failed"))
+		r.Finish(nil)
+
+		report, err := ind.Retry(r.ID())
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, r.ID(), report.ReportRetry)
+		assert.Equal(t, 1, report.ReportFailed)
+
+		failures, err := query.IndexFailures(report.ID, 0, 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, failures, 1)
+		assert.Equal(t, StageFile, failures[0].FileStage)
+	})
+}
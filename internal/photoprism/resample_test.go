@@ -5,6 +5,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/photoprism/photoprism/internal/detect"
 	"github.com/photoprism/photoprism/internal/face"
 
 	"github.com/disintegration/imaging"
@@ -35,7 +36,7 @@ func TestResample_Start(t *testing.T) {
 	fn := face.NewNet(conf.FaceNetModelPath(), conf.DisableTensorFlow())
 	convert := NewConvert(conf)
 
-	ind := NewIndex(conf, tf, nd, fn, convert, NewFiles(), NewPhotos())
+	ind := NewIndex(conf, tf, nd, fn, detect.New(conf.DetectModelPath(), true), convert, NewFiles(), NewPhotos())
 
 	imp := NewImport(conf, ind, convert)
 	opt := ImportOptionsMove(conf.ImportPath())
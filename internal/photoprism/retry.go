@@ -0,0 +1,155 @@
+package photoprism
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/metrics"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+var (
+	ErrReportNotFound = errors.New("report not found")
+	ErrNoFailedFiles  = errors.New("no failed files")
+	ErrBusy           = errors.New("another index or import is running")
+)
+
+// Retry indexes the files that failed in a previous index run again and returns the new report.
+func (ind *Index) Retry(id uint) (*entity.IndexReport, error) {
+	return retry(ind, nil, id)
+}
+
+// Retry imports or indexes the files that failed in a previous run again and returns the new report.
+func (imp *Import) Retry(id uint) (*entity.IndexReport, error) {
+	return retry(imp.index, imp, id)
+}
+
+// retry processes the failed files of a report again, files in the import folder are skipped if imp is nil.
+func retry(ind *Index, imp *Import, id uint) (*entity.IndexReport, error) {
+	prev := entity.FindIndexReport(id)
+
+	if prev == nil {
+		return nil, ErrReportNotFound
+	}
+
+	failures, err := query.IndexFailures(prev.ID, 0, 0)
+
+	if err != nil {
+		return nil, err
+	} else if len(failures) == 0 {
+		return nil, ErrNoFailedFiles
+	}
+
+	if err := mutex.MainWorker.Start(); err != nil {
+		return nil, ErrBusy
+	}
+
+	defer mutex.MainWorker.Stop()
+
+	if err := ind.tensorFlow.Init(); err != nil {
+		return nil, err
+	}
+
+	m := entity.NewIndexReport(prev.ReportType, prev.ReportRoot, prev.ReportPath)
+	m.ReportMove = prev.ReportMove
+	m.ReportRetry = prev.ID
+
+	indexOpt := IndexOptions{
+		Path:    "/",
+		Rescan:  true,
+		Stack:   true,
+		Convert: ind.conf.Settings().Index.Convert && ind.conf.SidecarWritable(),
+		Report:  NewReport(m),
+	}
+
+	var importJobs []ImportJob
+
+	for _, failure := range failures {
+		if mutex.MainWorker.Canceled() {
+			return indexOpt.Report.Finish(errors.New("retry canceled")), nil
+		}
+
+		// Files without name can't be found again, e.g. if no main file was found.
+		if failure.FileName == "" {
+			indexOpt.Report.Fail(failure.FileRoot, failure.FileName, failure.FileStage, errors.New(failure.FileError))
+			continue
+		}
+
+		if failure.FileRoot == entity.RootImport {
+			if imp == nil {
+				continue
+			}
+
+			importPath := imp.importPath(prev)
+			fileName := filepath.Join(importPath, failure.FileName)
+
+			mf, err := NewMediaFile(fileName)
+
+			if err != nil {
+				indexOpt.Report.Fail(failure.FileRoot, failure.FileName, StageFile, err)
+				continue
+			}
+
+			related, err := mf.RelatedFiles(ind.conf.Settings().StackSequences())
+
+			if err != nil {
+				indexOpt.Report.Fail(failure.FileRoot, failure.FileName, StageFile, err)
+				continue
+			}
+
+			importOpt := ImportOptionsCopy(importPath)
+
+			if prev.ReportMove {
+				importOpt = ImportOptionsMove(importPath)
+			}
+
+			importJobs = append(importJobs, ImportJob{
+				FileName:  fileName,
+				Related:   related,
+				IndexOpt:  indexOpt,
+				ImportOpt: importOpt,
+				Imp:       imp,
+			})
+
+			continue
+		}
+
+		fileName := FileName(failure.FileRoot, failure.FileName)
+
+		if !fs.FileExists(fileName) {
+			indexOpt.Report.Fail(failure.FileRoot, failure.FileName, StageFile, fmt.Errorf("%s not found", txt.Quote(failure.FileName)))
+			continue
+		}
+
+		opt := indexOpt
+		opt.Root = failure.FileRoot
+
+		res := ind.FileName(fileName, opt)
+
+		log.Infof("%s: retried %s (%s)", prev.ReportType, txt.Quote(failure.FileName), res)
+	}
+
+	if len(importJobs) > 0 {
+		jobs := make(chan ImportJob, len(importJobs))
+
+		for _, job := range importJobs {
+			metrics.QueueDepth.WithLabelValues(metrics.QueueImport).Inc()
+			jobs <- job
+		}
+
+		close(jobs)
+
+		ImportWorker(jobs)
+	}
+
+	if err := entity.UpdatePhotoCounts(); err != nil {
+		log.Errorf("%s: %s", prev.ReportType, err)
+	}
+
+	return indexOpt.Report.Finish(nil), nil
+}
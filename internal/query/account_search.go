@@ -17,6 +17,10 @@ func AccountSearch(f form.AccountSearch) (result entity.Accounts, err error) {
 		s = s.Where("acc_sync = 1")
 	}
 
+	if f.Library {
+		s = s.Where("acc_library = 1")
+	}
+
 	if f.Status != "" {
 		s = s.Where("sync_status = ?", f.Status)
 	}
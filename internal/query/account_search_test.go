@@ -80,6 +80,21 @@ func TestAccounts(t *testing.T) {
 			assert.IsType(t, entity.Account{}, r)
 		}
 	})
+	t.Run("library accounts", func(t *testing.T) {
+		f := form.AccountSearch{
+			Library: true,
+			Count:   10,
+		}
+		r, err := AccountSearch(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, r := range r {
+			assert.True(t, r.AccLibrary)
+		}
+	})
 }
 
 func TestAccountByID(t *testing.T) {
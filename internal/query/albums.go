@@ -144,7 +144,7 @@ func AlbumSearch(f form.AlbumSearch) (results AlbumResults, err error) {
 
 	if f.Query != "" {
 		likeString := "%" + f.Query + "%"
-		s = s.Where("albums.album_title LIKE ? OR albums.album_location LIKE ?", likeString, likeString)
+		s = s.Where(fmt.Sprintf("albums.album_title %[1]s ? OR albums.album_location %[1]s ?", Like()), likeString, likeString)
 	}
 
 	if f.Type != "" {
@@ -197,6 +197,13 @@ func UpdateAlbumDates() error {
 			 GROUP BY photo_path) AS p ON albums.album_path = p.photo_path
 		SET albums.album_year = YEAR(taken_max), albums.album_month = MONTH(taken_max), albums.album_day = DAY(taken_max)
 		WHERE albums.album_type = 'folder' AND albums.album_path IS NOT NULL AND p.taken_max IS NOT NULL`).Error
+	case Postgres:
+		return UnscopedDb().Exec(`UPDATE albums
+		SET album_year = EXTRACT(YEAR FROM p.taken_max), album_month = EXTRACT(MONTH FROM p.taken_max), album_day = EXTRACT(DAY FROM p.taken_max)
+		FROM (SELECT photo_path, MAX(taken_at_local) AS taken_max
+			FROM photos WHERE taken_src = 'meta' AND photos.photo_quality >= 3 AND photos.deleted_at IS NULL
+			GROUP BY photo_path) AS p
+		WHERE albums.album_path = p.photo_path AND albums.album_type = 'folder' AND albums.album_path IS NOT NULL AND p.taken_max IS NOT NULL`).Error
 	default:
 		return nil
 	}
@@ -0,0 +1,61 @@
+package query
+
+import (
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+)
+
+// AuditLogs returns audit log entries matching the search form, newest first.
+func AuditLogs(f form.AuditSearch) (result entity.AuditLogs, err error) {
+	if err := f.ParseQueryString(); err != nil {
+		return result, err
+	}
+
+	s := Db().Model(&entity.AuditLog{})
+
+	if q := strings.TrimSpace(f.Query); len(q) >= 3 {
+		s = s.Where("action LIKE ? OR actor_name "+Like()+" ?", q+"%", "%"+q+"%")
+	}
+
+	if f.Actor != "" {
+		s = s.Where("actor_uid = ? OR actor_name = ?", f.Actor, f.Actor)
+	}
+
+	if f.Action != "" {
+		s = s.Where("action LIKE ?", f.Action+"%")
+	}
+
+	if f.Resource != "" {
+		s = s.Where("resource_uid = ?", f.Resource)
+	}
+
+	if f.Client != "" {
+		s = s.Where("client_ip = ?", f.Client)
+	}
+
+	if f.Session != "" {
+		s = s.Where("session_hash = ?", f.Session)
+	}
+
+	if !f.Before.IsZero() {
+		s = s.Where("created_at < ?", f.Before.Format("2006-01-02"))
+	}
+
+	if !f.After.IsZero() {
+		s = s.Where("created_at > ?", f.After.Format("2006-01-02"))
+	}
+
+	s = s.Order("id DESC")
+
+	if f.Count > 0 && f.Count <= MaxResults {
+		s = s.Limit(f.Count).Offset(f.Offset)
+	} else {
+		s = s.Limit(MaxResults).Offset(f.Offset)
+	}
+
+	err = s.Find(&result).Error
+
+	return result, err
+}
@@ -0,0 +1,50 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditLogs(t *testing.T) {
+	m := entity.NewAuditLog(entity.Admin, "abc", "127.0.0.1", "albums.delete", "at9lxuqxpogaaba7", nil, nil)
+
+	if err := m.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("action", func(t *testing.T) {
+		r, err := AuditLogs(form.AuditSearch{Action: "albums.", Count: 10})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.LessOrEqual(t, 1, len(r))
+
+		for _, l := range r {
+			assert.Equal(t, "albums.delete", l.Action)
+		}
+	})
+	t.Run("query", func(t *testing.T) {
+		r, err := AuditLogs(form.AuditSearch{Query: "resource:at9lxuqxpogaaba7 actor:admin"})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.LessOrEqual(t, 1, len(r))
+		assert.Equal(t, m.ID, r[0].ID)
+	})
+	t.Run("no result", func(t *testing.T) {
+		r, err := AuditLogs(form.AuditSearch{Client: "10.0.0.254", Count: 5000})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, r)
+	})
+}
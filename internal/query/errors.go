@@ -17,7 +17,7 @@ func Errors(limit, offset int, s string) (results entity.Errors, err error) {
 	} else if s == "warnings" {
 		stmt = stmt.Where("error_level = 'warning'")
 	} else if len(s) >= 3 {
-		stmt = stmt.Where("error_message LIKE ?", "%"+s+"%")
+		stmt = stmt.Where("error_message "+Like()+" ?", "%"+s+"%")
 	}
 
 	err = stmt.Order("error_time DESC").Limit(limit).Offset(offset).Find(&results).Error
@@ -47,6 +47,13 @@ func Files(limit, offset int, pathName string, includeMissing bool) (files entit
 	return files, err
 }
 
+// FilesByRoot returns all not-missing files in a given file root, e.g. of a remote library account.
+func FilesByRoot(rootName string) (files entity.Files, err error) {
+	err = Db().Where("file_missing = 0 AND file_root = ?", rootName).Order("id").Find(&files).Error
+
+	return files, err
+}
+
 // FilesByUID
 func FilesByUID(u []string, limit int, offset int) (files entity.Files, err error) {
 	if err := Db().Where("(photo_uid IN (?) AND file_primary = 1) OR file_uid IN (?)", u, u).Preload("Photo").Limit(limit).Offset(offset).Find(&files).Error; err != nil {
@@ -74,6 +81,15 @@ func VideoByPhotoUID(u string) (file entity.File, err error) {
 	return file, nil
 }
 
+// FileByID returns the file entity for a given ID.
+func FileByID(id uint) (file entity.File, err error) {
+	if err := Db().Where("id = ?", id).Preload("Photo").First(&file).Error; err != nil {
+		return file, err
+	}
+
+	return file, nil
+}
+
 // FileByUID returns the file entity for a given UID.
 func FileByUID(uid string) (file entity.File, err error) {
 	if err := Db().Where("file_uid = ?", uid).Preload("Photo").First(&file).Error; err != nil {
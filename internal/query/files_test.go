@@ -129,6 +129,24 @@ func TestVideoByPhotoUID(t *testing.T) {
 	})
 }
 
+func TestFileByID(t *testing.T) {
+	t.Run("files found", func(t *testing.T) {
+		file, err := FileByID(1000000)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "exampleFileName.jpg", file.FileName)
+	})
+
+	t.Run("no files found", func(t *testing.T) {
+		_, err := FileByID(111)
+
+		assert.Error(t, err)
+	})
+}
+
 func TestFileByUID(t *testing.T) {
 	t.Run("files found", func(t *testing.T) {
 		file, err := FileByUID("ft8es39w45bnlqdw")
@@ -170,6 +188,31 @@ func TestFileByHash(t *testing.T) {
 	})
 }
 
+func TestFilesByRoot(t *testing.T) {
+	t.Run("originals", func(t *testing.T) {
+		files, err := FilesByRoot(entity.RootOriginals)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.LessOrEqual(t, 1, len(files))
+
+		for _, f := range files {
+			assert.Equal(t, entity.RootOriginals, f.FileRoot)
+		}
+	})
+	t.Run("remote", func(t *testing.T) {
+		files, err := FilesByRoot(entity.RemoteRoot(1000000))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, files)
+	})
+}
+
 func TestSetPhotoPrimary(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		assert.Equal(t, false, entity.FileFixturesExampleXMP.FilePrimary)
@@ -35,40 +35,46 @@ func Geo(f form.GeoSearch) (results GeoResults, err error) {
 		photos.photo_title, photos.photo_description, photos.photo_favorite, photos.taken_at, files.file_hash, files.file_width, 
 		files.file_height`).
 		Joins(`JOIN files ON files.photo_id = photos.id AND 
-		files.file_missing = 0 AND files.file_primary AND files.deleted_at IS NULL`).
+		files.file_missing = 0 AND files.file_primary = 1 AND files.deleted_at IS NULL`).
 		Where("photos.deleted_at IS NULL").
 		Where("photos.photo_lat <> 0")
 
+	if s, err = geoFilter(s, f); err != nil {
+		return results, err
+	}
+
+	s = s.Order("taken_at, photos.photo_uid")
+
+	if result := s.Scan(&results); result.Error != nil {
+		return results, result.Error
+	}
+
+	log.Infof("geo: found %d photos for %s [%s]", len(results), f.SerializeAll(), time.Since(start))
+
+	return results, nil
+}
+
+// geoFilter adds the search form filters to a geo query.
+func geoFilter(s *gorm.DB, f form.GeoSearch) (*gorm.DB, error) {
 	f.Query = txt.Clip(f.Query, txt.ClipKeyword)
 
 	if f.Query != "" {
 		// Filter by label, label category and keywords.
-		var categories []entity.Category
 		var labels []entity.Label
 		var labelIds []uint
 
 		if len(f.Query) < 2 {
-			return results, fmt.Errorf("query too short")
+			return s, fmt.Errorf("query too short")
 		}
 
-		if err := Db().Where(AnySlug("custom_slug", f.Query, " ")).Find(&labels).Error; len(labels) == 0 || err != nil {
+		if err := Db().Where(AnySlug("custom_slug", f.Query, " ")).Or(AnySynonym(f.Query, " ")).Find(&labels).Error; len(labels) == 0 || err != nil {
 			log.Infof("search: label %s not found, using fuzzy search", txt.Quote(f.Query))
 
 			if likeAny := LikeAny("k.keyword", f.Query); likeAny != "" {
 				s = s.Where("photos.id IN (SELECT pk.photo_id FROM keywords k JOIN photos_keywords pk ON k.id = pk.keyword_id WHERE (?))", gorm.Expr(likeAny))
 			}
 		} else {
-			for _, l := range labels {
-				labelIds = append(labelIds, l.ID)
-
-				Db().Where("category_id = ?", l.ID).Find(&categories)
-
-				log.Infof("search: label %s includes %d categories", txt.Quote(l.LabelName), len(categories))
-
-				for _, category := range categories {
-					labelIds = append(labelIds, category.LabelID)
-				}
-			}
+			labelIds = expandLabels(labels)
 
 			if likeAny := LikeAny("k.keyword", f.Query); likeAny != "" {
 				s = s.Where("photos.id IN (SELECT pk.photo_id FROM keywords k JOIN photos_keywords pk ON k.id = pk.keyword_id WHERE (?)) OR "+
@@ -201,6 +207,12 @@ func Geo(f form.GeoSearch) (results GeoResults, err error) {
 		}
 	}
 
+	s, err := geoRegion(s, f.Bbox, f.Polygon, f.Near)
+
+	if err != nil {
+		return s, err
+	}
+
 	if !f.Before.IsZero() {
 		s = s.Where("photos.taken_at <= ?", f.Before.Format("2006-01-02"))
 	}
@@ -209,13 +221,5 @@ func Geo(f form.GeoSearch) (results GeoResults, err error) {
 		s = s.Where("photos.taken_at >= ?", f.After.Format("2006-01-02"))
 	}
 
-	s = s.Order("taken_at, photos.photo_uid")
-
-	if result := s.Scan(&results); result.Error != nil {
-		return results, result.Error
-	}
-
-	log.Infof("geo: found %d photos for %s [%s]", len(results), f.SerializeAll(), time.Since(start))
-
-	return results, nil
+	return s, nil
 }
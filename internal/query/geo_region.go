@@ -0,0 +1,286 @@
+package query
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/jinzhu/gorm"
+	geojson "github.com/paulmach/go.geojson"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/s2"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// MaxPolygonEdges is the max number of polygon edges that can be used to filter by location.
+var MaxPolygonEdges = 500
+
+// GeoBounds represents a bounding box in degrees.
+type GeoBounds struct {
+	South float64
+	West  float64
+	North float64
+	East  float64
+}
+
+// ParseGeoBounds parses a bounding box in the format "west,south,east,north" as used by GeoJSON.
+func ParseGeoBounds(s string) (b GeoBounds, err error) {
+	values := strings.Split(s, ",")
+
+	if len(values) != 4 {
+		return b, fmt.Errorf("invalid bounding box %s", txt.Quote(s))
+	}
+
+	var v [4]float64
+
+	for i := range values {
+		if v[i], err = strconv.ParseFloat(strings.TrimSpace(values[i]), 64); err != nil {
+			return b, fmt.Errorf("invalid bounding box %s", txt.Quote(s))
+		}
+	}
+
+	b = GeoBounds{West: v[0], South: v[1], East: v[2], North: v[3]}
+
+	if b.South > b.North || b.South < -90 || b.North > 90 || b.West < -180 || b.West > 180 || b.East < -180 || b.East > 180 {
+		return b, fmt.Errorf("invalid bounding box %s", txt.Quote(s))
+	}
+
+	return b, nil
+}
+
+// PlaceBounds returns the bounding box of places or locations matching the name,
+// based on the stored place bounds and the cells of matching locations.
+func PlaceBounds(name string) (b GeoBounds, found bool, err error) {
+	name = strings.TrimSpace(name)
+
+	if name == "" {
+		return b, false, nil
+	}
+
+	var places []entity.Place
+	var cells []entity.Cell
+
+	like := Like()
+
+	if err = UnscopedDb().
+		Where(fmt.Sprintf("place_label %[1]s ? OR place_city %[1]s ? OR place_state %[1]s ?", like), name+",%", name, name).
+		Where("id <> ?", entity.UnknownPlace.ID).
+		Find(&places).Error; err != nil {
+		return b, false, err
+	}
+
+	if err = UnscopedDb().Select("id").
+		Where(fmt.Sprintf("cell_name %s ?", like), name).
+		Find(&cells).Error; err != nil {
+		return b, false, err
+	}
+
+	var bounds s2.Bounds
+
+	for _, p := range places {
+		bounds = bounds.Union(p.Bounds())
+	}
+
+	for _, c := range cells {
+		if cell, ok := s2.CellBounds(c.ID); ok {
+			bounds = bounds.Union(cell)
+		}
+	}
+
+	if bounds.Empty() {
+		return b, false, nil
+	}
+
+	return GeoBounds{South: bounds.South, West: bounds.West, North: bounds.North, East: bounds.East}, true, nil
+}
+
+// geoPolygons returns the polygons of a GeoJSON geometry or feature.
+func geoPolygons(data string) ([][][][]float64, error) {
+	var object struct {
+		Type string `json:"type"`
+	}
+
+	if err := json.Unmarshal([]byte(data), &object); err != nil {
+		return nil, fmt.Errorf("invalid polygon")
+	}
+
+	var g *geojson.Geometry
+
+	if object.Type == "Feature" {
+		if f, err := geojson.UnmarshalFeature([]byte(data)); err != nil {
+			return nil, fmt.Errorf("invalid polygon")
+		} else {
+			g = f.Geometry
+		}
+	} else if geometry, err := geojson.UnmarshalGeometry([]byte(data)); err != nil {
+		return nil, fmt.Errorf("invalid polygon")
+	} else {
+		g = geometry
+	}
+
+	switch {
+	case g == nil:
+		return nil, fmt.Errorf("invalid polygon")
+	case g.IsPolygon():
+		return [][][][]float64{g.Polygon}, nil
+	case g.IsMultiPolygon():
+		return g.MultiPolygon, nil
+	default:
+		return nil, fmt.Errorf("unsupported geometry type %s", txt.Quote(string(g.Type)))
+	}
+}
+
+// geoRegion adds bounding box, polygon, and place name filters that are evaluated using S2 cell coverings,
+// polygons are additionally matched exactly.
+func geoRegion(s *gorm.DB, bbox, polygon, near string) (*gorm.DB, error) {
+	if near != "" {
+		if b, found, err := PlaceBounds(near); err != nil {
+			return s, err
+		} else if !found {
+			log.Infof("search: place %s not found", txt.Quote(near))
+			return s.Where("1 = 0"), nil
+		} else {
+			s = geoBounds(s, b)
+		}
+	}
+
+	if bbox != "" {
+		if b, err := ParseGeoBounds(bbox); err != nil {
+			return s, err
+		} else {
+			s = geoBounds(s, b)
+		}
+	}
+
+	if polygon != "" {
+		polygons, err := geoPolygons(polygon)
+
+		if err != nil {
+			return s, err
+		}
+
+		var loops [][][]float64
+
+		// The outer rings are used to preselect photos by cell, holes are excluded by the exact check.
+		for _, p := range polygons {
+			if len(p) > 0 {
+				loops = append(loops, p[0])
+			}
+		}
+
+		ranges := s2.CoverPolygon(loops)
+
+		if len(ranges) == 0 {
+			return s, fmt.Errorf("invalid polygon")
+		}
+
+		s = geoCover(s, ranges, polygonBounds(loops))
+
+		if s, err = geoContains(s, polygons); err != nil {
+			return s, err
+		}
+	}
+
+	return s, nil
+}
+
+// geoContains adds an exact point-in-polygon filter based on the even-odd rule, using [lng, lat] coordinates as in GeoJSON.
+// A ray is cast from each photo location towards the east, so that it is inside a polygon if the number of edges crossed is odd.
+func geoContains(s *gorm.DB, polygons [][][][]float64) (*gorm.DB, error) {
+	var wheres []string
+	var values []interface{}
+
+	edges := 0
+
+	for _, polygon := range polygons {
+		var terms []string
+
+		for _, ring := range polygon {
+			for i := range ring {
+				a, b := ring[i], ring[(i+1)%len(ring)]
+
+				if len(a) < 2 || len(b) < 2 || a[1] < -90 || a[1] > 90 || a[0] < -180 || a[0] > 180 {
+					return s, fmt.Errorf("invalid polygon")
+				} else if a[1] == b[1] {
+					// Horizontal edges are never crossed.
+					continue
+				}
+
+				// Edge longitude at the photo latitude: lng = slope * lat + offset.
+				slope := (b[0] - a[0]) / (b[1] - a[1])
+				offset := a[0] - slope*a[1]
+
+				terms = append(terms, "CASE WHEN photos.photo_lat >= ? AND photos.photo_lat < ? AND photos.photo_lng < ? * photos.photo_lat + ? THEN 1 ELSE 0 END")
+				values = append(values, math.Min(a[1], b[1]), math.Max(a[1], b[1]), slope, offset)
+			}
+		}
+
+		edges += len(terms)
+
+		if len(terms) > 0 {
+			wheres = append(wheres, fmt.Sprintf("(%s) %% 2 = 1", strings.Join(terms, " + ")))
+		}
+	}
+
+	if len(wheres) == 0 {
+		return s, fmt.Errorf("invalid polygon")
+	} else if edges > MaxPolygonEdges {
+		return s, fmt.Errorf("polygon must not have more than %d edges", MaxPolygonEdges)
+	}
+
+	return s.Where(strings.Join(wheres, " OR "), values...), nil
+}
+
+// polygonBounds returns the bounding box of the polygon loops, using [lng, lat] coordinates as in GeoJSON.
+func polygonBounds(loops [][][]float64) (b GeoBounds) {
+	b = GeoBounds{South: 90, West: 180, North: -90, East: -180}
+
+	for _, ring := range loops {
+		for _, p := range ring {
+			b.South = math.Min(b.South, p[1])
+			b.North = math.Max(b.North, p[1])
+			b.West = math.Min(b.West, p[0])
+			b.East = math.Max(b.East, p[0])
+		}
+	}
+
+	return b
+}
+
+// geoBounds adds a bounding box filter.
+func geoBounds(s *gorm.DB, b GeoBounds) *gorm.DB {
+	return geoCover(s, s2.CoverRect(b.South, b.West, b.North, b.East), b)
+}
+
+// geoCover adds a filter that matches photos within the S2 cell token ranges and the bounding box.
+// Photos without a known location cell, e.g. because places are disabled, are matched by the bounding box only.
+func geoCover(s *gorm.DB, ranges []s2.TokenRange, b GeoBounds) *gorm.DB {
+	if len(ranges) == 0 {
+		return s.Where("1 = 0")
+	}
+
+	wheres := make([]string, 0, len(ranges)+1)
+	values := make([]interface{}, 0, len(ranges)*2+1)
+
+	for _, r := range ranges {
+		wheres = append(wheres, "photos.cell_id BETWEEN ? AND ?")
+		values = append(values, s2.Prefix(r.Min), s2.Prefix(r.Max))
+	}
+
+	wheres = append(wheres, "photos.cell_id = ?")
+	values = append(values, entity.UnknownLocation.ID)
+
+	s = s.Where(strings.Join(wheres, " OR "), values...)
+	s = s.Where("photos.photo_lat BETWEEN ? AND ?", b.South, b.North)
+
+	if b.West <= b.East {
+		s = s.Where("photos.photo_lng BETWEEN ? AND ?", b.West, b.East)
+	} else {
+		// Crosses the antimeridian.
+		s = s.Where("photos.photo_lng >= ? OR photos.photo_lng <= ?", b.West, b.East)
+	}
+
+	return s
+}
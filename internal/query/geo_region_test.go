@@ -0,0 +1,212 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/pkg/s2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseGeoBounds(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		b, err := ParseGeoBounds("9.0, 48.0, 10.0, 49.0")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, GeoBounds{South: 48, West: 9, North: 49, East: 10}, b)
+	})
+	t.Run("antimeridian", func(t *testing.T) {
+		b, err := ParseGeoBounds("170,-20,-170,-10")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, GeoBounds{South: -20, West: 170, North: -10, East: -170}, b)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		_, err := ParseGeoBounds("9,48,10")
+		assert.Error(t, err)
+		_, err = ParseGeoBounds("9,foo,10,49")
+		assert.Error(t, err)
+		_, err = ParseGeoBounds("9,49,10,48")
+		assert.Error(t, err)
+		_, err = ParseGeoBounds("9,48,190,49")
+		assert.Error(t, err)
+	})
+}
+
+func TestPlaceBounds(t *testing.T) {
+	t.Run("cell name", func(t *testing.T) {
+		b, found, err := PlaceBounds("Adosada Platform")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, found)
+		assert.LessOrEqual(t, b.South, 19.681944)
+		assert.GreaterOrEqual(t, b.North, 19.681944)
+		assert.LessOrEqual(t, b.West, -98.846588)
+		assert.GreaterOrEqual(t, b.East, -98.846588)
+	})
+	t.Run("place", func(t *testing.T) {
+		b, found, err := PlaceBounds("Teotihuacán")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, found)
+		assert.InDelta(t, 19.67, b.South, 0.000001)
+		assert.InDelta(t, -98.86, b.West, 0.000001)
+		assert.InDelta(t, 19.70, b.North, 0.000001)
+		assert.InDelta(t, -98.83, b.East, 0.000001)
+	})
+	t.Run("not found", func(t *testing.T) {
+		_, found, err := PlaceBounds("Yosemite National Park")
+
+		assert.NoError(t, err)
+		assert.False(t, found)
+	})
+}
+
+func TestGeoRegion(t *testing.T) {
+	t.Run("bbox", func(t *testing.T) {
+		result, err := Geo(form.GeoSearch{Bbox: "9,48,10,49"})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.LessOrEqual(t, 1, len(result))
+
+		for _, r := range result {
+			assert.InDelta(t, 48.519234, r.PhotoLat, 0.0001)
+			assert.InDelta(t, 9.057997, r.PhotoLng, 0.0001)
+		}
+	})
+	t.Run("bbox no results", func(t *testing.T) {
+		result, err := Geo(form.GeoSearch{Bbox: "-10,-10,-9,-9"})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, result, 0)
+	})
+	t.Run("invalid bbox", func(t *testing.T) {
+		_, err := Geo(form.GeoSearch{Bbox: "foo"})
+		assert.Error(t, err)
+	})
+	t.Run("polygon feature", func(t *testing.T) {
+		result, err := Geo(form.GeoSearch{Polygon: `{"type":"Feature","geometry":{"type":"Polygon","coordinates":[[[9,48],[10,48],[9,49],[9,48]]]}}`})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.LessOrEqual(t, 1, len(result))
+	})
+	t.Run("polygon outside", func(t *testing.T) {
+		result, err := Geo(form.GeoSearch{Polygon: `{"type":"MultiPolygon","coordinates":[[[[9,48.6],[10,48.6],[9.5,49],[9,48.6]]]]}`})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, result, 0)
+	})
+	t.Run("polygon within bounds", func(t *testing.T) {
+		// The photo at 48.519234, 9.057997 is within the bounding box, but not the triangle.
+		result, err := Geo(form.GeoSearch{Polygon: `{"type":"Polygon","coordinates":[[[9,48],[10,48],[10,49],[9,48]]]}`})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, result, 0)
+	})
+	t.Run("polygon hole", func(t *testing.T) {
+		result, err := Geo(form.GeoSearch{Polygon: `{"type":"Polygon","coordinates":[[[9,48],[10,48],[10,49],[9,49],[9,48]],[[9.05,48.5],[9.05,48.55],[9.1,48.55],[9.1,48.5],[9.05,48.5]]]}`})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, result, 0)
+
+		result, err = Geo(form.GeoSearch{Polygon: `{"type":"Polygon","coordinates":[[[9,48],[10,48],[10,49],[9,49],[9,48]]]}`})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.LessOrEqual(t, 1, len(result))
+	})
+	t.Run("unsupported geometry", func(t *testing.T) {
+		_, err := Geo(form.GeoSearch{Polygon: `{"type":"Point","coordinates":[9,48]}`})
+		assert.Error(t, err)
+		_, err = Geo(form.GeoSearch{Polygon: `foo`})
+		assert.Error(t, err)
+	})
+	t.Run("near", func(t *testing.T) {
+		result, err := Geo(form.NewGeoSearch(`near:"Adosada Platform"`))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Only photos within the cell bounds are found.
+		for _, r := range result {
+			assert.InDelta(t, 19.681944, r.PhotoLat, 0.0001)
+			assert.InDelta(t, -98.846588, r.PhotoLng, 0.0001)
+		}
+	})
+	t.Run("near place", func(t *testing.T) {
+		place := entity.Place{ID: s2.TokenPrefix + "1ef75a71a36"}
+
+		if err := place.ExtendBounds(s2.PrefixedToken(48.519234, 9.057997)); err != nil {
+			t.Fatal(err)
+		}
+
+		result, err := Geo(form.NewGeoSearch(`near:"Mandeni"`))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.LessOrEqual(t, 1, len(result))
+
+		for _, r := range result {
+			assert.InDelta(t, 48.519234, r.PhotoLat, 0.0001)
+			assert.InDelta(t, 9.057997, r.PhotoLng, 0.0001)
+		}
+	})
+	t.Run("near not found", func(t *testing.T) {
+		result, err := Geo(form.NewGeoSearch(`near:"Yosemite National Park"`))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, result, 0)
+	})
+	t.Run("photo search", func(t *testing.T) {
+		result, _, err := PhotoSearch(form.PhotoSearch{Bbox: "9,48,10,49", Count: 100})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.LessOrEqual(t, 1, len(result))
+
+		for _, r := range result {
+			assert.InDelta(t, 48.519234, r.PhotoLat, 0.0001)
+		}
+	})
+}
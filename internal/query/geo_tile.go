@@ -0,0 +1,130 @@
+package query
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/pkg/capture"
+	"github.com/photoprism/photoprism/pkg/mvt"
+	"github.com/photoprism/photoprism/pkg/s2"
+)
+
+// GeoCluster represents geotagged photos in the same S2 cell for displaying them on a map,
+// Cell is the cell token prefix shared by the photos.
+type GeoCluster struct {
+	Cell     string  `json:"Cell"`
+	Lat      float64 `json:"Lat"`
+	Lng      float64 `json:"Lng"`
+	Count    int     `json:"Count"`
+	PhotoUID string  `json:"UID"`
+	FileHash string  `json:"Hash"`
+}
+
+type GeoClusters []GeoCluster
+
+// geoTileGrid is the number of clusters per tile side for photos without S2 cell.
+const geoTileGrid = 8
+
+// geoTileOrder sorts photos in a cluster so that the first one is the representative photo.
+const geoTileOrder = "photos.photo_favorite DESC, photos.taken_at DESC, photos.photo_uid"
+
+// GeoTileLevel returns the S2 cell level used to cluster photos at the given map zoom level,
+// resulting in up to 8x8 clusters per tile.
+func GeoTileLevel(zoom int) int {
+	level := zoom + 1
+
+	if level < 1 {
+		return 1
+	} else if level > s2.DefaultLevel {
+		return s2.DefaultLevel
+	}
+
+	return level
+}
+
+// GeoTile finds photos within a map tile based on Form values and clusters them by S2 cell in the database.
+// The representative photo of a cluster is the most recent favorite, or the most recent photo.
+func GeoTile(f form.GeoSearch, tile mvt.Tile) (results GeoClusters, err error) {
+	start := time.Now()
+
+	if !tile.Valid() {
+		return results, fmt.Errorf("invalid tile %s", tile.String())
+	}
+
+	if err := f.ParseQueryString(); err != nil {
+		return results, err
+	}
+
+	defer log.Debug(capture.Time(time.Now(), fmt.Sprintf("geo: tile %s search %s", tile.String(), form.Serialize(f, true))))
+
+	south, west, north, east := tile.Bounds()
+	level := GeoTileLevel(tile.Z)
+
+	query := func() (*gorm.DB, error) {
+		s := UnscopedDb().Table("photos").
+			Joins(`JOIN files ON files.photo_id = photos.id AND
+			files.file_missing = 0 AND files.file_primary = 1 AND files.deleted_at IS NULL`).
+			Where("photos.photo_lat <> 0").
+			Where("photos.photo_lat BETWEEN ? AND ?", south, north).
+			Where("photos.photo_lng BETWEEN ? AND ?", west, east)
+
+		return geoFilter(s, f)
+	}
+
+	// Photos are clustered by the prefix of their S2 cell token.
+	s, err := query()
+
+	if err != nil {
+		return results, err
+	}
+
+	prefix := fmt.Sprintf("SUBSTR(photos.cell_id, 1, %d)", len(s2.TokenPrefix)+GeoTilePrefix(level))
+
+	if results, err = geoTileClusters(s.Where("photos.cell_id LIKE ?", s2.TokenPrefix+"%"), prefix); err != nil {
+		return results, err
+	}
+
+	// Photos without S2 cell, e.g. because reverse geocoding is disabled, are clustered in a grid.
+	if s, err = query(); err != nil {
+		return results, err
+	}
+
+	grid := fmt.Sprintf("ROUND((photos.photo_lat - %f) / %f) * 1000 + ROUND((photos.photo_lng - %f) / %f)",
+		south, (north-south)/geoTileGrid, west, (east-west)/geoTileGrid)
+
+	unknown, err := geoTileClusters(s.Where("photos.cell_id NOT LIKE ?", s2.TokenPrefix+"%"), grid)
+
+	if err != nil {
+		return results, err
+	}
+
+	for _, c := range unknown {
+		c.Cell = s2.Prefix(s2.TokenLevel(c.Lat, c.Lng, level))
+		results = append(results, c)
+	}
+
+	log.Debugf("geo: found %d clusters in tile %s [%s]", len(results), tile.String(), time.Since(start))
+
+	return results, nil
+}
+
+// GeoTilePrefix returns the number of S2 cell token digits that photos in a cluster share at the given level.
+func GeoTilePrefix(level int) int {
+	// A token has 4 bits per digit, the cell id starts with 3 bits for the face and 2 bits per level.
+	return (2*level + 5) / 4
+}
+
+// geoTileClusters groups the photos found by key in the database, the cluster position is the center of
+// their locations and the representative photo is the most recent favorite, or the most recent photo.
+func geoTileClusters(s *gorm.DB, key string) (results GeoClusters, err error) {
+	photos := s.Select(fmt.Sprintf(`%s AS cell, photos.photo_lat, photos.photo_lng, photos.photo_uid, files.file_hash,
+		ROW_NUMBER() OVER (PARTITION BY %s ORDER BY %s) AS n`, key, key, geoTileOrder)).QueryExpr()
+
+	err = UnscopedDb().Raw(`SELECT cell, COUNT(*) AS count, AVG(photo_lat) AS lat, AVG(photo_lng) AS lng,
+		MAX(CASE WHEN n = 1 THEN photo_uid END) AS photo_uid, MAX(CASE WHEN n = 1 THEN file_hash END) AS file_hash
+		FROM (?) AS c GROUP BY cell ORDER BY cell`, photos).Scan(&results).Error
+
+	return results, err
+}
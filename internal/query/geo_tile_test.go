@@ -0,0 +1,98 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/pkg/mvt"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGeoTileLevel(t *testing.T) {
+	assert.Equal(t, 1, GeoTileLevel(0))
+	assert.Equal(t, 11, GeoTileLevel(10))
+	assert.Equal(t, 21, GeoTileLevel(22))
+}
+
+func TestGeoTilePrefix(t *testing.T) {
+	assert.Equal(t, 1, GeoTilePrefix(1))
+	assert.Equal(t, 6, GeoTilePrefix(11))
+	assert.Equal(t, 11, GeoTilePrefix(21))
+}
+
+func TestGeoTile(t *testing.T) {
+	t.Run("world", func(t *testing.T) {
+		all, err := Geo(form.NewGeoSearch(""))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		result, err := GeoTile(form.NewGeoSearch(""), mvt.Tile{})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.LessOrEqual(t, 1, len(result))
+
+		count := 0
+
+		for _, c := range result {
+			assert.NotEmpty(t, c.Cell)
+			assert.NotEmpty(t, c.PhotoUID)
+			assert.NotEmpty(t, c.FileHash)
+			count += c.Count
+		}
+
+		// Photos without S2 cell are included as well.
+		assert.Equal(t, len(all), count)
+	})
+	t.Run("representative", func(t *testing.T) {
+		result, err := GeoTile(form.NewGeoSearch(""), mvt.Tile{})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, c := range result {
+			var uids []string
+
+			// The representative photo is the most recent favorite, or the most recent photo.
+			if err := UnscopedDb().Table("photos").
+				Joins("JOIN files ON files.photo_id = photos.id AND files.file_primary = 1 AND files.file_missing = 0 AND files.deleted_at IS NULL").
+				Where("photos.photo_lat <> 0 AND photos.cell_id LIKE ?", c.Cell+"%").
+				Order(geoTileOrder).Pluck("photos.photo_uid", &uids).Error; err != nil {
+				t.Fatal(err)
+			}
+
+			if len(uids) == c.Count {
+				assert.Equal(t, uids[0], c.PhotoUID)
+			}
+		}
+	})
+	t.Run("empty tile", func(t *testing.T) {
+		// Southern Pacific Ocean.
+		result, err := GeoTile(form.NewGeoSearch(""), mvt.Tile{Z: 10, X: 0, Y: 1000})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, result, 0)
+	})
+	t.Run("invalid tile", func(t *testing.T) {
+		_, err := GeoTile(form.NewGeoSearch(""), mvt.Tile{Z: 1, X: 5, Y: 0})
+
+		assert.Error(t, err)
+	})
+	t.Run("search for bridge", func(t *testing.T) {
+		result, err := GeoTile(form.NewGeoSearch("Query:bridge"), mvt.Tile{})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.LessOrEqual(t, 1, len(result))
+	})
+}
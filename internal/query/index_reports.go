@@ -0,0 +1,35 @@
+package query
+
+import (
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+// IndexReports returns index and import reports, starting with the most recent run.
+func IndexReports(reportType string, limit, offset int) (results entity.IndexReports, err error) {
+	s := Db().Order("id DESC")
+
+	if reportType != "" {
+		s = s.Where("report_type = ?", reportType)
+	}
+
+	if limit > 0 {
+		s = s.Limit(limit).Offset(offset)
+	}
+
+	err = s.Find(&results).Error
+
+	return results, err
+}
+
+// IndexFailures returns the files that failed in an index or import run.
+func IndexFailures(reportID uint, limit, offset int) (results entity.IndexFailures, err error) {
+	s := Db().Where("report_id = ?", reportID).Order("id")
+
+	if limit > 0 {
+		s = s.Limit(limit).Offset(offset)
+	}
+
+	err = s.Find(&results).Error
+
+	return results, err
+}
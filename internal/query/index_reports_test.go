@@ -0,0 +1,54 @@
+package query
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIndexReports(t *testing.T) {
+	m := entity.NewIndexReport(entity.ReportImport, entity.RootImport, "")
+
+	if err := m.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{"a.jpg", "b.jpg"} {
+		if err := entity.NewIndexFailure(m.ID, entity.RootImport, name, "import", errors.New("failed")).Create(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Run("type", func(t *testing.T) {
+		r, err := IndexReports(entity.ReportImport, 1, 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, r, 1)
+		assert.Equal(t, m.ID, r[0].ID)
+	})
+	t.Run("failures", func(t *testing.T) {
+		r, err := IndexFailures(m.ID, 0, 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, r, 2)
+		assert.Equal(t, "a.jpg", r[0].FileName)
+	})
+	t.Run("failures limit", func(t *testing.T) {
+		r, err := IndexFailures(m.ID, 1, 1)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, r, 1)
+		assert.Equal(t, "b.jpg", r[0].FileName)
+	})
+}
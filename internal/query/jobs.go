@@ -0,0 +1,25 @@
+package query
+
+import (
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+// JobRuns returns the run history of a background job, starting with the most recent run.
+func JobRuns(name string, limit, offset int) (results entity.JobRuns, err error) {
+	s := Db().Where("job_name = ?", name).Order("id DESC")
+
+	if limit > 0 {
+		s = s.Limit(limit).Offset(offset)
+	}
+
+	err = s.Find(&results).Error
+
+	return results, err
+}
+
+// LastJobRun returns the most recent run of a background job.
+func LastJobRun(name string) (result entity.JobRun, err error) {
+	err = Db().Where("job_name = ?", name).Order("id DESC").First(&result).Error
+
+	return result, err
+}
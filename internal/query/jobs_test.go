@@ -0,0 +1,57 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJobRuns(t *testing.T) {
+	for _, trigger := range []string{entity.JobTriggerSchedule, entity.JobTriggerManual} {
+		m := entity.NewJobRun("query-test", trigger)
+
+		if err := m.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := m.Finish(entity.JobCounts{"files": 1}, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Run("all", func(t *testing.T) {
+		r, err := JobRuns("query-test", 0, 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, r, 2)
+		assert.Equal(t, entity.JobTriggerManual, r[0].RunTrigger)
+	})
+	t.Run("limit", func(t *testing.T) {
+		r, err := JobRuns("query-test", 1, 1)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, r, 1)
+		assert.Equal(t, entity.JobTriggerSchedule, r[0].RunTrigger)
+	})
+	t.Run("last", func(t *testing.T) {
+		r, err := LastJobRun("query-test")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, entity.JobTriggerManual, r.RunTrigger)
+		assert.Equal(t, 1, r.RunCounts["files"])
+	})
+	t.Run("not found", func(t *testing.T) {
+		_, err := LastJobRun("query-not-found")
+		assert.Error(t, err)
+	})
+}
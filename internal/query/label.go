@@ -13,6 +13,35 @@ func PhotoLabel(photoID, labelID uint) (label entity.PhotoLabel, err error) {
 	return label, nil
 }
 
+// ClassifiedLabels returns labels that were assigned to photos by image classification.
+func ClassifiedLabels() (results entity.Labels, err error) {
+	err = Db().
+		Where("id IN (SELECT label_id FROM photos_labels WHERE label_src = ?)", entity.SrcImage).
+		Order("id").
+		Find(&results).Error
+
+	return results, err
+}
+
+// ClassifiedPhotoLabels returns the photo labels of a label that were assigned by image classification.
+func ClassifiedPhotoLabels(labelID uint) (results entity.PhotoLabels, err error) {
+	err = Db().
+		Where("label_id = ? AND label_src = ?", labelID, entity.SrcImage).
+		Find(&results).Error
+
+	return results, err
+}
+
+// KeywordPhotoLabels returns the labels of a photo that were assigned based on its keywords.
+func KeywordPhotoLabels(photoID uint) (results entity.PhotoLabels, err error) {
+	err = Db().
+		Where("photo_id = ? AND label_src = ?", photoID, entity.SrcKeyword).
+		Preload("Label").
+		Find(&results).Error
+
+	return results, err
+}
+
 // LabelBySlug returns a Label based on the slug name.
 func LabelBySlug(labelSlug string) (label entity.Label, err error) {
 	if err := Db().Where("label_slug = ? OR custom_slug = ?", labelSlug, labelSlug).First(&label).Error; err != nil {
@@ -0,0 +1,34 @@
+package query
+
+import (
+	"fmt"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// AnySynonym returns a where condition that matches labels with any of the synonyms in search.
+func AnySynonym(search, sep string) string {
+	where := AnySlug("synonym_slug", search, sep)
+
+	if where == "" {
+		return "1 = 0"
+	}
+
+	return fmt.Sprintf("id IN (SELECT label_id FROM label_synonyms WHERE %s)", where)
+}
+
+// expandLabels returns the label ids including all child labels, transitively.
+func expandLabels(labels []entity.Label) []uint {
+	labelIds := make([]uint, 0, len(labels))
+
+	for _, l := range labels {
+		labelIds = append(labelIds, l.ID)
+	}
+
+	result := entity.LabelDescendantIDs(labelIds...)
+
+	log.Infof("search: %s includes %d labels", txt.Quote(labels[0].LabelName), len(result))
+
+	return result
+}
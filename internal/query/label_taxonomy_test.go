@@ -0,0 +1,78 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnySynonym(t *testing.T) {
+	assert.Equal(t, "id IN (SELECT label_id FROM label_synonyms WHERE synonym_slug = 'katze')", AnySynonym("katze", " "))
+	assert.Equal(t, "1 = 0", AnySynonym("", " "))
+}
+
+func TestLabels_Taxonomy(t *testing.T) {
+	animal := entity.FirstOrCreateLabel(entity.NewLabel("Query Animal", 0))
+	cat := entity.FirstOrCreateLabel(entity.NewLabel("Query Cat", 0))
+	kitten := entity.FirstOrCreateLabel(entity.NewLabel("Query Kitten", 0))
+
+	if err := cat.AddParent(animal); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := kitten.AddParent(cat); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := entity.NewLabelSynonym(cat.ID, "Query Katze", "de").Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("transitive", func(t *testing.T) {
+		result, err := Labels(form.LabelSearch{Query: "query animal"})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, result, 3)
+	})
+	t.Run("synonym", func(t *testing.T) {
+		result, err := Labels(form.LabelSearch{Query: "query katze"})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, result, 2)
+	})
+}
+
+func TestPhotoSearch_LabelSynonym(t *testing.T) {
+	landscape := entity.LabelFixtures.Pointer("landscape")
+
+	if err := entity.NewLabelSynonym(landscape.ID, "Landschaft", "de").Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	var frm form.PhotoSearch
+
+	frm.Query = "label:landscape"
+	expected, _, err := PhotoSearch(frm)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	frm.Query = "label:landschaft"
+	photos, _, err := PhotoSearch(frm)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(t, photos, len(expected))
+	assert.LessOrEqual(t, 2, len(photos))
+}
@@ -97,3 +97,21 @@ func TestPhotoLabel(t *testing.T) {
 		t.Log(r)
 	})
 }
+
+func TestClassifiedLabels(t *testing.T) {
+	labels, err := ClassifiedLabels()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, label := range labels {
+		photoLabels, err := ClassifiedPhotoLabels(label.ID)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NotEmpty(t, photoLabels)
+	}
+}
@@ -56,27 +56,19 @@ func Labels(f form.LabelSearch) (results []LabelResult, err error) {
 	}
 
 	if f.Query != "" {
-		var labelIds []uint
-		var categories []entity.Category
 		var label entity.Label
 
 		slugString := slug.Make(f.Query)
 		likeString := "%" + f.Query + "%"
 
-		if result := Db().First(&label, "label_slug = ? OR custom_slug = ?", slugString, slugString); result.Error != nil {
+		if result := Db().First(&label, "label_slug = ? OR custom_slug = ? OR id IN (SELECT label_id FROM label_synonyms WHERE synonym_slug = ?)", slugString, slugString, slugString); result.Error != nil {
 			log.Infof("search: label %s not found", txt.Quote(f.Query))
 
-			s = s.Where("labels.label_name LIKE ?", likeString)
+			s = s.Where("labels.label_name "+Like()+" ?", likeString)
 		} else {
-			labelIds = append(labelIds, label.ID)
+			labelIds := entity.LabelDescendantIDs(label.ID)
 
-			Db().Where("category_id = ?", label.ID).Find(&categories)
-
-			for _, category := range categories {
-				labelIds = append(labelIds, category.LabelID)
-			}
-
-			log.Infof("search: label %s includes %d categories", txt.Quote(label.LabelName), len(labelIds))
+			log.Infof("search: label %s includes %d labels", txt.Quote(label.LabelName), len(labelIds))
 
 			s = s.Where("labels.id IN (?)", labelIds)
 		}
@@ -130,7 +130,7 @@ func MomentsTime(threshold int) (results Moments, err error) {
 		Where("photos.photo_quality >= 3 AND deleted_at IS NULL AND photo_private = 0 AND photos.photo_year > 0 AND photos.photo_month > 0").
 		Group("photos.photo_year, photos.photo_month").
 		Order("photos.photo_year DESC, photos.photo_month DESC").
-		Having("photo_count >= ?", threshold)
+		Having("COUNT(*) >= ?", threshold)
 
 	if err := db.Scan(&results).Error; err != nil {
 		return results, err
@@ -145,7 +145,7 @@ func MomentsCountries(threshold int) (results Moments, err error) {
 		Select("photo_country AS country, photo_year AS year, COUNT(*) AS photo_count ").
 		Where("photos.photo_quality >= 3 AND deleted_at IS NULL AND photo_private = 0 AND photo_country <> 'zz' AND photo_year > 0").
 		Group("photo_country, photo_year").
-		Having("photo_count >= ?", threshold)
+		Having("COUNT(*) >= ?", threshold)
 
 	if err := db.Scan(&results).Error; err != nil {
 		return results, err
@@ -161,7 +161,7 @@ func MomentsStates(threshold int) (results Moments, err error) {
 		Joins("JOIN places p ON p.id = photos.place_id").
 		Where("photos.photo_quality >= 3 AND photos.deleted_at IS NULL AND photo_private = 0 AND p.place_state <> '' AND p.place_country <> 'zz'").
 		Group("p.place_country, p.place_state").
-		Having("photo_count >= ?", threshold)
+		Having("COUNT(*) >= ?", threshold)
 
 	if err := db.Scan(&results).Error; err != nil {
 		return results, err
@@ -184,7 +184,7 @@ func MomentsLabels(threshold int) (results Moments, err error) {
 		Joins("JOIN labels l ON l.id = pl.label_id").
 		Where("photos.photo_quality >= 3 AND photos.deleted_at IS NULL AND photo_private = 0 AND l.label_slug IN (?)", cats).
 		Group("l.label_slug").
-		Having("photo_count >= ?", threshold)
+		Having("COUNT(*) >= ?", threshold)
 
 	if err := db.Scan(&results).Error; err != nil {
 		return results, err
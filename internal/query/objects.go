@@ -0,0 +1,75 @@
+package query
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gosimple/slug"
+	"github.com/jinzhu/inflection"
+	"github.com/photoprism/photoprism/internal/detect"
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+// DetectObjects enables search by number of objects, e.g. "two dogs", if object detection is enabled.
+var DetectObjects = false
+
+// objectNumbers maps number words to numbers for queries like "two dogs".
+var objectNumbers = map[string]int{
+	"one":   1,
+	"two":   2,
+	"three": 3,
+	"four":  4,
+	"five":  5,
+	"six":   6,
+	"seven": 7,
+	"eight": 8,
+	"nine":  9,
+	"ten":   10,
+}
+
+// ObjectCount parses queries like "two dogs" or "3 cats" and returns the number and object name.
+func ObjectCount(s string) (count int, name string) {
+	words := strings.Fields(strings.ToLower(s))
+
+	if len(words) < 2 {
+		return 0, ""
+	}
+
+	if n, ok := objectNumbers[words[0]]; ok {
+		count = n
+	} else if n, err := strconv.Atoi(words[0]); err == nil && n > 0 {
+		count = n
+	} else {
+		return 0, ""
+	}
+
+	return count, strings.Join(words[1:], " ")
+}
+
+// objectLabels returns the number of objects and their label ids for queries like "two dogs".
+// Other queries, e.g. "2021 christmas", return 0 so that the regular label and keyword search is used.
+func objectLabels(s string) (count int, labelIds []uint) {
+	if !DetectObjects {
+		return 0, nil
+	}
+
+	count, name := ObjectCount(s)
+
+	if count < 1 || count > detect.MaxObjects {
+		return 0, nil
+	}
+
+	// Match the full name, e.g. "sports cars", and its singular form.
+	words := strings.Fields(name)
+	words[len(words)-1] = inflection.Singular(words[len(words)-1])
+	slugs := []string{slug.Make(name), slug.Make(strings.Join(words, " "))}
+
+	var labels []entity.Label
+
+	if err := Db().Where("label_slug IN (?) OR custom_slug IN (?) OR id IN (SELECT label_id FROM label_synonyms WHERE synonym_slug IN (?))",
+		slugs, slugs, slugs).Find(&labels).Error; err != nil || len(labels) == 0 {
+		return 0, nil
+	}
+
+	return count, expandLabels(labels)
+}
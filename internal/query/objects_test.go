@@ -0,0 +1,104 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/detect"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestObjectCount(t *testing.T) {
+	t.Run("words", func(t *testing.T) {
+		count, name := ObjectCount("Two Dogs")
+		assert.Equal(t, 2, count)
+		assert.Equal(t, "dogs", name)
+	})
+	t.Run("number", func(t *testing.T) {
+		count, name := ObjectCount("3 red cars")
+		assert.Equal(t, 3, count)
+		assert.Equal(t, "red cars", name)
+	})
+	t.Run("no count", func(t *testing.T) {
+		count, name := ObjectCount("dogs")
+		assert.Equal(t, 0, count)
+		assert.Equal(t, "", name)
+
+		count, _ = ObjectCount("big dogs")
+		assert.Equal(t, 0, count)
+
+		count, _ = ObjectCount("0 dogs")
+		assert.Equal(t, 0, count)
+	})
+}
+
+func TestPhotoSearch_ObjectCount(t *testing.T) {
+	DetectObjects = true
+
+	defer func() {
+		DetectObjects = false
+	}()
+
+	file := entity.FileFixtures["exampleFileName.jpg"]
+	label := entity.FirstOrCreateLabel(entity.NewLabel("Query Dog", 0))
+
+	for _, obj := range (detect.Objects{
+		{Name: "query dog", Score: 0.9, X: 0.3, Y: 0.4, W: 0.2, H: 0.3},
+		{Name: "query dog", Score: 0.8, X: 0.7, Y: 0.6, W: 0.2, H: 0.3},
+	}) {
+		if err := entity.NewObjectMarker(obj, file.ID, label.LabelName, label.LabelUID).Create(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	search := func(q string) []PhotoResult {
+		var frm form.PhotoSearch
+
+		frm.Query = q
+
+		photos, _, err := PhotoSearch(frm)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return photos
+	}
+
+	t.Run("two", func(t *testing.T) {
+		photos := search("two query dogs")
+
+		assert.Len(t, photos, 1)
+		assert.Equal(t, file.PhotoID, photos[0].ID)
+	})
+	t.Run("one", func(t *testing.T) {
+		assert.Len(t, search("1 query dog"), 1)
+	})
+	t.Run("three", func(t *testing.T) {
+		assert.Len(t, search("three query dogs"), 0)
+	})
+	t.Run("year", func(t *testing.T) {
+		// Numbers above the max number of objects, e.g. years, use the regular label search.
+		xmas := entity.FirstOrCreateLabel(entity.NewLabel("Queryxmas", 0))
+
+		if err := entity.FirstOrCreatePhotoLabel(entity.NewPhotoLabel(file.PhotoID, xmas.ID, 10, entity.SrcManual)); err == nil {
+			t.Fatal("photo label should not be nil")
+		}
+
+		photos := search("2021 queryxmas")
+
+		if assert.Len(t, photos, 1) {
+			assert.Equal(t, file.PhotoID, photos[0].ID)
+		}
+	})
+	t.Run("disabled", func(t *testing.T) {
+		DetectObjects = false
+
+		photos := search("2 queryxmas")
+
+		if assert.Len(t, photos, 1) {
+			assert.Equal(t, file.PhotoID, photos[0].ID)
+		}
+	})
+}
@@ -0,0 +1,25 @@
+package query
+
+import (
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+// PhotoChanges returns the edit history of a photo, newest first.
+func PhotoChanges(photoUID string, limit, offset int) (result entity.PhotoChanges, err error) {
+	if limit <= 0 || limit > MaxResults {
+		limit = MaxResults
+	}
+
+	err = Db().Where("photo_uid = ?", photoUID).
+		Order("id DESC").Limit(limit).Offset(offset).
+		Find(&result).Error
+
+	return result, err
+}
+
+// BatchChanges returns all changes that were made together.
+func BatchChanges(batchUID string) (result entity.PhotoChanges, err error) {
+	err = Db().Where("batch_uid = ?", batchUID).Order("id").Find(&result).Error
+
+	return result, err
+}
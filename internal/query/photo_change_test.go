@@ -0,0 +1,38 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPhotoChanges(t *testing.T) {
+	batch := entity.NewBatchUID()
+
+	if _, err := entity.SavePhotoChanges("pt9jtdre2lvl0y45", batch, entity.Admin, form.Photo{PhotoTitle: "Foo"}, form.Photo{PhotoTitle: "Bar"}); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("photo", func(t *testing.T) {
+		r, err := PhotoChanges("pt9jtdre2lvl0y45", 10, 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.LessOrEqual(t, 1, len(r))
+		assert.Equal(t, batch, r[0].BatchUID)
+	})
+	t.Run("batch", func(t *testing.T) {
+		r, err := BatchChanges(batch)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, r, 1)
+		assert.Equal(t, "Title", r[0].FieldName)
+	})
+}
@@ -22,6 +22,7 @@ type PhotoResult struct {
 	TakenAtLocal     time.Time     `json:"TakenAtLocal"`
 	TakenSrc         string        `json:"TakenSrc"`
 	TimeZone         string        `json:"TimeZone"`
+	PhotoRoot        string        `json:"Root"`
 	PhotoPath        string        `json:"Path"`
 	PhotoName        string        `json:"Name"`
 	OriginalName     string        `json:"OriginalName"`
@@ -53,7 +53,7 @@ func PhotoSearch(f form.PhotoSearch) (results PhotoResults, count int, err error
 		s = s.Where("edited_at IS NOT NULL").Order("edited_at DESC, photos.photo_uid, files.file_primary DESC")
 	case entity.SortOrderRelevance:
 		if f.Label != "" {
-			s = s.Order("photo_quality DESC, photos_labels.uncertainty ASC, taken_at DESC, files.file_primary DESC")
+			s = s.Order("photo_quality DESC, MIN(photos_labels.uncertainty) ASC, taken_at DESC, files.file_primary DESC")
 		} else {
 			s = s.Order("photo_quality DESC, taken_at DESC, files.file_primary DESC")
 		}
@@ -106,29 +106,19 @@ func PhotoSearch(f form.PhotoSearch) (results PhotoResults, count int, err error
 	}
 
 	// Filter by label, label category and keywords.
-	var categories []entity.Category
 	var labels []entity.Label
 	var labelIds []uint
 
 	if f.Label != "" {
-		if err := Db().Where(AnySlug("label_slug", f.Label, Or)).Or(AnySlug("custom_slug", f.Label, Or)).Find(&labels).Error; len(labels) == 0 || err != nil {
+		if err := Db().Where(AnySlug("label_slug", f.Label, Or)).Or(AnySlug("custom_slug", f.Label, Or)).Or(AnySynonym(f.Label, Or)).Find(&labels).Error; len(labels) == 0 || err != nil {
 			log.Errorf("search: labels %s not found", txt.Quote(f.Label))
 			return results, 0, fmt.Errorf("%s not found", txt.Quote(f.Label))
 		} else {
-			for _, l := range labels {
-				labelIds = append(labelIds, l.ID)
-
-				Db().Where("category_id = ?", l.ID).Find(&categories)
-
-				log.Infof("search: label %s includes %d categories", txt.Quote(l.LabelName), len(categories))
-
-				for _, category := range categories {
-					labelIds = append(labelIds, category.LabelID)
-				}
-			}
+			labelIds = expandLabels(labels)
 
+			// PostgreSQL requires all selected tables to be grouped by their primary key.
 			s = s.Joins("JOIN photos_labels ON photos_labels.photo_id = photos.id AND photos_labels.uncertainty < 100 AND photos_labels.label_id IN (?)", labelIds).
-				Group("photos.id, files.id")
+				Group("photos.id, files.id, cameras.id, lenses.id, places.id")
 		}
 	}
 
@@ -139,25 +129,19 @@ func PhotoSearch(f form.PhotoSearch) (results PhotoResults, count int, err error
 		if likeAny := LikeAny("k.keyword", f.Query); likeAny != "" {
 			s = s.Where("photos.id IN (SELECT pk.photo_id FROM keywords k JOIN photos_keywords pk ON k.id = pk.keyword_id WHERE (?))", gorm.Expr(likeAny))
 		}
+	} else if count, ids := objectLabels(f.Query); count > 0 {
+		// Find photos with a minimum number of label markers, e.g. "two dogs".
+		s = s.Where("photos.id IN (SELECT f.photo_id FROM files f JOIN markers m ON m.file_id = f.id AND m.marker_type = ? AND m.marker_invalid = 0 "+
+			"JOIN labels l ON l.label_uid = m.ref_uid WHERE l.id IN (?) GROUP BY f.id, f.photo_id HAVING COUNT(m.id) >= ?)", entity.MarkerLabel, ids, count)
 	} else if f.Query != "" {
-		if err := Db().Where(AnySlug("custom_slug", f.Query, " ")).Find(&labels).Error; len(labels) == 0 || err != nil {
+		if err := Db().Where(AnySlug("custom_slug", f.Query, " ")).Or(AnySynonym(f.Query, " ")).Find(&labels).Error; len(labels) == 0 || err != nil {
 			log.Infof("search: label %s not found, using fuzzy search", txt.Quote(f.Query))
 
 			if likeAny := LikeAny("k.keyword", f.Query); likeAny != "" {
 				s = s.Where("photos.id IN (SELECT pk.photo_id FROM keywords k JOIN photos_keywords pk ON k.id = pk.keyword_id WHERE (?))", gorm.Expr(likeAny))
 			}
 		} else {
-			for _, l := range labels {
-				labelIds = append(labelIds, l.ID)
-
-				Db().Where("category_id = ?", l.ID).Find(&categories)
-
-				log.Infof("search: label %s includes %d categories", txt.Quote(l.LabelName), len(categories))
-
-				for _, category := range categories {
-					labelIds = append(labelIds, category.LabelID)
-				}
-			}
+			labelIds = expandLabels(labels)
 
 			if likeAny := LikeAny("k.keyword", f.Query); likeAny != "" {
 				s = s.Where("photos.id IN (SELECT pk.photo_id FROM keywords k JOIN photos_keywords pk ON k.id = pk.keyword_id WHERE (?)) OR "+
@@ -267,6 +251,19 @@ func PhotoSearch(f form.PhotoSearch) (results PhotoResults, count int, err error
 		s = s.Where("photos.photo_type IN ('image','raw','live')")
 	}
 
+	// Filter by originals root, e.g. an additional library.
+	if f.Root != "" {
+		roots := strings.Split(f.Root, Or)
+
+		for i, r := range roots {
+			if r == "originals" {
+				roots[i] = entity.RootOriginals
+			}
+		}
+
+		s = s.Where("photos.photo_root IN (?)", roots)
+	}
+
 	if f.Path != "" {
 		p := f.Path
 
@@ -304,7 +301,7 @@ func PhotoSearch(f form.PhotoSearch) (results PhotoResults, count int, err error
 	if strings.Contains(f.Title, Or) {
 		s = s.Where("photos.photo_title IN (?)", strings.Split(strings.ToLower(f.Title), Or))
 	} else if f.Title != "" {
-		s = s.Where("photos.photo_title LIKE ?", strings.ReplaceAll(strings.ToLower(f.Title), "*", "%"))
+		s = s.Where("photos.photo_title "+Like()+" ?", strings.ReplaceAll(strings.ToLower(f.Title), "*", "%"))
 	}
 
 	if strings.Contains(f.Hash, Or) {
@@ -355,6 +352,10 @@ func PhotoSearch(f form.PhotoSearch) (results PhotoResults, count int, err error
 		s = s.Where("photos.photo_lng BETWEEN ? AND ?", lngMin, lngMax)
 	}
 
+	if s, err = geoRegion(s, f.Bbox, f.Polygon, f.Near); err != nil {
+		return results, 0, err
+	}
+
 	if !f.Before.IsZero() {
 		s = s.Where("photos.taken_at <= ?", f.Before.Format("2006-01-02"))
 	}
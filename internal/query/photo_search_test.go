@@ -37,6 +37,34 @@ func TestPhotoSearch(t *testing.T) {
 			}
 		}
 	})
+	t.Run("search for root", func(t *testing.T) {
+		var frm form.PhotoSearch
+
+		frm.Root = "originals"
+		frm.Count = 10
+
+		photos, _, err := PhotoSearch(frm)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.LessOrEqual(t, 3, len(photos))
+
+		for _, r := range photos {
+			assert.Equal(t, entity.RootOriginals, r.PhotoRoot)
+		}
+
+		frm.Root = "family"
+
+		photos, _, err = PhotoSearch(frm)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, photos)
+	})
 	t.Run("search for ID and merged", func(t *testing.T) {
 		var frm form.PhotoSearch
 
@@ -560,6 +588,20 @@ func TestPhotoSearch(t *testing.T) {
 		assert.LessOrEqual(t, 1, len(photos))
 	})
 
+	t.Run("search for labels by relevance", func(t *testing.T) {
+		var f form.PhotoSearch
+		f.Label = "botanical-garden|nature|landscape|park"
+		f.Order = entity.SortOrderRelevance
+
+		photos, _, err := PhotoSearch(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.LessOrEqual(t, 1, len(photos))
+	})
+
 	t.Run("search for primary files", func(t *testing.T) {
 		var f form.PhotoSearch
 		f.Primary = true
@@ -0,0 +1,24 @@
+package query
+
+import (
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+// PhotoPredictions represents the raw image classification results of a primary file.
+type PhotoPredictions struct {
+	PhotoID     uint
+	FileID      uint
+	Predictions entity.ImagePredictions
+}
+
+// PrimaryFilePredictions returns the raw image classification results of primary files.
+func PrimaryFilePredictions(limit, offset int) (results []PhotoPredictions, err error) {
+	err = Db().Table(entity.FilePredictions{}.TableName()).
+		Select("files.photo_id, files_predictions.file_id, files_predictions.predictions").
+		Joins("JOIN files ON files.id = files_predictions.file_id AND files.file_primary = 1 AND files.deleted_at IS NULL").
+		Order("files_predictions.file_id").
+		Limit(limit).Offset(offset).
+		Scan(&results).Error
+
+	return results, err
+}
@@ -0,0 +1,29 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrimaryFilePredictions(t *testing.T) {
+	file := entity.FileFixtures["exampleFileName.jpg"]
+	predictions := entity.ImagePredictions{{{Name: "tabby cat", Probability: 0.8}}}
+
+	if err := entity.NewFilePredictions(file.ID, "nasnet", predictions).Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	results, err := PrimaryFilePredictions(10, 0)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if assert.Len(t, results, 1) {
+		assert.Equal(t, file.ID, results[0].FileID)
+		assert.Equal(t, file.PhotoID, results[0].PhotoID)
+		assert.Equal(t, predictions, results[0].Predictions)
+	}
+}
@@ -47,9 +47,10 @@ import (
 var log = event.Log
 
 const (
-	MySQL  = "mysql"
-	SQLite = "sqlite3"
-	Or     = "|"
+	MySQL    = "mysql"
+	Postgres = "postgres"
+	SQLite   = "sqlite3"
+	Or       = "|"
 )
 
 // Max result limit for queries.
@@ -92,6 +93,15 @@ func DbDialect() string {
 	return Db().Dialect().GetName()
 }
 
+// Like returns the case-insensitive LIKE operator of the current sql dialect.
+func Like() string {
+	if DbDialect() == Postgres {
+		return "ILIKE"
+	}
+
+	return "LIKE"
+}
+
 // LikeAny returns a where condition that matches any keyword in search.
 func LikeAny(col, search string) (where string) {
 	var wheres []string
@@ -102,9 +112,11 @@ func LikeAny(col, search string) (where string) {
 		return ""
 	}
 
+	like := Like()
+
 	for _, w := range words {
 		if len(w) > 3 {
-			wheres = append(wheres, fmt.Sprintf("%s LIKE '%s%%'", col, w))
+			wheres = append(wheres, fmt.Sprintf("%s %s '%s%%'", col, like, w))
 		} else {
 			wheres = append(wheres, fmt.Sprintf("%s = '%s'", col, w))
 		}
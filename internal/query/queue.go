@@ -0,0 +1,43 @@
+package query
+
+import (
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+// PendingQueueItems returns items that have not been started yet, ordered by priority.
+func PendingQueueItems(queueName string, limit int) (results entity.QueueItems, err error) {
+	err = Db().
+		Where("queue_name = ? AND started_at IS NULL", queueName).
+		Order("item_priority DESC, id").
+		Limit(limit).
+		Find(&results).Error
+
+	return results, err
+}
+
+// QueueCounts returns the number of items in each work queue.
+func QueueCounts() (counts map[string]int, err error) {
+	var rows []struct {
+		QueueName string
+		Count     int
+	}
+
+	counts = map[string]int{
+		entity.QueueIndex:    0,
+		entity.QueueThumbs:   0,
+		entity.QueueClassify: 0,
+	}
+
+	if err := Db().Table(entity.QueueItem{}.TableName()).
+		Select("queue_name, COUNT(*) AS count").
+		Group("queue_name").
+		Scan(&rows).Error; err != nil {
+		return counts, err
+	}
+
+	for _, row := range rows {
+		counts[row.QueueName] = row.Count
+	}
+
+	return counts, nil
+}
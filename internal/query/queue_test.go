@@ -0,0 +1,48 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPendingQueueItems(t *testing.T) {
+	rescan := entity.NewQueueItem(entity.QueueIndex, entity.RootOriginals, "queue/rescan.jpg", entity.PriorityRescan)
+	imported := entity.NewQueueItem(entity.QueueIndex, entity.RootOriginals, "queue/import.jpg", entity.PriorityImport)
+	started := entity.NewQueueItem(entity.QueueIndex, entity.RootOriginals, "queue/started.jpg", entity.PriorityImport)
+
+	for _, m := range []*entity.QueueItem{rescan, imported, started} {
+		if err := m.Enqueue(); err != nil {
+			t.Fatal(err)
+		}
+
+		defer m.Done()
+	}
+
+	if err := started.Start(); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("pending", func(t *testing.T) {
+		results, err := PendingQueueItems(entity.QueueIndex, 10)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, results, 2)
+		assert.Equal(t, imported.ID, results[0].ID)
+		assert.Equal(t, rescan.ID, results[1].ID)
+	})
+	t.Run("counts", func(t *testing.T) {
+		counts, err := QueueCounts()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 3, counts[entity.QueueIndex])
+		assert.Equal(t, 0, counts[entity.QueueClassify])
+	})
+}
@@ -33,6 +33,7 @@ package webdav
 
 import (
 	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
 	"path"
@@ -169,6 +170,11 @@ func (c Client) Download(from, to string, force bool) error {
 	return ioutil.WriteFile(to, bytes, 0644)
 }
 
+// ReadStream returns a reader for the contents of a remote file.
+func (c Client) ReadStream(from string) (io.ReadCloser, error) {
+	return c.client.ReadStream(from)
+}
+
 // DownloadDir downloads all files from a remote to a local directory.
 func (c Client) DownloadDir(from, to string, recursive, force bool) (errs []error) {
 	files, err := c.Files(from)
@@ -8,7 +8,10 @@ import (
 	"sync"
 
 	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/api"
+	"github.com/photoprism/photoprism/internal/config"
 	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/txt"
 )
 
 var basicAuth = struct {
@@ -40,7 +43,12 @@ func GetCredentials(c *gin.Context) (username, password, raw string) {
 	return credentials[0], credentials[1], data
 }
 
-func BasicAuth() gin.HandlerFunc {
+// TwoFactorUser tests if the user must log in with a second factor, which basic auth doesn't support.
+func TwoFactorUser(conf *config.Config, user *entity.User) bool {
+	return user.TwoFactor() || conf.TwoFactorRequired(user.Role())
+}
+
+func BasicAuth(conf *config.Config) gin.HandlerFunc {
 	realm := "Authorization Required"
 	realm = "Basic realm=" + strconv.Quote(realm)
 
@@ -53,7 +61,18 @@ func BasicAuth() gin.HandlerFunc {
 		defer basicAuth.mutex.Unlock()
 
 		if user, ok := basicAuth.user[raw]; ok {
-			c.Set(gin.AuthUserKey, user.UserUID)
+			// Two-factor authentication may have been enabled in the meantime.
+			if current := entity.FindUserByUID(user.UserUID); current != nil && !TwoFactorUser(conf, current) {
+				c.Set(gin.AuthUserKey, user.UserUID)
+				return
+			}
+
+			delete(basicAuth.user, raw)
+		}
+
+		if wait := api.LoginBlocked(c.ClientIP(), username); wait > 0 {
+			c.Header("Retry-After", strconv.Itoa(int(wait.Seconds()+0.5)))
+			c.AbortWithStatus(http.StatusTooManyRequests)
 			return
 		}
 
@@ -64,11 +83,19 @@ func BasicAuth() gin.HandlerFunc {
 		}
 
 		if user == nil || invalid {
+			api.LoginFailed(c, username, "invalid basic auth credentials")
 			c.Header("WWW-Authenticate", realm)
 			c.AbortWithStatus(http.StatusUnauthorized)
 			return
 		}
 
+		// Passwords alone must not grant access if a second factor is required.
+		if TwoFactorUser(conf, user) {
+			log.Warnf("webdav: basic auth denied for %s, two-factor authentication is required", txt.Quote(user.UserName))
+			c.AbortWithStatus(http.StatusForbidden)
+			return
+		}
+
 		basicAuth.user[raw] = *user
 
 		c.Set(gin.AuthUserKey, user.UserUID)
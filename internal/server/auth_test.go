@@ -0,0 +1,69 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBasicAuth(t *testing.T) {
+	conf := service.Config()
+
+	app := gin.New()
+	app.GET("/webdav", BasicAuth(conf), func(c *gin.Context) {
+		c.String(http.StatusOK, c.GetString(gin.AuthUserKey))
+	})
+
+	request := func(username, password string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("GET", "/webdav", nil)
+		req.SetBasicAuth(username, password)
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, req)
+		return w
+	}
+
+	m := entity.FirstOrCreateUser(&entity.User{UserUID: "u000000000000040", UserName: "basicauth", RoleAdmin: true})
+
+	if m == nil {
+		t.Fatal("user should not be nil")
+	}
+
+	if err := m.DisableTotp(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := m.SetPassword("basicauth"); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("InvalidPassword", func(t *testing.T) {
+		w := request("basicauth", "foobar")
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+	t.Run("Success", func(t *testing.T) {
+		w := request("basicauth", "basicauth")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, m.UserUID, w.Body.String())
+	})
+	t.Run("TwoFactor", func(t *testing.T) {
+		if _, err := m.SetupTotp(); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := entity.Db().Model(m).UpdateColumn("totp_enabled", true).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		// Cached credentials must be checked again.
+		w := request("basicauth", "basicauth")
+		assert.Equal(t, http.StatusForbidden, w.Code)
+
+		w = request("basicauth", "basicauth")
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+}
@@ -1,9 +1,11 @@
 package server
 
 import (
+	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/metrics"
 )
 
 // Logger instances a Logger middleware for Gin.
@@ -25,6 +27,15 @@ func Logger() gin.HandlerFunc {
 		method := c.Request.Method
 		statusCode := c.Writer.Status()
 
+		// Use the route pattern so that ids in the path don't create new series.
+		route := c.FullPath()
+
+		if route == "" {
+			route = "unmatched"
+		}
+
+		metrics.HttpDuration.WithLabelValues(method, route, strconv.Itoa(statusCode)).Observe(latency.Seconds())
+
 		if raw != "" {
 			path = path + "?" + raw
 		}
@@ -39,11 +39,26 @@ func registerRoutes(router *gin.Engine, conf *config.Config) {
 		c.HTML(http.StatusOK, "rainbow.tmpl", gin.H{"config": clientConfig})
 	})
 
+	// Prometheus Metrics, requires a token.
+	if conf.MetricsToken() == "" {
+		log.Debug("metrics: endpoint disabled, no token configured")
+	} else {
+		api.GetMetrics(router.Group(conf.BaseUri("")))
+	}
+
 	// JSON-REST API Version 1
 	v1 := router.Group(conf.BaseUri(config.ApiUri))
 	{
 		api.GetStatus(v1)
 		api.GetErrors(v1)
+		api.GetAuditLog(v1)
+		api.ExportAuditLog(v1)
+
+		api.GetJobs(v1)
+		api.GetJobRuns(v1)
+		api.RunJob(v1)
+		api.PauseJob(v1)
+		api.ResumeJob(v1)
 
 		api.GetConfig(v1)
 		api.GetConfigOptions(v1)
@@ -53,19 +68,29 @@ func registerRoutes(router *gin.Engine, conf *config.Config) {
 		api.SaveSettings(v1)
 
 		api.ChangePassword(v1)
+		api.UpdateUserWebDAV(v1)
+		api.SetupTwoFactor(v1)
+		api.ActivateTwoFactor(v1)
+		api.DisableTwoFactor(v1)
 		api.CreateSession(v1)
 		api.DeleteSession(v1)
 
 		api.GetThumb(v1)
+		api.GetMarkerThumb(v1)
 		api.GetDownload(v1)
 		api.GetVideo(v1)
 		api.CreateZip(v1)
 		api.DownloadZip(v1)
 
 		api.GetGeo(v1)
+		api.GetGeoTile(v1)
+		api.GetMapTile(v1)
 		api.GetPhoto(v1)
 		api.GetPhotoYaml(v1)
 		api.UpdatePhoto(v1)
+		api.GetPhotoChanges(v1)
+		api.RevertPhotoChange(v1)
+		api.UndoPhotoChanges(v1)
 		api.GetPhotos(v1)
 		api.GetPhotoDownload(v1)
 		api.GetPhotoLinks(v1)
@@ -81,12 +106,24 @@ func registerRoutes(router *gin.Engine, conf *config.Config) {
 		api.GetMomentsTime(v1)
 		api.GetFile(v1)
 		api.DeleteFile(v1)
+		api.CreateFileMarker(v1)
 		api.UpdateFileMarker(v1)
+		api.DeleteFileMarker(v1)
 		api.PhotoPrimary(v1)
 		api.PhotoUnstack(v1)
 
 		api.GetLabels(v1)
+		api.GetLabelRules(v1)
+		api.GetLabelRule(v1)
+		api.UpdateLabelRule(v1)
+		api.DeleteLabelRule(v1)
+		api.ApplyLabelRules(v1)
 		api.UpdateLabel(v1)
+		api.GetLabelTaxonomy(v1)
+		api.AddLabelParent(v1)
+		api.RemoveLabelParent(v1)
+		api.AddLabelSynonym(v1)
+		api.RemoveLabelSynonym(v1)
 		api.GetLabelLinks(v1)
 		api.CreateLabelLink(v1)
 		api.UpdateLabelLink(v1)
@@ -96,6 +133,7 @@ func registerRoutes(router *gin.Engine, conf *config.Config) {
 		api.LabelCover(v1)
 
 		api.GetFoldersOriginals(v1)
+		api.GetFoldersLibraries(v1)
 		api.GetFoldersImport(v1)
 		api.GetFolderCover(v1)
 
@@ -104,12 +142,17 @@ func registerRoutes(router *gin.Engine, conf *config.Config) {
 		api.CancelImport(v1)
 		api.StartIndexing(v1)
 		api.CancelIndexing(v1)
+		api.GetIndexReports(v1)
+		api.GetIndexReport(v1)
+		api.GetIndexFailures(v1)
+		api.RetryIndexReport(v1)
 
 		api.BatchPhotosApprove(v1)
 		api.BatchPhotosArchive(v1)
 		api.BatchPhotosRestore(v1)
 		api.BatchPhotosPrivate(v1)
 		api.BatchPhotosDelete(v1)
+		api.BatchPhotosEdit(v1)
 		api.BatchAlbumsDelete(v1)
 		api.BatchLabelsDelete(v1)
 
@@ -156,11 +199,11 @@ func registerRoutes(router *gin.Engine, conf *config.Config) {
 	if conf.DisableWebDAV() {
 		log.Info("webdav: server disabled")
 	} else {
-		WebDAV(conf.OriginalsPath(), router.Group(conf.BaseUri(WebDAVOriginals), BasicAuth()), conf)
+		WebDAV(conf.OriginalsPath(), router.Group(conf.BaseUri(WebDAVOriginals), BasicAuth(conf)), conf)
 		log.Infof("webdav: %s/ enabled, waiting for requests", conf.BaseUri(WebDAVOriginals))
 
 		if conf.ImportPath() != "" {
-			WebDAV(conf.ImportPath(), router.Group(conf.BaseUri(WebDAVImport), BasicAuth()), conf)
+			WebDAV(conf.ImportPath(), router.Group(conf.BaseUri(WebDAVImport), BasicAuth(conf)), conf)
 			log.Infof("webdav: %s/ enabled, waiting for requests", conf.BaseUri(WebDAVImport))
 		}
 	}
@@ -0,0 +1,27 @@
+package server
+
+import (
+	"os"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/sirupsen/logrus"
+)
+
+func TestMain(m *testing.M) {
+	log = logrus.StandardLogger()
+	log.SetLevel(logrus.DebugLevel)
+
+	gin.SetMode(gin.TestMode)
+
+	c := config.TestConfig()
+	service.SetConfig(c)
+
+	code := m.Run()
+
+	_ = c.CloseDb()
+
+	os.Exit(code)
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/photoprism/photoprism/internal/auto"
 	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
 	"golang.org/x/net/webdav"
 )
 
@@ -46,7 +47,30 @@ func MarkUploadAsFavorite(fileName string) {
 	log.Infof("webdav: marked %s as favorite", txt.Quote(filepath.Base(fileName)))
 }
 
+// WebDAVLockRoot returns the absolute path of a directory with symbolic links resolved.
+func WebDAVLockRoot(dir string) (string, error) {
+	abs, err := filepath.Abs(dir)
+
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.EvalSymlinks(abs)
+}
+
+// WebDAVReadOnly checks if the request method would modify files or locks.
+func WebDAVReadOnly(method string) bool {
+	switch method {
+	case MethodPut, MethodPost, MethodPatch, MethodDelete, MethodMkcol, MethodCopy, MethodMove, MethodLock, MethodUnlock, MethodProppatch:
+		return false
+	default:
+		return true
+	}
+}
+
 // ANY /webdav/*
+//
+// Users are authenticated with basic auth and restricted to their own directory, see User.WebDAVPath().
 func WebDAV(path string, router *gin.RouterGroup, conf *config.Config) {
 	if router == nil {
 		log.Error("webdav: router is nil")
@@ -58,54 +82,76 @@ func WebDAV(path string, router *gin.RouterGroup, conf *config.Config) {
 		return
 	}
 
-	f := webdav.Dir(path)
-
-	srv := &webdav.Handler{
-		Prefix:     router.BasePath(),
-		FileSystem: f,
-		LockSystem: webdav.NewMemLS(),
-		Logger: func(r *http.Request, err error) {
-			if err != nil {
-				switch r.Method {
-				case MethodPut, MethodPost, MethodPatch, MethodDelete, MethodCopy, MethodMove:
-					log.Errorf("webdav: %s in %s %s", txt.Quote(err.Error()), r.Method, r.URL)
-				case MethodPropfind:
-					log.Tracef("webdav: %s in %s %s", txt.Quote(err.Error()), r.Method, r.URL)
-				default:
-					log.Debugf("webdav: %s in %s %s", txt.Quote(err.Error()), r.Method, r.URL)
-				}
-
-			} else {
-				// Mark uploaded files as favorite if X-Favorite HTTP header is "1".
-				if r.Method == MethodPut && r.Header.Get("X-Favorite") == "1" {
-					if router.BasePath() == WebDAVOriginals {
-						MarkUploadAsFavorite(filepath.Join(conf.OriginalsPath(), strings.TrimPrefix(r.URL.Path, router.BasePath())))
-					} else if router.BasePath() == WebDAVImport {
-						MarkUploadAsFavorite(filepath.Join(conf.ImportPath(), strings.TrimPrefix(r.URL.Path, router.BasePath())))
+	handler := func(c *gin.Context) {
+		user := entity.FindUserByUID(c.GetString(gin.AuthUserKey))
+
+		if !user.CanUseWebDAV() {
+			log.Warnf("webdav: access denied for %s", txt.Quote(c.GetString(gin.AuthUserKey)))
+			c.AbortWithStatus(http.StatusForbidden)
+			return
+		}
+
+		if user.WebDAVReadOnly && !WebDAVReadOnly(c.Request.Method) {
+			log.Warnf("webdav: %s has read-only access, %s %s denied", txt.Quote(user.UserName), c.Request.Method, c.Request.URL)
+			c.AbortWithStatus(http.StatusForbidden)
+			return
+		}
+
+		root := filepath.Join(path, user.WebDAVPath())
+
+		if err := os.MkdirAll(root, os.ModePerm); err != nil {
+			log.Errorf("webdav: %s", err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		// Locks are keyed by the resolved absolute path, so that they also apply to users with overlapping directories.
+		lockRoot, err := WebDAVLockRoot(root)
+
+		if err != nil {
+			log.Errorf("webdav: %s", err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		srv := &webdav.Handler{
+			Prefix:     router.BasePath(),
+			FileSystem: webdav.Dir(root),
+			LockSystem: NewLockSystem(lockRoot),
+			Logger: func(r *http.Request, err error) {
+				if err != nil {
+					switch r.Method {
+					case MethodPut, MethodPost, MethodPatch, MethodDelete, MethodCopy, MethodMove:
+						log.Errorf("webdav: %s in %s %s", txt.Quote(err.Error()), r.Method, r.URL)
+					case MethodPropfind:
+						log.Tracef("webdav: %s in %s %s", txt.Quote(err.Error()), r.Method, r.URL)
+					default:
+						log.Debugf("webdav: %s in %s %s", txt.Quote(err.Error()), r.Method, r.URL)
 					}
-				}
 
-				switch r.Method {
-				case MethodPut, MethodPost, MethodPatch, MethodDelete, MethodCopy, MethodMove:
-					log.Infof("webdav: %s %s", r.Method, r.URL)
+				} else {
+					// Mark uploaded files as favorite if X-Favorite HTTP header is "1".
+					if r.Method == MethodPut && r.Header.Get("X-Favorite") == "1" {
+						MarkUploadAsFavorite(filepath.Join(root, strings.TrimPrefix(r.URL.Path, router.BasePath())))
+					}
 
-					if router.BasePath() == WebDAVOriginals {
-						auto.ShouldIndex()
-					} else if router.BasePath() == WebDAVImport {
-						auto.ShouldImport()
+					switch r.Method {
+					case MethodPut, MethodPost, MethodPatch, MethodDelete, MethodCopy, MethodMove:
+						log.Infof("webdav: %s %s by %s", r.Method, r.URL, txt.Quote(user.UserName))
+
+						if strings.HasSuffix(router.BasePath(), WebDAVOriginals) {
+							auto.ShouldIndex()
+						} else if strings.HasSuffix(router.BasePath(), WebDAVImport) {
+							auto.ShouldImport()
+						}
+					default:
+						log.Tracef("webdav: %s %s", r.Method, r.URL)
 					}
-				default:
-					log.Tracef("webdav: %s %s", r.Method, r.URL)
 				}
-			}
-		},
-	}
-
-	handler := func(c *gin.Context) {
-		w := c.Writer
-		r := c.Request
+			},
+		}
 
-		srv.ServeHTTP(w, r)
+		srv.ServeHTTP(c.Writer, c.Request)
 	}
 
 	router.Handle(MethodHead, "/*path", handler)
@@ -0,0 +1,246 @@
+package server
+
+import (
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/rnd"
+	"golang.org/x/net/webdav"
+)
+
+// webdavLocks contains the tokens of locks currently held by requests.
+var webdavLocks = struct {
+	held  map[string]bool
+	mutex sync.Mutex
+}{held: make(map[string]bool)}
+
+// LockSystem implements webdav.LockSystem and stores locks in the database so that they survive restarts.
+// Locks are keyed by absolute path, so that users whose root directories overlap share them.
+type LockSystem struct {
+	root string
+}
+
+// NewLockSystem returns a new persistent lock system for the given root directory,
+// which should be an absolute path with symbolic links resolved.
+func NewLockSystem(root string) webdav.LockSystem {
+	return &LockSystem{root: cleanLockName(filepath.ToSlash(root))}
+}
+
+// abs returns the absolute, slash-separated path of a resource name.
+func (ls *LockSystem) abs(name string) string {
+	return path.Join(ls.root, cleanLockName(name))
+}
+
+// rel returns the resource name of an absolute path, or "/" if it is outside the root directory.
+func (ls *LockSystem) rel(name string) string {
+	if !lockContains(ls.root, name) {
+		return "/"
+	}
+
+	return cleanLockName(strings.TrimPrefix(name, ls.root))
+}
+
+// lockContains returns true if the absolute path is the directory or within it.
+func lockContains(dir, name string) bool {
+	return name == dir || dir == "/" || strings.HasPrefix(name, dir+"/")
+}
+
+// lockCovers returns true if the lock applies to the resource with the given absolute path.
+func lockCovers(l entity.WebDAVLock, name string) bool {
+	if name == l.LockRoot {
+		return true
+	} else if l.ZeroDepth {
+		return false
+	}
+
+	return lockContains(l.LockRoot, name)
+}
+
+// lockDetails returns the lock metadata.
+func (ls *LockSystem) lockDetails(l entity.WebDAVLock, now time.Time) webdav.LockDetails {
+	d := webdav.LockDetails{Root: ls.rel(l.LockRoot), Duration: -1, OwnerXML: l.OwnerXML, ZeroDepth: l.ZeroDepth}
+
+	if l.ExpiresAt != nil {
+		d.Duration = l.ExpiresAt.Sub(now)
+	}
+
+	return d
+}
+
+// lockExpires returns the expiration time for a lock duration, nil if the duration is infinite.
+func lockExpires(now time.Time, duration time.Duration) *time.Time {
+	if duration < 0 {
+		return nil
+	}
+
+	t := now.UTC().Add(duration)
+
+	return &t
+}
+
+// cleanLockName returns a clean, slash-separated resource name.
+func cleanLockName(name string) string {
+	if name == "" || name[0] != '/' {
+		name = "/" + name
+	}
+
+	return path.Clean(name)
+}
+
+// find returns the lock with the given token.
+func (ls *LockSystem) find(now time.Time, token string) (result entity.WebDAVLock, err error) {
+	locks, err := entity.FindWebDAVLocks(ls.root, now.UTC())
+
+	if err != nil {
+		return result, err
+	}
+
+	for _, l := range locks {
+		if l.LockToken == token {
+			return l, nil
+		}
+	}
+
+	return result, webdav.ErrNoSuchLock
+}
+
+// Confirm confirms that the caller can claim all of the locks specified by the conditions.
+func (ls *LockSystem) Confirm(now time.Time, name0, name1 string, conditions ...webdav.Condition) (func(), error) {
+	webdavLocks.mutex.Lock()
+	defer webdavLocks.mutex.Unlock()
+
+	locks, err := entity.FindWebDAVLocks(ls.root, now.UTC())
+
+	if err != nil {
+		return nil, err
+	}
+
+	lookup := func(name string) string {
+		for _, c := range conditions {
+			if c.Token == "" || webdavLocks.held[c.Token] {
+				continue
+			}
+
+			for _, l := range locks {
+				if l.LockToken == c.Token && lockCovers(l, name) {
+					return l.LockToken
+				}
+			}
+		}
+
+		return ""
+	}
+
+	var t0, t1 string
+
+	if name0 != "" {
+		if t0 = lookup(ls.abs(name0)); t0 == "" {
+			return nil, webdav.ErrConfirmationFailed
+		}
+	}
+
+	if name1 != "" {
+		if t1 = lookup(ls.abs(name1)); t1 == "" {
+			return nil, webdav.ErrConfirmationFailed
+		}
+	}
+
+	// Don't hold the same lock twice.
+	if t1 == t0 {
+		t1 = ""
+	}
+
+	for _, t := range []string{t0, t1} {
+		if t != "" {
+			webdavLocks.held[t] = true
+		}
+	}
+
+	return func() {
+		webdavLocks.mutex.Lock()
+		defer webdavLocks.mutex.Unlock()
+
+		delete(webdavLocks.held, t0)
+		delete(webdavLocks.held, t1)
+	}, nil
+}
+
+// Create creates a lock with the given depth, duration, owner and root.
+func (ls *LockSystem) Create(now time.Time, details webdav.LockDetails) (string, error) {
+	webdavLocks.mutex.Lock()
+	defer webdavLocks.mutex.Unlock()
+
+	root := ls.abs(details.Root)
+	locks, err := entity.FindWebDAVLocks(root, now.UTC())
+
+	if err != nil {
+		return "", err
+	}
+
+	for _, l := range locks {
+		// Fail if the resource or an ancestor is already locked,
+		// or if a new infinite depth lock would include a locked descendant.
+		if lockCovers(l, root) {
+			return "", webdav.ErrLocked
+		} else if !details.ZeroDepth && lockContains(root, l.LockRoot) {
+			return "", webdav.ErrLocked
+		}
+	}
+
+	l := entity.WebDAVLock{
+		LockToken: "opaquelocktoken:" + rnd.UUID(),
+		LockRoot:  root,
+		ZeroDepth: details.ZeroDepth,
+		OwnerXML:  details.OwnerXML,
+		ExpiresAt: lockExpires(now, details.Duration),
+	}
+
+	if err := l.Create(); err != nil {
+		return "", err
+	}
+
+	return l.LockToken, nil
+}
+
+// Refresh refreshes the lock with the given token.
+func (ls *LockSystem) Refresh(now time.Time, token string, duration time.Duration) (webdav.LockDetails, error) {
+	webdavLocks.mutex.Lock()
+	defer webdavLocks.mutex.Unlock()
+
+	l, err := ls.find(now, token)
+
+	if err != nil {
+		return webdav.LockDetails{}, err
+	} else if webdavLocks.held[token] {
+		return webdav.LockDetails{}, webdav.ErrLocked
+	}
+
+	if err := l.SetExpiresAt(lockExpires(now, duration)); err != nil {
+		return webdav.LockDetails{}, err
+	}
+
+	details := ls.lockDetails(l, now.UTC())
+	details.Duration = duration
+
+	return details, nil
+}
+
+// Unlock unlocks the lock with the given token.
+func (ls *LockSystem) Unlock(now time.Time, token string) error {
+	webdavLocks.mutex.Lock()
+	defer webdavLocks.mutex.Unlock()
+
+	l, err := ls.find(now, token)
+
+	if err != nil {
+		return err
+	} else if webdavLocks.held[token] {
+		return webdav.ErrLocked
+	}
+
+	return l.Delete()
+}
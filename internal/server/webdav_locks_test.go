@@ -0,0 +1,97 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/webdav"
+)
+
+func TestLockSystem(t *testing.T) {
+	now := time.Now()
+	dir := t.TempDir()
+
+	// The admin has access to the root, the user is restricted to a subdirectory.
+	admin := NewLockSystem(dir)
+	user := NewLockSystem(dir + "/alice")
+
+	t.Run("create and unlock", func(t *testing.T) {
+		token, err := user.Create(now, webdav.LockDetails{Root: "/photo.jpg", Duration: time.Minute})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// The same file is locked for other users with overlapping directories.
+		_, err = admin.Create(now, webdav.LockDetails{Root: "/alice/photo.jpg", Duration: time.Minute})
+		assert.Equal(t, webdav.ErrLocked, err)
+
+		// Infinite depth locks can't include a locked descendant.
+		_, err = admin.Create(now, webdav.LockDetails{Root: "/", Duration: time.Minute})
+		assert.Equal(t, webdav.ErrLocked, err)
+
+		// Other files can still be locked.
+		other, err := admin.Create(now, webdav.LockDetails{Root: "/bob/photo.jpg", Duration: time.Minute})
+		assert.NoError(t, err)
+		assert.NoError(t, admin.Unlock(now, other))
+
+		assert.NoError(t, user.Unlock(now, token))
+		assert.Equal(t, webdav.ErrNoSuchLock, user.Unlock(now, token))
+	})
+	t.Run("confirm", func(t *testing.T) {
+		token, err := admin.Create(now, webdav.LockDetails{Root: "/alice", Duration: time.Minute})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// The lock of the parent directory applies to files of the user.
+		release, err := user.Confirm(now, "/photo.jpg", "", webdav.Condition{Token: token})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Locks can't be held twice or removed while they are held.
+		_, err = admin.Confirm(now, "/alice/photo.jpg", "", webdav.Condition{Token: token})
+		assert.Equal(t, webdav.ErrConfirmationFailed, err)
+		assert.Equal(t, webdav.ErrLocked, admin.Unlock(now, token))
+
+		release()
+
+		_, err = user.Confirm(now, "/photo.jpg", "", webdav.Condition{Token: "opaquelocktoken:invalid"})
+		assert.Equal(t, webdav.ErrConfirmationFailed, err)
+
+		assert.NoError(t, admin.Unlock(now, token))
+	})
+	t.Run("refresh", func(t *testing.T) {
+		token, err := admin.Create(now, webdav.LockDetails{Root: "/alice/2021", Duration: time.Minute, ZeroDepth: true})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		details, err := user.Refresh(now, token, time.Hour)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Lock roots are returned relative to the directory of the user.
+		assert.Equal(t, "/2021", details.Root)
+		assert.Equal(t, time.Hour, details.Duration)
+		assert.True(t, details.ZeroDepth)
+
+		details, err = admin.Refresh(now, token, -1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "/alice/2021", details.Root)
+
+		// Expired locks are removed.
+		_, err = admin.Refresh(now, token, time.Second)
+		assert.NoError(t, err)
+		_, err = admin.Refresh(now.Add(time.Minute), token, time.Minute)
+		assert.Equal(t, webdav.ErrNoSuchLock, err)
+	})
+}
@@ -0,0 +1,151 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebDAVReadOnly(t *testing.T) {
+	assert.True(t, WebDAVReadOnly(MethodGet))
+	assert.True(t, WebDAVReadOnly(MethodHead))
+	assert.True(t, WebDAVReadOnly(MethodOptions))
+	assert.True(t, WebDAVReadOnly(MethodPropfind))
+	assert.False(t, WebDAVReadOnly(MethodPut))
+	assert.False(t, WebDAVReadOnly(MethodDelete))
+	assert.False(t, WebDAVReadOnly(MethodMkcol))
+	assert.False(t, WebDAVReadOnly(MethodMove))
+	assert.False(t, WebDAVReadOnly(MethodLock))
+	assert.False(t, WebDAVReadOnly(MethodUnlock))
+	assert.False(t, WebDAVReadOnly(MethodProppatch))
+}
+
+func TestWebDAVLockRoot(t *testing.T) {
+	dir := t.TempDir()
+	target := filepath.Join(dir, "target")
+	link := filepath.Join(dir, "link")
+
+	if err := os.MkdirAll(target, os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.Symlink(target, link); err != nil {
+		t.Fatal(err)
+	}
+
+	expected, err := filepath.EvalSymlinks(target)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := WebDAVLockRoot(link)
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, result)
+
+	_, err = WebDAVLockRoot(filepath.Join(dir, "missing"))
+
+	assert.Error(t, err)
+}
+
+func TestMarkUploadAsFavorite(t *testing.T) {
+	dir := t.TempDir()
+	fileName := filepath.Join(dir, "2021", "upload.jpg")
+	yamlName := filepath.Join(dir, "2021", "upload.yml")
+
+	MarkUploadAsFavorite(fileName)
+
+	assert.True(t, fs.FileExists(yamlName))
+
+	if data, err := os.ReadFile(yamlName); err != nil {
+		t.Fatal(err)
+	} else {
+		assert.Equal(t, "Favorite: true\n", string(data))
+	}
+
+	// Existing metadata is not overwritten.
+	if err := os.WriteFile(yamlName, []byte("Title: Upload\n"), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	MarkUploadAsFavorite(fileName)
+
+	if data, err := os.ReadFile(yamlName); err != nil {
+		t.Fatal(err)
+	} else {
+		assert.Equal(t, "Title: Upload\n", string(data))
+	}
+}
+
+func TestWebDAV(t *testing.T) {
+	conf := service.Config()
+	dir := t.TempDir()
+
+	user := entity.FirstOrCreateUser(&entity.User{UserUID: "u000000000000041", UserName: "webdavuser"})
+
+	if user == nil {
+		t.Fatal("user is nil")
+	}
+
+	app := gin.New()
+	router := app.Group("/import", func(c *gin.Context) {
+		c.Set(gin.AuthUserKey, c.GetHeader("X-Test-User"))
+	})
+
+	WebDAV(dir, router, conf)
+
+	request := func(method, path, body string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("X-Test-User", user.UserUID)
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		if err := user.SetWebDAV(false, false, ""); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, http.StatusForbidden, request(MethodGet, "/import/", "").Code)
+	})
+	t.Run("read and write", func(t *testing.T) {
+		if err := user.SetWebDAV(true, false, ""); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, http.StatusCreated, request(MethodPut, "/import/test.txt", "test").Code)
+		assert.True(t, fs.FileExists(filepath.Join(dir, "webdavuser", "test.txt")))
+
+		r := request(MethodGet, "/import/test.txt", "")
+
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "test", r.Body.String())
+	})
+	t.Run("read only", func(t *testing.T) {
+		if err := user.SetWebDAV(true, true, ""); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, http.StatusForbidden, request(MethodPut, "/import/test.txt", "changed").Code)
+		assert.Equal(t, http.StatusOK, request(MethodGet, "/import/test.txt", "").Code)
+	})
+	t.Run("storage path", func(t *testing.T) {
+		if err := user.SetWebDAV(true, false, "../shared"); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, http.StatusCreated, request(MethodPut, "/import/shared.txt", "test").Code)
+		assert.True(t, fs.FileExists(filepath.Join(dir, "shared", "shared.txt")))
+	})
+}
@@ -0,0 +1,19 @@
+package service
+
+import (
+	"sync"
+
+	"github.com/photoprism/photoprism/internal/detect"
+)
+
+var onceObjectDetector sync.Once
+
+func initObjectDetector() {
+	services.Detect = detect.New(conf.DetectModelPath(), !conf.DetectObjects())
+}
+
+func ObjectDetector() *detect.Detector {
+	onceObjectDetector.Do(initObjectDetector)
+
+	return services.Detect
+}
@@ -0,0 +1,33 @@
+package service
+
+import (
+	"sync"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/limiter"
+)
+
+var onceLoginLimiter sync.Once
+var onceShareLimiter sync.Once
+
+func initLoginLimiter() {
+	services.LoginLimiter = limiter.New(Config().LoginAttempts(), time.Second, Config().LoginLockout())
+}
+
+// LoginLimiter returns the rate limiter for failed logins by user name and client IP.
+func LoginLimiter() *limiter.Limiter {
+	onceLoginLimiter.Do(initLoginLimiter)
+
+	return services.LoginLimiter
+}
+
+func initShareLimiter() {
+	services.ShareLimiter = limiter.New(Config().LoginAttempts(), time.Second, Config().LoginLockout())
+}
+
+// ShareLimiter returns the rate limiter for invalid share link tokens by client IP.
+func ShareLimiter() *limiter.Limiter {
+	onceShareLimiter.Do(initShareLimiter)
+
+	return services.ShareLimiter
+}
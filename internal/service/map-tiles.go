@@ -0,0 +1,10 @@
+package service
+
+import (
+	"github.com/photoprism/photoprism/internal/maps/tiles"
+)
+
+// MapTiles returns the local map tile archive, or nil if none is configured.
+func MapTiles() tiles.Source {
+	return Config().MapTileSource()
+}
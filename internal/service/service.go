@@ -3,7 +3,9 @@ package service
 import (
 	"github.com/photoprism/photoprism/internal/classify"
 	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/detect"
 	"github.com/photoprism/photoprism/internal/face"
+	"github.com/photoprism/photoprism/internal/limiter"
 	"github.com/photoprism/photoprism/internal/nsfw"
 	"github.com/photoprism/photoprism/internal/photoprism"
 	"github.com/photoprism/photoprism/internal/query"
@@ -15,23 +17,27 @@ import (
 var conf *config.Config
 
 var services struct {
-	FolderCache *gc.Cache
-	CoverCache  *gc.Cache
-	ThumbCache  *gc.Cache
-	Classify    *classify.TensorFlow
-	Convert     *photoprism.Convert
-	Files       *photoprism.Files
-	Photos      *photoprism.Photos
-	Import      *photoprism.Import
-	Index       *photoprism.Index
-	Moments     *photoprism.Moments
-	Purge       *photoprism.Purge
-	CleanUp     *photoprism.CleanUp
-	Nsfw        *nsfw.Detector
-	FaceNet     *face.Net
-	Query       *query.Query
-	Resample    *photoprism.Resample
-	Session     *session.Session
+	FolderCache  *gc.Cache
+	CoverCache   *gc.Cache
+	ThumbCache   *gc.Cache
+	TileCache    *gc.Cache
+	Classify     *classify.TensorFlow
+	Convert      *photoprism.Convert
+	Files        *photoprism.Files
+	Photos       *photoprism.Photos
+	Import       *photoprism.Import
+	Index        *photoprism.Index
+	Moments      *photoprism.Moments
+	Purge        *photoprism.Purge
+	CleanUp      *photoprism.CleanUp
+	Nsfw         *nsfw.Detector
+	FaceNet      *face.Net
+	Detect       *detect.Detector
+	Query        *query.Query
+	Resample     *photoprism.Resample
+	Session      *session.Session
+	LoginLimiter *limiter.Limiter
+	ShareLimiter *limiter.Limiter
 }
 
 func SetConfig(c *config.Config) {
@@ -7,6 +7,7 @@ import (
 	gc "github.com/patrickmn/go-cache"
 
 	"github.com/photoprism/photoprism/internal/classify"
+	"github.com/photoprism/photoprism/internal/limiter"
 	"github.com/photoprism/photoprism/internal/nsfw"
 	"github.com/photoprism/photoprism/internal/photoprism"
 	"github.com/photoprism/photoprism/internal/query"
@@ -44,6 +45,10 @@ func TestThumbCache(t *testing.T) {
 	assert.IsType(t, &gc.Cache{}, ThumbCache())
 }
 
+func TestTileCache(t *testing.T) {
+	assert.IsType(t, &gc.Cache{}, TileCache())
+}
+
 func TestClassify(t *testing.T) {
 	assert.IsType(t, &classify.TensorFlow{}, Classify())
 }
@@ -87,3 +92,11 @@ func TestResample(t *testing.T) {
 func TestSession(t *testing.T) {
 	assert.IsType(t, &session.Session{}, Session())
 }
+
+func TestLoginLimiter(t *testing.T) {
+	assert.IsType(t, &limiter.Limiter{}, LoginLimiter())
+}
+
+func TestShareLimiter(t *testing.T) {
+	assert.IsType(t, &limiter.Limiter{}, ShareLimiter())
+}
@@ -0,0 +1,20 @@
+package service
+
+import (
+	"sync"
+	"time"
+
+	gc "github.com/patrickmn/go-cache"
+)
+
+var onceTileCache sync.Once
+
+func initTileCache() {
+	services.TileCache = gc.New(time.Hour, 10*time.Minute)
+}
+
+func TileCache() *gc.Cache {
+	onceTileCache.Do(initTileCache)
+
+	return services.TileCache
+}
@@ -0,0 +1,65 @@
+package session
+
+import (
+	"sync"
+	"time"
+
+	gc "github.com/patrickmn/go-cache"
+)
+
+// ChallengeExpiration is the time a user has to complete a two-step login.
+const ChallengeExpiration = 5 * time.Minute
+
+// ChallengeAttempts is the max number of passcodes that may be entered per challenge.
+const ChallengeAttempts = 3
+
+var challengeMutex sync.Mutex
+
+// Challenge represents a pending two-step login.
+type Challenge struct {
+	UserUID  string
+	Attempts int
+}
+
+// Challenge creates a token for completing a two-step login of the given user.
+func (s *Session) Challenge(userUID string) string {
+	token := NewID()
+	s.challenges.Set(token, Challenge{UserUID: userUID}, gc.DefaultExpiration)
+	log.Debugf("session: created login challenge")
+	return token
+}
+
+// Challenged returns the user uid of a pending login challenge and counts the attempt.
+// An empty string is returned if the token is unknown, expired or exceeded the max number of attempts.
+func (s *Session) Challenged(token string) string {
+	if token == "" {
+		return ""
+	}
+
+	challengeMutex.Lock()
+	defer challengeMutex.Unlock()
+
+	hit, ok := s.challenges.Get(token)
+
+	if !ok {
+		return ""
+	}
+
+	c := hit.(Challenge)
+	c.Attempts++
+
+	if c.Attempts > ChallengeAttempts {
+		s.challenges.Delete(token)
+		log.Warnf("session: too many attempts for login challenge")
+		return ""
+	}
+
+	s.challenges.Set(token, c, gc.DefaultExpiration)
+
+	return c.UserUID
+}
+
+// DeleteChallenge removes a login challenge once it has been completed.
+func (s *Session) DeleteChallenge(token string) {
+	s.challenges.Delete(token)
+}
@@ -0,0 +1,33 @@
+package session
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSession_Challenge(t *testing.T) {
+	s := New(time.Hour, "")
+
+	t.Run("valid", func(t *testing.T) {
+		token := s.Challenge("u000000000000001")
+		assert.Equal(t, 48, len(token))
+		assert.Equal(t, "u000000000000001", s.Challenged(token))
+		s.DeleteChallenge(token)
+		assert.Equal(t, "", s.Challenged(token))
+	})
+	t.Run("too many attempts", func(t *testing.T) {
+		token := s.Challenge("u000000000000001")
+
+		for i := 0; i < ChallengeAttempts; i++ {
+			assert.Equal(t, "u000000000000001", s.Challenged(token))
+		}
+
+		assert.Equal(t, "", s.Challenged(token))
+	})
+	t.Run("unknown", func(t *testing.T) {
+		assert.Equal(t, "", s.Challenged(""))
+		assert.Equal(t, "", s.Challenged("xxx"))
+	})
+}
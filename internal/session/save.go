@@ -21,6 +21,8 @@ func New(expiration time.Duration, cachePath string) *Session {
 
 	cleanupInterval := 15 * time.Minute
 
+	s.challenges = gc.New(ChallengeExpiration, cleanupInterval)
+
 	if cachePath != "" {
 		fileMutex.RLock()
 		defer fileMutex.RUnlock()
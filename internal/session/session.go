@@ -40,6 +40,7 @@ var log = event.Log
 
 // Session represents a session store.
 type Session struct {
-	cacheFile string
-	cache     *gc.Cache
+	cacheFile  string
+	cache      *gc.Cache
+	challenges *gc.Cache
 }
@@ -9,6 +9,7 @@ import (
 	"path"
 	"path/filepath"
 
+	"github.com/photoprism/photoprism/internal/metrics"
 	"github.com/photoprism/photoprism/pkg/fs"
 	"github.com/photoprism/photoprism/pkg/txt"
 
@@ -118,9 +119,12 @@ func FromCache(imageFilename, hash, thumbPath string, width, height int, opts ..
 	}
 
 	if fs.FileExists(fileName) {
+		metrics.ThumbCache.WithLabelValues(metrics.CacheHit).Inc()
 		return fileName, nil
 	}
 
+	metrics.ThumbCache.WithLabelValues(metrics.CacheMiss).Inc()
+
 	return "", ErrThumbNotCached
 }
 
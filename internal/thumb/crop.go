@@ -0,0 +1,116 @@
+package thumb
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"math"
+	"path/filepath"
+
+	"github.com/disintegration/imaging"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// Area represents a relative image area, e.g. of a detected object.
+// X and Y are the center of the area, W and H its size.
+type Area struct {
+	X float32
+	Y float32
+	W float32
+	H float32
+}
+
+// NewArea returns a new relative image area.
+func NewArea(x, y, w, h float32) Area {
+	return Area{X: x, Y: y, W: w, H: h}
+}
+
+// Valid tests if the area is inside the image and not empty.
+func (a Area) Valid() bool {
+	return a.W > 0 && a.H > 0 && a.W <= 1 && a.H <= 1 && a.X > 0 && a.X < 1 && a.Y > 0 && a.Y < 1
+}
+
+// String returns the area as string for use in file names.
+func (a Area) String() string {
+	return fmt.Sprintf("%03d%03d%03d%03d", permille(a.X), permille(a.Y), permille(a.W), permille(a.H))
+}
+
+// Bounds returns the absolute area bounds for the given image size.
+func (a Area) Bounds(width, height int) image.Rectangle {
+	x0 := int(math.Round(float64((a.X - a.W/2) * float32(width))))
+	y0 := int(math.Round(float64((a.Y - a.H/2) * float32(height))))
+	x1 := int(math.Round(float64((a.X + a.W/2) * float32(width))))
+	y1 := int(math.Round(float64((a.Y + a.H/2) * float32(height))))
+
+	return image.Rect(x0, y0, x1, y1).Intersect(image.Rect(0, 0, width, height))
+}
+
+// Crop returns the image area resized to a square of the given size.
+func Crop(img image.Image, area Area, size int) image.Image {
+	bounds := img.Bounds()
+	rect := area.Bounds(bounds.Dx(), bounds.Dy()).Add(bounds.Min)
+
+	return imaging.Fill(imaging.Crop(img, rect), size, size, imaging.Center, Filter.Imaging())
+}
+
+// CropFilename returns the file name of a cropped thumbnail.
+func CropFilename(hash, thumbPath string, area Area, size int) (fileName string, err error) {
+	if !area.Valid() {
+		return "", fmt.Errorf("resample: invalid crop area %s", area.String())
+	}
+
+	fileName, err = Filename(hash, thumbPath, size, size, ResampleFillCenter)
+
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s_%s_crop.%s", fileName[:len(fileName)-len(filepath.Ext(fileName))], area.String(), fs.FormatJpeg), nil
+}
+
+// CropFromFile returns a square thumbnail of an image area, e.g. to show a detected object.
+func CropFromFile(imageFilename, hash, thumbPath string, area Area, size, orientation int) (fileName string, err error) {
+	if InvalidSize(size) || size == 0 {
+		return "", fmt.Errorf("resample: size has an invalid value (%d)", size)
+	}
+
+	if len(imageFilename) < 4 {
+		return "", errors.New("resample: image filename is empty or too short")
+	}
+
+	fileName, err = CropFilename(hash, thumbPath, area, size)
+
+	if err != nil {
+		return "", err
+	}
+
+	if fs.FileExists(fileName) {
+		return fileName, nil
+	}
+
+	img, err := imaging.Open(imageFilename)
+
+	if err != nil {
+		log.Errorf("resample: %s in %s", err, txt.Quote(filepath.Base(imageFilename)))
+		return "", err
+	}
+
+	if orientation > 1 {
+		img = Rotate(img, orientation)
+	}
+
+	result := Crop(img, area, size)
+
+	if err := imaging.Save(result, fileName, imaging.JPEGQuality(JpegQuality)); err != nil {
+		log.Errorf("resample: failed to save %s", txt.Quote(filepath.Base(fileName)))
+		return "", err
+	}
+
+	return fileName, nil
+}
+
+// permille returns a relative value in per mille, e.g. for use in file names.
+func permille(f float32) int {
+	return int(math.Round(float64(f * 1000)))
+}
@@ -0,0 +1,63 @@
+package thumb
+
+import (
+	"image"
+	"os"
+	"testing"
+
+	"github.com/disintegration/imaging"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArea(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		a := NewArea(0.5, 0.25, 0.2, 0.5)
+
+		assert.True(t, a.Valid())
+		assert.Equal(t, "500250200500", a.String())
+		assert.Equal(t, image.Rect(80, 0, 120, 100), a.Bounds(200, 200))
+	})
+	t.Run("invalid", func(t *testing.T) {
+		assert.False(t, NewArea(0.5, 0.5, 0, 0).Valid())
+		assert.False(t, NewArea(0, 0.5, 0.1, 0.1).Valid())
+		assert.False(t, NewArea(0.5, 0.5, 1.5, 0.1).Valid())
+	})
+}
+
+func TestCropFromFile(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		src := "testdata/example.jpg"
+		dst := "testdata/1/2/3/123456789098765432_100x100_center_500500400400_crop.jpg"
+
+		assert.FileExists(t, src)
+
+		fileName, err := CropFromFile(src, "123456789098765432", "testdata", NewArea(0.5, 0.5, 0.4, 0.4), 100, OrientationNormal)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, dst, fileName)
+
+		img, err := imaging.Open(fileName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 100, img.Bounds().Dx())
+		assert.Equal(t, 100, img.Bounds().Dy())
+
+		_ = os.Remove(dst)
+	})
+	t.Run("invalid area", func(t *testing.T) {
+		_, err := CropFromFile("testdata/example.jpg", "123456789098765432", "testdata", NewArea(0.5, 0.5, 0, 0), 100, OrientationNormal)
+
+		assert.Error(t, err)
+	})
+	t.Run("invalid size", func(t *testing.T) {
+		_, err := CropFromFile("testdata/example.jpg", "123456789098765432", "testdata", NewArea(0.5, 0.5, 0.4, 0.4), 0, OrientationNormal)
+
+		assert.Error(t, err)
+	})
+}
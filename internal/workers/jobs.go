@@ -0,0 +1,339 @@
+package workers
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/pkg/cron"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// Background job names.
+const (
+	JobIndex    = "index"
+	JobImport   = "import"
+	JobMoments  = "moments"
+	JobCleanUp  = "cleanup"
+	JobPurge    = "purge"
+	JobOptimize = "optimize"
+	JobBackup   = "backup"
+	JobShare    = "share"
+	JobSync     = "sync"
+)
+
+// JobNames lists all background jobs in display order, scheduled jobs that are due at the same time run in this order.
+var JobNames = []string{JobIndex, JobImport, JobMoments, JobCleanUp, JobPurge, JobOptimize, JobBackup, JobShare, JobSync}
+
+var (
+	ErrJobNotFound = errors.New("job not found")
+	ErrJobRunning  = errors.New("job already running")
+	ErrJobBusy     = errors.New("another job is running")
+)
+
+// JobFunc runs a background job and returns the result counts.
+type JobFunc func(conf *config.Config) (entity.JobCounts, error)
+
+var jobFuncs = map[string]JobFunc{
+	JobMoments:  momentsJob,
+	JobCleanUp:  cleanUpJob,
+	JobPurge:    purgeJob,
+	JobOptimize: optimizeJob,
+	JobBackup:   backupJob,
+	JobSync:     syncJob,
+	JobShare:    shareJob,
+}
+
+var jobsMutex = sync.Mutex{}
+var jobsRunning = make(map[string]bool)
+
+// RegisterJob sets the function of a background job that is implemented in another package, e.g. indexing.
+func RegisterJob(name string, fn JobFunc) {
+	jobsMutex.Lock()
+	defer jobsMutex.Unlock()
+
+	jobFuncs[name] = fn
+}
+
+// jobFunc returns the function of a background job, if it exists.
+func jobFunc(name string) (fn JobFunc, ok bool) {
+	jobsMutex.Lock()
+	defer jobsMutex.Unlock()
+
+	fn, ok = jobFuncs[name]
+
+	return fn, ok
+}
+
+// JobRunning tests if the background job is currently running.
+func JobRunning(name string) bool {
+	jobsMutex.Lock()
+	defer jobsMutex.Unlock()
+
+	return jobsRunning[name]
+}
+
+// JobSchedule returns the cron expression of a background job, or an empty string if it is not scheduled.
+// Metadata, sharing and sync jobs run every wakeup interval unless configured otherwise in the jobs file.
+func JobSchedule(conf *config.Config, name string) string {
+	if s, ok := conf.JobSchedules()[name]; ok {
+		return s
+	}
+
+	switch name {
+	case JobOptimize, JobSync, JobShare:
+		return cron.EveryPrefix + conf.WakeupInterval().String()
+	default:
+		return ""
+	}
+}
+
+// JobInfo represents the current state of a background job.
+type JobInfo struct {
+	Name     string         `json:"Name"`
+	Schedule string         `json:"Schedule"`
+	Paused   bool           `json:"Paused"`
+	Running  bool           `json:"Running"`
+	NextRun  *time.Time     `json:"NextRun"`
+	LastRun  *entity.JobRun `json:"LastRun"`
+}
+
+// Job returns the current state of a background job.
+func Job(conf *config.Config, name string) (result JobInfo, err error) {
+	if _, ok := jobFunc(name); !ok {
+		return result, ErrJobNotFound
+	}
+
+	result = JobInfo{
+		Name:     name,
+		Schedule: JobSchedule(conf, name),
+		Paused:   entity.FindJob(name).JobPaused,
+		Running:  JobRunning(name),
+	}
+
+	if next := scheduler.next(name); !next.IsZero() {
+		result.NextRun = &next
+	}
+
+	if last, err := query.LastJobRun(name); err == nil {
+		result.LastRun = &last
+	}
+
+	return result, nil
+}
+
+// Jobs returns the current state of all available background jobs.
+func Jobs(conf *config.Config) (result []JobInfo) {
+	for _, name := range JobNames {
+		if job, err := Job(conf, name); err == nil {
+			result = append(result, job)
+		}
+	}
+
+	return result
+}
+
+// PauseJob pauses or resumes scheduled runs of a background job, manual runs are still possible.
+func PauseJob(name string, paused bool) error {
+	if _, ok := jobFunc(name); !ok {
+		return ErrJobNotFound
+	}
+
+	return entity.FindJob(name).SetPaused(paused)
+}
+
+// RunJob runs a background job and adds the result to the job history.
+func RunJob(conf *config.Config, name, trigger string) (run *entity.JobRun, err error) {
+	fn, ok := jobFunc(name)
+
+	if !ok {
+		return nil, ErrJobNotFound
+	}
+
+	jobsMutex.Lock()
+
+	if jobsRunning[name] {
+		jobsMutex.Unlock()
+		return nil, ErrJobRunning
+	}
+
+	jobsRunning[name] = true
+	jobsMutex.Unlock()
+
+	defer func() {
+		jobsMutex.Lock()
+		delete(jobsRunning, name)
+		jobsMutex.Unlock()
+	}()
+
+	run = entity.NewJobRun(name, trigger)
+
+	if err := run.Create(); err != nil {
+		return nil, err
+	}
+
+	counts, err := runJobFunc(conf, name, fn)
+
+	// Skipped runs are not kept in the history.
+	if err == ErrJobBusy {
+		if err := run.Delete(); err != nil {
+			log.Errorf("jobs: %s", err)
+		}
+
+		return nil, err
+	}
+
+	if finishErr := run.Finish(counts, err); finishErr != nil {
+		log.Errorf("jobs: %s", finishErr)
+	}
+
+	if pruneErr := entity.PruneJobRuns(name, entity.JobRunsKeep); pruneErr != nil {
+		log.Errorf("jobs: %s", pruneErr)
+	}
+
+	if err != nil {
+		log.Errorf("jobs: %s failed (%s)", name, err)
+	} else {
+		log.Debugf("jobs: %s completed in %s", name, run.Duration())
+	}
+
+	return run, err
+}
+
+// StartJob runs a background job in a goroutine.
+func StartJob(conf *config.Config, name, trigger string) error {
+	if _, ok := jobFunc(name); !ok {
+		return ErrJobNotFound
+	} else if JobRunning(name) {
+		return ErrJobRunning
+	}
+
+	go func() {
+		if _, err := RunJob(conf, name, trigger); err != nil && err != ErrJobBusy && err != ErrJobRunning {
+			log.Debugf("jobs: %s", err)
+		}
+	}()
+
+	return nil
+}
+
+// runJobFunc calls the job function and recovers from panics.
+func runJobFunc(conf *config.Config, name string, fn JobFunc) (counts entity.JobCounts, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%s: %s (panic)\nstack: %s", name, r, debug.Stack())
+			log.Error(err)
+		}
+	}()
+
+	return fn(conf)
+}
+
+// momentsJob updates moments.
+func momentsJob(conf *config.Config) (entity.JobCounts, error) {
+	if mutex.MainWorker.Busy() {
+		return nil, ErrJobBusy
+	}
+
+	return nil, service.Moments().Start()
+}
+
+// cleanUpJob removes orphaned index entries and thumbnails.
+func cleanUpJob(conf *config.Config) (entity.JobCounts, error) {
+	if mutex.MainWorker.Busy() {
+		return nil, ErrJobBusy
+	}
+
+	thumbs, orphans, err := service.CleanUp().Start(photoprism.CleanUpOptions{})
+
+	return entity.JobCounts{"thumbs": thumbs, "orphans": orphans}, err
+}
+
+// purgeJob flags missing files and removes hidden photos.
+func purgeJob(conf *config.Config) (entity.JobCounts, error) {
+	if mutex.MainWorker.Busy() {
+		return nil, ErrJobBusy
+	}
+
+	// Prevents flagging all files as missing if the originals folder is not mounted.
+	if originalsPath := conf.OriginalsPath(); !fs.PathExists(originalsPath) || fs.IsEmpty(originalsPath) {
+		return nil, fmt.Errorf("originals folder %s is empty or missing", txt.Quote(originalsPath))
+	}
+
+	files, photos, err := service.Purge().Start(photoprism.PurgeOptions{})
+
+	return entity.JobCounts{"files": len(files), "photos": len(photos)}, err
+}
+
+// optimizeJob checks and optimizes photo metadata.
+func optimizeJob(conf *config.Config) (entity.JobCounts, error) {
+	if mutex.WorkersBusy() {
+		return nil, ErrJobBusy
+	}
+
+	worker := NewMeta(conf)
+	err := worker.Start(time.Minute)
+
+	return entity.JobCounts{"photos": worker.Optimized()}, err
+}
+
+// backupJob creates album and index database backups.
+func backupJob(conf *config.Config) (counts entity.JobCounts, err error) {
+	counts = entity.JobCounts{}
+
+	if counts["albums"], err = photoprism.BackupAlbums(conf.AlbumsPath(), true); err != nil {
+		return counts, err
+	}
+
+	out, err := photoprism.DumpIndex()
+
+	if err != nil {
+		return counts, err
+	}
+
+	backupPath := filepath.Join(conf.BackupPath(), conf.DatabaseDriver())
+
+	if err := os.MkdirAll(backupPath, os.ModePerm); err != nil {
+		return counts, err
+	}
+
+	backupFile := filepath.Join(backupPath, time.Now().UTC().Format("2006-01-02")+".sql")
+
+	if err := ioutil.WriteFile(backupFile, out, os.ModePerm); err != nil {
+		return counts, err
+	}
+
+	counts["index"] = 1
+
+	return counts, nil
+}
+
+// syncJob synchronizes files with remote accounts.
+func syncJob(conf *config.Config) (entity.JobCounts, error) {
+	if mutex.SyncWorker.Busy() {
+		return nil, ErrJobBusy
+	}
+
+	return nil, NewSync(conf).Start()
+}
+
+// shareJob uploads shared files to remote accounts.
+func shareJob(conf *config.Config) (entity.JobCounts, error) {
+	if mutex.ShareWorker.Busy() {
+		return nil, ErrJobBusy
+	}
+
+	return nil, NewShare(conf).Start()
+}
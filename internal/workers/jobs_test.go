@@ -0,0 +1,100 @@
+package workers
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJobSchedule(t *testing.T) {
+	conf := config.TestConfig()
+
+	assert.Equal(t, "@every "+conf.WakeupInterval().String(), JobSchedule(conf, JobSync))
+	assert.Equal(t, "@every "+conf.WakeupInterval().String(), JobSchedule(conf, JobOptimize))
+	assert.Equal(t, "", JobSchedule(conf, JobBackup))
+}
+
+func TestRunJob(t *testing.T) {
+	conf := config.TestConfig()
+
+	RegisterJob("test-ok", func(conf *config.Config) (entity.JobCounts, error) {
+		return entity.JobCounts{"files": 2}, nil
+	})
+	RegisterJob("test-error", func(conf *config.Config) (entity.JobCounts, error) {
+		return nil, errors.New("failed")
+	})
+	RegisterJob("test-busy", func(conf *config.Config) (entity.JobCounts, error) {
+		return nil, ErrJobBusy
+	})
+	RegisterJob("test-panic", func(conf *config.Config) (entity.JobCounts, error) {
+		panic("oops")
+	})
+
+	t.Run("success", func(t *testing.T) {
+		run, err := RunJob(conf, "test-ok", entity.JobTriggerManual)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.False(t, run.Running())
+		assert.Equal(t, 2, run.RunCounts["files"])
+		assert.False(t, JobRunning("test-ok"))
+	})
+	t.Run("error", func(t *testing.T) {
+		run, err := RunJob(conf, "test-error", entity.JobTriggerSchedule)
+
+		assert.EqualError(t, err, "failed")
+		assert.Equal(t, "failed", run.RunError)
+	})
+	t.Run("busy", func(t *testing.T) {
+		run, err := RunJob(conf, "test-busy", entity.JobTriggerSchedule)
+
+		assert.Equal(t, ErrJobBusy, err)
+		assert.Nil(t, run)
+
+		runs, err := query.JobRuns("test-busy", 0, 0)
+		assert.NoError(t, err)
+		assert.Empty(t, runs)
+	})
+	t.Run("panic", func(t *testing.T) {
+		run, err := RunJob(conf, "test-panic", entity.JobTriggerManual)
+
+		assert.Error(t, err)
+		assert.Contains(t, run.RunError, "oops")
+	})
+	t.Run("not found", func(t *testing.T) {
+		_, err := RunJob(conf, "test-not-found", entity.JobTriggerManual)
+		assert.Equal(t, ErrJobNotFound, err)
+	})
+}
+
+func TestPauseJob(t *testing.T) {
+	conf := config.TestConfig()
+
+	assert.NoError(t, PauseJob(JobBackup, true))
+
+	job, err := Job(conf, JobBackup)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, job.Paused)
+	assert.NoError(t, PauseJob(JobBackup, false))
+	assert.Equal(t, ErrJobNotFound, PauseJob("test-not-found", true))
+}
+
+func TestJobs(t *testing.T) {
+	jobs := Jobs(config.TestConfig())
+
+	assert.NotEmpty(t, jobs)
+
+	for _, job := range jobs {
+		assert.NotEqual(t, JobIndex, job.Name, "index jobs are registered by the auto package")
+	}
+}
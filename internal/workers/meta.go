@@ -16,7 +16,8 @@ import (
 
 // Meta represents a background metadata maintenance worker.
 type Meta struct {
-	conf *config.Config
+	conf      *config.Config
+	optimized int
 }
 
 // NewMeta returns a new background metadata maintenance worker.
@@ -24,6 +25,11 @@ func NewMeta(conf *config.Config) *Meta {
 	return &Meta{conf: conf}
 }
 
+// Optimized returns the number of photos optimized in the last run.
+func (worker *Meta) Optimized() int {
+	return worker.optimized
+}
+
 // originalsPath returns the original media files path as string.
 func (worker *Meta) originalsPath() string {
 	return worker.conf.OriginalsPath()
@@ -51,7 +57,7 @@ func (worker *Meta) Start(delay time.Duration) (err error) {
 
 	limit := 50
 	offset := 0
-	optimized := 0
+	worker.optimized = 0
 
 	for {
 		photos, err := query.PhotosCheck(limit, offset, delay)
@@ -82,7 +88,7 @@ func (worker *Meta) Start(delay time.Duration) (err error) {
 			if err != nil {
 				log.Errorf("metadata: %s (optimize photo)", err)
 			} else if updated {
-				optimized++
+				worker.optimized++
 				log.Debugf("metadata: optimized photo %s", photo.String())
 			}
 
@@ -101,8 +107,8 @@ func (worker *Meta) Start(delay time.Duration) (err error) {
 		time.Sleep(100 * time.Millisecond)
 	}
 
-	if optimized > 0 {
-		log.Infof("metadata: optimized %d photos", optimized)
+	if worker.optimized > 0 {
+		log.Infof("metadata: optimized %d photos", worker.optimized)
 	}
 
 	if err := query.ResetPhotoQuality(); err != nil {
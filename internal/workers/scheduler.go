@@ -0,0 +1,146 @@
+package workers
+
+import (
+	"sync"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/cron"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// Scheduler starts background jobs based on their cron expressions.
+type Scheduler struct {
+	mutex     sync.Mutex
+	schedules map[string]cron.Schedule
+	nextRun   map[string]time.Time
+	running   bool
+}
+
+var scheduler = NewScheduler()
+
+// NewScheduler returns a new background job scheduler.
+func NewScheduler() *Scheduler {
+	return &Scheduler{
+		schedules: make(map[string]cron.Schedule),
+		nextRun:   make(map[string]time.Time),
+	}
+}
+
+// Init parses the job schedules and calculates the next run times.
+func (s *Scheduler) Init(conf *config.Config, now time.Time) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.schedules = make(map[string]cron.Schedule)
+	s.nextRun = make(map[string]time.Time)
+
+	for _, name := range JobNames {
+		if _, ok := jobFunc(name); !ok {
+			continue
+		}
+
+		spec := JobSchedule(conf, name)
+
+		if spec == "" {
+			continue
+		}
+
+		schedule, err := cron.Parse(spec)
+
+		if err != nil {
+			log.Errorf("jobs: invalid %s schedule %s (%s)", name, txt.Quote(spec), err)
+			continue
+		}
+
+		s.schedules[name] = schedule
+		s.nextRun[name] = schedule.Next(now)
+
+		log.Debugf("jobs: scheduled %s with %s", name, txt.Quote(spec))
+	}
+}
+
+// Due returns the names of jobs that are due to run and calculates their next run times.
+func (s *Scheduler) Due(now time.Time) (result []string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for _, name := range JobNames {
+		next, ok := s.nextRun[name]
+
+		if !ok || next.IsZero() || now.Before(next) {
+			continue
+		}
+
+		s.nextRun[name] = s.schedules[name].Next(now)
+
+		result = append(result, name)
+	}
+
+	return result
+}
+
+// next returns the next run time of a job, or the zero time if it is not scheduled.
+func (s *Scheduler) next(name string) time.Time {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.nextRun[name]
+}
+
+// Run starts the jobs that are due in a goroutine, where they run one after another in the order of JobNames.
+// Jobs that become due while scheduled jobs are still running wait until they are done.
+func (s *Scheduler) Run(conf *config.Config, now time.Time) {
+	if !s.start() {
+		log.Debugf("jobs: scheduled jobs are still running")
+		return
+	}
+
+	due := s.Due(now)
+
+	go func() {
+		defer s.done()
+		s.runJobs(conf, due)
+	}()
+}
+
+// start flags the scheduler as running, returns false if it is already running.
+func (s *Scheduler) start() bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.running {
+		return false
+	}
+
+	s.running = true
+
+	return true
+}
+
+// done flags the scheduler as no longer running.
+func (s *Scheduler) done() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.running = false
+}
+
+// runJobs runs jobs sequentially, unless they are paused or already running.
+func (s *Scheduler) runJobs(conf *config.Config, names []string) {
+	for _, name := range names {
+		if entity.FindJob(name).JobPaused {
+			log.Debugf("jobs: %s is paused", name)
+			continue
+		}
+
+		if run, err := RunJob(conf, name, entity.JobTriggerSchedule); err == ErrJobRunning {
+			log.Debugf("jobs: %s is still running", name)
+		} else if err == ErrJobBusy {
+			log.Debugf("jobs: skipped %s, another job is running", name)
+		} else if err != nil && run == nil {
+			log.Errorf("jobs: %s", err)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package workers
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScheduler_Due(t *testing.T) {
+	conf := config.TestConfig()
+	start := time.Date(2021, 5, 14, 10, 30, 0, 0, time.UTC)
+
+	s := NewScheduler()
+	s.Init(conf, start)
+
+	next := s.next(JobSync)
+
+	assert.Equal(t, start.Add(conf.WakeupInterval()), next)
+	assert.True(t, s.next(JobBackup).IsZero())
+	assert.Empty(t, s.Due(start.Add(time.Minute)))
+
+	due := s.Due(next)
+
+	assert.Contains(t, due, JobSync)
+	assert.Contains(t, due, JobShare)
+	assert.Contains(t, due, JobOptimize)
+	assert.Equal(t, next.Add(conf.WakeupInterval()), s.next(JobSync))
+	assert.Empty(t, s.Due(next))
+}
+
+func TestScheduler_Run(t *testing.T) {
+	conf := config.TestConfig()
+
+	var mutex sync.Mutex
+	var order []string
+	var running int
+
+	job := func(name string) JobFunc {
+		return func(conf *config.Config) (entity.JobCounts, error) {
+			mutex.Lock()
+			running++
+			concurrent := running > 1
+			order = append(order, name)
+			mutex.Unlock()
+
+			time.Sleep(10 * time.Millisecond)
+
+			mutex.Lock()
+			running--
+			mutex.Unlock()
+
+			assert.False(t, concurrent)
+
+			return nil, nil
+		}
+	}
+
+	RegisterJob("test-first", job("test-first"))
+	RegisterJob("test-second", job("test-second"))
+
+	s := NewScheduler()
+
+	if !s.start() {
+		t.Fatal("scheduler should not be running")
+	}
+
+	// Scheduled jobs don't overlap.
+	assert.False(t, s.start())
+
+	s.runJobs(conf, []string{"test-first", "test-second"})
+	s.done()
+
+	assert.Equal(t, []string{"test-first", "test-second"}, order)
+	assert.True(t, s.start())
+}
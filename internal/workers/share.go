@@ -8,6 +8,7 @@ import (
 	"github.com/photoprism/photoprism/internal/config"
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/metrics"
 	"github.com/photoprism/photoprism/internal/mutex"
 	"github.com/photoprism/photoprism/internal/photoprism"
 	"github.com/photoprism/photoprism/internal/query"
@@ -29,6 +30,7 @@ func NewShare(conf *config.Config) *Share {
 // logError logs an error message if err is not nil.
 func (worker *Share) logError(err error) {
 	if err != nil {
+		metrics.WorkerErrors.WithLabelValues("share").Inc()
 		log.Errorf("share: %s", err.Error())
 	}
 }
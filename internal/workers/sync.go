@@ -9,6 +9,7 @@ import (
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/event"
 	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/metrics"
 	"github.com/photoprism/photoprism/internal/mutex"
 	"github.com/photoprism/photoprism/internal/query"
 	"github.com/photoprism/photoprism/internal/remote"
@@ -29,6 +30,7 @@ func NewSync(conf *config.Config) *Sync {
 // logError logs an error message if err is not nil.
 func (worker *Sync) logError(err error) {
 	if err != nil {
+		metrics.WorkerErrors.WithLabelValues("sync").Inc()
 		log.Errorf("sync: %s", err.Error())
 	}
 }
@@ -153,5 +155,12 @@ func (worker *Sync) Start() (err error) {
 		}
 	}
 
+	if mutex.SyncWorker.Canceled() {
+		return err
+	}
+
+	// Index remote accounts that are used as read-only library.
+	worker.libraries()
+
 	return err
 }
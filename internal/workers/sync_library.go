@@ -0,0 +1,240 @@
+package workers
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/remote"
+	"github.com/photoprism/photoprism/internal/remote/webdav"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// libraries indexes all remote accounts that are configured as read-only library.
+func (worker *Sync) libraries() {
+	accounts, err := query.AccountSearch(form.AccountSearch{Library: true})
+
+	if err != nil {
+		worker.logError(err)
+		return
+	}
+
+	for _, a := range accounts {
+		if mutex.SyncWorker.Canceled() {
+			return
+		}
+
+		if a.AccType != remote.ServiceWebDAV {
+			continue
+		}
+
+		if _, err := worker.library(a); err != nil {
+			worker.logError(err)
+		}
+	}
+}
+
+// remoteFiles returns the supported media files in the library path of a remote account, and true if the list is complete.
+func (worker *Sync) remoteFiles(a entity.Account, client webdav.Client) (result map[string]fs.FileInfo, complete bool, err error) {
+	result = make(map[string]fs.FileInfo)
+	start := time.Now()
+
+	subDirs, err := client.Directories(a.LibraryPath, true, webdav.AsyncTimeout)
+
+	if err != nil {
+		return result, false, err
+	}
+
+	// Directories returns a partial result when the timeout was reached.
+	complete = time.Since(start) < webdav.AsyncTimeout
+
+	for _, dir := range append(subDirs.Abs(), a.LibraryPath) {
+		if mutex.SyncWorker.Canceled() {
+			return result, false, nil
+		}
+
+		files, err := client.Files(dir)
+
+		if err != nil {
+			return result, false, err
+		}
+
+		for _, file := range files {
+			switch fs.GetMediaType(file.Name) {
+			case fs.MediaImage, fs.MediaRaw, fs.MediaVideo, fs.MediaSidecar:
+				rel := strings.TrimPrefix(strings.TrimPrefix(file.Abs, strings.TrimSuffix(a.LibraryPath, "/")), "/")
+				result[rel] = file
+			}
+		}
+	}
+
+	return result, complete, nil
+}
+
+// library indexes new and changed files in the library path of a remote account without keeping the originals.
+// Only thumbnails and metadata are stored locally, originals are streamed from the remote server when needed.
+func (worker *Sync) library(a entity.Account) (indexed int, err error) {
+	client := webdav.New(a.AccURL, a.AccUser, a.AccPass)
+
+	files, complete, err := worker.remoteFiles(a, client)
+
+	if err != nil {
+		return 0, err
+	}
+
+	indexedFiles, err := query.IndexedFiles()
+
+	if err != nil {
+		return 0, err
+	}
+
+	root := a.LibraryRoot()
+	baseDir := photoprism.RemoteRootPath(root)
+	stack := worker.conf.Settings().StackSequences()
+
+	// Find new and changed files.
+	changed := make(map[string]bool)
+
+	for name, file := range files {
+		if modTime, ok := indexedFiles[path.Join(root, name)]; ok && modTime == file.Date.Unix() {
+			continue
+		}
+
+		changed[fs.AbsPrefix(name, stack)] = true
+	}
+
+	// Group them with related files by directory and base name, so that they are indexed together.
+	related := make(map[string][]string)
+
+	for name := range files {
+		if k := fs.AbsPrefix(name, stack); changed[k] {
+			related[k] = append(related[k], name)
+		}
+	}
+
+	if len(related) > 0 {
+		log.Infof("sync: indexing %d files in library %s", len(related), txt.Quote(a.AccName))
+	}
+
+	for _, names := range related {
+		if mutex.SyncWorker.Canceled() {
+			return indexed, nil
+		}
+
+		indexed += worker.indexRemote(a, client, baseDir, files, names)
+	}
+
+	if indexed > 0 {
+		worker.logError(entity.UpdatePhotoCounts())
+	}
+
+	// Flag files that were removed from the remote library as missing.
+	if complete && !mutex.SyncWorker.Canceled() {
+		worker.purgeRemote(root, files)
+	}
+
+	return indexed, nil
+}
+
+// indexRemote downloads a group of related files to a temporary location, indexes them, and removes the copies again.
+func (worker *Sync) indexRemote(a entity.Account, client webdav.Client, baseDir string, files map[string]fs.FileInfo, names []string) (indexed int) {
+	var downloaded []string
+
+	defer func() {
+		for _, fileName := range downloaded {
+			if err := os.Remove(fileName); err != nil {
+				worker.logWarn(err)
+			}
+		}
+	}()
+
+	for _, name := range names {
+		file := files[name]
+		localName := filepath.Join(baseDir, name)
+
+		if err := client.Download(path.Join(a.LibraryPath, name), localName, true); err != nil {
+			worker.logError(err)
+			return 0
+		}
+
+		downloaded = append(downloaded, localName)
+
+		// Keep the remote modification time so that unchanged files are skipped next time.
+		if err := os.Chtimes(localName, file.Date, file.Date); err != nil {
+			worker.logWarn(err)
+		}
+	}
+
+	done := make(map[string]bool)
+
+	for _, fileName := range downloaded {
+		if done[fileName] {
+			continue
+		}
+
+		mf, err := photoprism.NewMediaFile(fileName)
+
+		if err != nil || !mf.IsMedia() {
+			continue
+		}
+
+		related, err := mf.RelatedFiles(worker.conf.Settings().StackSequences())
+
+		if err != nil {
+			worker.logWarn(err)
+			continue
+		}
+
+		for _, f := range related.Files {
+			done[f.FileName()] = true
+		}
+
+		done[mf.FileName()] = true
+
+		res := photoprism.IndexRelated(related, service.Index(), photoprism.IndexOptionsAll())
+
+		if res.Failed() {
+			worker.logError(res.Err)
+		} else if res.Success() {
+			indexed++
+		}
+	}
+
+	return indexed
+}
+
+// purgeRemote flags indexed files of a remote library root as missing if they no longer exist on the server.
+func (worker *Sync) purgeRemote(root string, files map[string]fs.FileInfo) {
+	indexed, err := query.FilesByRoot(root)
+
+	if err != nil {
+		worker.logError(err)
+		return
+	}
+
+	for _, file := range indexed {
+		if _, ok := files[file.FileName]; ok {
+			continue
+		}
+
+		// Files generated while indexing, e.g. JPEGs converted from RAW, are stored locally.
+		if fs.FileExists(photoprism.FileName(file.FileRoot, file.FileName)) {
+			continue
+		}
+
+		if err := file.Purge(); err != nil {
+			worker.logError(err)
+		} else {
+			log.Infof("sync: flagged file %s as missing", txt.Quote(file.FileName))
+		}
+	}
+}
@@ -42,9 +42,11 @@ import (
 var log = event.Log
 var stop = make(chan bool, 1)
 
-// Start runs PhotoPrism background workers every wakeup interval.
+// Start runs PhotoPrism background jobs according to their schedules, see JobSchedule.
 func Start(conf *config.Config) {
-	ticker := time.NewTicker(conf.WakeupInterval())
+	ticker := time.NewTicker(time.Minute)
+
+	scheduler.Init(conf, time.Now())
 
 	go func() {
 		for {
@@ -56,10 +58,8 @@ func Start(conf *config.Config) {
 				mutex.ShareWorker.Cancel()
 				mutex.SyncWorker.Cancel()
 				return
-			case <-ticker.C:
-				StartMeta(conf)
-				StartShare(conf)
-				StartSync(conf)
+			case now := <-ticker.C:
+				scheduler.Run(conf, now)
 			}
 		}
 	}()
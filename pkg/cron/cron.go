@@ -0,0 +1,220 @@
+/*
+
+Package cron parses cron expressions and calculates the next activation time of a schedule.
+
+Copyright (c) 2018 - 2021 Michael Mayer <hello@photoprism.org>
+
+    This program is free software: you can redistribute it and/or modify
+    it under the terms of the GNU Affero General Public License as published
+    by the Free Software Foundation, either version 3 of the License, or
+    (at your option) any later version.
+
+    This program is distributed in the hope that it will be useful,
+    but WITHOUT ANY WARRANTY; without even the implied warranty of
+    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+    GNU Affero General Public License for more details.
+
+    You should have received a copy of the GNU Affero General Public License
+    along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+    PhotoPrism® is a registered trademark of Michael Mayer.  You may use it as required
+    to describe our software, run your own server, for educational purposes, but not for
+    offering commercial goods, products, or services without prior written permission.
+    In other words, please ask.
+
+Feel free to send an e-mail to hello@photoprism.org if you have questions,
+want to support our work, or just want to say hello.
+
+Additional information can be found in our Developer Guide:
+https://docs.photoprism.org/developer-guide/
+
+*/
+package cron
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule calculates the next activation time after a given time.
+type Schedule interface {
+	Next(t time.Time) time.Time
+}
+
+// Macros maps predefined schedules to cron expressions.
+var Macros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// EveryPrefix starts a schedule with a fixed interval, e.g. "@every 15m".
+const EveryPrefix = "@every "
+
+// MinInterval is the shortest supported interval for fixed interval schedules.
+const MinInterval = time.Minute
+
+// Parse returns the schedule for a standard cron expression with five fields (minute, hour, day of month,
+// month, day of week), a predefined macro like "@daily", or a fixed interval like "@every 1h30m".
+func Parse(spec string) (Schedule, error) {
+	spec = strings.TrimSpace(spec)
+
+	if spec == "" {
+		return nil, errors.New("empty schedule")
+	}
+
+	if strings.HasPrefix(spec, EveryPrefix) {
+		d, err := time.ParseDuration(strings.TrimSpace(spec[len(EveryPrefix):]))
+
+		if err != nil {
+			return nil, err
+		} else if d < MinInterval {
+			return nil, fmt.Errorf("interval must be at least %s", MinInterval)
+		}
+
+		return Every(d), nil
+	}
+
+	if expr, ok := Macros[strings.ToLower(spec)]; ok {
+		spec = expr
+	} else if strings.HasPrefix(spec, "@") {
+		return nil, fmt.Errorf("unknown schedule %s", spec)
+	}
+
+	fields := strings.Fields(spec)
+
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("expected 5 fields, found %d in %s", len(fields), spec)
+	}
+
+	var err error
+	result := &SpecSchedule{}
+
+	if result.Minute, err = parseField(fields[0], minutes); err != nil {
+		return nil, err
+	} else if result.Hour, err = parseField(fields[1], hours); err != nil {
+		return nil, err
+	} else if result.Dom, err = parseField(fields[2], dom); err != nil {
+		return nil, err
+	} else if result.Month, err = parseField(fields[3], months); err != nil {
+		return nil, err
+	} else if result.Dow, err = parseField(fields[4], dow); err != nil {
+		return nil, err
+	}
+
+	// Sunday may be specified as 0 or 7.
+	if result.Dow&(1<<7) != 0 {
+		result.Dow = result.Dow&^(1<<7) | 1
+	}
+
+	result.AnyDom = fields[2] == "*" || fields[2] == "?"
+	result.AnyDow = fields[4] == "*" || fields[4] == "?"
+
+	return result, nil
+}
+
+// Valid tests if the schedule can be parsed.
+func Valid(spec string) bool {
+	_, err := Parse(spec)
+
+	return err == nil
+}
+
+// bounds represents the valid range and names of a field.
+type bounds struct {
+	name     string
+	min, max int
+	names    map[string]int
+}
+
+var (
+	minutes = bounds{"minute", 0, 59, nil}
+	hours   = bounds{"hour", 0, 23, nil}
+	dom     = bounds{"day of month", 1, 31, nil}
+	months  = bounds{"month", 1, 12, map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	dow = bounds{"day of week", 0, 7, map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+// parseField returns a bit set of the values matched by a comma-separated list of ranges with optional steps.
+func parseField(field string, b bounds) (result uint64, err error) {
+	for _, part := range strings.Split(field, ",") {
+		step := 1
+		rangeStr := part
+
+		if i := strings.Index(part, "/"); i >= 0 {
+			rangeStr = part[:i]
+
+			if step, err = strconv.Atoi(part[i+1:]); err != nil || step < 1 {
+				return 0, fmt.Errorf("invalid %s step in %s", b.name, part)
+			}
+		}
+
+		var start, end int
+
+		switch {
+		case rangeStr == "*" || rangeStr == "?":
+			start, end = b.min, b.max
+
+			// Sunday must not be matched twice.
+			if b.max == 7 {
+				end = 6
+			}
+		case strings.Contains(rangeStr, "-"):
+			i := strings.Index(rangeStr, "-")
+
+			if start, err = b.value(rangeStr[:i]); err != nil {
+				return 0, err
+			} else if end, err = b.value(rangeStr[i+1:]); err != nil {
+				return 0, err
+			}
+		default:
+			if start, err = b.value(rangeStr); err != nil {
+				return 0, err
+			}
+
+			end = start
+
+			// A single value with step, e.g. "5/15", repeats until the end of the range.
+			if strings.Contains(part, "/") {
+				end = b.max
+			}
+		}
+
+		if start > end {
+			return 0, fmt.Errorf("invalid %s range %s", b.name, part)
+		}
+
+		for v := start; v <= end; v += step {
+			result |= 1 << uint(v)
+		}
+	}
+
+	return result, nil
+}
+
+// value returns the numeric value of a field, names are accepted for months and days of the week.
+func (b bounds) value(s string) (int, error) {
+	if v, ok := b.names[strings.ToLower(s)]; ok {
+		return v, nil
+	}
+
+	v, err := strconv.Atoi(s)
+
+	if err != nil || v < b.min || v > b.max {
+		return 0, fmt.Errorf("invalid %s %s", b.name, s)
+	}
+
+	return v, nil
+}
@@ -0,0 +1,73 @@
+package cron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	start := time.Date(2021, 5, 14, 10, 30, 15, 0, time.UTC) // Friday
+
+	t.Run("every minute", func(t *testing.T) {
+		s, err := Parse("* * * * *")
+		assert.NoError(t, err)
+		assert.Equal(t, time.Date(2021, 5, 14, 10, 31, 0, 0, time.UTC), s.Next(start))
+	})
+	t.Run("daily", func(t *testing.T) {
+		s, err := Parse("@daily")
+		assert.NoError(t, err)
+		assert.Equal(t, time.Date(2021, 5, 15, 0, 0, 0, 0, time.UTC), s.Next(start))
+	})
+	t.Run("hourly", func(t *testing.T) {
+		s, err := Parse("@hourly")
+		assert.NoError(t, err)
+		assert.Equal(t, time.Date(2021, 5, 14, 11, 0, 0, 0, time.UTC), s.Next(start))
+	})
+	t.Run("step", func(t *testing.T) {
+		s, err := Parse("*/15 2-4 * * *")
+		assert.NoError(t, err)
+		assert.Equal(t, time.Date(2021, 5, 15, 2, 0, 0, 0, time.UTC), s.Next(start))
+		assert.Equal(t, time.Date(2021, 5, 15, 2, 15, 0, 0, time.UTC), s.Next(time.Date(2021, 5, 15, 2, 0, 0, 0, time.UTC)))
+	})
+	t.Run("weekday names", func(t *testing.T) {
+		s, err := Parse("0 3 * * mon,wed")
+		assert.NoError(t, err)
+		assert.Equal(t, time.Date(2021, 5, 17, 3, 0, 0, 0, time.UTC), s.Next(start))
+	})
+	t.Run("sunday as 7", func(t *testing.T) {
+		s, err := Parse("0 0 * * 7")
+		assert.NoError(t, err)
+		assert.Equal(t, time.Date(2021, 5, 16, 0, 0, 0, 0, time.UTC), s.Next(start))
+	})
+	t.Run("month names", func(t *testing.T) {
+		s, err := Parse("30 4 1 jan *")
+		assert.NoError(t, err)
+		assert.Equal(t, time.Date(2022, 1, 1, 4, 30, 0, 0, time.UTC), s.Next(start))
+	})
+	t.Run("day of month or week", func(t *testing.T) {
+		s, err := Parse("0 0 20 * 1")
+		assert.NoError(t, err)
+		assert.Equal(t, time.Date(2021, 5, 17, 0, 0, 0, 0, time.UTC), s.Next(start))
+		assert.Equal(t, time.Date(2021, 5, 20, 0, 0, 0, 0, time.UTC), s.Next(time.Date(2021, 5, 17, 0, 0, 0, 0, time.UTC)))
+	})
+	t.Run("impossible date", func(t *testing.T) {
+		s, err := Parse("0 0 30 2 *")
+		assert.NoError(t, err)
+		assert.True(t, s.Next(start).IsZero())
+	})
+	t.Run("every", func(t *testing.T) {
+		s, err := Parse("@every 1h30m")
+		assert.NoError(t, err)
+		assert.Equal(t, time.Date(2021, 5, 14, 12, 0, 15, 0, time.UTC), s.Next(start))
+	})
+	t.Run("errors", func(t *testing.T) {
+		for _, spec := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "0 0 0 * *", "* * * 13 *", "5-1 * * * *",
+			"*/0 * * * *", "@every 10s", "@every xyz", "@sometimes", "0 0 * * funday"} {
+			_, err := Parse(spec)
+			assert.Error(t, err, spec)
+			assert.False(t, Valid(spec), spec)
+		}
+	})
+}
@@ -0,0 +1,66 @@
+package cron
+
+import (
+	"time"
+)
+
+// SpecSchedule represents a cron expression as bit sets of matching values.
+type SpecSchedule struct {
+	Minute, Hour, Dom, Month, Dow uint64
+	AnyDom, AnyDow                bool
+}
+
+// maxYears limits the search for the next activation time, e.g. for February 30th.
+const maxYears = 5
+
+// Next returns the next activation time after t, or the zero time if there is none.
+func (s *SpecSchedule) Next(t time.Time) time.Time {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(maxYears, 0, 0)
+
+	for t.Before(limit) {
+		if s.Month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+
+		if !s.matchDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+
+		if s.Hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+			continue
+		}
+
+		if s.Minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+
+		return t
+	}
+
+	return time.Time{}
+}
+
+// matchDay tests if the day matches, either day of month or day of week must match if both are restricted.
+func (s *SpecSchedule) matchDay(t time.Time) bool {
+	domMatch := s.Dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.Dow&(1<<uint(t.Weekday())) != 0
+
+	if s.AnyDom || s.AnyDow {
+		return domMatch && dowMatch
+	}
+
+	return domMatch || dowMatch
+}
+
+// Every represents a schedule with a fixed interval.
+type Every time.Duration
+
+// Next returns the time after the interval has passed.
+func (e Every) Next(t time.Time) time.Time {
+	return t.Add(time.Duration(e)).Truncate(time.Second)
+}
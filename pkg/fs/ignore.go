@@ -77,6 +77,12 @@ func (l *IgnoreList) Ignored() []string {
 	return l.ignoredFiles
 }
 
+// Reset clears the lists of hidden and ignored files, e.g. to free memory in long-running processes.
+func (l *IgnoreList) Reset() {
+	l.hiddenFiles = nil
+	l.ignoredFiles = nil
+}
+
 // AppendItems adds items to the list of ignored items.
 func (l *IgnoreList) AppendItems(dir string, patterns []string) error {
 	if dir == "" {
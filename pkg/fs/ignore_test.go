@@ -126,6 +126,21 @@ func TestIgnoreList_Ignored(t *testing.T) {
 	})
 }
 
+func TestIgnoreList_Reset(t *testing.T) {
+	list := NewIgnoreList(".ppignore", true, false)
+	assert.NoError(t, list.Dir("testdata/directory"))
+	assert.True(t, list.Ignore("testdata/directory/bar.txt"))
+	assert.True(t, list.Ignore("testdata/.hidden"))
+	assert.NotEmpty(t, list.Ignored())
+	assert.NotEmpty(t, list.Hidden())
+
+	list.Reset()
+
+	assert.Empty(t, list.Ignored())
+	assert.Empty(t, list.Hidden())
+	assert.True(t, list.Ignore("testdata/directory/bar.txt"), "patterns must be kept")
+}
+
 func TestNewIgnoreItem(t *testing.T) {
 	t.Run("case sensitive false", func(t *testing.T) {
 		ignore := NewIgnoreItem("testdata/directory", "Test_", false)
@@ -0,0 +1,230 @@
+/*
+Package mvt encodes point features as Mapbox Vector Tiles and provides web map tile calculations.
+
+See https://github.com/mapbox/vector-tile-spec/tree/master/2.1
+
+Copyright (c) 2018 - 2021 Michael Mayer <hello@photoprism.org>
+
+	This program is free software: you can redistribute it and/or modify
+	it under the terms of the GNU Affero General Public License as published
+	by the Free Software Foundation, either version 3 of the License, or
+	(at your option) any later version.
+
+	This program is distributed in the hope that it will be useful,
+	but WITHOUT ANY WARRANTY; without even the implied warranty of
+	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+	GNU Affero General Public License for more details.
+
+	You should have received a copy of the GNU Affero General Public License
+	along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+	PhotoPrism® is a registered trademark of Michael Mayer.  You may use it as required
+	to describe our software, run your own server, for educational purposes, but not for
+	offering commercial goods, products, or services without prior written permission.
+	In other words, please ask.
+
+Feel free to send an e-mail to hello@photoprism.org if you have questions,
+want to support our work, or just want to say hello.
+
+Additional information can be found in our Developer Guide:
+https://docs.photoprism.org/developer-guide/
+*/
+package mvt
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// DefaultExtent is the default number of units along a tile edge.
+const DefaultExtent = 4096
+
+// ContentType is the MIME type of encoded vector tiles.
+const ContentType = "application/vnd.mapbox-vector-tile"
+
+// Protocol buffer wire types.
+const (
+	wireVarint  = 0
+	wireFixed64 = 1
+	wireBytes   = 2
+)
+
+// Geometry commands and types.
+const (
+	cmdMoveTo = 1
+	typePoint = 1
+)
+
+// Feature represents a point with properties, X and Y are tile coordinates within the layer extent.
+type Feature struct {
+	ID         uint64
+	X          int
+	Y          int
+	Properties map[string]interface{}
+}
+
+// Layer represents a named list of features.
+type Layer struct {
+	Name     string
+	Extent   int
+	Features []Feature
+}
+
+// Encode returns the protocol buffer encoded tile containing the given layers.
+func Encode(layers ...Layer) []byte {
+	var tile []byte
+
+	for _, l := range layers {
+		tile = appendBytes(tile, 3, l.encode())
+	}
+
+	return tile
+}
+
+// encode returns the protocol buffer encoded layer.
+func (l Layer) encode() []byte {
+	var keys []string
+	var values [][]byte
+
+	keyIndex := make(map[string]int)
+	valueIndex := make(map[string]int)
+
+	extent := l.Extent
+
+	if extent <= 0 {
+		extent = DefaultExtent
+	}
+
+	b := appendVarint(nil, 15, 2)
+	b = appendBytes(b, 1, []byte(l.Name))
+
+	for _, f := range l.Features {
+		var tags []uint64
+
+		names := make([]string, 0, len(f.Properties))
+
+		for name := range f.Properties {
+			names = append(names, name)
+		}
+
+		sort.Strings(names)
+
+		for _, name := range names {
+			k, ok := keyIndex[name]
+
+			if !ok {
+				k = len(keys)
+				keyIndex[name] = k
+				keys = append(keys, name)
+			}
+
+			value := encodeValue(f.Properties[name])
+			v, ok := valueIndex[string(value)]
+
+			if !ok {
+				v = len(values)
+				valueIndex[string(value)] = v
+				values = append(values, value)
+			}
+
+			tags = append(tags, uint64(k), uint64(v))
+		}
+
+		var feature []byte
+
+		if f.ID > 0 {
+			feature = appendVarint(feature, 1, f.ID)
+		}
+
+		if len(tags) > 0 {
+			feature = appendPacked(feature, 2, tags)
+		}
+
+		feature = appendVarint(feature, 3, typePoint)
+		feature = appendPacked(feature, 4, []uint64{cmdMoveTo | 1<<3, zigzag(f.X), zigzag(f.Y)})
+
+		b = appendBytes(b, 2, feature)
+	}
+
+	for _, k := range keys {
+		b = appendBytes(b, 3, []byte(k))
+	}
+
+	for _, v := range values {
+		b = appendBytes(b, 4, v)
+	}
+
+	return appendVarint(b, 5, uint64(extent))
+}
+
+// encodeValue returns the protocol buffer encoded property value.
+func encodeValue(value interface{}) []byte {
+	switch v := value.(type) {
+	case string:
+		return appendBytes(nil, 1, []byte(v))
+	case float32:
+		return appendDouble(nil, 3, float64(v))
+	case float64:
+		return appendDouble(nil, 3, v)
+	case int:
+		return appendVarint(nil, 6, zigzag(v))
+	case int64:
+		return appendVarint(nil, 6, zigzag(int(v)))
+	case uint:
+		return appendVarint(nil, 5, uint64(v))
+	case uint64:
+		return appendVarint(nil, 5, v)
+	case bool:
+		if v {
+			return appendVarint(nil, 7, 1)
+		}
+
+		return appendVarint(nil, 7, 0)
+	default:
+		return appendBytes(nil, 1, []byte(fmt.Sprint(v)))
+	}
+}
+
+// zigzag returns the zigzag encoded value of a signed integer.
+func zigzag(v int) uint64 {
+	return uint64((int64(v) << 1) ^ (int64(v) >> 63))
+}
+
+func appendUvarint(b []byte, v uint64) []byte {
+	buf := make([]byte, binary.MaxVarintLen64)
+	return append(b, buf[:binary.PutUvarint(buf, v)]...)
+}
+
+func appendKey(b []byte, field, wireType int) []byte {
+	return appendUvarint(b, uint64(field<<3|wireType))
+}
+
+func appendVarint(b []byte, field int, v uint64) []byte {
+	b = appendKey(b, field, wireVarint)
+	return appendUvarint(b, v)
+}
+
+func appendDouble(b []byte, field int, v float64) []byte {
+	b = appendKey(b, field, wireFixed64)
+	buf := make([]byte, 8)
+	binary.LittleEndian.PutUint64(buf, math.Float64bits(v))
+	return append(b, buf...)
+}
+
+func appendBytes(b []byte, field int, v []byte) []byte {
+	b = appendKey(b, field, wireBytes)
+	b = appendUvarint(b, uint64(len(v)))
+	return append(b, v...)
+}
+
+func appendPacked(b []byte, field int, values []uint64) []byte {
+	var packed []byte
+
+	for _, v := range values {
+		packed = appendUvarint(packed, v)
+	}
+
+	return appendBytes(b, field, packed)
+}
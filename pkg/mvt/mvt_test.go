@@ -0,0 +1,68 @@
+package mvt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncode(t *testing.T) {
+	t.Run("point", func(t *testing.T) {
+		result := Encode(Layer{Name: "a", Features: []Feature{{X: 1, Y: 2}}})
+
+		expected := []byte{
+			0x1a, 0x11, // layer
+			0x78, 0x02, // version
+			0x0a, 0x01, 'a', // name
+			0x12, 0x07, // feature
+			0x18, 0x01, // type point
+			0x22, 0x03, 0x09, 0x02, 0x04, // geometry
+			0x28, 0x80, 0x20, // extent
+		}
+
+		assert.Equal(t, expected, result)
+	})
+	t.Run("properties", func(t *testing.T) {
+		result := Encode(Layer{Name: "photos", Extent: 256, Features: []Feature{
+			{ID: 1, X: -1, Y: 1, Properties: map[string]interface{}{"Count": 5, "Hash": "abc"}},
+			{ID: 2, X: 3, Y: 4, Properties: map[string]interface{}{"Count": 5, "Hash": "def"}},
+		}})
+
+		assert.Equal(t, byte(0x1a), result[0])
+		assert.Contains(t, string(result), "Count")
+		assert.Contains(t, string(result), "abc")
+		assert.Contains(t, string(result), "def")
+		// Keys and values are only stored once per layer.
+		assert.Equal(t, 1, countOf(result, []byte("Count")))
+		assert.Equal(t, 1, countOf(result, []byte{0x22, 0x02, 0x30, 0x0a}))
+	})
+	t.Run("empty", func(t *testing.T) {
+		assert.Empty(t, Encode())
+	})
+}
+
+func TestEncodeValue(t *testing.T) {
+	assert.Equal(t, []byte{0x0a, 0x03, 'f', 'o', 'o'}, encodeValue("foo"))
+	assert.Equal(t, []byte{0x30, 0x03}, encodeValue(-2))
+	assert.Equal(t, []byte{0x28, 0x07}, encodeValue(uint(7)))
+	assert.Equal(t, []byte{0x38, 0x01}, encodeValue(true))
+	assert.Equal(t, []byte{0x19, 0, 0, 0, 0, 0, 0, 0xf0, 0x3f}, encodeValue(1.0))
+}
+
+func TestZigzag(t *testing.T) {
+	assert.Equal(t, uint64(0), zigzag(0))
+	assert.Equal(t, uint64(1), zigzag(-1))
+	assert.Equal(t, uint64(2), zigzag(1))
+	assert.Equal(t, uint64(3), zigzag(-2))
+	assert.Equal(t, uint64(8192), zigzag(4096))
+}
+
+func countOf(b, sub []byte) (n int) {
+	for i := 0; i+len(sub) <= len(b); i++ {
+		if string(b[i:i+len(sub)]) == string(sub) {
+			n++
+		}
+	}
+
+	return n
+}
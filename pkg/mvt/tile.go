@@ -0,0 +1,73 @@
+package mvt
+
+import (
+	"fmt"
+	"math"
+)
+
+// MaxZoom is the maximum supported zoom level.
+const MaxZoom = 22
+
+// Tile represents a web map tile in the XYZ scheme using the spherical mercator projection.
+type Tile struct {
+	Z int
+	X int
+	Y int
+}
+
+// NewTile returns a new tile and an error if the coordinates are out of range.
+func NewTile(z, x, y int) (Tile, error) {
+	t := Tile{Z: z, X: x, Y: y}
+
+	if !t.Valid() {
+		return t, fmt.Errorf("invalid tile %s", t.String())
+	}
+
+	return t, nil
+}
+
+// Valid returns true if the tile coordinates are within range.
+func (t Tile) Valid() bool {
+	if t.Z < 0 || t.Z > MaxZoom {
+		return false
+	}
+
+	n := 1 << uint(t.Z)
+
+	return t.X >= 0 && t.X < n && t.Y >= 0 && t.Y < n
+}
+
+// String returns the tile coordinates as z/x/y string.
+func (t Tile) String() string {
+	return fmt.Sprintf("%d/%d/%d", t.Z, t.X, t.Y)
+}
+
+// Bounds returns the tile bounding box in degrees.
+func (t Tile) Bounds() (south, west, north, east float64) {
+	n := float64(int(1) << uint(t.Z))
+
+	west = float64(t.X)/n*360 - 180
+	east = float64(t.X+1)/n*360 - 180
+	north = tileLat(float64(t.Y), n)
+	south = tileLat(float64(t.Y+1), n)
+
+	return south, west, north, east
+}
+
+// Pixel returns the position of the coordinates within the tile, scaled to the given extent.
+func (t Tile) Pixel(lat, lng float64, extent int) (x, y int) {
+	n := float64(int(1) << uint(t.Z))
+	e := float64(extent)
+
+	latRad := lat * math.Pi / 180
+
+	tx := (lng + 180) / 360 * n
+	ty := (1 - math.Log(math.Tan(latRad)+1/math.Cos(latRad))/math.Pi) / 2 * n
+
+	return int(math.Round((tx - float64(t.X)) * e)), int(math.Round((ty - float64(t.Y)) * e))
+}
+
+// tileLat returns the latitude of the northern tile edge.
+func tileLat(y, n float64) float64 {
+	return math.Atan(math.Sinh(math.Pi*(1-2*y/n))) * 180 / math.Pi
+}
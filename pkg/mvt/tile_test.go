@@ -0,0 +1,59 @@
+package mvt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewTile(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		tile, err := NewTile(1, 1, 0)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "1/1/0", tile.String())
+	})
+	t.Run("invalid", func(t *testing.T) {
+		_, err := NewTile(1, 2, 0)
+		assert.Error(t, err)
+		_, err = NewTile(-1, 0, 0)
+		assert.Error(t, err)
+		_, err = NewTile(MaxZoom+1, 0, 0)
+		assert.Error(t, err)
+	})
+}
+
+func TestTile_Bounds(t *testing.T) {
+	t.Run("world", func(t *testing.T) {
+		south, west, north, east := Tile{}.Bounds()
+
+		assert.InDelta(t, -85.0511, south, 0.0001)
+		assert.Equal(t, -180.0, west)
+		assert.InDelta(t, 85.0511, north, 0.0001)
+		assert.Equal(t, 180.0, east)
+	})
+	t.Run("north east", func(t *testing.T) {
+		south, west, north, east := Tile{Z: 1, X: 1, Y: 0}.Bounds()
+
+		assert.InDelta(t, 0.0, south, 0.0001)
+		assert.Equal(t, 0.0, west)
+		assert.InDelta(t, 85.0511, north, 0.0001)
+		assert.Equal(t, 180.0, east)
+	})
+}
+
+func TestTile_Pixel(t *testing.T) {
+	t.Run("center", func(t *testing.T) {
+		x, y := Tile{}.Pixel(0, 0, DefaultExtent)
+
+		assert.Equal(t, 2048, x)
+		assert.Equal(t, 2048, y)
+	})
+	t.Run("berlin", func(t *testing.T) {
+		tile := Tile{Z: 10, X: 550, Y: 335}
+		x, y := tile.Pixel(52.52, 13.405, DefaultExtent)
+
+		assert.True(t, x >= 0 && x < DefaultExtent, x)
+		assert.True(t, y >= 0 && y < DefaultExtent, y)
+	})
+}
@@ -0,0 +1,68 @@
+package s2
+
+import (
+	"math"
+
+	"github.com/golang/geo/r1"
+	"github.com/golang/geo/s1"
+	gs2 "github.com/golang/geo/s2"
+)
+
+// Bounds represents a bounding box in degrees, west is greater than east if it crosses the antimeridian.
+type Bounds struct {
+	South float64
+	West  float64
+	North float64
+	East  float64
+}
+
+// CellBounds returns the bounding box of the cell with the given token.
+func CellBounds(token string) (b Bounds, ok bool) {
+	token = NormalizeToken(token)
+
+	if len(token) < 3 {
+		return b, false
+	}
+
+	c := gs2.CellIDFromToken(token)
+
+	if !c.IsValid() {
+		return b, false
+	}
+
+	return boundsFromRect(gs2.CellFromCellID(c).RectBound()), true
+}
+
+// Empty returns true if no bounds are set.
+func (b Bounds) Empty() bool {
+	return b == Bounds{}
+}
+
+// Union returns the smallest bounding box containing both boxes, empty boxes are ignored.
+func (b Bounds) Union(other Bounds) Bounds {
+	if other.Empty() {
+		return b
+	} else if b.Empty() {
+		return other
+	}
+
+	return boundsFromRect(b.rect().Union(other.rect()))
+}
+
+// rect returns the bounding box as S2 rectangle.
+func (b Bounds) rect() gs2.Rect {
+	return gs2.Rect{
+		Lat: r1.Interval{Lo: b.South * math.Pi / 180, Hi: b.North * math.Pi / 180},
+		Lng: s1.IntervalFromEndpoints(b.West*math.Pi/180, b.East*math.Pi/180),
+	}
+}
+
+// boundsFromRect returns the bounding box of a S2 rectangle.
+func boundsFromRect(r gs2.Rect) Bounds {
+	return Bounds{
+		South: r.Lo().Lat.Degrees(),
+		West:  r.Lo().Lng.Degrees(),
+		North: r.Hi().Lat.Degrees(),
+		East:  r.Hi().Lng.Degrees(),
+	}
+}
@@ -0,0 +1,47 @@
+package s2
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCellBounds(t *testing.T) {
+	t.Run("teotihuacan", func(t *testing.T) {
+		b, ok := CellBounds(PrefixedToken(19.681944, -98.846588))
+
+		assert.True(t, ok)
+		assert.LessOrEqual(t, b.South, 19.681944)
+		assert.GreaterOrEqual(t, b.North, 19.681944)
+		assert.LessOrEqual(t, b.West, -98.846588)
+		assert.GreaterOrEqual(t, b.East, -98.846588)
+		assert.InDelta(t, 19.681944, b.North, 0.001)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		_, ok := CellBounds("zz")
+		assert.False(t, ok)
+	})
+}
+
+func TestBounds_Union(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		b := Bounds{South: 1, West: 2, North: 3, East: 4}
+
+		assert.Equal(t, b, Bounds{}.Union(b))
+		assert.Equal(t, b, b.Union(Bounds{}))
+	})
+	t.Run("overlapping", func(t *testing.T) {
+		result := Bounds{South: 1, West: 2, North: 3, East: 4}.Union(Bounds{South: 2, West: 3, North: 5, East: 6})
+
+		assert.InDelta(t, 1, result.South, 0.000001)
+		assert.InDelta(t, 2, result.West, 0.000001)
+		assert.InDelta(t, 5, result.North, 0.000001)
+		assert.InDelta(t, 6, result.East, 0.000001)
+	})
+	t.Run("antimeridian", func(t *testing.T) {
+		result := Bounds{South: 1, West: 170, North: 2, East: 175}.Union(Bounds{South: 1, West: -175, North: 2, East: -170})
+
+		assert.InDelta(t, 170, result.West, 0.000001)
+		assert.InDelta(t, -170, result.East, 0.000001)
+	})
+}
@@ -0,0 +1,100 @@
+package s2
+
+import (
+	"math"
+
+	"github.com/golang/geo/r1"
+	"github.com/golang/geo/s1"
+	gs2 "github.com/golang/geo/s2"
+)
+
+// CoverMaxCells is the max number of cells used to approximate a region.
+var CoverMaxCells = 24
+
+// CoverMaxLevel is the max level of cells used to approximate a region, about 150 m.
+var CoverMaxLevel = 16
+
+// TokenRange represents a range of cell tokens at the default level.
+type TokenRange struct {
+	Min string
+	Max string
+}
+
+// CoverRect returns token ranges that cover a bounding box,
+// west may be greater than east if the box crosses the antimeridian.
+func CoverRect(south, west, north, east float64) []TokenRange {
+	if south > north || south < -90 || north > 90 || west < -180 || west > 180 || east < -180 || east > 180 {
+		return nil
+	}
+
+	rect := gs2.Rect{
+		Lat: r1.Interval{Lo: south * math.Pi / 180, Hi: north * math.Pi / 180},
+		Lng: s1.IntervalFromEndpoints(west*math.Pi/180, east*math.Pi/180),
+	}
+
+	return cover(rect)
+}
+
+// CoverPolygon returns token ranges that cover the polygon loops, using [lng, lat] coordinates as in GeoJSON.
+// Holes are ignored, so that the result may include cells within them.
+func CoverPolygon(loops [][][]float64) []TokenRange {
+	var union gs2.CellUnion
+
+	for _, ring := range loops {
+		points := make([]gs2.Point, 0, len(ring))
+
+		for _, p := range ring {
+			if len(p) < 2 || p[1] < -90 || p[1] > 90 || p[0] < -180 || p[0] > 180 {
+				return nil
+			}
+
+			points = append(points, gs2.PointFromLatLng(gs2.LatLngFromDegrees(p[1], p[0])))
+		}
+
+		// GeoJSON rings are closed, S2 loops are not.
+		if n := len(points); n > 1 && points[0] == points[n-1] {
+			points = points[:n-1]
+		}
+
+		if len(points) < 3 {
+			return nil
+		}
+
+		loop := gs2.LoopFromPoints(points)
+
+		// Use the smaller region in case the ring orientation is clockwise.
+		loop.Normalize()
+
+		union = append(union, coverer().Covering(loop)...)
+	}
+
+	union.Normalize()
+
+	return tokenRanges(union)
+}
+
+// coverer returns a new region coverer.
+func coverer() *gs2.RegionCoverer {
+	return &gs2.RegionCoverer{MinLevel: 1, MaxLevel: CoverMaxLevel, MaxCells: CoverMaxCells}
+}
+
+// cover returns token ranges that cover the region.
+func cover(region gs2.Region) []TokenRange {
+	return tokenRanges(coverer().Covering(region))
+}
+
+// tokenRanges returns the ranges of default level tokens within the cells.
+func tokenRanges(cells gs2.CellUnion) (result []TokenRange) {
+	for _, c := range cells {
+		if c.Level() > DefaultLevel {
+			c = c.Parent(DefaultLevel)
+		}
+
+		min := c.ChildBeginAtLevel(DefaultLevel)
+		max := c.ChildEndAtLevel(DefaultLevel).Prev()
+
+		result = append(result, TokenRange{Min: min.ToToken(), Max: max.ToToken()})
+	}
+
+	return result
+}
@@ -0,0 +1,63 @@
+package s2
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// inRanges returns true if the token is within one of the ranges.
+func inRanges(token string, ranges []TokenRange) bool {
+	for _, r := range ranges {
+		if token >= r.Min && token <= r.Max {
+			return true
+		}
+	}
+
+	return false
+}
+
+func TestCoverRect(t *testing.T) {
+	t.Run("berlin", func(t *testing.T) {
+		result := CoverRect(52.3, 13.0, 52.7, 13.8)
+
+		assert.NotEmpty(t, result)
+		assert.LessOrEqual(t, len(result), CoverMaxCells)
+		assert.True(t, inRanges(Token(52.5200, 13.4050), result))
+		assert.False(t, inRanges(Token(48.1351, 11.5820), result))
+		assert.Len(t, result[0].Min, len(Token(52.5200, 13.4050)))
+		assert.Len(t, result[0].Max, len(Token(52.5200, 13.4050)))
+	})
+	t.Run("antimeridian", func(t *testing.T) {
+		result := CoverRect(-20, 170, -10, -170)
+
+		assert.True(t, inRanges(Token(-17.7134, 178.0650), result))
+		assert.True(t, inRanges(Token(-13.7590, -172.1046), result))
+		assert.False(t, inRanges(Token(-15, 0), result))
+	})
+	t.Run("invalid", func(t *testing.T) {
+		assert.Empty(t, CoverRect(52.7, 13.0, 52.3, 13.8))
+		assert.Empty(t, CoverRect(-91, 13.0, 52.3, 13.8))
+	})
+}
+
+func TestCoverPolygon(t *testing.T) {
+	triangle := [][][]float64{{{13.0, 52.3}, {13.8, 52.3}, {13.4, 52.7}, {13.0, 52.3}}}
+
+	t.Run("counterclockwise", func(t *testing.T) {
+		result := CoverPolygon(triangle)
+
+		assert.NotEmpty(t, result)
+		assert.True(t, inRanges(Token(52.4, 13.4), result))
+		assert.False(t, inRanges(Token(52.68, 13.05), result))
+		assert.False(t, inRanges(Token(48.1351, 11.5820), result))
+	})
+	t.Run("clockwise", func(t *testing.T) {
+		reversed := [][][]float64{{{13.0, 52.3}, {13.4, 52.7}, {13.8, 52.3}, {13.0, 52.3}}}
+		assert.Equal(t, CoverPolygon(triangle), CoverPolygon(reversed))
+	})
+	t.Run("invalid", func(t *testing.T) {
+		assert.Empty(t, CoverPolygon([][][]float64{{{13.0, 52.3}, {13.8, 52.3}}}))
+		assert.Empty(t, CoverPolygon([][][]float64{{{13.0, 52.3}, {13.8, 52.3}, {200, 52.7}}}))
+	})
+}
@@ -0,0 +1,167 @@
+/*
+
+Package totp provides time-based one-time passcodes as specified in RFC 6238.
+
+Copyright (c) 2018 - 2021 Michael Mayer <hello@photoprism.org>
+
+    This program is free software: you can redistribute it and/or modify
+    it under the terms of the GNU Affero General Public License as published
+    by the Free Software Foundation, either version 3 of the License, or
+    (at your option) any later version.
+
+    This program is distributed in the hope that it will be useful,
+    but WITHOUT ANY WARRANTY; without even the implied warranty of
+    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+    GNU Affero General Public License for more details.
+
+    You should have received a copy of the GNU Affero General Public License
+    along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+    PhotoPrism® is a registered trademark of Michael Mayer.  You may use it as required
+    to describe our software, run your own server, for educational purposes, but not for
+    offering commercial goods, products, or services without prior written permission.
+    In other words, please ask.
+
+Feel free to send an e-mail to hello@photoprism.org if you have questions,
+want to support our work, or just want to say hello.
+
+Additional information can be found in our Developer Guide:
+https://docs.photoprism.org/developer-guide/
+
+*/
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	Digits = 6                // Number of passcode digits.
+	Period = 30 * time.Second // Time step size.
+	Skew   = 1                // Number of time steps accepted before and after the current one.
+)
+
+var encoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// Secret returns a new random base32 encoded shared secret.
+func Secret() string {
+	b := make([]byte, 20)
+
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+
+	return encoding.EncodeToString(b)
+}
+
+// decode returns the key bytes for a base32 encoded secret.
+func decode(secret string) ([]byte, error) {
+	secret = strings.ToUpper(strings.TrimRight(strings.ReplaceAll(secret, " ", ""), "="))
+
+	if secret == "" {
+		return nil, fmt.Errorf("totp: empty secret")
+	}
+
+	return encoding.DecodeString(secret)
+}
+
+// counterPasscode returns the passcode for the given counter value.
+func counterPasscode(key []byte, counter uint64) string {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, counter)
+
+	h := hmac.New(sha1.New, key)
+	h.Write(b)
+	sum := h.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	mod := uint32(1)
+
+	for i := 0; i < Digits; i++ {
+		mod *= 10
+	}
+
+	return fmt.Sprintf("%0*d", Digits, value%mod)
+}
+
+// Passcode returns the passcode for the given secret and time.
+func Passcode(secret string, t time.Time) (string, error) {
+	key, err := decode(secret)
+
+	if err != nil {
+		return "", err
+	}
+
+	return counterPasscode(key, uint64(t.Unix())/uint64(Period.Seconds())), nil
+}
+
+// Valid tests if the passcode matches the secret at the given time, allowing for clock skew.
+func Valid(secret, passcode string, t time.Time) bool {
+	_, ok := Match(secret, passcode, t)
+
+	return ok
+}
+
+// Match returns the time step counter of a matching passcode, so that callers can reject reused passcodes.
+func Match(secret, passcode string, t time.Time) (counter int64, ok bool) {
+	passcode = strings.ReplaceAll(passcode, " ", "")
+
+	if len(passcode) != Digits {
+		return 0, false
+	}
+
+	key, err := decode(secret)
+
+	if err != nil {
+		return 0, false
+	}
+
+	current := int64(t.Unix()) / int64(Period.Seconds())
+
+	for i := -Skew; i <= Skew; i++ {
+		if current+int64(i) < 0 {
+			continue
+		}
+
+		expected := counterPasscode(key, uint64(current+int64(i)))
+
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(passcode)) == 1 {
+			return current + int64(i), true
+		}
+	}
+
+	return 0, false
+}
+
+// URI returns an otpauth:// provisioning uri that can be rendered as QR code for authenticator apps.
+func URI(issuer, account, secret string) string {
+	label := url.PathEscape(account)
+
+	if issuer != "" {
+		label = url.PathEscape(issuer) + ":" + label
+	}
+
+	v := url.Values{}
+	v.Set("secret", secret)
+
+	if issuer != "" {
+		v.Set("issuer", issuer)
+	}
+
+	v.Set("algorithm", "SHA1")
+	v.Set("digits", fmt.Sprintf("%d", Digits))
+	v.Set("period", fmt.Sprintf("%d", int(Period.Seconds())))
+
+	return fmt.Sprintf("otpauth://totp/%s?%s", label, v.Encode())
+}
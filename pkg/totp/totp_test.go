@@ -0,0 +1,93 @@
+package totp
+
+import (
+	"encoding/base32"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// RFC 6238 test secret, see https://tools.ietf.org/html/rfc6238#appendix-B
+var testSecret = base32.StdEncoding.EncodeToString([]byte("12345678901234567890"))
+
+func TestSecret(t *testing.T) {
+	s := Secret()
+	assert.Equal(t, 32, len(s))
+	assert.NotEqual(t, s, Secret())
+}
+
+func TestPasscode(t *testing.T) {
+	t.Run("rfc6238", func(t *testing.T) {
+		expected := map[int64]string{
+			59:          "287082",
+			1111111109:  "081804",
+			1111111111:  "050471",
+			1234567890:  "005924",
+			2000000000:  "279037",
+			20000000000: "353130",
+		}
+
+		for sec, code := range expected {
+			result, err := Passcode(testSecret, time.Unix(sec, 0))
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, code, result)
+		}
+	})
+	t.Run("empty secret", func(t *testing.T) {
+		_, err := Passcode("", time.Now())
+		assert.Error(t, err)
+	})
+}
+
+func TestValid(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+
+	t.Run("current", func(t *testing.T) {
+		assert.True(t, Valid(testSecret, "050471", now))
+	})
+	t.Run("skew", func(t *testing.T) {
+		assert.True(t, Valid(testSecret, "050471", now.Add(Period)))
+		assert.False(t, Valid(testSecret, "050471", now.Add(3*Period)))
+	})
+	t.Run("spaces", func(t *testing.T) {
+		assert.True(t, Valid(testSecret, "050 471", now))
+	})
+	t.Run("invalid", func(t *testing.T) {
+		assert.False(t, Valid(testSecret, "123456", now))
+		assert.False(t, Valid(testSecret, "", now))
+		assert.False(t, Valid("", "050471", now))
+	})
+}
+
+func TestMatch(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+
+	t.Run("current", func(t *testing.T) {
+		counter, ok := Match(testSecret, "050471", now)
+		assert.True(t, ok)
+		assert.Equal(t, int64(37037037), counter)
+	})
+	t.Run("skew", func(t *testing.T) {
+		counter, ok := Match(testSecret, "050471", now.Add(Period))
+		assert.True(t, ok)
+		assert.Equal(t, int64(37037037), counter)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		counter, ok := Match(testSecret, "123456", now)
+		assert.False(t, ok)
+		assert.Equal(t, int64(0), counter)
+	})
+}
+
+func TestURI(t *testing.T) {
+	uri := URI("PhotoPrism", "admin", "JBSWY3DPEHPK3PXP")
+	assert.True(t, strings.HasPrefix(uri, "otpauth://totp/PhotoPrism:admin?"))
+	assert.Contains(t, uri, "secret=JBSWY3DPEHPK3PXP")
+	assert.Contains(t, uri, "issuer=PhotoPrism")
+}
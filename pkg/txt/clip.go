@@ -1,12 +1,16 @@
 package txt
 
-import "strings"
+import (
+	"strings"
+	"unicode/utf8"
+)
 
 const (
 	ClipDefault     = 160
 	ClipSlug        = 80
 	ClipKeyword     = 40
 	ClipVarchar     = 255
+	ClipError       = 512
 	ClipDescription = 16000
 )
 
@@ -26,6 +30,22 @@ func Clip(s string, size int) string {
 	return s
 }
 
+// ClipBytes shortens a string to at most size bytes without splitting multi-byte characters,
+// e.g. for VARBINARY columns that limit the number of bytes rather than characters.
+func ClipBytes(s string, size int) string {
+	if size <= 0 {
+		return ""
+	} else if len(s) <= size {
+		return s
+	}
+
+	for size > 0 && !utf8.RuneStart(s[size]) {
+		size--
+	}
+
+	return s[:size]
+}
+
 func TrimLen(s string, size int) string {
 	if len(s) < size || size < 4 {
 		return s
@@ -1,7 +1,9 @@
 package txt
 
 import (
+	"strings"
 	"testing"
+	"unicode/utf8"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -18,6 +20,25 @@ func TestClip(t *testing.T) {
 	})
 }
 
+func TestClipBytes(t *testing.T) {
+	t.Run("clip", func(t *testing.T) {
+		assert.Equal(t, "I'm ", ClipBytes("I'm ä lazy BRoWN fox!", 5))
+		assert.Equal(t, "I'm ä", ClipBytes("I'm ä lazy BRoWN fox!", 6))
+	})
+	t.Run("ok", func(t *testing.T) {
+		assert.Equal(t, "I'm ä lazy BRoWN fox!", ClipBytes("I'm ä lazy BRoWN fox!", 128))
+	})
+	t.Run("multi-byte", func(t *testing.T) {
+		s := ClipBytes(strings.Repeat("ä", 512), 512)
+		assert.Equal(t, 512, len(s))
+		assert.Equal(t, 256, utf8.RuneCountInString(s))
+		assert.Equal(t, "", ClipBytes("ä", 1))
+	})
+	t.Run("empty", func(t *testing.T) {
+		assert.Equal(t, "", ClipBytes("fox", -1))
+	})
+}
+
 func TestTrimLen(t *testing.T) {
 	t.Run("len < size", func(t *testing.T) {
 		assert.Equal(t, "fox!", TrimLen("fox!", 6))
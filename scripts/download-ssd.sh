@@ -0,0 +1,60 @@
+#!/usr/bin/env bash
+
+TODAY=`date -u +%Y%m%d`
+
+MODEL_NAME="SSD MobileNet"
+MODEL_URL="https://dl.photoprism.org/tensorflow/ssd.zip?$TODAY"
+MODEL_PATH="assets/ssd"
+MODEL_ZIP="/tmp/photoprism/ssd.zip"
+MODEL_SHA1=""
+MODEL_HASH="$MODEL_SHA1  $MODEL_ZIP"
+MODEL_VERSION="$MODEL_PATH/version.txt"
+MODEL_BACKUP="storage/backup/ssd-$TODAY"
+
+# The archive is optional and only installed if its checksum has been pinned
+if [[ -z ${MODEL_SHA1} ]]; then
+  echo "$MODEL_NAME model checksum not pinned, skipping."
+  exit
+fi
+
+echo "Installing $MODEL_NAME model for TensorFlow..."
+
+# Create directories
+mkdir -p /tmp/photoprism
+mkdir -p storage/backup
+
+# Check for update
+if [[ -f ${MODEL_ZIP} ]] && [[ `sha1sum ${MODEL_ZIP}` == ${MODEL_HASH} ]]; then
+  if [[ -f ${MODEL_VERSION} ]]; then
+    echo "Already up to date."
+    exit
+  fi
+else
+  # Download model
+  echo "Downloading latest model from $MODEL_URL..."
+  wget ${MODEL_URL} -O ${MODEL_ZIP}
+
+  TMP_HASH=`sha1sum ${MODEL_ZIP}`
+
+  echo ${TMP_HASH}
+
+  # Verify checksum
+  if [[ ${TMP_HASH} != ${MODEL_HASH} ]]; then
+    echo "Checksum mismatch, expected $MODEL_SHA1."
+    rm -f ${MODEL_ZIP}
+    exit 1
+  fi
+fi
+
+# Create backup
+if [[ -e ${MODEL_PATH} ]]; then
+  echo "Creating backup of existing directory: $MODEL_BACKUP"
+  rm -rf ${MODEL_BACKUP}
+  mv ${MODEL_PATH} ${MODEL_BACKUP}
+fi
+
+# Unzip model
+unzip ${MODEL_ZIP} -d assets
+echo "$MODEL_NAME $TODAY $MODEL_HASH" > ${MODEL_VERSION}
+
+echo "Latest $MODEL_NAME installed."